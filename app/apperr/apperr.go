@@ -8,6 +8,7 @@ const (
 	ErrTypeBadRequest     ErrorType = "bad_request"     // 400
 	ErrTypeNotFound       ErrorType = "not_found"       // 404
 	ErrTypeAlreadyExists  ErrorType = "already_exists"  // 409
+	ErrTypeUnprocessable  ErrorType = "unprocessable"   // 422
 	ErrTypeInternalServer ErrorType = "internal_server" // 500
 )
 
@@ -16,6 +17,7 @@ var mapErrTypeStatus = map[ErrorType]int{
 	ErrTypeBadRequest:     400, // Bad Request
 	ErrTypeNotFound:       404, // Not Found
 	ErrTypeAlreadyExists:  409, // Conflict
+	ErrTypeUnprocessable:  422, // Unprocessable Entity
 	ErrTypeInternalServer: 500, // Internal Server Error
 }
 
@@ -4,8 +4,18 @@ package apperr
 var (
 	ErrInvalidInput      = NewAppError("INVALID_INPUT", ErrTypeBadRequest)
 	ErrInsufficientFunds = NewAppError("INSUFFICIENT_FUNDS", ErrTypeBadRequest)
+	ErrCurrencyMismatch  = NewAppError("CURRENCY_MISMATCH", ErrTypeBadRequest)
+	ErrQuoteExpired      = NewAppError("QUOTE_EXPIRED", ErrTypeBadRequest)
+	ErrQuoteUsed         = NewAppError("QUOTE_ALREADY_USED", ErrTypeAlreadyExists)
+	ErrHoldExpired       = NewAppError("HOLD_EXPIRED", ErrTypeBadRequest)
+	ErrHoldClosed        = NewAppError("HOLD_NOT_AUTHORIZED", ErrTypeAlreadyExists)
+	ErrAccountFrozen     = NewAppError("ACCOUNT_FROZEN", ErrTypeAlreadyExists)
+	ErrAccountClosed     = NewAppError("ACCOUNT_CLOSED", ErrTypeAlreadyExists)
+	ErrLimitExceeded     = NewAppError("LIMIT_EXCEEDED", ErrTypeUnprocessable)
+	ErrRiskDenied        = NewAppError("RISK_DENIED", ErrTypeUnprocessable)
 	ErrNotFound          = NewAppError("NOT_FOUND", ErrTypeNotFound)
 	ErrAlreadyExists     = NewAppError("ALREADY_EXISTS", ErrTypeAlreadyExists)
 	ErrResourceBusy      = NewAppError("RESOURCE_BUSY", ErrTypeBadRequest)
+	ErrIdempotencyReused = NewAppError("IDEMPOTENCY_KEY_REUSED", ErrTypeUnprocessable)
 	ErrInternalServer    = NewAppError("INTERNAL_SERVER_ERROR", ErrTypeInternalServer)
 )
@@ -4,16 +4,198 @@ import "fmt"
 
 // Config represents the application configuration
 type Config struct {
-	AppName  string   `mapstructure:"app_name"`
-	Env      string   `mapstructure:"env"`
-	Server   Server   `mapstructure:"server"`
-	Postgres Postgres `mapstructure:"postgres"`
+	AppName     string      `mapstructure:"app_name"`
+	Env         string      `mapstructure:"env"`
+	Server      Server      `mapstructure:"server"`
+	GRPC        GRPC        `mapstructure:"grpc"`
+	Postgres    Postgres    `mapstructure:"postgres"`
+	FX          FX          `mapstructure:"fx"`
+	Idempotency Idempotency `mapstructure:"idempotency"`
+	Hold        Hold        `mapstructure:"hold"`
+	Limits      Limits      `mapstructure:"limits"`
+	Risk        Risk        `mapstructure:"risk"`
+	Fees        Fees        `mapstructure:"fees"`
+	Scheduler   Scheduler   `mapstructure:"scheduler"`
+	Interest    Interest    `mapstructure:"interest"`
+	Snapshots   Snapshots   `mapstructure:"snapshots"`
+	Events      Events      `mapstructure:"events"`
+	Webhooks    Webhooks    `mapstructure:"webhooks"`
+	Streams     Streams     `mapstructure:"streams"`
 }
 
 type Server struct {
 	Port uint `mapstructure:"port"`
 }
 
+// GRPC configures the gRPC server that serves the account API to internal services next to the HTTP server.
+type GRPC struct {
+	Enabled bool `mapstructure:"enabled"`
+	Port    uint `mapstructure:"port"`
+}
+
+type FX struct {
+	QuoteTTLSeconds int `mapstructure:"quote_ttl_seconds"`
+}
+
+// Idempotency configures how long idempotency keys are kept.
+// When PurgeEnabled, a worker deletes the expired keys every PurgeIntervalSeconds.
+type Idempotency struct {
+	TTLSeconds           int  `mapstructure:"ttl_seconds"`
+	PurgeEnabled         bool `mapstructure:"purge_enabled"`
+	PurgeIntervalSeconds int  `mapstructure:"purge_interval_seconds"`
+}
+
+type Hold struct {
+	ExpirySeconds int `mapstructure:"expiry_seconds"`
+}
+
+type Limits struct {
+	Rules []LimitRule `mapstructure:"rules"`
+}
+
+// LimitRule configures one transfer limit. Amounts are decimal strings in the rule currency.
+type LimitRule struct {
+	Name     string `mapstructure:"name"`
+	Type     string `mapstructure:"type"`
+	Window   string `mapstructure:"window"`
+	Currency string `mapstructure:"currency"`
+	Amount   string `mapstructure:"amount"`
+	Count    int64  `mapstructure:"count"`
+}
+
+// Risk configures the built-in risk rules. A rule left at its zero value is disabled.
+// Action is what a rule decides when it matches: review (default) or deny.
+type Risk struct {
+	NewAccount    NewAccountRisk    `mapstructure:"new_account"`
+	RoundTrip     RoundTripRisk     `mapstructure:"round_trip"`
+	UnusualAmount UnusualAmountRisk `mapstructure:"unusual_amount"`
+}
+
+// NewAccountRisk flags large transfers from recently opened accounts.
+// Amounts maps a currency code to the largest unflagged amount in that currency.
+type NewAccountRisk struct {
+	MaxAgeHours int               `mapstructure:"max_age_hours"`
+	Amounts     map[string]string `mapstructure:"amounts"`
+	Action      string            `mapstructure:"action"`
+}
+
+// RoundTripRisk flags a transfer sent back to an account the money came from shortly before.
+type RoundTripRisk struct {
+	WindowMinutes int    `mapstructure:"window_minutes"`
+	Action        string `mapstructure:"action"`
+}
+
+// UnusualAmountRisk flags transfers far above the source account's average outgoing transfer.
+type UnusualAmountRisk struct {
+	HistoryDays  int    `mapstructure:"history_days"`
+	MinTransfers int64  `mapstructure:"min_transfers"`
+	Multiplier   int64  `mapstructure:"multiplier"`
+	Action       string `mapstructure:"action"`
+}
+
+type Fees struct {
+	Schedules []FeeSchedule `mapstructure:"schedules"`
+}
+
+// FeeSchedule configures the fee of the transfers of one type from accounts of one currency.
+// Amounts are decimal strings in the schedule currency; rates are fractions of the transfer amount.
+type FeeSchedule struct {
+	Name         string    `mapstructure:"name"`
+	TransferType string    `mapstructure:"transfer_type"`
+	Currency     string    `mapstructure:"currency"`
+	Type         string    `mapstructure:"type"`
+	Flat         string    `mapstructure:"flat"`
+	Rate         string    `mapstructure:"rate"`
+	Tiers        []FeeTier `mapstructure:"tiers"`
+	Min          string    `mapstructure:"min"`
+	Max          string    `mapstructure:"max"`
+}
+
+// FeeTier configures one tier of a tiered fee schedule. The last tier leaves UpTo empty.
+type FeeTier struct {
+	UpTo string `mapstructure:"up_to"`
+	Flat string `mapstructure:"flat"`
+	Rate string `mapstructure:"rate"`
+}
+
+// Scheduler configures the worker that makes scheduled transfers.
+// The worker polls for due schedules every PollIntervalSeconds and runs at most BatchSize per poll.
+type Scheduler struct {
+	Enabled             bool `mapstructure:"enabled"`
+	PollIntervalSeconds int  `mapstructure:"poll_interval_seconds"`
+	BatchSize           int  `mapstructure:"batch_size"`
+}
+
+// Interest configures interest on accounts and the worker that accrues and posts it.
+// When enabled, the worker checks every PollIntervalSeconds for days to accrue and months to post.
+type Interest struct {
+	Enabled             bool           `mapstructure:"enabled"`
+	PollIntervalSeconds int            `mapstructure:"poll_interval_seconds"`
+	Rates               []InterestRate `mapstructure:"rates"`
+}
+
+// InterestRate configures the annual rate of the accounts of one type and currency.
+// AnnualRate is a decimal fraction; DayCount is act/365, act/360 or act/act.
+type InterestRate struct {
+	AccountType string `mapstructure:"account_type"`
+	Currency    string `mapstructure:"currency"`
+	AnnualRate  string `mapstructure:"annual_rate"`
+	DayCount    string `mapstructure:"day_count"`
+}
+
+// Snapshots configures the worker that records the closing balance of every account each day.
+// When enabled, the worker checks every PollIntervalSeconds for ended days not snapshotted yet.
+type Snapshots struct {
+	Enabled             bool `mapstructure:"enabled"`
+	PollIntervalSeconds int  `mapstructure:"poll_interval_seconds"`
+}
+
+// Events configures the outbox relay that publishes domain events.
+// When enabled, the relay publishes up to BatchSize unpublished events every PollIntervalMillis.
+// Publisher is log (default), file or nats.
+type Events struct {
+	Enabled            bool   `mapstructure:"enabled"`
+	PollIntervalMillis int    `mapstructure:"poll_interval_ms"`
+	BatchSize          int    `mapstructure:"batch_size"`
+	Publisher          string `mapstructure:"publisher"`
+	FilePath           string `mapstructure:"file_path"`
+	NATS               NATS   `mapstructure:"nats"`
+}
+
+// NATS configures the NATS publisher. Events are published to JetStream on SubjectPrefix.<event type>.
+// When Stream is set, the publisher creates that stream, bound to SubjectPrefix.>, unless it exists.
+type NATS struct {
+	URL            string `mapstructure:"url"`
+	SubjectPrefix  string `mapstructure:"subject_prefix"`
+	Stream         string `mapstructure:"stream"`
+	TimeoutSeconds int    `mapstructure:"timeout_seconds"`
+}
+
+// Webhooks configures the worker that delivers events to webhook endpoints.
+// When enabled, the worker sends up to BatchSize due deliveries every PollIntervalSeconds.
+// A failed delivery is retried after InitialBackoffSeconds, doubled after every attempt up to
+// MaxBackoffSeconds, and moved to the dead letters after MaxAttempts attempts.
+// AllowPrivateNetworks lets endpoints resolve to loopback and private addresses; it is meant for
+// local development only.
+type Webhooks struct {
+	Enabled               bool `mapstructure:"enabled"`
+	PollIntervalSeconds   int  `mapstructure:"poll_interval_seconds"`
+	BatchSize             int  `mapstructure:"batch_size"`
+	TimeoutSeconds        int  `mapstructure:"timeout_seconds"`
+	MaxAttempts           int  `mapstructure:"max_attempts"`
+	InitialBackoffSeconds int  `mapstructure:"initial_backoff_seconds"`
+	MaxBackoffSeconds     int  `mapstructure:"max_backoff_seconds"`
+	AllowPrivateNetworks  bool `mapstructure:"allow_private_networks"`
+}
+
+// Streams configures the in-process broadcaster of the account activity streams.
+// The last HistorySize events are kept so that reconnecting clients can resume; a client more
+// than SubscriberBuffer events behind is disconnected and resumes once it reconnects.
+type Streams struct {
+	HistorySize      int `mapstructure:"history_size"`
+	SubscriberBuffer int `mapstructure:"subscriber_buffer"`
+}
+
 type Postgres struct {
 	Host         string `mapstructure:"host"`
 	User         string `mapstructure:"user"`
@@ -1,13 +1,82 @@
 package entity
 
-import "time"
+import (
+	"errors"
+	"time"
+)
+
+// AccountStatus is the lifecycle state of an account.
+type AccountStatus string
+
+const (
+	// AccountActive accounts can send and receive money.
+	AccountActive AccountStatus = "active"
+	// AccountFrozen accounts keep receiving money but cannot be debited.
+	AccountFrozen AccountStatus = "frozen"
+	// AccountClosed accounts can neither send nor receive money. Closing is final.
+	AccountClosed AccountStatus = "closed"
+)
+
+// AccountType is the product an account is opened as.
+type AccountType string
+
+const (
+	// AccountChecking is a transactional account; it is the default type.
+	AccountChecking AccountType = "checking"
+	// AccountSavings is an account that earns interest.
+	AccountSavings AccountType = "savings"
+)
+
+// IsValid reports whether the account type is supported.
+func (t AccountType) IsValid() bool {
+	return t == AccountChecking || t == AccountSavings
+}
+
+var ErrInvalidAccountTransition = errors.New("invalid account status transition")
+
+// accountTransitions lists the statuses each status may move to.
+var accountTransitions = map[AccountStatus][]AccountStatus{
+	AccountActive: {AccountFrozen, AccountClosed},
+	AccountFrozen: {AccountActive, AccountClosed},
+}
 
 type Account struct {
-	ID        uint64 `gorm:"primaryKey"`
-	Balance   float64
-	CreatedAt time.Time
+	ID       uint64 `gorm:"primaryKey"`
+	Balance  Money
+	Currency Currency
+	Type     AccountType
+	Status   AccountStatus
+	// OverdraftLimit is how far below zero the balance may go, zero when no overdraft is granted
+	OverdraftLimit Money
+	ClosedAt       *time.Time
+	CreatedAt      time.Time
+	// Version is increased by every update of the account, made under its row lock, so a higher
+	// version is always a later state
+	Version uint64
 }
 
 func (Account) TableName() string {
 	return "accounts"
 }
+
+// CanDebit reports whether money can be taken from the account.
+func (a Account) CanDebit() bool {
+	return a.Status == AccountActive
+}
+
+// CanCredit reports whether money can be paid into the account.
+func (a Account) CanCredit() bool {
+	return a.Status == AccountActive || a.Status == AccountFrozen
+}
+
+// TransitionTo moves the account to the given status, rejecting transitions
+// the lifecycle does not allow.
+func (a *Account) TransitionTo(status AccountStatus) error {
+	for _, allowed := range accountTransitions[a.Status] {
+		if allowed == status {
+			a.Status = status
+			return nil
+		}
+	}
+	return ErrInvalidAccountTransition
+}
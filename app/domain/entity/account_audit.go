@@ -0,0 +1,25 @@
+package entity
+
+import "time"
+
+// Fields of an account whose administrative changes are audited.
+const (
+	AuditFieldOverdraftLimit = "overdraft_limit"
+)
+
+// AccountAudit records an administrative change to an account setting:
+// who changed which field, from what to what, and why.
+type AccountAudit struct {
+	ID        uint64 `gorm:"primaryKey;autoIncrement"`
+	AccountID uint64
+	Field     string
+	OldValue  string
+	NewValue  string
+	ChangedBy string
+	Reason    string
+	CreatedAt time.Time
+}
+
+func (AccountAudit) TableName() string {
+	return "account_audits"
+}
@@ -0,0 +1,23 @@
+package entity
+
+import "time"
+
+// BalanceSnapshot is the closing balance of an account on one day: the sum of its postings
+// made before the following midnight UTC. A historical balance starts from the latest snapshot
+// taken before it and adds the postings made since, instead of summing the whole ledger.
+type BalanceSnapshot struct {
+	AccountID    uint64    `gorm:"primaryKey"`
+	SnapshotDate time.Time `gorm:"primaryKey"` // midnight UTC of the day
+	Balance      Money
+	Currency     Currency
+	CreatedAt    time.Time
+}
+
+func (BalanceSnapshot) TableName() string {
+	return "balance_snapshots"
+}
+
+// End returns the midnight that closed the day; the snapshot covers the postings made before it.
+func (s BalanceSnapshot) End() time.Time {
+	return StartOfDay(s.SnapshotDate).AddDate(0, 0, 1)
+}
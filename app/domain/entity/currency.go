@@ -0,0 +1,48 @@
+package entity
+
+// Currency is an ISO 4217 alphabetic currency code.
+type Currency string
+
+const (
+	CurrencyUSD Currency = "USD"
+	CurrencyEUR Currency = "EUR"
+)
+
+// DefaultCurrency is assigned to accounts created without an explicit currency
+// and to every account that existed before currencies were introduced.
+const DefaultCurrency = CurrencyUSD
+
+// currencyExponents maps supported currencies to their ISO 4217 minor-unit exponent,
+// i.e. the number of fractional digits an amount in that currency may carry.
+var currencyExponents = map[Currency]int{
+	"AED": 2, "AUD": 2, "BHD": 3, "BRL": 2, "CAD": 2, "CHF": 2, "CLF": 4, "CLP": 0,
+	"CNY": 2, "CZK": 2, "DKK": 2, "EUR": 2, "GBP": 2, "HKD": 2, "HUF": 2, "IDR": 2,
+	"ILS": 2, "INR": 2, "ISK": 0, "JOD": 3, "JPY": 0, "KRW": 0, "KWD": 3, "MXN": 2,
+	"NOK": 2, "NZD": 2, "OMR": 3, "PLN": 2, "SAR": 2, "SEK": 2, "SGD": 2, "THB": 2,
+	"TND": 3, "TRY": 2, "USD": 2, "VND": 0, "ZAR": 2,
+}
+
+// IsValid reports whether c is a supported ISO 4217 currency.
+func (c Currency) IsValid() bool {
+	_, ok := currencyExponents[c]
+	return ok
+}
+
+// Exponent returns the number of minor-unit digits of c.
+// Unknown currencies report MoneyScale so no precision is lost.
+func (c Currency) Exponent() int {
+	if exp, ok := currencyExponents[c]; ok {
+		return exp
+	}
+	return MoneyScale
+}
+
+// Fits reports whether amount is a whole number of minor units of c.
+func (c Currency) Fits(amount Money) bool {
+	return amount.FitsPrecision(c.Exponent())
+}
+
+// Convert converts amount into c using rate, rounding to the minor unit of c.
+func (c Currency) Convert(amount Money, rate Rate) (Money, error) {
+	return amount.Convert(rate, c.Exponent())
+}
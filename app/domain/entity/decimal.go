@@ -0,0 +1,142 @@
+package entity
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+var (
+	ErrInvalidDecimal  = errors.New("invalid decimal")
+	ErrDecimalOverflow = errors.New("decimal out of range")
+)
+
+// pow10 returns 10^n for small non-negative n.
+func pow10(n int) int64 {
+	p := int64(1)
+	for i := 0; i < n; i++ {
+		p *= 10
+	}
+	return p
+}
+
+// parseFixed parses a decimal string into an integer number of 10^-scale units.
+// It rejects exponents, empty input and more than scale fractional digits.
+func parseFixed(s string, scale int) (int64, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return 0, ErrInvalidDecimal
+	}
+
+	negative := false
+	switch s[0] {
+	case '-':
+		negative = true
+		s = s[1:]
+	case '+':
+		s = s[1:]
+	}
+
+	intPart, fracPart, hasDot := strings.Cut(s, ".")
+	if intPart == "" && fracPart == "" || hasDot && fracPart == "" {
+		return 0, ErrInvalidDecimal
+	}
+	if len(fracPart) > scale {
+		return 0, fmt.Errorf("%w: more than %d fractional digits", ErrInvalidDecimal, scale)
+	}
+	if !isDigits(intPart) || !isDigits(fracPart) {
+		return 0, ErrInvalidDecimal
+	}
+
+	factor := pow10(scale)
+	var whole int64
+	if intPart != "" {
+		v, err := strconv.ParseInt(intPart, 10, 64)
+		if err != nil || v > math.MaxInt64/factor {
+			return 0, ErrDecimalOverflow
+		}
+		whole = v
+	}
+
+	var frac int64
+	if fracPart != "" {
+		padded := fracPart + strings.Repeat("0", scale-len(fracPart))
+		v, err := strconv.ParseInt(padded, 10, 64)
+		if err != nil {
+			return 0, ErrInvalidDecimal
+		}
+		frac = v
+	}
+
+	units := whole*factor + frac
+	if units < 0 {
+		return 0, ErrDecimalOverflow
+	}
+	if negative {
+		units = -units
+	}
+	return units, nil
+}
+
+// formatFixed formats units of 10^-scale as a decimal string, trimming trailing
+// zeros but keeping at least minFrac fractional digits.
+func formatFixed(units int64, scale int, minFrac int) string {
+	sign := ""
+	if units < 0 {
+		sign = "-"
+	}
+
+	// Work on the unsigned magnitude so math.MinInt64 formats correctly.
+	abs := uint64(units)
+	if units < 0 {
+		abs = uint64(-(units + 1)) + 1
+	}
+	factor := uint64(pow10(scale))
+	whole := abs / factor
+	frac := strings.TrimRight(fmt.Sprintf("%0*d", scale, abs%factor), "0")
+	if len(frac) < minFrac {
+		frac += strings.Repeat("0", minFrac-len(frac))
+	}
+	if frac == "" {
+		return sign + strconv.FormatUint(whole, 10)
+	}
+
+	return sign + strconv.FormatUint(whole, 10) + "." + frac
+}
+
+// mulDivRound returns a*b/c rounded half away from zero to a multiple of step.
+// Intermediate values use arbitrary precision so the product cannot overflow.
+func mulDivRound(a, b, c, step int64) (int64, error) {
+	num := new(big.Int).Mul(big.NewInt(a), big.NewInt(b))
+	den := new(big.Int).Mul(big.NewInt(c), big.NewInt(step))
+
+	quo, rem := new(big.Int).QuoRem(num, den, new(big.Int))
+	// Round half away from zero: compare 2*|rem| with |den|
+	rem.Abs(rem).Lsh(rem, 1)
+	if rem.Cmp(new(big.Int).Abs(den)) >= 0 {
+		if num.Sign()*den.Sign() < 0 {
+			quo.Sub(quo, big.NewInt(1))
+		} else {
+			quo.Add(quo, big.NewInt(1))
+		}
+	}
+
+	quo.Mul(quo, big.NewInt(step))
+	if !quo.IsInt64() {
+		return 0, ErrDecimalOverflow
+	}
+	return quo.Int64(), nil
+}
+
+// isDigits reports whether s consists of ASCII digits only.
+func isDigits(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,17 @@
+package entity
+
+import "time"
+
+// ExchangeRate is the current rate for converting BaseCurrency into QuoteCurrency:
+// one unit of BaseCurrency buys Rate units of QuoteCurrency.
+type ExchangeRate struct {
+	ID            uint64 `gorm:"primaryKey;autoIncrement"`
+	BaseCurrency  Currency
+	QuoteCurrency Currency
+	Rate          Rate
+	UpdatedAt     time.Time
+}
+
+func (ExchangeRate) TableName() string {
+	return "rates"
+}
@@ -0,0 +1,155 @@
+package entity
+
+import "errors"
+
+// TransferType classifies transfers for fee purposes.
+type TransferType string
+
+const (
+	// TransferInternal is a transfer between two accounts of the same currency.
+	TransferInternal TransferType = "internal"
+	// TransferFX is a cross-currency transfer made with an FX quote.
+	TransferFX TransferType = "fx"
+)
+
+// IsValid reports whether the transfer type is supported.
+func (t TransferType) IsValid() bool {
+	return t == TransferInternal || t == TransferFX
+}
+
+// FeeType is how a fee schedule prices a transfer.
+type FeeType string
+
+const (
+	// FeeFlat charges the same amount on every transfer.
+	FeeFlat FeeType = "flat"
+	// FeePercentage charges a fraction of the transfer amount.
+	FeePercentage FeeType = "percentage"
+	// FeeTiered prices the whole transfer amount with the tier it falls in.
+	FeeTiered FeeType = "tiered"
+)
+
+var ErrInvalidFeeSchedule = errors.New("invalid fee schedule")
+
+// FeeTier prices the transfers of a tiered schedule up to an amount, inclusive.
+// The last tier has no upper bound and leaves UpTo zero.
+type FeeTier struct {
+	UpTo Money
+	Flat Money
+	Rate Rate // fraction of the amount, e.g. 0.01 for 1%
+}
+
+// FeeSchedule is one configured fee on the transfers of a type and currency.
+// Fees are charged in the source account currency, on top of the transfer amount.
+type FeeSchedule struct {
+	Name         string
+	TransferType TransferType
+	Currency     Currency // source account currency
+	Type         FeeType
+	Flat         Money     // fee of flat schedules, and fixed part of percentage ones
+	Rate         Rate      // fraction of the amount charged by percentage schedules
+	Tiers        []FeeTier // tiers of tiered schedules, by ascending UpTo
+	Min          Money     // lowest fee charged, none when zero
+	Max          Money     // highest fee charged, none when zero
+}
+
+// Validate checks that the schedule is complete for its type.
+func (s FeeSchedule) Validate() error {
+	if s.Name == "" || !s.TransferType.IsValid() || !s.Currency.IsValid() {
+		return ErrInvalidFeeSchedule
+	}
+	if s.Flat.IsNegative() || s.Rate.units < 0 || s.Min.IsNegative() || s.Max.IsNegative() {
+		return ErrInvalidFeeSchedule
+	}
+	if !s.Max.IsZero() && s.Min.GreaterThan(s.Max) {
+		return ErrInvalidFeeSchedule
+	}
+	for _, m := range []Money{s.Flat, s.Min, s.Max} {
+		if !s.Currency.Fits(m) {
+			return ErrInvalidFeeSchedule
+		}
+	}
+
+	switch s.Type {
+	case FeeFlat:
+		if !s.Flat.IsPositive() {
+			return ErrInvalidFeeSchedule
+		}
+	case FeePercentage:
+		if !s.Rate.IsPositive() {
+			return ErrInvalidFeeSchedule
+		}
+	case FeeTiered:
+		return s.validateTiers()
+	default:
+		return ErrInvalidFeeSchedule
+	}
+	return nil
+}
+
+// validateTiers checks that the tiers have increasing bounds and that only the last one is unbounded.
+func (s FeeSchedule) validateTiers() error {
+	if len(s.Tiers) == 0 {
+		return ErrInvalidFeeSchedule
+	}
+	var previous Money
+	for i, tier := range s.Tiers {
+		last := i == len(s.Tiers)-1
+		if last != tier.UpTo.IsZero() || !last && !tier.UpTo.GreaterThan(previous) {
+			return ErrInvalidFeeSchedule
+		}
+		if tier.Flat.IsNegative() || tier.Rate.units < 0 || !s.Currency.Fits(tier.Flat) {
+			return ErrInvalidFeeSchedule
+		}
+		previous = tier.UpTo
+	}
+	return nil
+}
+
+// AppliesTo reports whether the schedule prices transfers of the given type from an account
+// of the given currency.
+func (s FeeSchedule) AppliesTo(transferType TransferType, currency Currency) bool {
+	return s.TransferType == transferType && s.Currency == currency
+}
+
+// Compute prices a transfer of amount. The percentage part is rounded half away from zero
+// to the minor unit of the schedule currency, then the total is brought within Min and Max.
+func (s FeeSchedule) Compute(amount Money) (FeeBreakdown, error) {
+	flat, rate := s.Flat, s.Rate
+	switch s.Type {
+	case FeeFlat:
+		rate = Rate{}
+	case FeeTiered:
+		tier := s.Tiers[len(s.Tiers)-1]
+		for _, t := range s.Tiers {
+			if !t.UpTo.IsZero() && !amount.GreaterThan(t.UpTo) {
+				tier = t
+				break
+			}
+		}
+		flat, rate = tier.Flat, tier.Rate
+	}
+
+	percentage, err := s.Currency.Convert(amount, rate)
+	if err != nil {
+		return FeeBreakdown{}, err
+	}
+
+	fee := flat.Add(percentage)
+	if fee.LessThan(s.Min) {
+		fee = s.Min
+	}
+	if !s.Max.IsZero() && fee.GreaterThan(s.Max) {
+		fee = s.Max
+	}
+	return FeeBreakdown{Schedule: s.Name, Fixed: flat, Percentage: percentage, Amount: fee}, nil
+}
+
+// FeeBreakdown is the fee charged on a transfer and how it was computed.
+// Amount is Fixed plus Percentage, unless the schedule's minimum or maximum applied.
+type FeeBreakdown struct {
+	Schedule   string // name of the fee schedule, empty when no fee was charged
+	Fixed      Money
+	Percentage Money
+	Amount     Money // debited from the source account on top of the transfer amount
+}
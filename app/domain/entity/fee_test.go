@@ -0,0 +1,86 @@
+package entity
+
+import "testing"
+
+func TestFeeSchedule_Compute(t *testing.T) {
+	tiered := FeeSchedule{
+		Name: "tiered", TransferType: TransferInternal, Currency: CurrencyUSD, Type: FeeTiered,
+		Tiers: []FeeTier{
+			{UpTo: MustParseMoney("1000"), Flat: MustParseMoney("0")},
+			{UpTo: MustParseMoney("10000"), Flat: MustParseMoney("1.00")},
+			{Rate: MustParseRate("0.0005")},
+		},
+		Max: MustParseMoney("25.00"),
+	}
+	percentage := FeeSchedule{
+		Name: "percentage", TransferType: TransferFX, Currency: CurrencyUSD, Type: FeePercentage,
+		Flat: MustParseMoney("0.50"), Rate: MustParseRate("0.005"),
+		Min: MustParseMoney("1.00"), Max: MustParseMoney("50.00"),
+	}
+
+	tests := []struct {
+		name     string
+		schedule FeeSchedule
+		amount   string
+		want     FeeBreakdown
+	}{
+		{
+			name:     "flat",
+			schedule: FeeSchedule{Name: "flat", Type: FeeFlat, Currency: CurrencyUSD, Flat: MustParseMoney("0.75")},
+			amount:   "5000",
+			want:     FeeBreakdown{Schedule: "flat", Fixed: MustParseMoney("0.75"), Amount: MustParseMoney("0.75")},
+		},
+		{
+			name:     "percentage_rounded_to_cents",
+			schedule: percentage,
+			amount:   "333.33",
+			// 0.5% of 333.33 is 1.66665, rounded half away from zero
+			want: FeeBreakdown{Schedule: "percentage", Fixed: MustParseMoney("0.50"),
+				Percentage: MustParseMoney("1.67"), Amount: MustParseMoney("2.17")},
+		},
+		{
+			name:     "percentage_min",
+			schedule: percentage,
+			amount:   "10",
+			want: FeeBreakdown{Schedule: "percentage", Fixed: MustParseMoney("0.50"),
+				Percentage: MustParseMoney("0.05"), Amount: MustParseMoney("1.00")},
+		},
+		{
+			name:     "percentage_max",
+			schedule: percentage,
+			amount:   "20000",
+			want: FeeBreakdown{Schedule: "percentage", Fixed: MustParseMoney("0.50"),
+				Percentage: MustParseMoney("100.00"), Amount: MustParseMoney("50.00")},
+		},
+		{
+			name:     "tier_bound_inclusive",
+			schedule: tiered,
+			amount:   "1000",
+			want:     FeeBreakdown{Schedule: "tiered"},
+		},
+		{
+			name:     "middle_tier",
+			schedule: tiered,
+			amount:   "1000.01",
+			want:     FeeBreakdown{Schedule: "tiered", Fixed: MustParseMoney("1.00"), Amount: MustParseMoney("1.00")},
+		},
+		{
+			name:     "unbounded_tier_capped",
+			schedule: tiered,
+			amount:   "100000",
+			want:     FeeBreakdown{Schedule: "tiered", Percentage: MustParseMoney("50.00"), Amount: MustParseMoney("25.00")},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.schedule.Compute(MustParseMoney(tt.amount))
+			if err != nil {
+				t.Fatalf("Compute() unexpected error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Compute() got = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
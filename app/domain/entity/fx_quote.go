@@ -0,0 +1,29 @@
+package entity
+
+import "time"
+
+// FXQuote locks an exchange rate for a short time so a client can execute
+// a cross-currency transfer at the rate it was shown. A quote is single-use.
+type FXQuote struct {
+	ID                  string `gorm:"primaryKey"`
+	SourceCurrency      Currency
+	DestinationCurrency Currency
+	Rate                Rate
+	ExpiresAt           time.Time
+	UsedAt              *time.Time
+	CreatedAt           time.Time
+}
+
+func (FXQuote) TableName() string {
+	return "fx_quotes"
+}
+
+// IsExpired reports whether the quote can no longer be used at the given time.
+func (q FXQuote) IsExpired(now time.Time) bool {
+	return !now.Before(q.ExpiresAt)
+}
+
+// IsUsed reports whether the quote has already been applied to a transfer.
+func (q FXQuote) IsUsed() bool {
+	return q.UsedAt != nil
+}
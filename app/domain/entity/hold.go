@@ -0,0 +1,64 @@
+package entity
+
+import "time"
+
+// HoldStatus is the lifecycle state of a hold.
+type HoldStatus string
+
+const (
+	HoldAuthorized HoldStatus = "authorized"
+	HoldCaptured   HoldStatus = "captured"
+	HoldVoided     HoldStatus = "voided"
+	HoldExpired    HoldStatus = "expired"
+)
+
+// Hold reserves funds on the source account for a transfer that is settled later.
+// A hold does not move money: it only lowers the available balance of the source account
+// until it is captured, voided or expires.
+type Hold struct {
+	ID                   uint64 `gorm:"primaryKey;autoIncrement"`
+	SourceAccountID      uint64
+	DestinationAccountID uint64
+	Amount               Money    // amount of the transfer
+	Currency             Currency // currency of Amount, always the source account currency
+	// Fee is the fee of a transfer of Amount, reserved with it and charged on capture
+	Fee            FeeBreakdown `gorm:"embedded;embeddedPrefix:fee_"`
+	CapturedAmount Money        // settled amount, at most Amount
+	Status         HoldStatus
+	TransactionID  *uint64 // transfer created by the capture
+	// RiskRule and RiskReason are set when a risk rule flagged the authorization for review
+	RiskRule   string
+	RiskReason string
+	ExpiresAt  time.Time
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+func (Hold) TableName() string {
+	return "holds"
+}
+
+// IsExpired reports whether the hold no longer reserves funds at the given time.
+func (h Hold) IsExpired(now time.Time) bool {
+	return !now.Before(h.ExpiresAt)
+}
+
+// Reserved returns the funds the hold keeps from being spent: the amount and its fee.
+func (h Hold) Reserved() Money {
+	return h.Amount.Add(h.Fee.Amount)
+}
+
+// IsActive reports whether the hold still reserves funds at the given time.
+func (h Hold) IsActive(now time.Time) bool {
+	return h.Status == HoldAuthorized && !h.IsExpired(now)
+}
+
+// NeedsReview reports whether the capture of the hold must be held for risk review.
+func (h Hold) NeedsReview() bool {
+	return h.RiskRule != ""
+}
+
+// RiskAssessment returns the review decision of a flagged hold.
+func (h Hold) RiskAssessment() RiskAssessment {
+	return RiskAssessment{Decision: RiskDecisionReview, Rule: h.RiskRule, Reason: h.RiskReason}
+}
@@ -0,0 +1,24 @@
+package entity
+
+import "time"
+
+// IdempotencyKey records the outcome of a request sent with an Idempotency-Key header
+// so that retries of the same request replay the original response.
+type IdempotencyKey struct {
+	Scope          string `gorm:"primaryKey"` // the endpoint the key was used on, e.g. "POST /api/v1/transactions"
+	Key            string `gorm:"primaryKey"`
+	RequestHash    string // fingerprint of the request payload
+	ResponseStatus int
+	ResponseBody   []byte
+	CreatedAt      time.Time
+	ExpiresAt      time.Time
+}
+
+func (IdempotencyKey) TableName() string {
+	return "idempotency_keys"
+}
+
+// IsExpired reports whether the key may be reused for a new request at the given time.
+func (k IdempotencyKey) IsExpired(now time.Time) bool {
+	return !now.Before(k.ExpiresAt)
+}
@@ -0,0 +1,129 @@
+package entity
+
+import (
+	"errors"
+	"time"
+)
+
+// DayCount is the day-count convention that spreads an annual rate over the days of a year.
+type DayCount string
+
+const (
+	// DayCountAct365 divides the annual rate by 365 days, leap years included.
+	DayCountAct365 DayCount = "act/365"
+	// DayCountAct360 divides the annual rate by 360 days.
+	DayCountAct360 DayCount = "act/360"
+	// DayCountActAct divides the annual rate by the actual number of days of the year, 365 or 366.
+	DayCountActAct DayCount = "act/act"
+)
+
+// IsValid reports whether the day-count convention is supported.
+func (d DayCount) IsValid() bool {
+	return d == DayCountAct365 || d == DayCountAct360 || d == DayCountActAct
+}
+
+// DaysInYear returns the number of days the annual rate is divided by on date.
+func (d DayCount) DaysInYear(date time.Time) int64 {
+	switch d {
+	case DayCountAct360:
+		return 360
+	case DayCountActAct:
+		year := date.UTC().Year()
+		if year%4 == 0 && (year%100 != 0 || year%400 == 0) {
+			return 366
+		}
+	}
+	return 365
+}
+
+var ErrInvalidInterestRate = errors.New("invalid interest rate")
+
+// InterestRate is the annual rate paid on the accounts of a type and currency.
+type InterestRate struct {
+	AccountType AccountType
+	Currency    Currency
+	AnnualRate  Rate // fraction per year, e.g. 0.0425 for 4.25%
+	DayCount    DayCount
+}
+
+// Validate checks that the rate is complete.
+func (r InterestRate) Validate() error {
+	if !r.AccountType.IsValid() || !r.Currency.IsValid() || !r.AnnualRate.IsPositive() || !r.DayCount.IsValid() {
+		return ErrInvalidInterestRate
+	}
+	return nil
+}
+
+// DailyInterest returns the interest earned on date by an end-of-day balance, rounded half
+// away from zero to MoneyScale digits. Sub-cent amounts are kept so that the monthly posting
+// credits their sum. A balance at or below zero earns nothing.
+func (r InterestRate) DailyInterest(balance Money, date time.Time) (Money, error) {
+	if !balance.IsPositive() {
+		return Money{}, nil
+	}
+	units, err := mulDivRound(balance.units, r.AnnualRate.units, pow10(RateScale)*r.DayCount.DaysInYear(date), 1)
+	if err != nil {
+		return Money{}, ErrMoneyOverflow
+	}
+	return Money{units: units}, nil
+}
+
+// InterestAccrual is the interest an account earned on one day. Accruals are credited
+// to the account by the monthly posting; until then JournalEntryID and PostedAt are nil.
+type InterestAccrual struct {
+	ID          uint64 `gorm:"primaryKey;autoIncrement"`
+	AccountID   uint64
+	AccrualDate time.Time // midnight UTC of the accrued day
+	Balance     Money     // end-of-day balance the interest was computed on
+	AnnualRate  Rate
+	DayCount    DayCount
+	Amount      Money
+	Currency    Currency
+	// Carried marks the part of a posting below the currency minor unit, dated on the first day
+	// of the next month and credited by the next posting; its Balance is zero
+	Carried bool
+	// JournalEntryID is the entry that credited the accrual
+	JournalEntryID *uint64
+	PostedAt       *time.Time
+	CreatedAt      time.Time
+}
+
+func (InterestAccrual) TableName() string {
+	return "interest_accruals"
+}
+
+// InterestRunKind is the job an interest run performed.
+type InterestRunKind string
+
+const (
+	// InterestRunAccrual accrues the interest of one day.
+	InterestRunAccrual InterestRunKind = "accrual"
+	// InterestRunPosting posts the interest accrued in one month.
+	InterestRunPosting InterestRunKind = "posting"
+)
+
+// InterestRun records that the accrual of a day, or the posting of a month, completed.
+// RunDate is the accrued day, or the first day of the posted month.
+type InterestRun struct {
+	ID        uint64 `gorm:"primaryKey;autoIncrement"`
+	Kind      InterestRunKind
+	RunDate   time.Time
+	Accounts  int64 // number of accounts accrued or credited
+	CreatedAt time.Time
+}
+
+func (InterestRun) TableName() string {
+	return "interest_runs"
+}
+
+// StartOfDay returns midnight UTC of the day containing t.
+func StartOfDay(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// StartOfMonth returns midnight UTC of the first day of the month containing t.
+func StartOfMonth(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+}
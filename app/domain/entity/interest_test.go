@@ -0,0 +1,91 @@
+package entity
+
+import (
+	"testing"
+	"time"
+)
+
+func TestInterestRate_DailyInterest(t *testing.T) {
+	date := time.Date(2025, 9, 14, 0, 0, 0, 0, time.UTC)
+	leapDate := time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		rate    InterestRate
+		balance string
+		date    time.Time
+		want    string
+	}{
+		{
+			// 10000 * 0.0365 / 365
+			name:    "act_365",
+			rate:    InterestRate{AnnualRate: MustParseRate("0.0365"), DayCount: DayCountAct365},
+			balance: "10000.00",
+			date:    date,
+			want:    "1.00",
+		},
+		{
+			// 10000 * 0.0425 / 365 = 1.16438..., kept to four digits
+			name:    "sub_cent_digits_kept",
+			rate:    InterestRate{AnnualRate: MustParseRate("0.0425"), DayCount: DayCountAct365},
+			balance: "10000.00",
+			date:    date,
+			want:    "1.1644",
+		},
+		{
+			name:    "act_360",
+			rate:    InterestRate{AnnualRate: MustParseRate("0.036"), DayCount: DayCountAct360},
+			balance: "10000.00",
+			date:    date,
+			want:    "1.00",
+		},
+		{
+			name:    "act_act_leap_year",
+			rate:    InterestRate{AnnualRate: MustParseRate("0.0366"), DayCount: DayCountActAct},
+			balance: "10000.00",
+			date:    leapDate,
+			want:    "1.00",
+		},
+		{
+			name:    "negative_balance_earns_nothing",
+			rate:    InterestRate{AnnualRate: MustParseRate("0.0365"), DayCount: DayCountAct365},
+			balance: "-500.00",
+			date:    date,
+			want:    "0",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.rate.DailyInterest(MustParseMoney(tt.balance), tt.date)
+			if err != nil {
+				t.Fatalf("DailyInterest() unexpected error = %v", err)
+			}
+			if got != MustParseMoney(tt.want) {
+				t.Errorf("DailyInterest() got = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDayCount_DaysInYear(t *testing.T) {
+	tests := []struct {
+		dayCount DayCount
+		year     int
+		want     int64
+	}{
+		{DayCountAct365, 2024, 365},
+		{DayCountAct360, 2024, 360},
+		{DayCountActAct, 2024, 366},
+		{DayCountActAct, 2025, 365},
+		{DayCountActAct, 2100, 365},
+		{DayCountActAct, 2000, 366},
+	}
+
+	for _, tt := range tests {
+		got := tt.dayCount.DaysInYear(time.Date(tt.year, 6, 1, 0, 0, 0, 0, time.UTC))
+		if got != tt.want {
+			t.Errorf("DaysInYear(%s, %d) got = %d, want %d", tt.dayCount, tt.year, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,168 @@
+package entity
+
+import (
+	"errors"
+	"time"
+)
+
+// PostingDirection is the side of the ledger a posting is booked on.
+type PostingDirection string
+
+const (
+	PostingDebit  PostingDirection = "debit"
+	PostingCredit PostingDirection = "credit"
+)
+
+// System accounts are bank-side ledger accounts that have no row in the accounts table.
+// Customer accounts are liabilities of the bank, so a credit increases their balance.
+const (
+	// SystemAccountOpeningBalance is the equity account that funds opening balances.
+	SystemAccountOpeningBalance = "equity:opening_balance"
+	// SystemAccountFXPosition holds the bank's currency position built up by conversions.
+	SystemAccountFXPosition = "fx:position"
+	// SystemAccountFeeRevenue is the income account transfer fees are credited to.
+	SystemAccountFeeRevenue = "revenue:fees"
+	// SystemAccountInterestExpense is the expense account interest paid to customers is debited from.
+	SystemAccountInterestExpense = "expense:interest"
+)
+
+var (
+	ErrUnbalancedEntry = errors.New("journal entry debits and credits do not balance")
+	ErrInvalidPosting  = errors.New("invalid posting")
+)
+
+// JournalEntry groups the postings of one business event. Within an entry,
+// debits equal credits for every currency.
+type JournalEntry struct {
+	ID            uint64 `gorm:"primaryKey;autoIncrement"`
+	TransactionID *uint64
+	Description   string
+	CreatedAt     time.Time
+
+	Postings []Posting `gorm:"foreignKey:JournalEntryID"`
+}
+
+func (JournalEntry) TableName() string {
+	return "journal_entries"
+}
+
+// Posting is a single debit or credit of a positive amount against either a customer
+// account (AccountID) or a system account (SystemAccount).
+type Posting struct {
+	ID             uint64 `gorm:"primaryKey;autoIncrement"`
+	JournalEntryID uint64
+	AccountID      *uint64
+	SystemAccount  string
+	Direction      PostingDirection
+	Amount         Money
+	Currency       Currency
+	CreatedAt      time.Time
+}
+
+func (Posting) TableName() string {
+	return "postings"
+}
+
+// SignedAmount returns the posting's effect on a customer account balance:
+// positive for credits, negative for debits.
+func (p Posting) SignedAmount() Money {
+	if p.Direction == PostingDebit {
+		return p.Amount.Neg()
+	}
+	return p.Amount
+}
+
+// Debit adds a debit posting against a customer account.
+func (e *JournalEntry) Debit(accountID uint64, amount Money, currency Currency) *JournalEntry {
+	e.Postings = append(e.Postings, Posting{AccountID: &accountID, Direction: PostingDebit, Amount: amount, Currency: currency})
+	return e
+}
+
+// Credit adds a credit posting against a customer account.
+func (e *JournalEntry) Credit(accountID uint64, amount Money, currency Currency) *JournalEntry {
+	e.Postings = append(e.Postings, Posting{AccountID: &accountID, Direction: PostingCredit, Amount: amount, Currency: currency})
+	return e
+}
+
+// DebitSystem adds a debit posting against a system account.
+func (e *JournalEntry) DebitSystem(account string, amount Money, currency Currency) *JournalEntry {
+	e.Postings = append(e.Postings, Posting{SystemAccount: account, Direction: PostingDebit, Amount: amount, Currency: currency})
+	return e
+}
+
+// CreditSystem adds a credit posting against a system account.
+func (e *JournalEntry) CreditSystem(account string, amount Money, currency Currency) *JournalEntry {
+	e.Postings = append(e.Postings, Posting{SystemAccount: account, Direction: PostingCredit, Amount: amount, Currency: currency})
+	return e
+}
+
+// Validate checks that every posting is well formed and that debits equal credits
+// per currency. The database enforces the same rule with a deferred constraint trigger.
+func (e *JournalEntry) Validate() error {
+	if len(e.Postings) < 2 {
+		return ErrUnbalancedEntry
+	}
+
+	totals := make(map[Currency]Money)
+	for _, p := range e.Postings {
+		if !p.Amount.IsPositive() || !p.Currency.IsValid() {
+			return ErrInvalidPosting
+		}
+		if (p.AccountID == nil) == (p.SystemAccount == "") {
+			return ErrInvalidPosting
+		}
+		switch p.Direction {
+		case PostingDebit:
+			totals[p.Currency] = totals[p.Currency].Add(p.Amount)
+		case PostingCredit:
+			totals[p.Currency] = totals[p.Currency].Sub(p.Amount)
+		default:
+			return ErrInvalidPosting
+		}
+	}
+
+	for _, total := range totals {
+		if !total.IsZero() {
+			return ErrUnbalancedEntry
+		}
+	}
+	return nil
+}
+
+// NewTransferEntry builds the journal entry of a transfer: the source account is debited
+// and the destination credited. Cross-currency transfers are balanced per currency
+// through the FX position account, and a fee is debited from the source account too
+// and credited to the fee revenue account.
+func NewTransferEntry(tx *Transaction) *JournalEntry {
+	entry := &JournalEntry{TransactionID: &tx.ID, Description: "transfer"}
+	if tx.OriginalTransactionID != nil {
+		entry.Description = "reversal"
+	}
+	entry.Debit(tx.SourceAccountID, tx.Amount, tx.Currency)
+	if tx.Currency != tx.DestinationCurrency {
+		entry.CreditSystem(SystemAccountFXPosition, tx.Amount, tx.Currency)
+		entry.DebitSystem(SystemAccountFXPosition, tx.DestinationAmount, tx.DestinationCurrency)
+	}
+	entry.Credit(tx.DestinationAccountID, tx.DestinationAmount, tx.DestinationCurrency)
+	if tx.Fee.Amount.IsPositive() {
+		entry.Debit(tx.SourceAccountID, tx.Fee.Amount, tx.Currency)
+		entry.CreditSystem(SystemAccountFeeRevenue, tx.Fee.Amount, tx.Currency)
+	}
+	return entry
+}
+
+// NewOpeningEntry builds the journal entry that funds an account's opening balance.
+func NewOpeningEntry(account *Account) *JournalEntry {
+	entry := &JournalEntry{Description: "opening balance"}
+	entry.DebitSystem(SystemAccountOpeningBalance, account.Balance, account.Currency)
+	entry.Credit(account.ID, account.Balance, account.Currency)
+	return entry
+}
+
+// NewInterestEntry builds the journal entry that pays the interest accrued by an account.
+func NewInterestEntry(accountID uint64, amount Money, currency Currency) *JournalEntry {
+	entry := &JournalEntry{Description: "interest"}
+	entry.DebitSystem(SystemAccountInterestExpense, amount, currency)
+	entry.Credit(accountID, amount, currency)
+	return entry
+}
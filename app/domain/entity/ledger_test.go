@@ -0,0 +1,74 @@
+package entity
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestJournalEntry_Validate(t *testing.T) {
+	usd := MustParseMoney("100.00")
+	eur := MustParseMoney("92.35")
+
+	tests := []struct {
+		name    string
+		entry   *JournalEntry
+		wantErr error
+	}{
+		{
+			name:  "balanced_transfer",
+			entry: new(JournalEntry).Debit(111, usd, CurrencyUSD).Credit(222, usd, CurrencyUSD),
+		},
+		{
+			name: "balanced_cross_currency",
+			entry: NewTransferEntry(&Transaction{
+				SourceAccountID: 111, DestinationAccountID: 222,
+				Amount: usd, Currency: CurrencyUSD,
+				DestinationAmount: eur, DestinationCurrency: CurrencyEUR,
+			}),
+		},
+		{
+			name: "balanced_with_fee",
+			entry: NewTransferEntry(&Transaction{
+				SourceAccountID: 111, DestinationAccountID: 222,
+				Amount: usd, Currency: CurrencyUSD,
+				DestinationAmount: usd, DestinationCurrency: CurrencyUSD,
+				Fee: FeeBreakdown{Schedule: "internal_usd", Amount: MustParseMoney("1.50")},
+			}),
+		},
+		{
+			name:    "single_posting",
+			entry:   new(JournalEntry).Debit(111, usd, CurrencyUSD),
+			wantErr: ErrUnbalancedEntry,
+		},
+		{
+			name:    "debits_exceed_credits",
+			entry:   new(JournalEntry).Debit(111, usd, CurrencyUSD).Credit(222, MustParseMoney("99.99"), CurrencyUSD),
+			wantErr: ErrUnbalancedEntry,
+		},
+		{
+			// Equal totals in different currencies do not balance each other
+			name:    "currencies_do_not_net",
+			entry:   new(JournalEntry).Debit(111, usd, CurrencyUSD).Credit(222, usd, CurrencyEUR),
+			wantErr: ErrUnbalancedEntry,
+		},
+		{
+			name:    "zero_amount",
+			entry:   new(JournalEntry).Debit(111, Money{}, CurrencyUSD).Credit(222, Money{}, CurrencyUSD),
+			wantErr: ErrInvalidPosting,
+		},
+		{
+			name:    "unknown_currency",
+			entry:   new(JournalEntry).Debit(111, usd, "").Credit(222, usd, ""),
+			wantErr: ErrInvalidPosting,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.entry.Validate()
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
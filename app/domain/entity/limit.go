@@ -0,0 +1,99 @@
+package entity
+
+import (
+	"errors"
+	"time"
+)
+
+// LimitRuleType is the kind of check a limit rule performs on a transfer.
+type LimitRuleType string
+
+const (
+	// LimitMaxAmount caps the amount of a single transfer.
+	LimitMaxAmount LimitRuleType = "max_amount"
+	// LimitMaxTotal caps the total amount sent by an account within a window.
+	LimitMaxTotal LimitRuleType = "max_total"
+	// LimitMaxCount caps the number of transfers sent by an account within a window.
+	LimitMaxCount LimitRuleType = "max_count"
+)
+
+// LimitWindow is the calendar period, in UTC, that windowed rules aggregate over.
+type LimitWindow string
+
+const (
+	LimitWindowHour  LimitWindow = "hour"
+	LimitWindowDay   LimitWindow = "day"
+	LimitWindowMonth LimitWindow = "month"
+)
+
+var ErrInvalidLimitRule = errors.New("invalid limit rule")
+
+// Start returns the start of the window containing now.
+func (w LimitWindow) Start(now time.Time) time.Time {
+	now = now.UTC()
+	switch w {
+	case LimitWindowHour:
+		return now.Truncate(time.Hour)
+	case LimitWindowDay:
+		return time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	case LimitWindowMonth:
+		return time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	}
+	return now
+}
+
+// IsValid reports whether the window is supported.
+func (w LimitWindow) IsValid() bool {
+	return w == LimitWindowHour || w == LimitWindowDay || w == LimitWindowMonth
+}
+
+// LimitRule is one configured limit on the transfers an account sends.
+// Amount rules apply to accounts of their currency only; count rules without
+// a currency apply to every account.
+type LimitRule struct {
+	Name     string
+	Type     LimitRuleType
+	Window   LimitWindow // unused by max_amount rules
+	Currency Currency
+	Amount   Money // limit of max_amount and max_total rules
+	Count    int64 // limit of max_count rules
+}
+
+// Validate checks that the rule is complete for its type.
+func (r LimitRule) Validate() error {
+	if r.Name == "" {
+		return ErrInvalidLimitRule
+	}
+	if r.Currency != "" && !r.Currency.IsValid() {
+		return ErrInvalidLimitRule
+	}
+
+	switch r.Type {
+	case LimitMaxAmount:
+		if r.Currency == "" || !r.Amount.IsPositive() {
+			return ErrInvalidLimitRule
+		}
+	case LimitMaxTotal:
+		if r.Currency == "" || !r.Amount.IsPositive() || !r.Window.IsValid() {
+			return ErrInvalidLimitRule
+		}
+	case LimitMaxCount:
+		if r.Count <= 0 || !r.Window.IsValid() {
+			return ErrInvalidLimitRule
+		}
+	default:
+		return ErrInvalidLimitRule
+	}
+	return nil
+}
+
+// AppliesTo reports whether the rule limits accounts of the given currency.
+func (r LimitRule) AppliesTo(currency Currency) bool {
+	return r.Currency == "" || r.Currency == currency
+}
+
+// TransferUsage is what an account has sent within a window.
+type TransferUsage struct {
+	Total Money
+	Count int64
+}
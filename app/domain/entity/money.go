@@ -0,0 +1,241 @@
+package entity
+
+import (
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"strconv"
+)
+
+// MoneyScale is the number of fractional digits kept by Money.
+// Four digits cover every ISO 4217 minor unit in use.
+const MoneyScale = 4
+
+// moneyFactor is 10^MoneyScale, the number of units in one whole currency unit.
+const moneyFactor int64 = 10000
+
+// maxMoneyUnits bounds Money to 14 integer digits, the range of the NUMERIC(18, 4) columns amounts
+// are stored in. The sum or difference of two amounts in range still fits in int64, so Add, Sub and
+// Neg never wrap; a result out of range is rejected when it is written.
+const maxMoneyUnits int64 = 1e18 - 1
+
+var (
+	ErrInvalidMoney  = errors.New("invalid money amount")
+	ErrMoneyOverflow = errors.New("money amount out of range")
+)
+
+// Money is an exact fixed-point monetary amount with MoneyScale fractional digits.
+// Amounts never pass through binary floating point: they are stored as NUMERIC
+// in the database and encoded as decimal strings in JSON.
+type Money struct {
+	units int64
+}
+
+// MoneyFromUnits creates Money from a raw number of 10^-MoneyScale units.
+func MoneyFromUnits(units int64) Money {
+	return Money{units: units}
+}
+
+// NewMoney creates Money from a whole number of currency units.
+func NewMoney(whole int64) Money {
+	return Money{units: whole * moneyFactor}
+}
+
+// ParseMoney parses a decimal string such as "10", "-3.5" or "1000.2500".
+// It rejects exponents, empty input, more than MoneyScale fractional digits and amounts out of range.
+func ParseMoney(s string) (Money, error) {
+	units, err := parseFixed(s, MoneyScale)
+	if errors.Is(err, ErrDecimalOverflow) || err == nil && !inMoneyRange(units) {
+		return Money{}, ErrMoneyOverflow
+	}
+	if err != nil {
+		return Money{}, fmt.Errorf("%w: %v", ErrInvalidMoney, err)
+	}
+	return Money{units: units}, nil
+}
+
+// inMoneyRange reports whether a number of units is within the range of Money.
+func inMoneyRange(units int64) bool {
+	return units >= -maxMoneyUnits && units <= maxMoneyUnits
+}
+
+// MustParseMoney is like ParseMoney but panics on error.
+// It is intended for constants and tests.
+func MustParseMoney(s string) Money {
+	m, err := ParseMoney(s)
+	if err != nil {
+		panic(err)
+	}
+	return m
+}
+
+// Units returns the raw number of 10^-MoneyScale units.
+func (m Money) Units() int64 {
+	return m.units
+}
+
+// Add returns m + o.
+func (m Money) Add(o Money) Money {
+	return Money{units: m.units + o.units}
+}
+
+// Sub returns m - o.
+func (m Money) Sub(o Money) Money {
+	return Money{units: m.units - o.units}
+}
+
+// Neg returns -m.
+func (m Money) Neg() Money {
+	return Money{units: -m.units}
+}
+
+// Cmp compares m and o and returns -1, 0 or +1.
+func (m Money) Cmp(o Money) int {
+	switch {
+	case m.units < o.units:
+		return -1
+	case m.units > o.units:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// LessThan reports whether m < o.
+func (m Money) LessThan(o Money) bool {
+	return m.units < o.units
+}
+
+// GreaterThan reports whether m > o.
+func (m Money) GreaterThan(o Money) bool {
+	return m.units > o.units
+}
+
+// IsZero reports whether m is zero.
+func (m Money) IsZero() bool {
+	return m.units == 0
+}
+
+// IsPositive reports whether m is greater than zero.
+func (m Money) IsPositive() bool {
+	return m.units > 0
+}
+
+// IsNegative reports whether m is less than zero.
+func (m Money) IsNegative() bool {
+	return m.units < 0
+}
+
+// FitsPrecision reports whether m has at most exponent fractional digits,
+// i.e. whether it is a whole number of minor units of a currency with that exponent.
+func (m Money) FitsPrecision(exponent int) bool {
+	if exponent >= MoneyScale {
+		return true
+	}
+	return m.units%pow10(MoneyScale-exponent) == 0
+}
+
+// Convert multiplies m by rate and rounds the result half away from zero
+// to exponent fractional digits.
+func (m Money) Convert(rate Rate, exponent int) (Money, error) {
+	units, err := mulDivRound(m.units, rate.units, pow10(RateScale), roundingStep(exponent))
+	if err != nil || !inMoneyRange(units) {
+		return Money{}, ErrMoneyOverflow
+	}
+	return Money{units: units}, nil
+}
+
+// Truncate drops the digits of m beyond exponent fractional digits, rounding toward zero.
+func (m Money) Truncate(exponent int) Money {
+	return Money{units: m.units - m.units%roundingStep(exponent)}
+}
+
+// roundingStep returns the number of Money units in one minor unit of a currency
+// with the given exponent.
+func roundingStep(exponent int) int64 {
+	if exponent >= MoneyScale {
+		return 1
+	}
+	return pow10(MoneyScale - exponent)
+}
+
+// String formats m as a decimal string with at least two fractional digits,
+// e.g. "1000.00", "-0.50" or "0.125".
+func (m Money) String() string {
+	return formatFixed(m.units, MoneyScale, 2)
+}
+
+// MarshalJSON encodes m as a JSON string to keep clients from parsing it as a float.
+func (m Money) MarshalJSON() ([]byte, error) {
+	return []byte(strconv.Quote(m.String())), nil
+}
+
+// UnmarshalJSON accepts either a JSON string ("10.50") or a JSON number (10.50).
+// Numbers are parsed from their literal text, never through float64.
+func (m *Money) UnmarshalJSON(data []byte) error {
+	s := string(data)
+	if s == "null" {
+		return nil
+	}
+	if unquoted, err := strconv.Unquote(s); err == nil {
+		s = unquoted
+	}
+
+	parsed, err := ParseMoney(s)
+	if err != nil {
+		return err
+	}
+	*m = parsed
+	return nil
+}
+
+// UnmarshalParam parses m from a query or form parameter such as "10.50".
+func (m *Money) UnmarshalParam(param string) error {
+	parsed, err := ParseMoney(param)
+	if err != nil {
+		return err
+	}
+	*m = parsed
+	return nil
+}
+
+// Value implements driver.Valuer so Money is written to NUMERIC columns as text.
+// Amounts out of range, such as an oversized sum, are rejected.
+func (m Money) Value() (driver.Value, error) {
+	if !inMoneyRange(m.units) {
+		return nil, ErrMoneyOverflow
+	}
+	return m.String(), nil
+}
+
+// Scan implements sql.Scanner for NUMERIC columns. Values out of range, such as an oversized
+// SUM, are rejected with ErrMoneyOverflow.
+func (m *Money) Scan(src interface{}) error {
+	var s string
+	switch v := src.(type) {
+	case nil:
+		*m = Money{}
+		return nil
+	case string:
+		s = v
+	case []byte:
+		s = string(v)
+	case int64:
+		if v < -maxMoneyUnits/moneyFactor || v > maxMoneyUnits/moneyFactor {
+			return ErrMoneyOverflow
+		}
+		*m = NewMoney(v)
+		return nil
+	case float64:
+		s = strconv.FormatFloat(v, 'f', MoneyScale, 64)
+	default:
+		return fmt.Errorf("cannot scan %T into Money", src)
+	}
+
+	parsed, err := ParseMoney(s)
+	if err != nil {
+		return err
+	}
+	*m = parsed
+	return nil
+}
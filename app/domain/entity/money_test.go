@@ -0,0 +1,241 @@
+package entity
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func TestParseMoney(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    int64
+		wantErr bool
+	}{
+		{name: "integer", input: "10", want: 100000},
+		{name: "two_decimals", input: "10.50", want: 105000},
+		{name: "four_decimals", input: "0.0001", want: 1},
+		{name: "negative", input: "-3.5", want: -35000},
+		{name: "explicit_plus", input: "+1.25", want: 12500},
+		{name: "leading_dot", input: ".5", want: 5000},
+		{name: "too_many_decimals", input: "1.00001", wantErr: true},
+		{name: "exponent", input: "1e3", wantErr: true},
+		{name: "trailing_dot", input: "1.", wantErr: true},
+		{name: "empty", input: "", wantErr: true},
+		{name: "sign_only", input: "-", wantErr: true},
+		{name: "overflow", input: "99999999999999999999", wantErr: true},
+		{name: "largest", input: "99999999999999.9999", want: 999999999999999999},
+		{name: "out_of_range", input: "100000000000000", wantErr: true},
+		{name: "negative_out_of_range", input: "-100000000000000", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseMoney(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParseMoney() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && got.Units() != tt.want {
+				t.Errorf("ParseMoney() got = %v, want %v", got.Units(), tt.want)
+			}
+		})
+	}
+}
+
+func TestMoney_String(t *testing.T) {
+	tests := []struct {
+		name  string
+		money Money
+		want  string
+	}{
+		{name: "zero", money: Money{}, want: "0.00"},
+		{name: "whole", money: NewMoney(1000), want: "1000.00"},
+		{name: "cents", money: MustParseMoney("10.5"), want: "10.50"},
+		{name: "sub_cent", money: MustParseMoney("0.125"), want: "0.125"},
+		{name: "negative", money: MustParseMoney("-0.5"), want: "-0.50"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.money.String(); got != tt.want {
+				t.Errorf("String() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMoney_ArithmeticIsExact(t *testing.T) {
+	// 0.1 + 0.2 drifts in float64 but must be exact here
+	sum := MustParseMoney("0.1").Add(MustParseMoney("0.2"))
+	if sum != MustParseMoney("0.3") {
+		t.Errorf("Add() got = %v, want 0.30", sum)
+	}
+
+	balance := MustParseMoney("1000.00")
+	for i := 0; i < 10; i++ {
+		balance = balance.Sub(MustParseMoney("0.10"))
+	}
+	if balance != MustParseMoney("999.00") {
+		t.Errorf("Sub() got = %v, want 999.00", balance)
+	}
+}
+
+func TestMoney_Value(t *testing.T) {
+	largest := MustParseMoney("99999999999999.9999")
+	if v, err := largest.Value(); err != nil || v != "99999999999999.9999" {
+		t.Errorf("Value() got = %v, %v", v, err)
+	}
+	// The sum of two amounts in range does not wrap, and is rejected when written
+	if _, err := largest.Add(largest).Value(); !errors.Is(err, ErrMoneyOverflow) {
+		t.Errorf("Value() error = %v, want ErrMoneyOverflow", err)
+	}
+}
+
+func TestMoney_JSON(t *testing.T) {
+	type payload struct {
+		Amount Money `json:"amount"`
+	}
+
+	var fromString, fromNumber payload
+	if err := json.Unmarshal([]byte(`{"amount":"100.50"}`), &fromString); err != nil {
+		t.Fatalf("Unmarshal() string error = %v", err)
+	}
+	if err := json.Unmarshal([]byte(`{"amount":100.50}`), &fromNumber); err != nil {
+		t.Fatalf("Unmarshal() number error = %v", err)
+	}
+	if fromString != fromNumber || fromString.Amount != MustParseMoney("100.5") {
+		t.Errorf("Unmarshal() got = %v and %v, want 100.50", fromString.Amount, fromNumber.Amount)
+	}
+
+	if err := json.Unmarshal([]byte(`{"amount":"1.23456"}`), &fromString); err == nil {
+		t.Errorf("Unmarshal() expected error for excess precision")
+	}
+
+	out, err := json.Marshal(payload{Amount: MustParseMoney("100.5")})
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	if string(out) != `{"amount":"100.50"}` {
+		t.Errorf("Marshal() got = %s", out)
+	}
+}
+
+func TestMoney_Scan(t *testing.T) {
+	tests := []struct {
+		name    string
+		src     interface{}
+		want    Money
+		wantErr bool
+	}{
+		{name: "numeric_text", src: "1500.7500", want: MustParseMoney("1500.75")},
+		{name: "numeric_bytes", src: []byte("-2.0000"), want: MustParseMoney("-2")},
+		{name: "bigint", src: int64(42), want: NewMoney(42)},
+		// A SUM may exceed the range of the columns
+		{name: "numeric_out_of_range", src: "123456789012345678.0000", wantErr: true},
+		{name: "bigint_out_of_range", src: int64(100000000000000), wantErr: true},
+		{name: "null", src: nil, want: Money{}},
+		{name: "unsupported", src: true, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got Money
+			err := got.Scan(tt.src)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Scan() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("Scan() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCurrency_Convert(t *testing.T) {
+	tests := []struct {
+		name     string
+		amount   Money
+		rate     Rate
+		currency Currency
+		want     Money
+	}{
+		{name: "round_half_up_to_cents", amount: NewMoney(100), rate: MustParseRate("0.92345"), currency: "EUR", want: MustParseMoney("92.35")},
+		{name: "round_down_to_cents", amount: NewMoney(100), rate: MustParseRate("0.92344"), currency: "EUR", want: MustParseMoney("92.34")},
+		{name: "zero_exponent", amount: MustParseMoney("10.00"), rate: MustParseRate("149.6789"), currency: "JPY", want: NewMoney(1497)},
+		{name: "three_decimals", amount: NewMoney(1), rate: MustParseRate("0.3071234"), currency: "KWD", want: MustParseMoney("0.307")},
+		{name: "negative", amount: NewMoney(-100), rate: MustParseRate("0.92345"), currency: "EUR", want: MustParseMoney("-92.35")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.currency.Convert(tt.amount, tt.rate)
+			if err != nil {
+				t.Fatalf("Convert() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Convert() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMoney_Truncate(t *testing.T) {
+	tests := []struct {
+		amount   string
+		exponent int
+		want     string
+	}{
+		{amount: "2.3288", exponent: 2, want: "2.32"},
+		{amount: "0.0099", exponent: 2, want: "0.00"},
+		{amount: "-2.3288", exponent: 2, want: "-2.32"},
+		{amount: "10.5", exponent: 0, want: "10.00"},
+		{amount: "0.1234", exponent: 4, want: "0.1234"},
+	}
+
+	for _, tt := range tests {
+		if got := MustParseMoney(tt.amount).Truncate(tt.exponent); got != MustParseMoney(tt.want) {
+			t.Errorf("Truncate(%s, %d) got = %v, want %s", tt.amount, tt.exponent, got, tt.want)
+		}
+	}
+}
+
+func TestCurrency_Fits(t *testing.T) {
+	if !Currency("USD").Fits(MustParseMoney("10.25")) {
+		t.Errorf("Fits() USD 10.25 should fit")
+	}
+	if Currency("USD").Fits(MustParseMoney("10.255")) {
+		t.Errorf("Fits() USD 10.255 should not fit")
+	}
+	if Currency("JPY").Fits(MustParseMoney("10.5")) {
+		t.Errorf("Fits() JPY 10.5 should not fit")
+	}
+}
+
+func TestRate_Inverse(t *testing.T) {
+	tests := []struct {
+		name    string
+		rate    Rate
+		want    Rate
+		wantErr bool
+	}{
+		{name: "one", rate: OneRate, want: OneRate},
+		{name: "exact", rate: MustParseRate("0.8"), want: MustParseRate("1.25")},
+		{name: "rounded", rate: MustParseRate("0.92345"), want: MustParseRate("1.08289566")},
+		{name: "zero", rate: Rate{}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.rate.Inverse()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Inverse() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("Inverse() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,151 @@
+package entity
+
+import (
+	"encoding/json"
+	"strconv"
+	"time"
+)
+
+// EventType names a domain event published to other services.
+type EventType string
+
+const (
+	// EventAccountCreated is recorded when an account is opened.
+	EventAccountCreated EventType = "account.created"
+	// EventTransferPosted is recorded when a transfer, a split payment leg or a reversal is posted.
+	EventTransferPosted EventType = "transfer.posted"
+	// EventTransferFailed is recorded when a pending transfer is failed.
+	EventTransferFailed EventType = "transfer.failed"
+)
+
+// OutboxEvent is a domain event written in the same database transaction as the change it
+// describes, and published afterwards by the outbox relay. Events are delivered at least once and
+// mostly in ID order, but a retried event can arrive after later ones; consumers deduplicate them by ID.
+type OutboxEvent struct {
+	ID          uint64 `gorm:"primaryKey;autoIncrement"`
+	Type        EventType
+	AggregateID string          // ID of the account or transaction the event is about
+	Payload     json.RawMessage // one of the *Event payloads below, by Type
+	Attempts    int             // failed publish attempts
+	LastError   string
+	CreatedAt   time.Time
+	PublishedAt *time.Time // nil until the relay published the event
+	// PublishLeasedUntil is set while a relay publishes the event; other relays skip it until then
+	PublishLeasedUntil *time.Time
+	// WebhooksQueuedAt is nil until the relay queued the event for the subscribed webhook endpoints
+	WebhooksQueuedAt *time.Time
+}
+
+func (OutboxEvent) TableName() string {
+	return "outbox_events"
+}
+
+// EventEnvelope is the message published for an outbox event.
+type EventEnvelope struct {
+	ID          uint64          `json:"id"`
+	Type        EventType       `json:"type"`
+	AggregateID string          `json:"aggregate_id"`
+	OccurredAt  time.Time       `json:"occurred_at"`
+	Data        json.RawMessage `json:"data"`
+}
+
+// Envelope returns the JSON message published for the event.
+func (e *OutboxEvent) Envelope() ([]byte, error) {
+	return json.Marshal(EventEnvelope{
+		ID:          e.ID,
+		Type:        e.Type,
+		AggregateID: e.AggregateID,
+		OccurredAt:  e.CreatedAt.UTC(),
+		Data:        e.Payload,
+	})
+}
+
+// AccountCreatedEvent is the payload of an account.created event.
+type AccountCreatedEvent struct {
+	AccountID uint64        `json:"account_id"`
+	Type      AccountType   `json:"type"`
+	Currency  Currency      `json:"currency"`
+	Balance   Money         `json:"balance"` // opening balance
+	Status    AccountStatus `json:"status"`
+}
+
+// TransferPostedEvent is the payload of a transfer.posted event.
+type TransferPostedEvent struct {
+	TransactionID         uint64    `json:"transaction_id"`
+	SourceAccountID       uint64    `json:"source_account_id"`
+	DestinationAccountID  uint64    `json:"destination_account_id"`
+	Amount                Money     `json:"amount"`
+	Currency              Currency  `json:"currency"`
+	DestinationAmount     Money     `json:"destination_amount"`
+	DestinationCurrency   Currency  `json:"destination_currency"`
+	ExchangeRate          Rate      `json:"exchange_rate"`
+	Fee                   Money     `json:"fee"`
+	OriginalTransactionID *uint64   `json:"original_transaction_id,omitempty"`
+	SplitPaymentID        *uint64   `json:"split_payment_id,omitempty"`
+	PostedAt              time.Time `json:"posted_at"`
+}
+
+// TransferFailedEvent is the payload of a transfer.failed event.
+type TransferFailedEvent struct {
+	TransactionID        uint64    `json:"transaction_id"`
+	SourceAccountID      uint64    `json:"source_account_id"`
+	DestinationAccountID uint64    `json:"destination_account_id"`
+	Amount               Money     `json:"amount"`
+	Currency             Currency  `json:"currency"`
+	Reason               string    `json:"reason"`
+	FailedAt             time.Time `json:"failed_at"`
+}
+
+// NewAccountCreatedEvent returns the account.created event of a newly created account.
+func NewAccountCreatedEvent(account *Account) (*OutboxEvent, error) {
+	return newOutboxEvent(EventAccountCreated, account.ID, AccountCreatedEvent{
+		AccountID: account.ID,
+		Type:      account.Type,
+		Currency:  account.Currency,
+		Balance:   account.Balance,
+		Status:    account.Status,
+	})
+}
+
+// NewTransferPostedEvent returns the transfer.posted event of a transaction that was just posted.
+func NewTransferPostedEvent(t *Transaction) (*OutboxEvent, error) {
+	return newOutboxEvent(EventTransferPosted, t.ID, TransferPostedEvent{
+		TransactionID:         t.ID,
+		SourceAccountID:       t.SourceAccountID,
+		DestinationAccountID:  t.DestinationAccountID,
+		Amount:                t.Amount,
+		Currency:              t.Currency,
+		DestinationAmount:     t.DestinationAmount,
+		DestinationCurrency:   t.DestinationCurrency,
+		ExchangeRate:          t.ExchangeRate,
+		Fee:                   t.Fee.Amount,
+		OriginalTransactionID: t.OriginalTransactionID,
+		SplitPaymentID:        t.SplitPaymentID,
+		PostedAt:              t.UpdatedAt.UTC(),
+	})
+}
+
+// NewTransferFailedEvent returns the transfer.failed event of a transaction that was just failed.
+func NewTransferFailedEvent(t *Transaction, reason string) (*OutboxEvent, error) {
+	return newOutboxEvent(EventTransferFailed, t.ID, TransferFailedEvent{
+		TransactionID:        t.ID,
+		SourceAccountID:      t.SourceAccountID,
+		DestinationAccountID: t.DestinationAccountID,
+		Amount:               t.Amount,
+		Currency:             t.Currency,
+		Reason:               reason,
+		FailedAt:             t.UpdatedAt.UTC(),
+	})
+}
+
+func newOutboxEvent(eventType EventType, aggregateID uint64, payload interface{}) (*OutboxEvent, error) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+	return &OutboxEvent{
+		Type:        eventType,
+		AggregateID: strconv.FormatUint(aggregateID, 10),
+		Payload:     data,
+	}, nil
+}
@@ -0,0 +1,124 @@
+package entity
+
+import (
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"strconv"
+)
+
+// RateScale is the number of fractional digits kept by Rate.
+const RateScale = 8
+
+var ErrInvalidRate = errors.New("invalid exchange rate")
+
+// Rate is an exact fixed-point exchange rate with RateScale fractional digits.
+// Like Money it is stored as NUMERIC and encoded as a decimal string in JSON.
+type Rate struct {
+	units int64
+}
+
+// OneRate is the identity rate applied to same-currency transfers.
+var OneRate = Rate{units: pow10(RateScale)}
+
+// ParseRate parses a decimal string such as "1.0825" into a Rate.
+func ParseRate(s string) (Rate, error) {
+	units, err := parseFixed(s, RateScale)
+	if err != nil {
+		return Rate{}, fmt.Errorf("%w: %v", ErrInvalidRate, err)
+	}
+	return Rate{units: units}, nil
+}
+
+// MustParseRate is like ParseRate but panics on error.
+// It is intended for constants and tests.
+func MustParseRate(s string) Rate {
+	r, err := ParseRate(s)
+	if err != nil {
+		panic(err)
+	}
+	return r
+}
+
+// Units returns the raw number of 10^-RateScale units.
+func (r Rate) Units() int64 {
+	return r.units
+}
+
+// IsPositive reports whether r is greater than zero.
+func (r Rate) IsPositive() bool {
+	return r.units > 0
+}
+
+// Inverse returns 1/r rounded half away from zero to RateScale fractional digits.
+func (r Rate) Inverse() (Rate, error) {
+	if r.units == 0 {
+		return Rate{}, ErrInvalidRate
+	}
+	units, err := mulDivRound(pow10(RateScale), pow10(RateScale), r.units, 1)
+	if err != nil {
+		return Rate{}, ErrInvalidRate
+	}
+	return Rate{units: units}, nil
+}
+
+// String formats r as a decimal string, e.g. "1.0825".
+func (r Rate) String() string {
+	return formatFixed(r.units, RateScale, 1)
+}
+
+// MarshalJSON encodes r as a JSON string.
+func (r Rate) MarshalJSON() ([]byte, error) {
+	return []byte(strconv.Quote(r.String())), nil
+}
+
+// UnmarshalJSON accepts either a JSON string ("1.0825") or a JSON number (1.0825).
+func (r *Rate) UnmarshalJSON(data []byte) error {
+	s := string(data)
+	if s == "null" {
+		return nil
+	}
+	if unquoted, err := strconv.Unquote(s); err == nil {
+		s = unquoted
+	}
+
+	parsed, err := ParseRate(s)
+	if err != nil {
+		return err
+	}
+	*r = parsed
+	return nil
+}
+
+// Value implements driver.Valuer so Rate is written to NUMERIC columns as text.
+func (r Rate) Value() (driver.Value, error) {
+	return r.String(), nil
+}
+
+// Scan implements sql.Scanner for NUMERIC columns.
+func (r *Rate) Scan(src interface{}) error {
+	var s string
+	switch v := src.(type) {
+	case nil:
+		*r = Rate{}
+		return nil
+	case string:
+		s = v
+	case []byte:
+		s = string(v)
+	case int64:
+		*r = Rate{units: v * pow10(RateScale)}
+		return nil
+	case float64:
+		s = strconv.FormatFloat(v, 'f', RateScale, 64)
+	default:
+		return fmt.Errorf("cannot scan %T into Rate", src)
+	}
+
+	parsed, err := ParseRate(s)
+	if err != nil {
+		return err
+	}
+	*r = parsed
+	return nil
+}
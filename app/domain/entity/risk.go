@@ -0,0 +1,55 @@
+package entity
+
+import "time"
+
+// RiskDecision is the outcome of evaluating a transfer for fraud and risk.
+type RiskDecision string
+
+const (
+	// RiskDecisionAllow lets the transfer be posted right away.
+	RiskDecisionAllow RiskDecision = "allow"
+	// RiskDecisionReview records the transfer as pending until an operator releases or rejects it.
+	RiskDecisionReview RiskDecision = "review"
+	// RiskDecisionDeny refuses the transfer.
+	RiskDecisionDeny RiskDecision = "deny"
+)
+
+// IsValid reports whether the decision is supported.
+func (d RiskDecision) IsValid() bool {
+	return d == RiskDecisionAllow || d == RiskDecisionReview || d == RiskDecisionDeny
+}
+
+// RiskAssessment is the decision of a risk rule, or of a chain of rules, with the rule
+// that made it and why. An allowed transfer has no rule.
+type RiskAssessment struct {
+	Decision RiskDecision
+	Rule     string
+	Reason   string
+}
+
+// RiskReviewStatus is the lifecycle state of a risk review.
+type RiskReviewStatus string
+
+const (
+	RiskReviewOpen     RiskReviewStatus = "open"
+	RiskReviewReleased RiskReviewStatus = "released"
+	RiskReviewRejected RiskReviewStatus = "rejected"
+)
+
+// RiskReview is the manual review of a transfer held as pending by a risk rule.
+// Releasing it posts the transfer; rejecting it fails the transfer.
+type RiskReview struct {
+	ID            uint64 `gorm:"primaryKey;autoIncrement"`
+	TransactionID uint64
+	Rule          string
+	Reason        string
+	Status        RiskReviewStatus
+	DecidedBy     string
+	DecisionNote  string
+	DecidedAt     *time.Time
+	CreatedAt     time.Time
+}
+
+func (RiskReview) TableName() string {
+	return "risk_reviews"
+}
@@ -0,0 +1,116 @@
+package entity
+
+import "time"
+
+// Recurrence is how often a scheduled transfer repeats.
+type Recurrence string
+
+const (
+	RecurrenceOnce    Recurrence = "once"
+	RecurrenceDaily   Recurrence = "daily"
+	RecurrenceWeekly  Recurrence = "weekly"
+	RecurrenceMonthly Recurrence = "monthly"
+)
+
+// ScheduleStatus is the lifecycle state of a scheduled transfer.
+type ScheduleStatus string
+
+const (
+	// ScheduleActive has an occurrence still to run at NextRunAt.
+	ScheduleActive ScheduleStatus = "active"
+	// ScheduleCompleted has run its last occurrence.
+	ScheduleCompleted ScheduleStatus = "completed"
+	// ScheduleCancelled was stopped before its last occurrence.
+	ScheduleCancelled ScheduleStatus = "cancelled"
+)
+
+// ScheduledTransfer is a transfer made at a future time, once or on a recurrence.
+// Occurrences are computed in UTC from StartAt, so a monthly transfer started on the
+// 31st runs on the last day of shorter months and on the 31st again afterwards.
+type ScheduledTransfer struct {
+	ID                   uint64 `gorm:"primaryKey;autoIncrement"`
+	SourceAccountID      uint64
+	DestinationAccountID uint64
+	Amount               Money    // debited from the source account at every occurrence
+	Currency             Currency // currency of Amount, always the source account currency
+	Recurrence           Recurrence
+	StartAt              time.Time  // first occurrence
+	EndAt                *time.Time // last time a recurring transfer may run, inclusive; nil runs forever
+	NextRunAt            time.Time
+	Occurrence           int64 // index of NextRunAt among the occurrences, 0 for StartAt
+	Status               ScheduleStatus
+	CreatedAt            time.Time
+	UpdatedAt            time.Time
+}
+
+func (ScheduledTransfer) TableName() string {
+	return "scheduled_transfers"
+}
+
+// OccurrenceAt returns the time of the n-th occurrence, 0 being StartAt.
+func (s ScheduledTransfer) OccurrenceAt(n int64) time.Time {
+	start := s.StartAt.UTC()
+	switch s.Recurrence {
+	case RecurrenceDaily:
+		return start.AddDate(0, 0, int(n))
+	case RecurrenceWeekly:
+		return start.AddDate(0, 0, 7*int(n))
+	case RecurrenceMonthly:
+		// time.AddDate normalizes Jan 31 + 1 month to Mar 3; clamp to the end of the month instead
+		year, month, day := start.Date()
+		first := time.Date(year, month+time.Month(n), 1, start.Hour(), start.Minute(), start.Second(),
+			start.Nanosecond(), time.UTC)
+		if last := first.AddDate(0, 1, -1).Day(); day > last {
+			day = last
+		}
+		return first.AddDate(0, 0, day-1)
+	}
+	return start
+}
+
+// Advance moves the schedule past the occurrence at NextRunAt.
+// Occurrences missed while no worker was running are skipped rather than replayed:
+// the next run is the first occurrence after now. A one-off transfer, or a recurring
+// one whose next occurrence falls after EndAt, is completed.
+func (s *ScheduledTransfer) Advance(now time.Time) {
+	if s.Recurrence == RecurrenceOnce || s.Recurrence == "" {
+		s.Status = ScheduleCompleted
+		return
+	}
+
+	next := s.Occurrence + 1
+	for !s.OccurrenceAt(next).After(now) {
+		next++
+	}
+	if s.EndAt != nil && s.OccurrenceAt(next).After(*s.EndAt) {
+		s.Status = ScheduleCompleted
+		return
+	}
+	s.Occurrence = next
+	s.NextRunAt = s.OccurrenceAt(next)
+}
+
+// RunStatus is the outcome of one occurrence of a scheduled transfer.
+type RunStatus string
+
+const (
+	RunSucceeded RunStatus = "succeeded"
+	RunFailed    RunStatus = "failed"
+)
+
+// ScheduledTransferRun records the outcome of one occurrence of a scheduled transfer.
+// A succeeded run links the transaction it made, which may still be pending a risk review.
+type ScheduledTransferRun struct {
+	ID                  uint64 `gorm:"primaryKey;autoIncrement"`
+	ScheduledTransferID uint64
+	ScheduledFor        time.Time
+	Status              RunStatus
+	TransactionID       *uint64
+	ErrorCode           string
+	ErrorMessage        string
+	CreatedAt           time.Time
+}
+
+func (ScheduledTransferRun) TableName() string {
+	return "scheduled_transfer_runs"
+}
@@ -0,0 +1,96 @@
+package entity
+
+import (
+	"testing"
+	"time"
+)
+
+func TestScheduledTransfer_OccurrenceAt(t *testing.T) {
+	start := time.Date(2025, 1, 31, 9, 30, 0, 0, time.UTC)
+	tests := []struct {
+		recurrence Recurrence
+		n          int64
+		want       time.Time
+	}{
+		{recurrence: RecurrenceOnce, n: 0, want: start},
+		{recurrence: RecurrenceDaily, n: 1, want: time.Date(2025, 2, 1, 9, 30, 0, 0, time.UTC)},
+		{recurrence: RecurrenceWeekly, n: 2, want: time.Date(2025, 2, 14, 9, 30, 0, 0, time.UTC)},
+		{recurrence: RecurrenceMonthly, n: 1, want: time.Date(2025, 2, 28, 9, 30, 0, 0, time.UTC)},
+		{recurrence: RecurrenceMonthly, n: 2, want: time.Date(2025, 3, 31, 9, 30, 0, 0, time.UTC)},
+		{recurrence: RecurrenceMonthly, n: 3, want: time.Date(2025, 4, 30, 9, 30, 0, 0, time.UTC)},
+		{recurrence: RecurrenceMonthly, n: 13, want: time.Date(2026, 2, 28, 9, 30, 0, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.recurrence), func(t *testing.T) {
+			s := ScheduledTransfer{Recurrence: tt.recurrence, StartAt: start}
+			if got := s.OccurrenceAt(tt.n); !got.Equal(tt.want) {
+				t.Errorf("OccurrenceAt(%d) = %v, want %v", tt.n, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestScheduledTransfer_Advance(t *testing.T) {
+	start := time.Date(2025, 9, 1, 8, 0, 0, 0, time.UTC)
+	endAt := time.Date(2025, 9, 3, 8, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name           string
+		schedule       ScheduledTransfer
+		now            time.Time
+		wantStatus     ScheduleStatus
+		wantNextRunAt  time.Time
+		wantOccurrence int64
+	}{
+		{
+			name:       "once_completes",
+			schedule:   ScheduledTransfer{Recurrence: RecurrenceOnce, StartAt: start, NextRunAt: start},
+			now:        start,
+			wantStatus: ScheduleCompleted,
+		},
+		{
+			name:           "daily_moves_to_next_day",
+			schedule:       ScheduledTransfer{Recurrence: RecurrenceDaily, StartAt: start, NextRunAt: start},
+			now:            start.Add(time.Minute),
+			wantStatus:     ScheduleActive,
+			wantNextRunAt:  start.AddDate(0, 0, 1),
+			wantOccurrence: 1,
+		},
+		{
+			name:           "missed_occurrences_are_skipped",
+			schedule:       ScheduledTransfer{Recurrence: RecurrenceDaily, StartAt: start, NextRunAt: start},
+			now:            start.AddDate(0, 0, 4).Add(time.Hour),
+			wantStatus:     ScheduleActive,
+			wantNextRunAt:  start.AddDate(0, 0, 5),
+			wantOccurrence: 5,
+		},
+		{
+			name: "last_occurrence_on_end_date",
+			schedule: ScheduledTransfer{Recurrence: RecurrenceDaily, StartAt: start, EndAt: &endAt,
+				NextRunAt: start.AddDate(0, 0, 1), Occurrence: 1},
+			now:            start.AddDate(0, 0, 1),
+			wantStatus:     ScheduleActive,
+			wantNextRunAt:  endAt,
+			wantOccurrence: 2,
+		},
+		{
+			name: "completes_after_end_date",
+			schedule: ScheduledTransfer{Recurrence: RecurrenceDaily, StartAt: start, EndAt: &endAt,
+				NextRunAt: endAt, Occurrence: 2},
+			now:        endAt,
+			wantStatus: ScheduleCompleted,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := tt.schedule
+			s.Status = ScheduleActive
+			s.Advance(tt.now)
+			if s.Status != tt.wantStatus {
+				t.Fatalf("Advance() status = %v, want %v", s.Status, tt.wantStatus)
+			}
+			if tt.wantStatus == ScheduleActive && (!s.NextRunAt.Equal(tt.wantNextRunAt) || s.Occurrence != tt.wantOccurrence) {
+				t.Errorf("Advance() next = %v (#%d), want %v (#%d)", s.NextRunAt, s.Occurrence, tt.wantNextRunAt, tt.wantOccurrence)
+			}
+		})
+	}
+}
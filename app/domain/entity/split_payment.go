@@ -0,0 +1,18 @@
+package entity
+
+import "time"
+
+// SplitPayment is the parent of a payment that debits one source account once and credits
+// several destination accounts. Each credited account is a leg: a regular transaction that
+// references the split payment through SplitPaymentID.
+type SplitPayment struct {
+	ID              uint64 `gorm:"primaryKey;autoIncrement"`
+	SourceAccountID uint64
+	Amount          Money    // total debited from the source account, the sum of the legs
+	Currency        Currency // source account currency, shared by every leg
+	CreatedAt       time.Time
+}
+
+func (SplitPayment) TableName() string {
+	return "split_payments"
+}
@@ -0,0 +1,39 @@
+package entity
+
+import "time"
+
+// StatementFormat is the file format an account statement is rendered in.
+type StatementFormat string
+
+const (
+	StatementCSV     StatementFormat = "csv"
+	StatementJSONL   StatementFormat = "jsonl"
+	StatementCamt053 StatementFormat = "camt053"
+)
+
+// IsValid reports whether the statement format is supported.
+func (f StatementFormat) IsValid() bool {
+	return f == StatementCSV || f == StatementJSONL || f == StatementCamt053
+}
+
+// StatementFilter selects the movements of one account booked in a period.
+type StatementFilter struct {
+	AccountID uint64
+	From      time.Time // inclusive
+	To        time.Time // exclusive
+}
+
+// StatementLine is one movement of an account: the net effect on it of one journal entry.
+// Movements come from the ledger, so interest and opening balances are listed with transfers.
+type StatementLine struct {
+	JournalEntryID uint64
+	TransactionID  *uint64 // nil for entries without a transaction, e.g. interest
+	Description    string  // the journal entry description: transfer, reversal, interest...
+	BookedAt       time.Time
+	Amount         Money // positive when the account was credited, fee included
+	Currency       Currency
+	// CounterpartyAccountID is the other account of a transfer
+	CounterpartyAccountID *uint64
+	// Fee is the part of a debit that paid the transfer fee
+	Fee Money
+}
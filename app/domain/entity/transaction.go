@@ -1,13 +1,62 @@
 package entity
 
-import "time"
+import (
+	"errors"
+	"time"
+)
+
+// TransactionStatus is the lifecycle state of a transaction.
+type TransactionStatus string
+
+const (
+	// TransactionPending is a transaction recorded but not yet applied to balances.
+	TransactionPending TransactionStatus = "pending"
+	// TransactionPosted is a transaction applied to balances and booked in the ledger.
+	TransactionPosted TransactionStatus = "posted"
+	// TransactionFailed is a pending transaction that will never be applied.
+	TransactionFailed TransactionStatus = "failed"
+	// TransactionReversed is a posted transaction whose full amount was returned.
+	TransactionReversed TransactionStatus = "reversed"
+)
+
+var ErrInvalidStatusTransition = errors.New("invalid transaction status transition")
+
+// transactionTransitions lists the statuses each status may move to.
+// Failed and reversed are final.
+var transactionTransitions = map[TransactionStatus][]TransactionStatus{
+	TransactionPending: {TransactionPosted, TransactionFailed},
+	TransactionPosted:  {TransactionReversed},
+}
+
+// CanTransitionTo reports whether a transaction in status s may move to next.
+func (s TransactionStatus) CanTransitionTo(next TransactionStatus) bool {
+	for _, allowed := range transactionTransitions[s] {
+		if allowed == next {
+			return true
+		}
+	}
+	return false
+}
 
 type Transaction struct {
 	ID                   uint64 `gorm:"primaryKey;autoIncrement"`
 	SourceAccountID      uint64
 	DestinationAccountID uint64
-	Amount               float64
-	TransactionTime      time.Time
+	Amount               Money    // debited from the source account
+	Currency             Currency // currency of Amount, always the source account currency
+	DestinationAmount    Money    // credited to the destination account
+	DestinationCurrency  Currency // currency of DestinationAmount
+	ExchangeRate         Rate     // applied rate, OneRate for same-currency transfers
+	QuoteID              *string  // FX quote the rate was taken from, nil for same-currency transfers
+	// OriginalTransactionID links a reversal to the transfer it undoes, nil for regular transfers
+	OriginalTransactionID *uint64
+	// SplitPaymentID links a leg of a split payment to its parent, nil for regular transfers
+	SplitPaymentID *uint64
+	// Fee is charged to the source account on top of Amount and credited to the fee revenue account
+	Fee             FeeBreakdown `gorm:"embedded;embeddedPrefix:fee_"`
+	Status          TransactionStatus
+	TransactionTime time.Time
+	UpdatedAt       time.Time // time of the last status change
 
 	// Relationships
 	SourceAccount      Account `gorm:"foreignKey:SourceAccountID"`
@@ -17,3 +66,26 @@ type Transaction struct {
 func (Transaction) TableName() string {
 	return "transactions"
 }
+
+// Type returns the transfer type fee schedules are selected by.
+func (t *Transaction) Type() TransferType {
+	if t.Currency != t.DestinationCurrency {
+		return TransferFX
+	}
+	return TransferInternal
+}
+
+// TotalDebit returns what the transfer takes from the source account: the amount and the fee.
+func (t *Transaction) TotalDebit() Money {
+	return t.Amount.Add(t.Fee.Amount)
+}
+
+// TransitionTo moves the transaction to the given status, rejecting transitions
+// the lifecycle does not allow.
+func (t *Transaction) TransitionTo(status TransactionStatus) error {
+	if !t.Status.CanTransitionTo(status) {
+		return ErrInvalidStatusTransition
+	}
+	t.Status = status
+	return nil
+}
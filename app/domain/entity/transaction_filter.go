@@ -0,0 +1,29 @@
+package entity
+
+import "time"
+
+// TransactionDirection is the side an account is on in a transfer.
+type TransactionDirection string
+
+const (
+	TransactionIncoming TransactionDirection = "incoming"
+	TransactionOutgoing TransactionDirection = "outgoing"
+)
+
+// TransactionFilter selects the transactions of one account, newest first.
+// Amount bounds apply to the amount in the account's own currency: Amount for
+// outgoing transfers and DestinationAmount for incoming ones.
+type TransactionFilter struct {
+	AccountID uint64
+	Direction TransactionDirection // both directions when empty
+	From      *time.Time           // inclusive lower bound of TransactionTime
+	To        *time.Time           // exclusive upper bound of TransactionTime
+	MinAmount *Money
+	MaxAmount *Money
+
+	// Keyset cursor: only transactions strictly older than (AfterTime, AfterID) are returned
+	AfterTime *time.Time
+	AfterID   uint64
+
+	Limit int
+}
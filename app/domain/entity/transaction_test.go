@@ -0,0 +1,37 @@
+package entity
+
+import "testing"
+
+func TestTransaction_TransitionTo(t *testing.T) {
+	tests := []struct {
+		from    TransactionStatus
+		to      TransactionStatus
+		wantErr bool
+	}{
+		{from: TransactionPending, to: TransactionPosted},
+		{from: TransactionPending, to: TransactionFailed},
+		{from: TransactionPosted, to: TransactionReversed},
+		{from: TransactionPending, to: TransactionReversed, wantErr: true},
+		{from: TransactionPosted, to: TransactionFailed, wantErr: true},
+		{from: TransactionPosted, to: TransactionPending, wantErr: true},
+		{from: TransactionFailed, to: TransactionPosted, wantErr: true},
+		{from: TransactionReversed, to: TransactionPosted, wantErr: true},
+		{from: "", to: TransactionPosted, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.from)+"_to_"+string(tt.to), func(t *testing.T) {
+			tx := &Transaction{Status: tt.from}
+			err := tx.TransitionTo(tt.to)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("TransitionTo() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			want := tt.to
+			if tt.wantErr {
+				want = tt.from
+			}
+			if tx.Status != want {
+				t.Errorf("TransitionTo() status = %v, want %v", tx.Status, want)
+			}
+		})
+	}
+}
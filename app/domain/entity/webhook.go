@@ -0,0 +1,223 @@
+package entity
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"database/sql/driver"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Headers of a webhook request.
+const (
+	// WebhookDeliveryHeader carries the delivery ID, the same on every retry of a delivery.
+	WebhookDeliveryHeader = "X-Webhook-Delivery"
+	// WebhookEventHeader carries the event type.
+	WebhookEventHeader = "X-Webhook-Event"
+	// WebhookTimestampHeader carries the Unix time the request was signed at.
+	WebhookTimestampHeader = "X-Webhook-Timestamp"
+	// WebhookSignatureHeader carries the HMAC-SHA256 signature of the request, "sha256=<hex>".
+	WebhookSignatureHeader = "X-Webhook-Signature"
+)
+
+var (
+	ErrInvalidWebhookSignature = errors.New("invalid webhook signature")
+	ErrWebhookTimestampExpired = errors.New("webhook timestamp outside the tolerance")
+	ErrWebhookDestination      = errors.New("webhook destination is not a public address")
+)
+
+// WebhookStatus is the state of a webhook endpoint.
+type WebhookStatus string
+
+const (
+	// WebhookActive receives the events it subscribed to.
+	WebhookActive WebhookStatus = "active"
+	// WebhookDisabled receives nothing; its pending deliveries are dead-lettered.
+	WebhookDisabled WebhookStatus = "disabled"
+)
+
+// IsValid reports whether the event type is one endpoints can subscribe to.
+func (t EventType) IsValid() bool {
+	return t == EventAccountCreated || t == EventTransferPosted || t == EventTransferFailed
+}
+
+// EventTypes is a set of event types, stored as a Postgres text array.
+type EventTypes []EventType
+
+// Contains reports whether the set holds t.
+func (e EventTypes) Contains(t EventType) bool {
+	for _, eventType := range e {
+		if eventType == t {
+			return true
+		}
+	}
+	return false
+}
+
+// Value encodes the set as a Postgres array literal. Event types never contain commas or quotes.
+func (e EventTypes) Value() (driver.Value, error) {
+	types := make([]string, len(e))
+	for i, t := range e {
+		types[i] = string(t)
+	}
+	return "{" + strings.Join(types, ",") + "}", nil
+}
+
+// Scan decodes a Postgres array literal.
+func (e *EventTypes) Scan(src interface{}) error {
+	var s string
+	switch v := src.(type) {
+	case string:
+		s = v
+	case []byte:
+		s = string(v)
+	default:
+		return fmt.Errorf("cannot scan %T into EventTypes", src)
+	}
+	s = strings.TrimSuffix(strings.TrimPrefix(s, "{"), "}")
+	*e = EventTypes{}
+	if s == "" {
+		return nil
+	}
+	for _, t := range strings.Split(s, ",") {
+		*e = append(*e, EventType(strings.Trim(t, `"`)))
+	}
+	return nil
+}
+
+// WebhookEndpoint is a URL that receives the events of the types it subscribed to.
+// Requests are signed with Secret, which is only shown when the endpoint is registered.
+type WebhookEndpoint struct {
+	ID         uint64 `gorm:"primaryKey;autoIncrement"`
+	URL        string
+	Secret     string
+	EventTypes EventTypes `gorm:"type:text[]"`
+	Status     WebhookStatus
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+func (WebhookEndpoint) TableName() string {
+	return "webhook_endpoints"
+}
+
+// WebhookDelivery is an event to send to an endpoint. It is retried with exponential backoff
+// until the endpoint answers 2xx; a delivery that keeps failing is moved to the dead letters.
+type WebhookDelivery struct {
+	ID             uint64 `gorm:"primaryKey;autoIncrement"`
+	EndpointID     uint64
+	EventID        uint64 // outbox event delivered
+	EventType      EventType
+	Payload        json.RawMessage // request body, the event envelope
+	Attempts       int
+	NextAttemptAt  *time.Time // nil once delivered
+	LastStatusCode int        // HTTP status of the last attempt, 0 when no response was received
+	LastError      string
+	DeliveredAt    *time.Time
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
+
+func (WebhookDelivery) TableName() string {
+	return "webhook_deliveries"
+}
+
+// WebhookDeadLetter is a delivery that failed permanently. Replaying it queues a new delivery.
+type WebhookDeadLetter struct {
+	ID             uint64 `gorm:"primaryKey;autoIncrement"`
+	DeliveryID     uint64
+	EndpointID     uint64
+	EventID        uint64
+	EventType      EventType
+	Payload        json.RawMessage
+	Attempts       int
+	LastStatusCode int
+	LastError      string
+	ReplayedAt     *time.Time // nil until replayed
+	CreatedAt      time.Time
+}
+
+func (WebhookDeadLetter) TableName() string {
+	return "webhook_dead_letters"
+}
+
+// NewWebhookDeadLetter returns the dead letter a failed delivery is moved to.
+func NewWebhookDeadLetter(d *WebhookDelivery) *WebhookDeadLetter {
+	return &WebhookDeadLetter{
+		DeliveryID:     d.ID,
+		EndpointID:     d.EndpointID,
+		EventID:        d.EventID,
+		EventType:      d.EventType,
+		Payload:        d.Payload,
+		Attempts:       d.Attempts,
+		LastStatusCode: d.LastStatusCode,
+		LastError:      d.LastError,
+	}
+}
+
+// SignWebhook returns the signature of a webhook body sent at timestamp: "sha256=" followed by
+// the hex HMAC-SHA256, keyed with the endpoint secret, of the Unix timestamp, a dot and the body.
+// Signing the timestamp lets receivers reject replayed requests.
+func SignWebhook(secret string, timestamp time.Time, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp.Unix(), 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// VerifyWebhook checks the timestamp and signature headers of a received webhook, rejecting
+// requests signed more than tolerance away from now. It is what receivers are expected to do.
+func VerifyWebhook(secret string, timestampHeader string, signatureHeader string, body []byte,
+	now time.Time, tolerance time.Duration) error {
+	unix, err := strconv.ParseInt(timestampHeader, 10, 64)
+	if err != nil {
+		return ErrInvalidWebhookSignature
+	}
+	timestamp := time.Unix(unix, 0)
+	if diff := now.Sub(timestamp); diff > tolerance || diff < -tolerance {
+		return ErrWebhookTimestampExpired
+	}
+	if !hmac.Equal([]byte(SignWebhook(secret, timestamp, body)), []byte(signatureHeader)) {
+		return ErrInvalidWebhookSignature
+	}
+	return nil
+}
+
+// ParseWebhookURL checks that a webhook URL is an absolute HTTPS URL without credentials and
+// returns its host. The addresses the host resolves to are checked with IsPublicWebhookAddress.
+func ParseWebhookURL(raw string) (string, error) {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return "", err
+	}
+	if u.Scheme != "https" {
+		return "", errors.New("webhook URL must use https")
+	}
+	if u.User != nil {
+		return "", errors.New("webhook URL must not contain credentials")
+	}
+	if u.Hostname() == "" {
+		return "", errors.New("webhook URL must have a host")
+	}
+	return u.Hostname(), nil
+}
+
+// sharedAddressSpace is the carrier-grade NAT range, internal although net.IP.IsPrivate does not report it.
+var sharedAddressSpace = &net.IPNet{IP: net.IPv4(100, 64, 0, 0), Mask: net.CIDRMask(10, 32)}
+
+// IsPublicWebhookAddress reports whether webhook requests may be sent to ip. Loopback, private,
+// link-local, unspecified and multicast addresses are refused, so that a registered URL cannot
+// reach the services of the internal network.
+func IsPublicWebhookAddress(ip net.IP) bool {
+	return ip != nil && !ip.IsLoopback() && !ip.IsPrivate() && !ip.IsUnspecified() &&
+		!ip.IsLinkLocalUnicast() && !ip.IsLinkLocalMulticast() && !ip.IsInterfaceLocalMulticast() &&
+		!ip.IsMulticast() && !sharedAddressSpace.Contains(ip)
+}
@@ -0,0 +1,89 @@
+package entity
+
+import (
+	"errors"
+	"net"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestVerifyWebhook(t *testing.T) {
+	const secret = "whsec_0123456789abcdef"
+	body := []byte(`{"id":1}`)
+	sentAt := time.Unix(1758369600, 0)
+	signature := SignWebhook(secret, sentAt, body)
+	timestamp := strconv.FormatInt(sentAt.Unix(), 10)
+
+	tests := []struct {
+		name      string
+		secret    string
+		timestamp string
+		signature string
+		body      string
+		now       time.Time
+		wantErr   error
+	}{
+		{name: "valid", secret: secret, timestamp: timestamp, signature: signature, body: string(body),
+			now: sentAt.Add(time.Minute)},
+		{name: "wrong_secret", secret: "whsec_other", timestamp: timestamp, signature: signature, body: string(body),
+			now: sentAt, wantErr: ErrInvalidWebhookSignature},
+		{name: "tampered_body", secret: secret, timestamp: timestamp, signature: signature, body: `{"id":2}`,
+			now: sentAt, wantErr: ErrInvalidWebhookSignature},
+		{name: "tampered_timestamp", secret: secret, timestamp: strconv.FormatInt(sentAt.Unix()+1, 10),
+			signature: signature, body: string(body), now: sentAt, wantErr: ErrInvalidWebhookSignature},
+		{name: "malformed_timestamp", secret: secret, timestamp: "yesterday", signature: signature, body: string(body),
+			now: sentAt, wantErr: ErrInvalidWebhookSignature},
+		{name: "replayed_late", secret: secret, timestamp: timestamp, signature: signature, body: string(body),
+			now: sentAt.Add(6 * time.Minute), wantErr: ErrWebhookTimestampExpired},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := VerifyWebhook(tt.secret, tt.timestamp, tt.signature, []byte(tt.body), tt.now, 5*time.Minute)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("VerifyWebhook() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestEventTypes_Scan(t *testing.T) {
+	types := EventTypes{EventAccountCreated, EventTransferPosted}
+	value, _ := types.Value()
+	var got EventTypes
+	if err := got.Scan(value); err != nil {
+		t.Fatalf("Scan() error = %v", err)
+	}
+	if len(got) != 2 || got[0] != EventAccountCreated || got[1] != EventTransferPosted {
+		t.Errorf("Scan() got = %v, want %v", got, types)
+	}
+}
+
+func TestIsPublicWebhookAddress(t *testing.T) {
+	tests := []struct {
+		address string
+		want    bool
+	}{
+		{address: "93.184.215.14", want: true},
+		{address: "2606:2800:21f:cb07:6820:80da:af6b:8b2c", want: true},
+		{address: "127.0.0.1"},
+		{address: "::1"},
+		{address: "10.1.2.3"},
+		{address: "172.16.0.1"},
+		{address: "192.168.1.1"},
+		{address: "100.64.0.1"},
+		{address: "169.254.169.254"},
+		{address: "fe80::1"},
+		{address: "fd00::1"},
+		{address: "0.0.0.0"},
+		{address: "::ffff:127.0.0.1"},
+		{address: "224.0.0.1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.address, func(t *testing.T) {
+			if got := IsPublicWebhookAddress(net.ParseIP(tt.address)); got != tt.want {
+				t.Errorf("IsPublicWebhookAddress(%s) = %v, want %v", tt.address, got, tt.want)
+			}
+		})
+	}
+}
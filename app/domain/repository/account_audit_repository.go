@@ -0,0 +1,16 @@
+package repository
+
+import (
+	"context"
+
+	"transaction_demo/app/domain/entity"
+)
+
+//go:generate mockgen -destination=./mock/mock_$GOFILE -source=$GOFILE -package=mock
+
+// AccountAuditRepository represents the repository interface for the account audit entity
+type AccountAuditRepository interface {
+	Create(ctx context.Context, audit *entity.AccountAudit) (*entity.AccountAudit, error)
+	// FindByAccount returns the audit trail of an account, oldest first.
+	FindByAccount(ctx context.Context, accountID uint64) ([]*entity.AccountAudit, error)
+}
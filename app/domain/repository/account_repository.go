@@ -14,4 +14,6 @@ type AccountRepository interface {
 	FindForUpdate(ctx context.Context, ids []uint64) ([]*entity.Account, error)
 	Create(ctx context.Context, account *entity.Account) (*entity.Account, error)
 	Update(ctx context.Context, account *entity.Account) error
+	// FindByType returns, by ascending ID, up to limit accounts of a type with an ID above afterID.
+	FindByType(ctx context.Context, accountType entity.AccountType, afterID uint64, limit int) ([]*entity.Account, error)
 }
@@ -0,0 +1,22 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"transaction_demo/app/domain/entity"
+)
+
+//go:generate mockgen -destination=./mock/mock_$GOFILE -source=$GOFILE -package=mock
+
+// BalanceSnapshotRepository represents the repository interface for daily balance snapshots
+type BalanceSnapshotRepository interface {
+	// CreateForDay snapshots the closing balance of a day for every account open during it,
+	// skipping the accounts already snapshotted that day, and returns how many were inserted.
+	CreateForDay(ctx context.Context, date time.Time) (int64, error)
+	// FindLatest returns the latest snapshot of an account dated on or before the given day,
+	// nil when there is none.
+	FindLatest(ctx context.Context, accountID uint64, onOrBefore time.Time) (*entity.BalanceSnapshot, error)
+	// FindLastDate returns the latest snapshotted day, nil when no snapshot was taken yet.
+	FindLastDate(ctx context.Context) (*time.Time, error)
+}
@@ -0,0 +1,20 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"transaction_demo/app/domain/entity"
+)
+
+//go:generate mockgen -destination=./mock/mock_$GOFILE -source=$GOFILE -package=mock
+
+// HoldRepository represents the repository interface for the hold entity
+type HoldRepository interface {
+	Create(ctx context.Context, hold *entity.Hold) (*entity.Hold, error)
+	FindForUpdate(ctx context.Context, id uint64) (*entity.Hold, error)
+	Update(ctx context.Context, hold *entity.Hold) error
+	// SumActive returns the total amount reserved on an account by holds that are
+	// authorized and not yet expired at the given time, fees included.
+	SumActive(ctx context.Context, accountID uint64, now time.Time) (entity.Money, error)
+}
@@ -0,0 +1,20 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"transaction_demo/app/domain/entity"
+)
+
+//go:generate mockgen -destination=./mock/mock_$GOFILE -source=$GOFILE -package=mock
+
+// IdempotencyRepository represents the repository interface for the idempotency key entity
+type IdempotencyRepository interface {
+	// Reserve inserts the key unless it already exists and reports whether it was inserted.
+	Reserve(ctx context.Context, key *entity.IdempotencyKey) (bool, error)
+	FindForUpdate(ctx context.Context, scope string, key string) (*entity.IdempotencyKey, error)
+	Update(ctx context.Context, key *entity.IdempotencyKey) error
+	// DeleteExpired deletes up to limit keys expired at now and returns how many were deleted.
+	DeleteExpired(ctx context.Context, now time.Time, limit int) (int64, error)
+}
@@ -0,0 +1,32 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"transaction_demo/app/domain/entity"
+)
+
+//go:generate mockgen -destination=./mock/mock_$GOFILE -source=$GOFILE -package=mock
+
+// InterestRepository represents the repository interface for interest accruals and runs
+type InterestRepository interface {
+	// CreateAccruals inserts accruals, skipping the ones of an account and day already accrued,
+	// and returns how many were inserted.
+	CreateAccruals(ctx context.Context, accruals []*entity.InterestAccrual) (int64, error)
+	// FindAccountsWithUnposted returns, by ascending ID, the accounts above afterID with
+	// unposted accruals dated before the given time.
+	FindAccountsWithUnposted(ctx context.Context, before time.Time, afterID uint64, limit int) ([]uint64, error)
+	// FindUnpostedForUpdate locks the unposted accruals of an account dated before the given time.
+	FindUnpostedForUpdate(ctx context.Context, accountID uint64, before time.Time) ([]*entity.InterestAccrual, error)
+	// MarkPosted records that accruals were posted, with the journal entry that credited them if any.
+	MarkPosted(ctx context.Context, ids []uint64, journalEntryID *uint64, postedAt time.Time) error
+	// FindAccruals returns the latest accruals of an account, newest first.
+	FindAccruals(ctx context.Context, accountID uint64, limit int) ([]*entity.InterestAccrual, error)
+	// FindRun returns the run of a kind and date, nil when it has not completed.
+	FindRun(ctx context.Context, kind entity.InterestRunKind, runDate time.Time) (*entity.InterestRun, error)
+	// FindLastRun returns the completed run of a kind with the latest date, nil when there is none.
+	FindLastRun(ctx context.Context, kind entity.InterestRunKind) (*entity.InterestRun, error)
+	// CreateRun records a completed run; recording the same run twice is a no-op.
+	CreateRun(ctx context.Context, run *entity.InterestRun) error
+}
@@ -0,0 +1,24 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"transaction_demo/app/domain/entity"
+)
+
+//go:generate mockgen -destination=./mock/mock_$GOFILE -source=$GOFILE -package=mock
+
+// LedgerRepository represents the repository interface for journal entries and their postings
+type LedgerRepository interface {
+	// CreateEntry persists a journal entry together with its postings.
+	CreateEntry(ctx context.Context, entry *entity.JournalEntry) (*entity.JournalEntry, error)
+	// FindPostingsByTransaction returns the postings of all journal entries of a transaction.
+	FindPostingsByTransaction(ctx context.Context, transactionID uint64) ([]*entity.Posting, error)
+	// SumByAccount returns an account balance derived from its postings (credits minus debits).
+	SumByAccount(ctx context.Context, accountID uint64) (entity.Money, error)
+	// SumByAccountBefore returns the balance derived from the postings made before the given time.
+	SumByAccountBefore(ctx context.Context, accountID uint64, before time.Time) (entity.Money, error)
+	// SumByAccountBetween returns the balance change from the postings made from one time until another.
+	SumByAccountBetween(ctx context.Context, accountID uint64, from time.Time, before time.Time) (entity.Money, error)
+}
@@ -50,6 +50,21 @@ func (mr *MockAccountRepositoryMockRecorder) Create(ctx, account interface{}) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockAccountRepository)(nil).Create), ctx, account)
 }
 
+// FindByType mocks base method.
+func (m *MockAccountRepository) FindByType(ctx context.Context, accountType entity.AccountType, afterID uint64, limit int) ([]*entity.Account, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByType", ctx, accountType, afterID, limit)
+	ret0, _ := ret[0].([]*entity.Account)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByType indicates an expected call of FindByType.
+func (mr *MockAccountRepositoryMockRecorder) FindByType(ctx, accountType, afterID, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByType", reflect.TypeOf((*MockAccountRepository)(nil).FindByType), ctx, accountType, afterID, limit)
+}
+
 // FindForUpdate mocks base method.
 func (m *MockAccountRepository) FindForUpdate(ctx context.Context, ids []uint64) ([]*entity.Account, error) {
 	m.ctrl.T.Helper()
@@ -7,6 +7,7 @@ package mock
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 	entity "transaction_demo/app/domain/entity"
 
 	gomock "github.com/golang/mock/gomock"
@@ -49,3 +50,121 @@ func (mr *MockTransactionRepositoryMockRecorder) Create(ctx, transaction interfa
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockTransactionRepository)(nil).Create), ctx, transaction)
 }
+
+// FindByAccount mocks base method.
+func (m *MockTransactionRepository) FindByAccount(ctx context.Context, filter entity.TransactionFilter) ([]*entity.Transaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByAccount", ctx, filter)
+	ret0, _ := ret[0].([]*entity.Transaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByAccount indicates an expected call of FindByAccount.
+func (mr *MockTransactionRepositoryMockRecorder) FindByAccount(ctx, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByAccount", reflect.TypeOf((*MockTransactionRepository)(nil).FindByAccount), ctx, filter)
+}
+
+// FindForUpdate mocks base method.
+func (m *MockTransactionRepository) FindForUpdate(ctx context.Context, id uint64) (*entity.Transaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindForUpdate", ctx, id)
+	ret0, _ := ret[0].(*entity.Transaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindForUpdate indicates an expected call of FindForUpdate.
+func (mr *MockTransactionRepositoryMockRecorder) FindForUpdate(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindForUpdate", reflect.TypeOf((*MockTransactionRepository)(nil).FindForUpdate), ctx, id)
+}
+
+// FindOne mocks base method.
+func (m *MockTransactionRepository) FindOne(ctx context.Context, id uint64) (*entity.Transaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindOne", ctx, id)
+	ret0, _ := ret[0].(*entity.Transaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindOne indicates an expected call of FindOne.
+func (mr *MockTransactionRepositoryMockRecorder) FindOne(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindOne", reflect.TypeOf((*MockTransactionRepository)(nil).FindOne), ctx, id)
+}
+
+// FindReversals mocks base method.
+func (m *MockTransactionRepository) FindReversals(ctx context.Context, originalID uint64) ([]*entity.Transaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindReversals", ctx, originalID)
+	ret0, _ := ret[0].([]*entity.Transaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindReversals indicates an expected call of FindReversals.
+func (mr *MockTransactionRepositoryMockRecorder) FindReversals(ctx, originalID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindReversals", reflect.TypeOf((*MockTransactionRepository)(nil).FindReversals), ctx, originalID)
+}
+
+// HasTransferSince mocks base method.
+func (m *MockTransactionRepository) HasTransferSince(ctx context.Context, sourceAccountID uint64, destinationAccountID uint64, since time.Time) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HasTransferSince", ctx, sourceAccountID, destinationAccountID, since)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HasTransferSince indicates an expected call of HasTransferSince.
+func (mr *MockTransactionRepositoryMockRecorder) HasTransferSince(ctx, sourceAccountID, destinationAccountID, since interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HasTransferSince", reflect.TypeOf((*MockTransactionRepository)(nil).HasTransferSince), ctx, sourceAccountID, destinationAccountID, since)
+}
+
+// IterateStatement mocks base method.
+func (m *MockTransactionRepository) IterateStatement(ctx context.Context, filter entity.StatementFilter, fn func(line *entity.StatementLine) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IterateStatement", ctx, filter, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// IterateStatement indicates an expected call of IterateStatement.
+func (mr *MockTransactionRepositoryMockRecorder) IterateStatement(ctx, filter, fn interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IterateStatement", reflect.TypeOf((*MockTransactionRepository)(nil).IterateStatement), ctx, filter, fn)
+}
+
+// SumOutgoingSince mocks base method.
+func (m *MockTransactionRepository) SumOutgoingSince(ctx context.Context, accountID uint64, since, now time.Time) (entity.TransferUsage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SumOutgoingSince", ctx, accountID, since, now)
+	ret0, _ := ret[0].(entity.TransferUsage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SumOutgoingSince indicates an expected call of SumOutgoingSince.
+func (mr *MockTransactionRepositoryMockRecorder) SumOutgoingSince(ctx, accountID, since, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SumOutgoingSince", reflect.TypeOf((*MockTransactionRepository)(nil).SumOutgoingSince), ctx, accountID, since, now)
+}
+
+// Update mocks base method.
+func (m *MockTransactionRepository) Update(ctx context.Context, transaction *entity.Transaction) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, transaction)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockTransactionRepositoryMockRecorder) Update(ctx, transaction interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockTransactionRepository)(nil).Update), ctx, transaction)
+}
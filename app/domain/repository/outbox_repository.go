@@ -0,0 +1,31 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"transaction_demo/app/domain/entity"
+)
+
+//go:generate mockgen -destination=./mock/mock_$GOFILE -source=$GOFILE -package=mock
+
+// OutboxRepository represents the repository interface for the outbox event entity
+type OutboxRepository interface {
+	Create(ctx context.Context, event *entity.OutboxEvent) error
+	// FindUnpublishedForUpdate locks the oldest unpublished events, up to limit, in ID order,
+	// skipping the ones locked by other relays and the ones leased past now.
+	FindUnpublishedForUpdate(ctx context.Context, limit int, now time.Time) ([]*entity.OutboxEvent, error)
+	// LeaseForPublish leases the given events to the calling relay until the given time.
+	LeaseForPublish(ctx context.Context, ids []uint64, until time.Time) error
+	// MarkPublished records that an event was published and ends its lease.
+	MarkPublished(ctx context.Context, id uint64, publishedAt time.Time) error
+	// RecordPublishFailure counts a failed publish attempt of an event and keeps its error.
+	RecordPublishFailure(ctx context.Context, id uint64, lastError string) error
+	// ReleaseLeases ends the leases of the given events, so that the next poll publishes them again.
+	ReleaseLeases(ctx context.Context, ids []uint64) error
+	// FindUnqueuedForUpdate locks the oldest events not yet queued for webhooks, up to limit,
+	// in ID order, skipping the ones locked by other relays.
+	FindUnqueuedForUpdate(ctx context.Context, limit int) ([]*entity.OutboxEvent, error)
+	// MarkWebhooksQueued records that the given events were queued for webhooks.
+	MarkWebhooksQueued(ctx context.Context, ids []uint64, queuedAt time.Time) error
+}
@@ -0,0 +1,16 @@
+package repository
+
+import (
+	"context"
+
+	"transaction_demo/app/domain/entity"
+)
+
+//go:generate mockgen -destination=./mock/mock_$GOFILE -source=$GOFILE -package=mock
+
+// QuoteRepository represents the repository interface for the FX quote entity
+type QuoteRepository interface {
+	Create(ctx context.Context, quote *entity.FXQuote) (*entity.FXQuote, error)
+	FindForUpdate(ctx context.Context, id string) (*entity.FXQuote, error)
+	Update(ctx context.Context, quote *entity.FXQuote) error
+}
@@ -0,0 +1,15 @@
+package repository
+
+import (
+	"context"
+
+	"transaction_demo/app/domain/entity"
+)
+
+//go:generate mockgen -destination=./mock/mock_$GOFILE -source=$GOFILE -package=mock
+
+// RateRepository represents the repository interface for the exchange rate entity
+type RateRepository interface {
+	FindRate(ctx context.Context, base entity.Currency, quote entity.Currency) (*entity.ExchangeRate, error)
+	Upsert(ctx context.Context, rate *entity.ExchangeRate) (*entity.ExchangeRate, error)
+}
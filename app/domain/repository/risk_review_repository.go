@@ -0,0 +1,18 @@
+package repository
+
+import (
+	"context"
+
+	"transaction_demo/app/domain/entity"
+)
+
+//go:generate mockgen -destination=./mock/mock_$GOFILE -source=$GOFILE -package=mock
+
+// RiskReviewRepository represents the repository interface for the risk review entity
+type RiskReviewRepository interface {
+	Create(ctx context.Context, review *entity.RiskReview) (*entity.RiskReview, error)
+	FindByTransaction(ctx context.Context, transactionID uint64) (*entity.RiskReview, error)
+	// FindByStatus returns the reviews in a status, oldest first.
+	FindByStatus(ctx context.Context, status entity.RiskReviewStatus, limit int) ([]*entity.RiskReview, error)
+	Update(ctx context.Context, review *entity.RiskReview) error
+}
@@ -0,0 +1,24 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"transaction_demo/app/domain/entity"
+)
+
+//go:generate mockgen -destination=./mock/mock_$GOFILE -source=$GOFILE -package=mock
+
+// ScheduledTransferRepository represents the repository interface for the scheduled transfer entity
+type ScheduledTransferRepository interface {
+	Create(ctx context.Context, schedule *entity.ScheduledTransfer) (*entity.ScheduledTransfer, error)
+	FindOne(ctx context.Context, id uint64) (*entity.ScheduledTransfer, error)
+	FindForUpdate(ctx context.Context, id uint64) (*entity.ScheduledTransfer, error)
+	// ClaimDue locks the active schedule due the longest at now, skipping the ones locked by
+	// other workers. It returns nil when no schedule is due.
+	ClaimDue(ctx context.Context, now time.Time) (*entity.ScheduledTransfer, error)
+	Update(ctx context.Context, schedule *entity.ScheduledTransfer) error
+	CreateRun(ctx context.Context, run *entity.ScheduledTransferRun) (*entity.ScheduledTransferRun, error)
+	// FindRuns returns the latest runs of a schedule, newest first.
+	FindRuns(ctx context.Context, scheduleID uint64, limit int) ([]*entity.ScheduledTransferRun, error)
+}
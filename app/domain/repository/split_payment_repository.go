@@ -0,0 +1,14 @@
+package repository
+
+import (
+	"context"
+
+	"transaction_demo/app/domain/entity"
+)
+
+//go:generate mockgen -destination=./mock/mock_$GOFILE -source=$GOFILE -package=mock
+
+// SplitPaymentRepository represents the repository interface for the split payment entity
+type SplitPaymentRepository interface {
+	Create(ctx context.Context, split *entity.SplitPayment) (*entity.SplitPayment, error)
+}
@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"transaction_demo/app/domain/entity"
 )
@@ -11,4 +12,23 @@ import (
 // TransactionRepository represents the repository interface for the transaction entity
 type TransactionRepository interface {
 	Create(ctx context.Context, transaction *entity.Transaction) (*entity.Transaction, error)
+	// Update persists a status change of a transaction.
+	Update(ctx context.Context, transaction *entity.Transaction) error
+	FindOne(ctx context.Context, id uint64) (*entity.Transaction, error)
+	FindForUpdate(ctx context.Context, id uint64) (*entity.Transaction, error)
+	// FindReversals returns the reversals recorded against a transaction.
+	FindReversals(ctx context.Context, originalID uint64) ([]*entity.Transaction, error)
+	// FindByAccount returns a page of an account's transactions ordered by time and ID, newest first.
+	FindByAccount(ctx context.Context, filter entity.TransactionFilter) ([]*entity.Transaction, error)
+	// SumOutgoingSince returns the total and number of transfers an account sent since the given time,
+	// reversals excluded. Holds authorized since then and still open at now count as transfers, and so
+	// do transfers pending risk review: their release is not checked against the limits again, and a
+	// rejected transfer is failed and no longer counts.
+	SumOutgoingSince(ctx context.Context, accountID uint64, since time.Time, now time.Time) (entity.TransferUsage, error)
+	// HasTransferSince reports whether a transfer from sourceAccountID to destinationAccountID was
+	// made since the given time. Failed transfers and reversals, which return money, do not count.
+	HasTransferSince(ctx context.Context, sourceAccountID uint64, destinationAccountID uint64, since time.Time) (bool, error)
+	// IterateStatement streams the movements of an account booked in a period, oldest first,
+	// calling fn for each without loading the period into memory. An error from fn stops the iteration.
+	IterateStatement(ctx context.Context, filter entity.StatementFilter, fn func(line *entity.StatementLine) error) error
 }
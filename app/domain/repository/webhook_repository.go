@@ -0,0 +1,36 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"transaction_demo/app/domain/entity"
+)
+
+//go:generate mockgen -destination=./mock/mock_$GOFILE -source=$GOFILE -package=mock
+
+// WebhookRepository represents the repository interface for webhook endpoints, deliveries and dead letters
+type WebhookRepository interface {
+	CreateEndpoint(ctx context.Context, endpoint *entity.WebhookEndpoint) (*entity.WebhookEndpoint, error)
+	FindEndpoint(ctx context.Context, id uint64) (*entity.WebhookEndpoint, error)
+	// FindEndpoints returns all the endpoints, oldest first.
+	FindEndpoints(ctx context.Context) ([]*entity.WebhookEndpoint, error)
+	UpdateEndpoint(ctx context.Context, endpoint *entity.WebhookEndpoint) error
+
+	// CreateDeliveries queues the event for every active endpoint subscribed to its type, due at now,
+	// and returns how many deliveries were queued. An endpoint already queued for the event is skipped.
+	CreateDeliveries(ctx context.Context, event *entity.OutboxEvent, payload json.RawMessage, now time.Time) (int64, error)
+	CreateDelivery(ctx context.Context, delivery *entity.WebhookDelivery) (*entity.WebhookDelivery, error)
+	// ClaimDueDelivery locks the undelivered delivery due the longest at now, skipping the ones locked
+	// by other workers. It returns nil when no delivery is due.
+	ClaimDueDelivery(ctx context.Context, now time.Time) (*entity.WebhookDelivery, error)
+	UpdateDelivery(ctx context.Context, delivery *entity.WebhookDelivery) error
+	DeleteDelivery(ctx context.Context, id uint64) error
+
+	CreateDeadLetter(ctx context.Context, deadLetter *entity.WebhookDeadLetter) (*entity.WebhookDeadLetter, error)
+	FindDeadLetterForUpdate(ctx context.Context, id uint64) (*entity.WebhookDeadLetter, error)
+	// FindDeadLetters returns the dead letters not replayed yet, newest first.
+	FindDeadLetters(ctx context.Context, limit int) ([]*entity.WebhookDeadLetter, error)
+	UpdateDeadLetter(ctx context.Context, deadLetter *entity.WebhookDeadLetter) error
+}
@@ -0,0 +1,43 @@
+package postgres
+
+import (
+	"context"
+
+	trmgorm "github.com/avito-tech/go-transaction-manager/drivers/gorm/v2"
+	"gorm.io/gorm"
+
+	"transaction_demo/app/domain/entity"
+	"transaction_demo/app/domain/repository"
+)
+
+// accountAuditRepository is the implementation of the AccountAuditRepository interface
+type accountAuditRepository struct {
+	db       *gorm.DB           // The database connection
+	txGetter *trmgorm.CtxGetter // The transaction manager context getter
+}
+
+func NewAccountAuditRepository(db *gorm.DB, txGetter *trmgorm.CtxGetter) repository.AccountAuditRepository {
+	return &accountAuditRepository{db: db, txGetter: txGetter}
+}
+
+func (r accountAuditRepository) Create(ctx context.Context, audit *entity.AccountAudit) (*entity.AccountAudit, error) {
+	// get the transaction if exists, otherwise use the default database connection
+	db := r.txGetter.DefaultTrOrDB(ctx, r.db).WithContext(ctx)
+
+	if err := db.Create(audit).Error; err != nil {
+		return nil, err
+	}
+
+	return audit, nil
+}
+
+func (r accountAuditRepository) FindByAccount(ctx context.Context, accountID uint64) ([]*entity.AccountAudit, error) {
+	var ents []*entity.AccountAudit
+	// get the transaction if exists, otherwise use the default database connection
+	err := r.txGetter.DefaultTrOrDB(ctx, r.db).WithContext(ctx).
+		Where("account_id = ?", accountID).
+		Order("id").
+		Find(&ents).Error
+
+	return ents, err
+}
@@ -42,6 +42,8 @@ func (r accountRepository) FindForUpdate(ctx context.Context, ids []uint64) ([]*
 	err := r.txGetter.DefaultTrOrDB(ctx, r.db).WithContext(ctx).
 		Clauses(clause.Locking{Strength: "UPDATE"}).
 		Where("id IN ?", ids).
+		// Lock rows in ascending ID order so concurrent callers never wait on each other in a cycle
+		Order("id").
 		Find(&ents).Error
 	if errors.Is(err, gorm.ErrRecordNotFound) {
 		return nil, nil
@@ -64,5 +66,20 @@ func (r accountRepository) Create(ctx context.Context, account *entity.Account)
 func (r accountRepository) Update(ctx context.Context, account *entity.Account) error {
 	// get the transaction if exists, otherwise use the default database connection
 	db := r.txGetter.DefaultTrOrDB(ctx, r.db).WithContext(ctx)
+	// Updates are made under the account lock, so versions are never skipped nor reused
+	account.Version++
 	return db.Save(account).Error
 }
+
+func (r accountRepository) FindByType(ctx context.Context, accountType entity.AccountType, afterID uint64,
+	limit int) ([]*entity.Account, error) {
+	var ents []*entity.Account
+	// get the transaction if exists, otherwise use the default database connection
+	err := r.txGetter.DefaultTrOrDB(ctx, r.db).WithContext(ctx).
+		Where("type = ? AND id > ?", accountType, afterID).
+		Order("id").
+		Limit(limit).
+		Find(&ents).Error
+
+	return ents, err
+}
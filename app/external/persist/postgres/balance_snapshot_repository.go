@@ -0,0 +1,83 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	trmgorm "github.com/avito-tech/go-transaction-manager/drivers/gorm/v2"
+	"gorm.io/gorm"
+
+	"transaction_demo/app/domain/entity"
+	"transaction_demo/app/domain/repository"
+)
+
+// createSnapshotsSQL snapshots a day in one statement. Each account's closing balance is its
+// previous snapshot plus the postings made since, or the sum of all its postings before the
+// day ended when it has no earlier snapshot.
+const createSnapshotsSQL = `
+INSERT INTO balance_snapshots (account_id, snapshot_date, balance, currency, created_at)
+SELECT a.id, @date, COALESCE(s.balance, 0) + COALESCE(p.delta, 0), a.currency, NOW()
+FROM accounts a
+LEFT JOIN LATERAL (
+    SELECT snapshot_date, balance FROM balance_snapshots
+    WHERE account_id = a.id AND snapshot_date < @date
+    ORDER BY snapshot_date DESC
+    LIMIT 1
+) s ON TRUE
+LEFT JOIN LATERAL (
+    SELECT SUM(CASE WHEN direction = @credit THEN amount ELSE -amount END) AS delta FROM postings
+    WHERE account_id = a.id AND created_at < @end
+        AND (s.snapshot_date IS NULL OR created_at >= s.snapshot_date + 1)
+) p ON TRUE
+WHERE a.created_at < @end AND (a.closed_at IS NULL OR a.closed_at >= @date)
+ON CONFLICT (account_id, snapshot_date) DO NOTHING`
+
+// balanceSnapshotRepository is the implementation of the BalanceSnapshotRepository interface
+type balanceSnapshotRepository struct {
+	db       *gorm.DB           // The database connection
+	txGetter *trmgorm.CtxGetter // The transaction manager context getter
+}
+
+func NewBalanceSnapshotRepository(db *gorm.DB, txGetter *trmgorm.CtxGetter) repository.BalanceSnapshotRepository {
+	return &balanceSnapshotRepository{db: db, txGetter: txGetter}
+}
+
+func (r balanceSnapshotRepository) CreateForDay(ctx context.Context, date time.Time) (int64, error) {
+	// get the transaction if exists, otherwise use the default database connection
+	db := r.txGetter.DefaultTrOrDB(ctx, r.db).WithContext(ctx)
+
+	res := db.Exec(createSnapshotsSQL, map[string]interface{}{
+		"date":   date,
+		"end":    date.AddDate(0, 0, 1),
+		"credit": entity.PostingCredit,
+	})
+
+	return res.RowsAffected, res.Error
+}
+
+func (r balanceSnapshotRepository) FindLatest(ctx context.Context, accountID uint64, onOrBefore time.Time,
+) (*entity.BalanceSnapshot, error) {
+	var ent entity.BalanceSnapshot
+	// get the transaction if exists, otherwise use the default database connection
+	err := r.txGetter.DefaultTrOrDB(ctx, r.db).WithContext(ctx).
+		Where("account_id = ? AND snapshot_date <= ?", accountID, onOrBefore).
+		Order("snapshot_date DESC").
+		First(&ent).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+
+	return &ent, err
+}
+
+func (r balanceSnapshotRepository) FindLastDate(ctx context.Context) (*time.Time, error) {
+	var date *time.Time
+	// get the transaction if exists, otherwise use the default database connection
+	err := r.txGetter.DefaultTrOrDB(ctx, r.db).WithContext(ctx).
+		Model(&entity.BalanceSnapshot{}).
+		Select("MAX(snapshot_date)").
+		Row().Scan(&date)
+
+	return date, err
+}
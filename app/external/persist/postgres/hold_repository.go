@@ -0,0 +1,69 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	trmgorm "github.com/avito-tech/go-transaction-manager/drivers/gorm/v2"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"transaction_demo/app/domain/entity"
+	"transaction_demo/app/domain/repository"
+)
+
+// holdRepository is the implementation of the HoldRepository interface
+type holdRepository struct {
+	db       *gorm.DB           // The database connection
+	txGetter *trmgorm.CtxGetter // The transaction manager context getter
+}
+
+func NewHoldRepository(db *gorm.DB, txGetter *trmgorm.CtxGetter) repository.HoldRepository {
+	return &holdRepository{db: db, txGetter: txGetter}
+}
+
+func (r holdRepository) Create(ctx context.Context, hold *entity.Hold) (*entity.Hold, error) {
+	// get the transaction if exists, otherwise use the default database connection
+	db := r.txGetter.DefaultTrOrDB(ctx, r.db).WithContext(ctx)
+
+	if err := db.Create(hold).Error; err != nil {
+		return nil, err
+	}
+
+	return hold, nil
+}
+
+func (r holdRepository) FindForUpdate(ctx context.Context, id uint64) (*entity.Hold, error) {
+	var ent entity.Hold
+	// get the transaction if exists, otherwise use the default database connection
+	// Lock the hold so it cannot be captured and voided concurrently
+	err := r.txGetter.DefaultTrOrDB(ctx, r.db).WithContext(ctx).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("id = ?", id).
+		First(&ent).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+
+	return &ent, err
+}
+
+func (r holdRepository) Update(ctx context.Context, hold *entity.Hold) error {
+	// get the transaction if exists, otherwise use the default database connection
+	db := r.txGetter.DefaultTrOrDB(ctx, r.db).WithContext(ctx)
+	return db.Save(hold).Error
+}
+
+func (r holdRepository) SumActive(ctx context.Context, accountID uint64, now time.Time) (entity.Money, error) {
+	var sum entity.Money
+	// get the transaction if exists, otherwise use the default database connection
+	// Expired holds stop counting as soon as their expiry passes, without a cleanup job
+	err := r.txGetter.DefaultTrOrDB(ctx, r.db).WithContext(ctx).
+		Model(&entity.Hold{}).
+		Select("COALESCE(SUM(amount + fee_amount), 0)").
+		Where("source_account_id = ? AND status = ? AND expires_at > ?", accountID, entity.HoldAuthorized, now).
+		Row().Scan(&sum)
+
+	return sum, err
+}
@@ -0,0 +1,82 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	trmgorm "github.com/avito-tech/go-transaction-manager/drivers/gorm/v2"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"transaction_demo/app/domain/entity"
+	"transaction_demo/app/domain/repository"
+)
+
+// deleteExpiredKeysSQL deletes a batch of expired keys. SKIP LOCKED leaves the keys locked by
+// requests in flight, such as one taking an expired key over, to a later purge.
+const deleteExpiredKeysSQL = `
+DELETE FROM idempotency_keys
+WHERE (scope, key) IN (
+    SELECT scope, key FROM idempotency_keys
+    WHERE expires_at <= @now
+    LIMIT @limit
+    FOR UPDATE SKIP LOCKED
+)`
+
+// idempotencyRepository is the implementation of the IdempotencyRepository interface
+type idempotencyRepository struct {
+	db       *gorm.DB           // The database connection
+	txGetter *trmgorm.CtxGetter // The transaction manager context getter
+}
+
+func NewIdempotencyRepository(db *gorm.DB, txGetter *trmgorm.CtxGetter) repository.IdempotencyRepository {
+	return &idempotencyRepository{db: db, txGetter: txGetter}
+}
+
+func (r idempotencyRepository) Reserve(ctx context.Context, key *entity.IdempotencyKey) (bool, error) {
+	// get the transaction if exists, otherwise use the default database connection
+	db := r.txGetter.DefaultTrOrDB(ctx, r.db).WithContext(ctx)
+
+	// INSERT ... ON CONFLICT DO NOTHING waits for a concurrent transaction holding the same key
+	// to finish, so at most one request executes per key
+	res := db.Clauses(clause.OnConflict{DoNothing: true}).Create(key)
+	if res.Error != nil {
+		return false, res.Error
+	}
+
+	return res.RowsAffected == 1, nil
+}
+
+func (r idempotencyRepository) FindForUpdate(ctx context.Context, scope string, key string,
+) (*entity.IdempotencyKey, error) {
+	var ent entity.IdempotencyKey
+	// get the transaction if exists, otherwise use the default database connection
+	err := r.txGetter.DefaultTrOrDB(ctx, r.db).WithContext(ctx).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("scope = ? AND key = ?", scope, key).
+		First(&ent).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+
+	return &ent, err
+}
+
+func (r idempotencyRepository) Update(ctx context.Context, key *entity.IdempotencyKey) error {
+	// get the transaction if exists, otherwise use the default database connection
+	db := r.txGetter.DefaultTrOrDB(ctx, r.db).WithContext(ctx)
+	return db.Save(key).Error
+}
+
+func (r idempotencyRepository) DeleteExpired(ctx context.Context, now time.Time, limit int) (int64, error) {
+	// get the transaction if exists, otherwise use the default database connection
+	db := r.txGetter.DefaultTrOrDB(ctx, r.db).WithContext(ctx)
+
+	res := db.Exec(deleteExpiredKeysSQL, map[string]interface{}{
+		"now":   now,
+		"limit": limit,
+	})
+
+	return res.RowsAffected, res.Error
+}
@@ -0,0 +1,129 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	trmgorm "github.com/avito-tech/go-transaction-manager/drivers/gorm/v2"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"transaction_demo/app/domain/entity"
+	"transaction_demo/app/domain/repository"
+)
+
+// interestRepository is the implementation of the InterestRepository interface
+type interestRepository struct {
+	db       *gorm.DB           // The database connection
+	txGetter *trmgorm.CtxGetter // The transaction manager context getter
+}
+
+func NewInterestRepository(db *gorm.DB, txGetter *trmgorm.CtxGetter) repository.InterestRepository {
+	return &interestRepository{db: db, txGetter: txGetter}
+}
+
+func (r interestRepository) CreateAccruals(ctx context.Context, accruals []*entity.InterestAccrual) (int64, error) {
+	if len(accruals) == 0 {
+		return 0, nil
+	}
+	// get the transaction if exists, otherwise use the default database connection
+	db := r.txGetter.DefaultTrOrDB(ctx, r.db).WithContext(ctx)
+
+	// The unique (account_id, accrual_date, carried) index makes a rerun of the same day a no-op
+	res := db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "account_id"}, {Name: "accrual_date"}, {Name: "carried"}},
+		DoNothing: true,
+	}).Create(&accruals)
+
+	return res.RowsAffected, res.Error
+}
+
+func (r interestRepository) FindAccountsWithUnposted(ctx context.Context, before time.Time, afterID uint64,
+	limit int) ([]uint64, error) {
+	var ids []uint64
+	// get the transaction if exists, otherwise use the default database connection
+	err := r.txGetter.DefaultTrOrDB(ctx, r.db).WithContext(ctx).
+		Model(&entity.InterestAccrual{}).
+		Distinct("account_id").
+		Where("posted_at IS NULL AND accrual_date < ? AND account_id > ?", before, afterID).
+		Order("account_id").
+		Limit(limit).
+		Pluck("account_id", &ids).Error
+
+	return ids, err
+}
+
+func (r interestRepository) FindUnpostedForUpdate(ctx context.Context, accountID uint64, before time.Time,
+) ([]*entity.InterestAccrual, error) {
+	var ents []*entity.InterestAccrual
+	// get the transaction if exists, otherwise use the default database connection
+	err := r.txGetter.DefaultTrOrDB(ctx, r.db).WithContext(ctx).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("account_id = ? AND posted_at IS NULL AND accrual_date < ?", accountID, before).
+		Order("accrual_date").
+		Find(&ents).Error
+
+	return ents, err
+}
+
+func (r interestRepository) MarkPosted(ctx context.Context, ids []uint64, journalEntryID *uint64,
+	postedAt time.Time) error {
+	// get the transaction if exists, otherwise use the default database connection
+	return r.txGetter.DefaultTrOrDB(ctx, r.db).WithContext(ctx).
+		Model(&entity.InterestAccrual{}).
+		Where("id IN ?", ids).
+		Updates(map[string]interface{}{"journal_entry_id": journalEntryID, "posted_at": postedAt}).Error
+}
+
+func (r interestRepository) FindAccruals(ctx context.Context, accountID uint64, limit int,
+) ([]*entity.InterestAccrual, error) {
+	var ents []*entity.InterestAccrual
+	// get the transaction if exists, otherwise use the default database connection
+	err := r.txGetter.DefaultTrOrDB(ctx, r.db).WithContext(ctx).
+		Where("account_id = ?", accountID).
+		Order("accrual_date DESC").
+		Limit(limit).
+		Find(&ents).Error
+
+	return ents, err
+}
+
+func (r interestRepository) FindRun(ctx context.Context, kind entity.InterestRunKind, runDate time.Time,
+) (*entity.InterestRun, error) {
+	var ent entity.InterestRun
+	// get the transaction if exists, otherwise use the default database connection
+	err := r.txGetter.DefaultTrOrDB(ctx, r.db).WithContext(ctx).
+		Where("kind = ? AND run_date = ?", kind, runDate).
+		First(&ent).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+
+	return &ent, err
+}
+
+func (r interestRepository) FindLastRun(ctx context.Context, kind entity.InterestRunKind,
+) (*entity.InterestRun, error) {
+	var ent entity.InterestRun
+	// get the transaction if exists, otherwise use the default database connection
+	err := r.txGetter.DefaultTrOrDB(ctx, r.db).WithContext(ctx).
+		Where("kind = ?", kind).
+		Order("run_date DESC").
+		First(&ent).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+
+	return &ent, err
+}
+
+func (r interestRepository) CreateRun(ctx context.Context, run *entity.InterestRun) error {
+	// get the transaction if exists, otherwise use the default database connection
+	db := r.txGetter.DefaultTrOrDB(ctx, r.db).WithContext(ctx)
+
+	return db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "kind"}, {Name: "run_date"}},
+		DoNothing: true,
+	}).Create(run).Error
+}
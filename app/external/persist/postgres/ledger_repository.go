@@ -0,0 +1,85 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	trmgorm "github.com/avito-tech/go-transaction-manager/drivers/gorm/v2"
+	"gorm.io/gorm"
+
+	"transaction_demo/app/domain/entity"
+	"transaction_demo/app/domain/repository"
+)
+
+// ledgerRepository is the implementation of the LedgerRepository interface
+type ledgerRepository struct {
+	db       *gorm.DB           // The database connection
+	txGetter *trmgorm.CtxGetter // The transaction manager context getter
+}
+
+func NewLedgerRepository(db *gorm.DB, txGetter *trmgorm.CtxGetter) repository.LedgerRepository {
+	return &ledgerRepository{db: db, txGetter: txGetter}
+}
+
+func (r ledgerRepository) CreateEntry(ctx context.Context, entry *entity.JournalEntry) (*entity.JournalEntry, error) {
+	// get the transaction if exists, otherwise use the default database connection
+	db := r.txGetter.DefaultTrOrDB(ctx, r.db).WithContext(ctx)
+
+	// Postings are inserted with the entry through the has-many association
+	if err := db.Create(entry).Error; err != nil {
+		return nil, err
+	}
+
+	return entry, nil
+}
+
+func (r ledgerRepository) FindPostingsByTransaction(ctx context.Context, transactionID uint64,
+) ([]*entity.Posting, error) {
+	var ents []*entity.Posting
+	// get the transaction if exists, otherwise use the default database connection
+	err := r.txGetter.DefaultTrOrDB(ctx, r.db).WithContext(ctx).
+		Joins("JOIN journal_entries ON journal_entries.id = postings.journal_entry_id").
+		Where("journal_entries.transaction_id = ?", transactionID).
+		Order("postings.id").
+		Find(&ents).Error
+
+	return ents, err
+}
+
+func (r ledgerRepository) SumByAccount(ctx context.Context, accountID uint64) (entity.Money, error) {
+	var sum entity.Money
+	// get the transaction if exists, otherwise use the default database connection
+	err := r.txGetter.DefaultTrOrDB(ctx, r.db).WithContext(ctx).
+		Model(&entity.Posting{}).
+		Select("COALESCE(SUM(CASE WHEN direction = ? THEN amount ELSE -amount END), 0)", entity.PostingCredit).
+		Where("account_id = ?", accountID).
+		Row().Scan(&sum)
+
+	return sum, err
+}
+
+func (r ledgerRepository) SumByAccountBefore(ctx context.Context, accountID uint64, before time.Time,
+) (entity.Money, error) {
+	var sum entity.Money
+	// get the transaction if exists, otherwise use the default database connection
+	err := r.txGetter.DefaultTrOrDB(ctx, r.db).WithContext(ctx).
+		Model(&entity.Posting{}).
+		Select("COALESCE(SUM(CASE WHEN direction = ? THEN amount ELSE -amount END), 0)", entity.PostingCredit).
+		Where("account_id = ? AND created_at < ?", accountID, before).
+		Row().Scan(&sum)
+
+	return sum, err
+}
+
+func (r ledgerRepository) SumByAccountBetween(ctx context.Context, accountID uint64, from time.Time,
+	before time.Time) (entity.Money, error) {
+	var sum entity.Money
+	// get the transaction if exists, otherwise use the default database connection
+	err := r.txGetter.DefaultTrOrDB(ctx, r.db).WithContext(ctx).
+		Model(&entity.Posting{}).
+		Select("COALESCE(SUM(CASE WHEN direction = ? THEN amount ELSE -amount END), 0)", entity.PostingCredit).
+		Where("account_id = ? AND created_at >= ? AND created_at < ?", accountID, from, before).
+		Row().Scan(&sum)
+
+	return sum, err
+}
@@ -0,0 +1,101 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	trmgorm "github.com/avito-tech/go-transaction-manager/drivers/gorm/v2"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"transaction_demo/app/domain/entity"
+	"transaction_demo/app/domain/repository"
+)
+
+// outboxRepository is the implementation of the OutboxRepository interface
+type outboxRepository struct {
+	db       *gorm.DB           // The database connection
+	txGetter *trmgorm.CtxGetter // The transaction manager context getter
+}
+
+func NewOutboxRepository(db *gorm.DB, txGetter *trmgorm.CtxGetter) repository.OutboxRepository {
+	return &outboxRepository{db: db, txGetter: txGetter}
+}
+
+func (r outboxRepository) Create(ctx context.Context, event *entity.OutboxEvent) error {
+	// get the transaction if exists, otherwise use the default database connection
+	db := r.txGetter.DefaultTrOrDB(ctx, r.db).WithContext(ctx)
+	return db.Create(event).Error
+}
+
+func (r outboxRepository) FindUnpublishedForUpdate(ctx context.Context, limit int, now time.Time,
+) ([]*entity.OutboxEvent, error) {
+	var events []*entity.OutboxEvent
+	// get the transaction if exists, otherwise use the default database connection
+	// SKIP LOCKED lets several relays claim batches at once, each a different one
+	err := r.txGetter.DefaultTrOrDB(ctx, r.db).WithContext(ctx).
+		Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+		Where("published_at IS NULL AND (publish_leased_until IS NULL OR publish_leased_until <= ?)", now).
+		Order("id").
+		Limit(limit).
+		Find(&events).Error
+
+	return events, err
+}
+
+// The updates below only write their own columns, so that a concurrent queuing of the same
+// events for webhooks is not overwritten
+
+func (r outboxRepository) LeaseForPublish(ctx context.Context, ids []uint64, until time.Time) error {
+	// get the transaction if exists, otherwise use the default database connection
+	return r.txGetter.DefaultTrOrDB(ctx, r.db).WithContext(ctx).
+		Model(&entity.OutboxEvent{}).
+		Where("id IN ?", ids).
+		UpdateColumn("publish_leased_until", until).Error
+}
+
+func (r outboxRepository) MarkPublished(ctx context.Context, id uint64, publishedAt time.Time) error {
+	// get the transaction if exists, otherwise use the default database connection
+	return r.txGetter.DefaultTrOrDB(ctx, r.db).WithContext(ctx).
+		Model(&entity.OutboxEvent{}).
+		Where("id = ?", id).
+		UpdateColumns(map[string]interface{}{"published_at": publishedAt, "publish_leased_until": nil}).Error
+}
+
+func (r outboxRepository) RecordPublishFailure(ctx context.Context, id uint64, lastError string) error {
+	// get the transaction if exists, otherwise use the default database connection
+	return r.txGetter.DefaultTrOrDB(ctx, r.db).WithContext(ctx).
+		Model(&entity.OutboxEvent{}).
+		Where("id = ?", id).
+		UpdateColumns(map[string]interface{}{"attempts": gorm.Expr("attempts + 1"), "last_error": lastError}).Error
+}
+
+func (r outboxRepository) ReleaseLeases(ctx context.Context, ids []uint64) error {
+	// get the transaction if exists, otherwise use the default database connection
+	return r.txGetter.DefaultTrOrDB(ctx, r.db).WithContext(ctx).
+		Model(&entity.OutboxEvent{}).
+		Where("id IN ? AND published_at IS NULL", ids).
+		UpdateColumn("publish_leased_until", nil).Error
+}
+
+func (r outboxRepository) FindUnqueuedForUpdate(ctx context.Context, limit int) ([]*entity.OutboxEvent, error) {
+	var events []*entity.OutboxEvent
+	// get the transaction if exists, otherwise use the default database connection
+	err := r.txGetter.DefaultTrOrDB(ctx, r.db).WithContext(ctx).
+		Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+		Where("webhooks_queued_at IS NULL").
+		Order("id").
+		Limit(limit).
+		Find(&events).Error
+
+	return events, err
+}
+
+func (r outboxRepository) MarkWebhooksQueued(ctx context.Context, ids []uint64, queuedAt time.Time) error {
+	// get the transaction if exists, otherwise use the default database connection
+	// Only the column is written, so that a concurrent publish of the same events is not overwritten
+	return r.txGetter.DefaultTrOrDB(ctx, r.db).WithContext(ctx).
+		Model(&entity.OutboxEvent{}).
+		Where("id IN ?", ids).
+		UpdateColumn("webhooks_queued_at", queuedAt).Error
+}
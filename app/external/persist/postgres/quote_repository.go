@@ -0,0 +1,55 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+
+	trmgorm "github.com/avito-tech/go-transaction-manager/drivers/gorm/v2"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"transaction_demo/app/domain/entity"
+	"transaction_demo/app/domain/repository"
+)
+
+// quoteRepository is the implementation of the QuoteRepository interface
+type quoteRepository struct {
+	db       *gorm.DB           // The database connection
+	txGetter *trmgorm.CtxGetter // The transaction manager context getter
+}
+
+func NewQuoteRepository(db *gorm.DB, txGetter *trmgorm.CtxGetter) repository.QuoteRepository {
+	return &quoteRepository{db: db, txGetter: txGetter}
+}
+
+func (r quoteRepository) Create(ctx context.Context, quote *entity.FXQuote) (*entity.FXQuote, error) {
+	// get the transaction if exists, otherwise use the default database connection
+	db := r.txGetter.DefaultTrOrDB(ctx, r.db).WithContext(ctx)
+
+	if err := db.Create(quote).Error; err != nil {
+		return nil, err
+	}
+
+	return quote, nil
+}
+
+func (r quoteRepository) FindForUpdate(ctx context.Context, id string) (*entity.FXQuote, error) {
+	var ent entity.FXQuote
+	// get the transaction if exists, otherwise use the default database connection
+	// Lock the quote so concurrent transfers cannot both consume it
+	err := r.txGetter.DefaultTrOrDB(ctx, r.db).WithContext(ctx).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("id = ?", id).
+		First(&ent).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+
+	return &ent, err
+}
+
+func (r quoteRepository) Update(ctx context.Context, quote *entity.FXQuote) error {
+	// get the transaction if exists, otherwise use the default database connection
+	db := r.txGetter.DefaultTrOrDB(ctx, r.db).WithContext(ctx)
+	return db.Save(quote).Error
+}
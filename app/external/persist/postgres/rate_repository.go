@@ -0,0 +1,54 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+
+	trmgorm "github.com/avito-tech/go-transaction-manager/drivers/gorm/v2"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"transaction_demo/app/domain/entity"
+	"transaction_demo/app/domain/repository"
+)
+
+// rateRepository is the implementation of the RateRepository interface
+type rateRepository struct {
+	db       *gorm.DB           // The database connection
+	txGetter *trmgorm.CtxGetter // The transaction manager context getter
+}
+
+func NewRateRepository(db *gorm.DB, txGetter *trmgorm.CtxGetter) repository.RateRepository {
+	return &rateRepository{db: db, txGetter: txGetter}
+}
+
+func (r rateRepository) FindRate(ctx context.Context, base entity.Currency, quote entity.Currency,
+) (*entity.ExchangeRate, error) {
+	var ent entity.ExchangeRate
+	// get the transaction if exists, otherwise use the default database connection
+	db := r.txGetter.DefaultTrOrDB(ctx, r.db).WithContext(ctx).
+		Where("base_currency = ? AND quote_currency = ?", base, quote)
+
+	err := db.First(&ent).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+
+	return &ent, err
+}
+
+func (r rateRepository) Upsert(ctx context.Context, rate *entity.ExchangeRate) (*entity.ExchangeRate, error) {
+	// get the transaction if exists, otherwise use the default database connection
+	db := r.txGetter.DefaultTrOrDB(ctx, r.db).WithContext(ctx)
+
+	// A currency pair has a single current rate, so replace it in place
+	err := db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "base_currency"}, {Name: "quote_currency"}},
+		DoUpdates: clause.AssignmentColumns([]string{"rate", "updated_at"}),
+	}).Create(rate).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return rate, nil
+}
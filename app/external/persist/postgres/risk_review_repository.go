@@ -0,0 +1,66 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+
+	trmgorm "github.com/avito-tech/go-transaction-manager/drivers/gorm/v2"
+	"gorm.io/gorm"
+
+	"transaction_demo/app/domain/entity"
+	"transaction_demo/app/domain/repository"
+)
+
+// riskReviewRepository is the implementation of the RiskReviewRepository interface
+type riskReviewRepository struct {
+	db       *gorm.DB           // The database connection
+	txGetter *trmgorm.CtxGetter // The transaction manager context getter
+}
+
+func NewRiskReviewRepository(db *gorm.DB, txGetter *trmgorm.CtxGetter) repository.RiskReviewRepository {
+	return &riskReviewRepository{db: db, txGetter: txGetter}
+}
+
+func (r riskReviewRepository) Create(ctx context.Context, review *entity.RiskReview) (*entity.RiskReview, error) {
+	// get the transaction if exists, otherwise use the default database connection
+	db := r.txGetter.DefaultTrOrDB(ctx, r.db).WithContext(ctx)
+
+	if err := db.Create(review).Error; err != nil {
+		return nil, err
+	}
+
+	return review, nil
+}
+
+func (r riskReviewRepository) FindByTransaction(ctx context.Context, transactionID uint64) (*entity.RiskReview, error) {
+	var ent entity.RiskReview
+	// get the transaction if exists, otherwise use the default database connection
+	// Reviews are changed under the lock of their transaction row, so no lock is taken here
+	err := r.txGetter.DefaultTrOrDB(ctx, r.db).WithContext(ctx).
+		Where("transaction_id = ?", transactionID).
+		First(&ent).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+
+	return &ent, err
+}
+
+func (r riskReviewRepository) FindByStatus(ctx context.Context, status entity.RiskReviewStatus, limit int,
+) ([]*entity.RiskReview, error) {
+	var ents []*entity.RiskReview
+	// get the transaction if exists, otherwise use the default database connection
+	err := r.txGetter.DefaultTrOrDB(ctx, r.db).WithContext(ctx).
+		Where("status = ?", status).
+		Order("id").
+		Limit(limit).
+		Find(&ents).Error
+
+	return ents, err
+}
+
+func (r riskReviewRepository) Update(ctx context.Context, review *entity.RiskReview) error {
+	// get the transaction if exists, otherwise use the default database connection
+	db := r.txGetter.DefaultTrOrDB(ctx, r.db).WithContext(ctx)
+	return db.Save(review).Error
+}
@@ -0,0 +1,111 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	trmgorm "github.com/avito-tech/go-transaction-manager/drivers/gorm/v2"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"transaction_demo/app/domain/entity"
+	"transaction_demo/app/domain/repository"
+)
+
+// scheduledTransferRepository is the implementation of the ScheduledTransferRepository interface
+type scheduledTransferRepository struct {
+	db       *gorm.DB           // The database connection
+	txGetter *trmgorm.CtxGetter // The transaction manager context getter
+}
+
+func NewScheduledTransferRepository(db *gorm.DB, txGetter *trmgorm.CtxGetter) repository.ScheduledTransferRepository {
+	return &scheduledTransferRepository{db: db, txGetter: txGetter}
+}
+
+func (r scheduledTransferRepository) Create(ctx context.Context, schedule *entity.ScheduledTransfer,
+) (*entity.ScheduledTransfer, error) {
+	// get the transaction if exists, otherwise use the default database connection
+	db := r.txGetter.DefaultTrOrDB(ctx, r.db).WithContext(ctx)
+
+	if err := db.Create(schedule).Error; err != nil {
+		return nil, err
+	}
+
+	return schedule, nil
+}
+
+func (r scheduledTransferRepository) FindOne(ctx context.Context, id uint64) (*entity.ScheduledTransfer, error) {
+	var ent entity.ScheduledTransfer
+	// get the transaction if exists, otherwise use the default database connection
+	err := r.txGetter.DefaultTrOrDB(ctx, r.db).WithContext(ctx).
+		Where("id = ?", id).
+		First(&ent).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+
+	return &ent, err
+}
+
+func (r scheduledTransferRepository) FindForUpdate(ctx context.Context, id uint64) (*entity.ScheduledTransfer, error) {
+	var ent entity.ScheduledTransfer
+	// get the transaction if exists, otherwise use the default database connection
+	// Lock the schedule so it cannot be cancelled while a worker runs it
+	err := r.txGetter.DefaultTrOrDB(ctx, r.db).WithContext(ctx).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("id = ?", id).
+		First(&ent).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+
+	return &ent, err
+}
+
+func (r scheduledTransferRepository) ClaimDue(ctx context.Context, now time.Time) (*entity.ScheduledTransfer, error) {
+	var ent entity.ScheduledTransfer
+	// get the transaction if exists, otherwise use the default database connection
+	// SKIP LOCKED lets several workers claim different schedules instead of queueing on the same row
+	err := r.txGetter.DefaultTrOrDB(ctx, r.db).WithContext(ctx).
+		Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+		Where("status = ? AND next_run_at <= ?", entity.ScheduleActive, now).
+		Order("next_run_at, id").
+		First(&ent).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+
+	return &ent, err
+}
+
+func (r scheduledTransferRepository) Update(ctx context.Context, schedule *entity.ScheduledTransfer) error {
+	// get the transaction if exists, otherwise use the default database connection
+	db := r.txGetter.DefaultTrOrDB(ctx, r.db).WithContext(ctx)
+	return db.Save(schedule).Error
+}
+
+func (r scheduledTransferRepository) CreateRun(ctx context.Context, run *entity.ScheduledTransferRun,
+) (*entity.ScheduledTransferRun, error) {
+	// get the transaction if exists, otherwise use the default database connection
+	db := r.txGetter.DefaultTrOrDB(ctx, r.db).WithContext(ctx)
+
+	if err := db.Create(run).Error; err != nil {
+		return nil, err
+	}
+
+	return run, nil
+}
+
+func (r scheduledTransferRepository) FindRuns(ctx context.Context, scheduleID uint64, limit int,
+) ([]*entity.ScheduledTransferRun, error) {
+	var ents []*entity.ScheduledTransferRun
+	// get the transaction if exists, otherwise use the default database connection
+	err := r.txGetter.DefaultTrOrDB(ctx, r.db).WithContext(ctx).
+		Where("scheduled_transfer_id = ?", scheduleID).
+		Order("scheduled_for DESC").
+		Limit(limit).
+		Find(&ents).Error
+
+	return ents, err
+}
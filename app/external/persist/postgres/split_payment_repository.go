@@ -0,0 +1,32 @@
+package postgres
+
+import (
+	"context"
+
+	trmgorm "github.com/avito-tech/go-transaction-manager/drivers/gorm/v2"
+	"gorm.io/gorm"
+
+	"transaction_demo/app/domain/entity"
+	"transaction_demo/app/domain/repository"
+)
+
+// splitPaymentRepository is the implementation of the SplitPaymentRepository interface
+type splitPaymentRepository struct {
+	db       *gorm.DB           // The database connection
+	txGetter *trmgorm.CtxGetter // The transaction manager context getter
+}
+
+func NewSplitPaymentRepository(db *gorm.DB, txGetter *trmgorm.CtxGetter) repository.SplitPaymentRepository {
+	return &splitPaymentRepository{db: db, txGetter: txGetter}
+}
+
+func (r splitPaymentRepository) Create(ctx context.Context, split *entity.SplitPayment) (*entity.SplitPayment, error) {
+	// get the transaction if exists, otherwise use the default database connection
+	db := r.txGetter.DefaultTrOrDB(ctx, r.db).WithContext(ctx)
+
+	if err := db.Create(split).Error; err != nil {
+		return nil, err
+	}
+
+	return split, nil
+}
@@ -2,9 +2,12 @@ package postgres
 
 import (
 	"context"
+	"errors"
+	"time"
 
 	trmgorm "github.com/avito-tech/go-transaction-manager/drivers/gorm/v2"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 
 	"transaction_demo/app/domain/entity"
 	"transaction_demo/app/domain/repository"
@@ -31,3 +34,190 @@ func (r *transactionRepository) Create(ctx context.Context, transaction *entity.
 	}
 	return transaction, nil
 }
+
+func (r *transactionRepository) Update(ctx context.Context, transaction *entity.Transaction) error {
+	// get the transaction if exists, otherwise use the default database connection
+	// Only the status changes once a transaction is recorded
+	return r.txGetter.DefaultTrOrDB(ctx, r.db).WithContext(ctx).
+		Model(transaction).
+		Select("status", "updated_at").
+		Updates(transaction).Error
+}
+
+func (r *transactionRepository) FindOne(ctx context.Context, id uint64) (*entity.Transaction, error) {
+	var ent entity.Transaction
+	// get the transaction if exists, otherwise use the default database connection
+	err := r.txGetter.DefaultTrOrDB(ctx, r.db).WithContext(ctx).
+		Where("id = ?", id).
+		First(&ent).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+
+	return &ent, err
+}
+
+func (r *transactionRepository) FindForUpdate(ctx context.Context, id uint64) (*entity.Transaction, error) {
+	var ent entity.Transaction
+	// get the transaction if exists, otherwise use the default database connection
+	// Lock the transaction so concurrent reversals cannot exceed its amount
+	err := r.txGetter.DefaultTrOrDB(ctx, r.db).WithContext(ctx).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("id = ?", id).
+		First(&ent).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+
+	return &ent, err
+}
+
+func (r *transactionRepository) FindReversals(ctx context.Context, originalID uint64) ([]*entity.Transaction, error) {
+	var ents []*entity.Transaction
+	// get the transaction if exists, otherwise use the default database connection
+	err := r.txGetter.DefaultTrOrDB(ctx, r.db).WithContext(ctx).
+		Where("original_transaction_id = ?", originalID).
+		Order("id").
+		Find(&ents).Error
+
+	return ents, err
+}
+
+func (r *transactionRepository) FindByAccount(ctx context.Context, filter entity.TransactionFilter,
+) ([]*entity.Transaction, error) {
+	var ents []*entity.Transaction
+	// get the transaction if exists, otherwise use the default database connection
+	db := r.txGetter.DefaultTrOrDB(ctx, r.db).WithContext(ctx)
+
+	// Amount bounds compare against the amount in the account currency on each side of the transfer
+	outgoing, outgoingArgs := "source_account_id = ?", []interface{}{filter.AccountID}
+	incoming, incomingArgs := "destination_account_id = ?", []interface{}{filter.AccountID}
+	if filter.MinAmount != nil {
+		outgoing, outgoingArgs = outgoing+" AND amount >= ?", append(outgoingArgs, *filter.MinAmount)
+		incoming, incomingArgs = incoming+" AND destination_amount >= ?", append(incomingArgs, *filter.MinAmount)
+	}
+	if filter.MaxAmount != nil {
+		outgoing, outgoingArgs = outgoing+" AND amount <= ?", append(outgoingArgs, *filter.MaxAmount)
+		incoming, incomingArgs = incoming+" AND destination_amount <= ?", append(incomingArgs, *filter.MaxAmount)
+	}
+
+	switch filter.Direction {
+	case entity.TransactionOutgoing:
+		db = db.Where(outgoing, outgoingArgs...)
+	case entity.TransactionIncoming:
+		db = db.Where(incoming, incomingArgs...)
+	default:
+		db = db.Where("("+outgoing+") OR ("+incoming+")", append(outgoingArgs, incomingArgs...)...)
+	}
+
+	if filter.From != nil {
+		db = db.Where("transaction_time >= ?", *filter.From)
+	}
+	if filter.To != nil {
+		db = db.Where("transaction_time < ?", *filter.To)
+	}
+	// Keyset pagination: continue strictly after the last row of the previous page
+	if filter.AfterTime != nil {
+		db = db.Where("(transaction_time, id) < (?, ?)", *filter.AfterTime, filter.AfterID)
+	}
+
+	err := db.Order("transaction_time DESC, id DESC").
+		Limit(filter.Limit).
+		Find(&ents).Error
+
+	return ents, err
+}
+
+// outgoingSinceSQL sums the transfers an account sent since a time with the holds it authorized
+// since then that are still open: a hold is spending that its capture will post.
+// Transfers pending risk review are counted like posted ones, since their release posts them
+// without evaluating the limits again; a rejection fails them, which drops them from the sum.
+// Failed transactions never moved money and reversals are not new spending.
+const outgoingSinceSQL = `
+SELECT COALESCE(SUM(amount), 0), COUNT(*)
+FROM (
+    SELECT amount FROM transactions
+    WHERE source_account_id = @account AND transaction_time >= @since
+        AND original_transaction_id IS NULL AND status <> @failed
+    UNION ALL
+    SELECT amount FROM holds
+    WHERE source_account_id = @account AND created_at >= @since
+        AND status = @authorized AND expires_at > @now
+) outgoing`
+
+func (r *transactionRepository) SumOutgoingSince(ctx context.Context, accountID uint64, since time.Time,
+	now time.Time) (entity.TransferUsage, error) {
+	var usage entity.TransferUsage
+	// get the transaction if exists, otherwise use the default database connection
+	err := r.txGetter.DefaultTrOrDB(ctx, r.db).WithContext(ctx).
+		Raw(outgoingSinceSQL, map[string]interface{}{
+			"account":    accountID,
+			"since":      since,
+			"now":        now,
+			"failed":     entity.TransactionFailed,
+			"authorized": entity.HoldAuthorized,
+		}).
+		Row().Scan(&usage.Total, &usage.Count)
+
+	return usage, err
+}
+
+func (r *transactionRepository) HasTransferSince(ctx context.Context, sourceAccountID uint64,
+	destinationAccountID uint64, since time.Time) (bool, error) {
+	var count int64
+	// get the transaction if exists, otherwise use the default database connection
+	// A reversal is booked in the opposite direction of the transfer it returns, and is not a transfer of its own
+	err := r.txGetter.DefaultTrOrDB(ctx, r.db).WithContext(ctx).
+		Model(&entity.Transaction{}).
+		Where("source_account_id = ? AND destination_account_id = ?", sourceAccountID, destinationAccountID).
+		Where("transaction_time >= ? AND status <> ?", since, entity.TransactionFailed).
+		Where("original_transaction_id IS NULL").
+		Limit(1).
+		Count(&count).Error
+
+	return count > 0, err
+}
+
+// statementLinesSQL nets the postings of each journal entry that hit the account, with the
+// transfer the entry booked, if any.
+const statementLinesSQL = `
+SELECT je.id AS journal_entry_id, je.transaction_id, je.description, je.created_at AS booked_at,
+    SUM(CASE WHEN p.direction = @credit THEN p.amount ELSE -p.amount END) AS amount, p.currency,
+    CASE WHEN t.source_account_id = @account THEN t.destination_account_id ELSE t.source_account_id END
+        AS counterparty_account_id,
+    CASE WHEN t.source_account_id = @account THEN t.fee_amount ELSE 0 END AS fee
+FROM postings p
+JOIN journal_entries je ON je.id = p.journal_entry_id
+LEFT JOIN transactions t ON t.id = je.transaction_id
+WHERE p.account_id = @account AND p.created_at >= @from AND p.created_at < @to
+GROUP BY je.id, p.currency, t.id
+ORDER BY je.created_at, je.id`
+
+func (r *transactionRepository) IterateStatement(ctx context.Context, filter entity.StatementFilter,
+	fn func(line *entity.StatementLine) error) error {
+	// get the transaction if exists, otherwise use the default database connection
+	db := r.txGetter.DefaultTrOrDB(ctx, r.db).WithContext(ctx)
+
+	rows, err := db.Raw(statementLinesSQL, map[string]interface{}{
+		"account": filter.AccountID,
+		"from":    filter.From,
+		"to":      filter.To,
+		"credit":  entity.PostingCredit,
+	}).Rows()
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	// Rows are scanned one at a time so a long period never sits in memory
+	for rows.Next() {
+		var line entity.StatementLine
+		if err = db.ScanRows(rows, &line); err != nil {
+			return err
+		}
+		if err = fn(&line); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
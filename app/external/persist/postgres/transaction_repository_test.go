@@ -0,0 +1,54 @@
+package postgres
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	trmgorm "github.com/avito-tech/go-transaction-manager/drivers/gorm/v2"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+// newDryRunDB returns a connection that builds statements without running them, and a function
+// returning the last query built.
+func newDryRunDB(t *testing.T) (*gorm.DB, func() string) {
+	t.Helper()
+	db, err := gorm.Open(postgres.New(postgres.Config{DSN: "host=localhost dbname=dry_run"}),
+		&gorm.Config{DryRun: true, DisableAutomaticPing: true})
+	if err != nil {
+		t.Fatalf("open dry run connection: %v", err)
+	}
+	var last string
+	err = db.Callback().Query().After("gorm:query").Register("test:capture", func(tx *gorm.DB) {
+		last = tx.Dialector.Explain(tx.Statement.SQL.String(), tx.Statement.Vars...)
+	})
+	if err != nil {
+		t.Fatalf("register capture callback: %v", err)
+	}
+	return db, func() string { return last }
+}
+
+func TestTransactionRepository_HasTransferSince(t *testing.T) {
+	// The round-trip rule asks whether 222 sent money to 111: the reversal of a transfer from 111
+	// to 222, booked from 222 to 111, returns money and must not count
+	db, lastQuery := newDryRunDB(t)
+	repo := NewTransactionRepository(db, trmgorm.DefaultCtxGetter)
+
+	since := time.Date(2025, 9, 28, 9, 0, 0, 0, time.UTC)
+	if _, err := repo.HasTransferSince(context.Background(), 222, 111, since); err != nil {
+		t.Fatalf("HasTransferSince() error = %v", err)
+	}
+
+	query := lastQuery()
+	for _, want := range []string{
+		"source_account_id = 222 AND destination_account_id = 111",
+		"status <> 'failed'",
+		"original_transaction_id IS NULL",
+	} {
+		if !strings.Contains(query, want) {
+			t.Errorf("HasTransferSince() query does not contain %q:\n%s", want, query)
+		}
+	}
+}
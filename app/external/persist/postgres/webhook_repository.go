@@ -0,0 +1,178 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	trmgorm "github.com/avito-tech/go-transaction-manager/drivers/gorm/v2"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"transaction_demo/app/domain/entity"
+	"transaction_demo/app/domain/repository"
+)
+
+// createDeliveriesSQL fans an event out to the active endpoints subscribed to its type.
+// The unique (endpoint_id, event_id) key only skips deliveries still queued: a dead-lettered delivery
+// is deleted, so the relay marks each event once queued and never fans it out again.
+const createDeliveriesSQL = `
+INSERT INTO webhook_deliveries (endpoint_id, event_id, event_type, payload, attempts, next_attempt_at,
+    last_status_code, last_error, created_at, updated_at)
+SELECT e.id, @event_id, @event_type, CAST(@payload AS JSONB), 0, @now, 0, '', @now, @now
+FROM webhook_endpoints e
+WHERE e.status = @active AND @event_type = ANY(e.event_types)
+ON CONFLICT (endpoint_id, event_id) DO NOTHING`
+
+// webhookRepository is the implementation of the WebhookRepository interface
+type webhookRepository struct {
+	db       *gorm.DB           // The database connection
+	txGetter *trmgorm.CtxGetter // The transaction manager context getter
+}
+
+func NewWebhookRepository(db *gorm.DB, txGetter *trmgorm.CtxGetter) repository.WebhookRepository {
+	return &webhookRepository{db: db, txGetter: txGetter}
+}
+
+func (r webhookRepository) CreateEndpoint(ctx context.Context, endpoint *entity.WebhookEndpoint,
+) (*entity.WebhookEndpoint, error) {
+	// get the transaction if exists, otherwise use the default database connection
+	db := r.txGetter.DefaultTrOrDB(ctx, r.db).WithContext(ctx)
+
+	if err := db.Create(endpoint).Error; err != nil {
+		return nil, err
+	}
+
+	return endpoint, nil
+}
+
+func (r webhookRepository) FindEndpoint(ctx context.Context, id uint64) (*entity.WebhookEndpoint, error) {
+	var ent entity.WebhookEndpoint
+	// get the transaction if exists, otherwise use the default database connection
+	err := r.txGetter.DefaultTrOrDB(ctx, r.db).WithContext(ctx).
+		Where("id = ?", id).
+		First(&ent).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+
+	return &ent, err
+}
+
+func (r webhookRepository) FindEndpoints(ctx context.Context) ([]*entity.WebhookEndpoint, error) {
+	var endpoints []*entity.WebhookEndpoint
+	// get the transaction if exists, otherwise use the default database connection
+	err := r.txGetter.DefaultTrOrDB(ctx, r.db).WithContext(ctx).
+		Order("id").
+		Find(&endpoints).Error
+
+	return endpoints, err
+}
+
+func (r webhookRepository) UpdateEndpoint(ctx context.Context, endpoint *entity.WebhookEndpoint) error {
+	// get the transaction if exists, otherwise use the default database connection
+	db := r.txGetter.DefaultTrOrDB(ctx, r.db).WithContext(ctx)
+	return db.Save(endpoint).Error
+}
+
+func (r webhookRepository) CreateDeliveries(ctx context.Context, event *entity.OutboxEvent, payload json.RawMessage,
+	now time.Time) (int64, error) {
+	// get the transaction if exists, otherwise use the default database connection
+	db := r.txGetter.DefaultTrOrDB(ctx, r.db).WithContext(ctx)
+
+	res := db.Exec(createDeliveriesSQL, map[string]interface{}{
+		"event_id":   event.ID,
+		"event_type": event.Type,
+		"payload":    string(payload),
+		"now":        now,
+		"active":     entity.WebhookActive,
+	})
+
+	return res.RowsAffected, res.Error
+}
+
+func (r webhookRepository) CreateDelivery(ctx context.Context, delivery *entity.WebhookDelivery,
+) (*entity.WebhookDelivery, error) {
+	// get the transaction if exists, otherwise use the default database connection
+	db := r.txGetter.DefaultTrOrDB(ctx, r.db).WithContext(ctx)
+
+	if err := db.Create(delivery).Error; err != nil {
+		return nil, err
+	}
+
+	return delivery, nil
+}
+
+func (r webhookRepository) ClaimDueDelivery(ctx context.Context, now time.Time) (*entity.WebhookDelivery, error) {
+	var ent entity.WebhookDelivery
+	// get the transaction if exists, otherwise use the default database connection
+	// SKIP LOCKED lets several workers send different deliveries instead of queueing on the same row
+	err := r.txGetter.DefaultTrOrDB(ctx, r.db).WithContext(ctx).
+		Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+		Where("delivered_at IS NULL AND next_attempt_at <= ?", now).
+		Order("next_attempt_at, id").
+		First(&ent).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+
+	return &ent, err
+}
+
+func (r webhookRepository) UpdateDelivery(ctx context.Context, delivery *entity.WebhookDelivery) error {
+	// get the transaction if exists, otherwise use the default database connection
+	db := r.txGetter.DefaultTrOrDB(ctx, r.db).WithContext(ctx)
+	return db.Save(delivery).Error
+}
+
+func (r webhookRepository) DeleteDelivery(ctx context.Context, id uint64) error {
+	// get the transaction if exists, otherwise use the default database connection
+	db := r.txGetter.DefaultTrOrDB(ctx, r.db).WithContext(ctx)
+	return db.Delete(&entity.WebhookDelivery{}, id).Error
+}
+
+func (r webhookRepository) CreateDeadLetter(ctx context.Context, deadLetter *entity.WebhookDeadLetter,
+) (*entity.WebhookDeadLetter, error) {
+	// get the transaction if exists, otherwise use the default database connection
+	db := r.txGetter.DefaultTrOrDB(ctx, r.db).WithContext(ctx)
+
+	if err := db.Create(deadLetter).Error; err != nil {
+		return nil, err
+	}
+
+	return deadLetter, nil
+}
+
+func (r webhookRepository) FindDeadLetterForUpdate(ctx context.Context, id uint64) (*entity.WebhookDeadLetter, error) {
+	var ent entity.WebhookDeadLetter
+	// get the transaction if exists, otherwise use the default database connection
+	// Lock the dead letter so that concurrent replays queue it once
+	err := r.txGetter.DefaultTrOrDB(ctx, r.db).WithContext(ctx).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("id = ?", id).
+		First(&ent).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+
+	return &ent, err
+}
+
+func (r webhookRepository) FindDeadLetters(ctx context.Context, limit int) ([]*entity.WebhookDeadLetter, error) {
+	var deadLetters []*entity.WebhookDeadLetter
+	// get the transaction if exists, otherwise use the default database connection
+	err := r.txGetter.DefaultTrOrDB(ctx, r.db).WithContext(ctx).
+		Where("replayed_at IS NULL").
+		Order("id DESC").
+		Limit(limit).
+		Find(&deadLetters).Error
+
+	return deadLetters, err
+}
+
+func (r webhookRepository) UpdateDeadLetter(ctx context.Context, deadLetter *entity.WebhookDeadLetter) error {
+	// get the transaction if exists, otherwise use the default database connection
+	db := r.txGetter.DefaultTrOrDB(ctx, r.db).WithContext(ctx)
+	return db.Save(deadLetter).Error
+}
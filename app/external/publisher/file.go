@@ -0,0 +1,53 @@
+package publisher
+
+import (
+	"context"
+	"os"
+	"sync"
+
+	"transaction_demo/app/domain/entity"
+	"transaction_demo/app/usecase"
+)
+
+// filePublisher appends events to a file as JSON lines, one envelope per line.
+type filePublisher struct {
+	path string
+	mu   sync.Mutex
+	file *os.File
+}
+
+func NewFilePublisher(path string) usecase.EventPublisher {
+	return &filePublisher{path: path}
+}
+
+// Publish appends the event and syncs the file, so that a published event survives a crash.
+func (p *filePublisher) Publish(_ context.Context, event *entity.OutboxEvent) error {
+	message, err := event.Envelope()
+	if err != nil {
+		return err
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.file == nil {
+		p.file, err = os.OpenFile(p.path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+		if err != nil {
+			return err
+		}
+	}
+	if _, err = p.file.Write(append(message, '\n')); err != nil {
+		return err
+	}
+	return p.file.Sync()
+}
+
+func (p *filePublisher) Close() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.file == nil {
+		return nil
+	}
+	err := p.file.Close()
+	p.file = nil
+	return err
+}
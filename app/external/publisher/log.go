@@ -0,0 +1,29 @@
+package publisher
+
+import (
+	"context"
+	"fmt"
+
+	"transaction_demo/app/domain/entity"
+	"transaction_demo/app/usecase"
+)
+
+// logPublisher prints events to the standard output. It never fails, which suits local development.
+type logPublisher struct{}
+
+func NewLogPublisher() usecase.EventPublisher {
+	return &logPublisher{}
+}
+
+func (p *logPublisher) Publish(_ context.Context, event *entity.OutboxEvent) error {
+	message, err := event.Envelope()
+	if err != nil {
+		return err
+	}
+	fmt.Println("event published", "event_id", event.ID, "type", event.Type, "message", string(message))
+	return nil
+}
+
+func (p *logPublisher) Close() error {
+	return nil
+}
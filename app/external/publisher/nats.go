@@ -0,0 +1,127 @@
+package publisher
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+
+	"transaction_demo/app/domain/entity"
+	"transaction_demo/app/usecase"
+)
+
+// natsPublisher publishes events to a NATS JetStream stream.
+//
+// Every message carries a Nats-Msg-Id header set to the event ID, which the stream uses to drop
+// the duplicates of at-least-once delivery, and Publish only returns nil once the stream
+// acknowledged the event. The connection is made by the first Publish and kept up by the client,
+// which reconnects by itself. When a stream name is configured, the stream is created on connect
+// unless it exists, bound to the subjects under the prefix.
+type natsPublisher struct {
+	url     string
+	prefix  string
+	stream  string
+	timeout time.Duration
+
+	mu   sync.Mutex
+	conn *nats.Conn
+	js   jetstream.JetStream
+}
+
+// NewNATSPublisher returns a publisher to the NATS server at rawURL, nats://[user:password@]host[:port].
+// stream is the JetStream stream to create if missing; an empty stream leaves streams to the operator.
+func NewNATSPublisher(rawURL string, subjectPrefix string, stream string, timeout time.Duration,
+) (usecase.EventPublisher, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Scheme != "nats" || u.Hostname() == "" {
+		return nil, fmt.Errorf("invalid NATS url: %q", rawURL)
+	}
+	return &natsPublisher{
+		url:     rawURL,
+		prefix:  subjectPrefix,
+		stream:  stream,
+		timeout: timeout,
+	}, nil
+}
+
+func (p *natsPublisher) Publish(ctx context.Context, event *entity.OutboxEvent) error {
+	message, err := event.Envelope()
+	if err != nil {
+		return err
+	}
+	js, err := p.jetStream(ctx)
+	if err != nil {
+		return err
+	}
+
+	msg := nats.NewMsg(subject(p.prefix, event.Type))
+	msg.Header.Set(jetstream.MsgIDHeader, strconv.FormatUint(event.ID, 10))
+	msg.Data = message
+
+	ctx, cancel := context.WithTimeout(ctx, p.timeout)
+	defer cancel()
+	_, err = js.PublishMsg(ctx, msg)
+	return err
+}
+
+func (p *natsPublisher) Close() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.conn != nil {
+		p.conn.Close()
+		p.conn, p.js = nil, nil
+	}
+	return nil
+}
+
+// jetStream returns the JetStream context of the connection, connecting first if needed.
+// A failed connection is made again by the next call.
+func (p *natsPublisher) jetStream(ctx context.Context) (jetstream.JetStream, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.js != nil {
+		return p.js, nil
+	}
+
+	conn, err := nats.Connect(p.url,
+		nats.Name("transaction_demo"),
+		nats.Timeout(p.timeout),
+		nats.MaxReconnects(-1))
+	if err != nil {
+		return nil, err
+	}
+	js, err := jetstream.New(conn, jetstream.WithDefaultTimeout(p.timeout))
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+	if err = p.ensureStream(ctx, js); err != nil {
+		conn.Close()
+		return nil, err
+	}
+	p.conn, p.js = conn, js
+	return js, nil
+}
+
+// ensureStream creates the configured stream; an existing stream is kept as the operator set it up.
+func (p *natsPublisher) ensureStream(ctx context.Context, js jetstream.JetStream) error {
+	if p.stream == "" {
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(ctx, p.timeout)
+	defer cancel()
+	_, err := js.CreateStream(ctx, jetstream.StreamConfig{
+		Name:     p.stream,
+		Subjects: []string{subject(p.prefix, ">")},
+	})
+	if err != nil && !errors.Is(err, jetstream.ErrStreamNameAlreadyInUse) {
+		return fmt.Errorf("nats: create stream %s: %w", p.stream, err)
+	}
+	return nil
+}
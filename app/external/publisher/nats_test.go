@@ -0,0 +1,244 @@
+package publisher
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"transaction_demo/app/domain/entity"
+)
+
+// natsMessage is a message received by the stand-in server.
+type natsMessage struct {
+	subject string
+	header  string
+	payload []byte
+}
+
+// natsStandIn answers JetStream requests: stream creations with createReply and publishes with ackReply.
+type natsStandIn struct {
+	createReply string
+	ackReply    string
+	messages    chan natsMessage
+}
+
+// startNATSStandIn runs a minimal NATS server speaking enough of the client protocol for the
+// publisher: INFO, CONNECT, PING, SUB, PUB and HPUB, replying with MSG to the reply subject.
+// It listens on addr, such as 127.0.0.1:0.
+func startNATSStandIn(t *testing.T, addr, createReply, ackReply string) (string, <-chan natsMessage) {
+	t.Helper()
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	t.Cleanup(func() { listener.Close() })
+
+	s := &natsStandIn{createReply: createReply, ackReply: ackReply, messages: make(chan natsMessage, 10)}
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go s.serve(conn)
+		}
+	}()
+	return "nats://" + listener.Addr().String(), s.messages
+}
+
+func (s *natsStandIn) serve(conn net.Conn) {
+	defer conn.Close()
+	fmt.Fprint(conn, "INFO {\"server_id\":\"stand-in\",\"proto\":1,\"headers\":true,\"max_payload\":1048576}\r\n")
+
+	// sids maps the subscribed subjects, such as the reply inbox, to their subscription ID
+	sids := make(map[string]string)
+	reader := bufio.NewReader(conn)
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			return
+		}
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+		switch fields[0] {
+		case "PING":
+			fmt.Fprint(conn, "PONG\r\n")
+		case "SUB":
+			sids[fields[1]] = fields[len(fields)-1]
+		case "PUB", "HPUB":
+			headerSize := 0
+			if fields[0] == "HPUB" {
+				headerSize, _ = strconv.Atoi(fields[len(fields)-2])
+			}
+			total, _ := strconv.Atoi(fields[len(fields)-1])
+			data := make([]byte, total+2)
+			if _, err = io.ReadFull(reader, data); err != nil {
+				return
+			}
+			msg := natsMessage{subject: fields[1], header: string(data[:headerSize]), payload: data[headerSize:total]}
+			reply := s.ackReply
+			if strings.HasPrefix(msg.subject, "$JS.API.STREAM.CREATE.") {
+				reply = s.createReply
+			}
+			s.messages <- msg
+			if len(fields) > 3 {
+				s.respond(conn, sids, fields[2], reply)
+			}
+		}
+	}
+}
+
+// respond sends data to a reply subject of the inbox the client subscribed to.
+func (s *natsStandIn) respond(conn net.Conn, sids map[string]string, replyTo, data string) {
+	inbox := replyTo[:strings.LastIndex(replyTo, ".")] + ".*"
+	fmt.Fprintf(conn, "MSG %s %s %d\r\n%s\r\n", replyTo, sids[inbox], len(data), data)
+}
+
+const (
+	natsAck            = `{"stream":"EVENTS","seq":1}`
+	natsStreamCreated  = `{"type":"io.nats.jetstream.api.v1.stream_create_response","config":{"name":"EVENTS","subjects":["ledger.>"]},"created":"2025-09-18T09:00:00Z","state":{}}`
+	natsStreamInUse    = `{"type":"io.nats.jetstream.api.v1.stream_create_response","error":{"code":400,"err_code":10058,"description":"stream name already in use with a different configuration"}}`
+	natsStreamNotAllow = `{"type":"io.nats.jetstream.api.v1.stream_create_response","error":{"code":503,"err_code":10039,"description":"jetstream not enabled for account"}}`
+)
+
+func TestNATSPublisher_Publish(t *testing.T) {
+	event := &entity.OutboxEvent{
+		ID:          42,
+		Type:        entity.EventTransferPosted,
+		AggregateID: "7",
+		Payload:     []byte(`{"transaction_id":7}`),
+		CreatedAt:   time.Date(2025, 9, 18, 9, 0, 0, 0, time.UTC),
+	}
+
+	tests := []struct {
+		name        string
+		stream      string
+		createReply string
+		ackReply    string
+		wantCreate  bool
+		wantPublish bool
+		wantErr     bool
+	}{
+		{
+			name:        "acknowledged",
+			ackReply:    natsAck,
+			wantPublish: true,
+		},
+		{
+			name:        "creates_stream",
+			stream:      "EVENTS",
+			createReply: natsStreamCreated,
+			ackReply:    natsAck,
+			wantCreate:  true,
+			wantPublish: true,
+		},
+		{
+			// A stream set up by the operator is kept as it is
+			name:        "existing_stream",
+			stream:      "EVENTS",
+			createReply: natsStreamInUse,
+			ackReply:    natsAck,
+			wantCreate:  true,
+			wantPublish: true,
+		},
+		{
+			name:        "stream_not_created",
+			stream:      "EVENTS",
+			createReply: natsStreamNotAllow,
+			wantCreate:  true,
+			wantErr:     true,
+		},
+		{
+			name:        "rejected",
+			ackReply:    `{"error":{"code":503,"err_code":10077,"description":"maximum messages exceeded"}}`,
+			wantPublish: true,
+			wantErr:     true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			url, messages := startNATSStandIn(t, "127.0.0.1:0", tt.createReply, tt.ackReply)
+			p, err := NewNATSPublisher(url, "ledger", tt.stream, time.Second)
+			if err != nil {
+				t.Fatalf("NewNATSPublisher() error = %v", err)
+			}
+			defer p.Close()
+
+			err = p.Publish(context.Background(), event)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Publish() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if tt.wantCreate {
+				msg := <-messages
+				var cfg struct {
+					Name     string   `json:"name"`
+					Subjects []string `json:"subjects"`
+				}
+				if err = json.Unmarshal(msg.payload, &cfg); err != nil || msg.subject != "$JS.API.STREAM.CREATE.EVENTS" ||
+					cfg.Name != "EVENTS" || len(cfg.Subjects) != 1 || cfg.Subjects[0] != "ledger.>" {
+					t.Errorf("Publish() sent stream creation %q %s", msg.subject, msg.payload)
+				}
+			}
+			if !tt.wantPublish {
+				return
+			}
+			msg := <-messages
+			if msg.subject != "ledger.transfer.posted" || !strings.Contains(msg.header, "Nats-Msg-Id: 42\r\n") {
+				t.Errorf("Publish() sent subject %q header %q", msg.subject, msg.header)
+			}
+			var envelope entity.EventEnvelope
+			if err = json.Unmarshal(msg.payload, &envelope); err != nil || envelope.ID != 42 ||
+				string(envelope.Data) != `{"transaction_id":7}` {
+				t.Errorf("Publish() sent payload %s", msg.payload)
+			}
+		})
+	}
+}
+
+func TestNATSPublisher_ConnectRetried(t *testing.T) {
+	// A server that is not up yet fails the publish, and the next publish connects
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	addr := listener.Addr().String()
+	listener.Close()
+
+	p, err := NewNATSPublisher("nats://"+addr, "", "", time.Second)
+	if err != nil {
+		t.Fatalf("NewNATSPublisher() error = %v", err)
+	}
+	defer p.Close()
+
+	event := &entity.OutboxEvent{ID: 1, Type: entity.EventAccountCreated, Payload: []byte(`{}`)}
+	if err = p.Publish(context.Background(), event); err == nil {
+		t.Fatalf("Publish() without a server succeeded")
+	}
+
+	_, messages := startNATSStandIn(t, addr, "", natsAck)
+	if err = p.Publish(context.Background(), event); err != nil {
+		t.Fatalf("Publish() after the server started error = %v", err)
+	}
+	if msg := <-messages; msg.subject != "account.created" {
+		t.Errorf("Publish() sent subject %q", msg.subject)
+	}
+}
+
+func TestNewNATSPublisher_InvalidURL(t *testing.T) {
+	for _, rawURL := range []string{"localhost:4222", "http://localhost:4222", "nats://"} {
+		if _, err := NewNATSPublisher(rawURL, "", "", time.Second); err == nil {
+			t.Errorf("NewNATSPublisher(%q) succeeded", rawURL)
+		}
+	}
+}
@@ -0,0 +1,57 @@
+// Package publisher provides the EventPublisher implementations the outbox relay publishes through.
+package publisher
+
+import (
+	"fmt"
+	"time"
+
+	"transaction_demo/app/config"
+	"transaction_demo/app/domain/entity"
+	"transaction_demo/app/usecase"
+)
+
+// Publishers selectable with events.publisher.
+const (
+	PublisherLog  = "log"
+	PublisherFile = "file"
+	PublisherNATS = "nats"
+)
+
+const (
+	defaultFilePath    = "events.jsonl"
+	defaultNATSTimeout = 5 * time.Second
+)
+
+// NewEventPublisher returns the publisher selected by the configuration, the log publisher by default.
+// Connections and files are opened on the first publish, so an unused publisher costs nothing.
+func NewEventPublisher(cf *config.Config) (usecase.EventPublisher, error) {
+	switch cf.Events.Publisher {
+	case "", PublisherLog:
+		return NewLogPublisher(), nil
+	case PublisherFile:
+		path := cf.Events.FilePath
+		if path == "" {
+			path = defaultFilePath
+		}
+		return NewFilePublisher(path), nil
+	case PublisherNATS:
+		if cf.Events.NATS.URL == "" {
+			return nil, fmt.Errorf("events.nats.url is required by the nats publisher")
+		}
+		timeout := time.Duration(cf.Events.NATS.TimeoutSeconds) * time.Second
+		if timeout <= 0 {
+			timeout = defaultNATSTimeout
+		}
+		return NewNATSPublisher(cf.Events.NATS.URL, cf.Events.NATS.SubjectPrefix, cf.Events.NATS.Stream, timeout)
+	default:
+		return nil, fmt.Errorf("unknown event publisher: %q", cf.Events.Publisher)
+	}
+}
+
+// subject returns the subject or topic an event is published on: the prefix, a dot and the event type.
+func subject(prefix string, eventType entity.EventType) string {
+	if prefix == "" {
+		return string(eventType)
+	}
+	return prefix + "." + string(eventType)
+}
@@ -0,0 +1,90 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"syscall"
+	"time"
+
+	"transaction_demo/app/config"
+	"transaction_demo/app/domain/entity"
+	"transaction_demo/app/usecase"
+)
+
+const (
+	defaultTimeout = 10 * time.Second
+	// maxResponseBody is how much of a response is read, so that the connection can be reused.
+	maxResponseBody = 64 << 10
+	userAgent       = "transaction-demo-webhooks/1.0"
+)
+
+// httpSender posts deliveries as signed JSON requests.
+type httpSender struct {
+	client *http.Client
+}
+
+func NewHTTPSender(cf *config.Config) usecase.WebhookSender {
+	timeout := time.Duration(cf.Webhooks.TimeoutSeconds) * time.Second
+	if timeout <= 0 {
+		timeout = defaultTimeout
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	// Requests go straight to the endpoint, never through a proxy, so that its address is the one checked
+	transport.Proxy = nil
+	if !cf.Webhooks.AllowPrivateNetworks {
+		dialer := &net.Dialer{Timeout: timeout, Control: checkPublicAddress}
+		transport.DialContext = dialer.DialContext
+	}
+	return &httpSender{
+		client: &http.Client{
+			Timeout:   timeout,
+			Transport: transport,
+			// A redirect is a failed delivery: the signed request is not sent anywhere else
+			CheckRedirect: func(*http.Request, []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+	}
+}
+
+// checkPublicAddress refuses connections to loopback, link-local and private addresses. It runs on the
+// address the host resolved to when dialed, so a host that resolved to a public address when the
+// endpoint was registered cannot be pointed at the internal network afterwards.
+func checkPublicAddress(network, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	if !entity.IsPublicWebhookAddress(net.ParseIP(host)) {
+		return fmt.Errorf("%w: %s", entity.ErrWebhookDestination, host)
+	}
+	return nil
+}
+
+// Send posts the delivery payload with the delivery ID, event type, timestamp and signature headers.
+func (s *httpSender) Send(ctx context.Context, endpoint *entity.WebhookEndpoint, delivery *entity.WebhookDelivery,
+	sentAt time.Time) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint.URL, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", userAgent)
+	req.Header.Set(entity.WebhookDeliveryHeader, strconv.FormatUint(delivery.ID, 10))
+	req.Header.Set(entity.WebhookEventHeader, string(delivery.EventType))
+	req.Header.Set(entity.WebhookTimestampHeader, strconv.FormatInt(sentAt.Unix(), 10))
+	req.Header.Set(entity.WebhookSignatureHeader, entity.SignWebhook(endpoint.Secret, sentAt, delivery.Payload))
+
+	res, err := s.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer res.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(res.Body, maxResponseBody))
+	return res.StatusCode, nil
+}
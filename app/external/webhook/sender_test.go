@@ -0,0 +1,110 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"transaction_demo/app/config"
+	"transaction_demo/app/domain/entity"
+)
+
+func Test_httpSender_Send(t *testing.T) {
+	const secret = "whsec_0123456789abcdef"
+	payload := []byte(`{"id":7,"type":"transfer.posted"}`)
+	tests := []struct {
+		name       string
+		handler    func(w http.ResponseWriter, r *http.Request)
+		wantStatus int
+		wantErr    bool
+	}{
+		{
+			name: "delivered",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusNoContent)
+			},
+			wantStatus: http.StatusNoContent,
+		},
+		{
+			name: "receiver_error",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				http.Error(w, "boom", http.StatusInternalServerError)
+			},
+			wantStatus: http.StatusInternalServerError,
+		},
+		{
+			// Redirects are not followed, so the signed body never reaches another host
+			name: "redirect_not_followed",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				http.Redirect(w, r, "http://example.com/hook", http.StatusFound)
+			},
+			wantStatus: http.StatusFound,
+		},
+		{
+			name: "timeout",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				time.Sleep(1500 * time.Millisecond)
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sentAt := time.Now()
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				body, _ := io.ReadAll(r.Body)
+				if r.Method != http.MethodPost || r.Header.Get("Content-Type") != "application/json" {
+					t.Errorf("unexpected request %s %s", r.Method, r.Header.Get("Content-Type"))
+				}
+				if r.Header.Get(entity.WebhookDeliveryHeader) != "42" ||
+					r.Header.Get(entity.WebhookEventHeader) != string(entity.EventTransferPosted) {
+					t.Errorf("unexpected headers %v", r.Header)
+				}
+				if string(body) != string(payload) {
+					t.Errorf("body = %s, want %s", body, payload)
+				}
+				err := entity.VerifyWebhook(secret, r.Header.Get(entity.WebhookTimestampHeader),
+					r.Header.Get(entity.WebhookSignatureHeader), body, time.Now(), 5*time.Minute)
+				if err != nil {
+					t.Errorf("VerifyWebhook() error = %v", err)
+				}
+				tt.handler(w, r)
+			}))
+			defer server.Close()
+
+			// The test server listens on a loopback address
+			cf := &config.Config{Webhooks: config.Webhooks{TimeoutSeconds: 1, AllowPrivateNetworks: true}}
+			endpoint := &entity.WebhookEndpoint{ID: 1, URL: server.URL, Secret: secret}
+			delivery := &entity.WebhookDelivery{ID: 42, EventType: entity.EventTransferPosted, Payload: payload}
+
+			status, err := NewHTTPSender(cf).Send(context.Background(), endpoint, delivery, sentAt)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Send() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if status != tt.wantStatus {
+				t.Errorf("Send() status = %d, want %d", status, tt.wantStatus)
+			}
+		})
+	}
+}
+
+func Test_httpSender_Send_privateAddress(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("a loopback endpoint must not be sent to")
+	}))
+	defer server.Close()
+
+	cf := &config.Config{Webhooks: config.Webhooks{TimeoutSeconds: 1}}
+	endpoint := &entity.WebhookEndpoint{ID: 1, URL: server.URL, Secret: "secret"}
+	delivery := &entity.WebhookDelivery{ID: 42, EventType: entity.EventTransferPosted, Payload: []byte(`{}`)}
+
+	_, err := NewHTTPSender(cf).Send(context.Background(), endpoint, delivery, time.Now())
+	if !errors.Is(err, entity.ErrWebhookDestination) {
+		t.Errorf("Send() error = %v, want %v", err, entity.ErrWebhookDestination)
+	}
+}
@@ -1,25 +1,32 @@
 package handler
 
 import (
+	"context"
 	"fmt"
+	"io"
 	"net/http"
 	"strconv"
+	"time"
 
+	"github.com/gin-contrib/sse"
 	"github.com/gin-gonic/gin"
 
 	"transaction_demo/app/apperr"
+	"transaction_demo/app/domain/entity"
 	"transaction_demo/app/usecase"
 	"transaction_demo/app/usecase/dto"
 )
 
 type AccountHandler struct {
 	BaseHandler
-	accountUC usecase.AccountUC
+	accountUC     usecase.AccountUC
+	idempotencyUC usecase.IdempotencyUC
 }
 
-func NewAccountHandler(accountUC usecase.AccountUC) *AccountHandler {
+func NewAccountHandler(accountUC usecase.AccountUC, idempotencyUC usecase.IdempotencyUC) *AccountHandler {
 	return &AccountHandler{
-		accountUC: accountUC,
+		accountUC:     accountUC,
+		idempotencyUC: idempotencyUC,
 	}
 }
 
@@ -29,29 +36,36 @@ func NewAccountHandler(accountUC usecase.AccountUC) *AccountHandler {
 // @Tags Account
 // @Accept json
 // @Produce json
+// @Param Idempotency-Key header string false "Makes the request safe to retry"
 // @Success 200
 // @Failure 400 {object} apperr.AppError
 // @Failure 404 {object} apperr.AppError
+// @Failure 422 {object} apperr.AppError
 // @Failure 500 {object} apperr.AppError
 // @Router /accounts [POST]
 func (hdl *AccountHandler) CreateAccount(ctx *gin.Context) {
 	var (
 		req dto.AccountDTO
+		res dto.IdempotentResponseDTO
 		err error
 	)
 	defer func() {
 		if err != nil {
 			hdl.RenderError(ctx, err)
 		} else {
-			hdl.RenderResponse(ctx, http.StatusCreated, nil, nil)
+			hdl.RenderIdempotentResponse(ctx, res)
 		}
 	}()
 
-	if err := ctx.ShouldBindJSON(&req); err != nil {
-		hdl.RenderError(ctx, apperr.ErrInvalidInput.WithError(err))
+	if err = ctx.ShouldBindJSON(&req); err != nil {
+		err = apperr.ErrInvalidInput.WithError(err).WithMessage("Invalid request body")
 		return
 	}
-	_, err = hdl.accountUC.Create(ctx, req)
+
+	res, err = executeIdempotent(ctx, hdl.idempotencyUC, req, func(txCtx context.Context) (int, interface{}, error) {
+		_, err := hdl.accountUC.Create(txCtx, req)
+		return http.StatusCreated, nil, err
+	})
 }
 
 // GetAccountBalance retrieves the balance of an account
@@ -95,35 +109,782 @@ func (hdl *AccountHandler) GetAccountBalance(ctx *gin.Context) {
 	res, err = hdl.accountUC.GetBalance(ctx, accountID)
 }
 
+// LastEventIDHeader is the header EventSource clients send when reconnecting to an event stream.
+const LastEventIDHeader = "Last-Event-ID"
+
+// streamKeepAlive is how often an idle event stream sends a comment, so that proxies keep it open.
+const streamKeepAlive = 15 * time.Second
+
+// StreamAccountEvents streams the activity of an account
+// @Summary Stream account activity
+// @Description  Server-Sent Events stream of the balance changes (event "balance", an AccountBalanceEventDTO) and new or updated transactions (event "transaction", a TransactionRecordDTO) of an account, as they are committed. A new connection starts with the current balance. A client reconnecting with Last-Event-ID first gets the events it missed, or the current balance when they are no longer retained.
+// @Tags Account
+// @Produce text/event-stream
+// @Param account_id path int true "Account ID"
+// @Param Last-Event-ID header int false "ID of the last event received, to resume the stream"
+// @Param last_event_id query int false "Same as Last-Event-ID, for clients that cannot set headers"
+// @Success 200 {object} dto.AccountBalanceEventDTO
+// @Failure 400 {object} apperr.AppError
+// @Failure 404 {object} apperr.AppError
+// @Failure 500 {object} apperr.AppError
+// @Router /accounts/{account_id}/events [GET]
+func (hdl *AccountHandler) StreamAccountEvents(ctx *gin.Context) {
+	var (
+		req dto.AccountEventsDTO
+		err error
+	)
+	if req.AccountID, err = hdl.parseAccountID(ctx); err != nil {
+		hdl.RenderError(ctx, err)
+		return
+	}
+	lastEventIDStr := ctx.GetHeader(LastEventIDHeader)
+	if lastEventIDStr == "" {
+		lastEventIDStr = ctx.Query("last_event_id")
+	}
+	if lastEventIDStr != "" {
+		if req.LastEventID, err = strconv.ParseUint(lastEventIDStr, 10, 64); err != nil {
+			fmt.Println("Invalid Last-Event-ID", lastEventIDStr)
+			hdl.RenderError(ctx, apperr.ErrInvalidInput.WithMessage("Last-Event-ID must be a non-negative integer"))
+			return
+		}
+	}
+
+	events, sub, err := hdl.accountUC.SubscribeEvents(ctx, req)
+	if err != nil {
+		hdl.RenderError(ctx, err)
+		return
+	}
+	defer sub.Close()
+
+	// Proxies such as nginx must not buffer the stream
+	ctx.Header("Cache-Control", "no-cache")
+	ctx.Header("X-Accel-Buffering", "no")
+	ctx.Status(http.StatusOK)
+	for _, event := range events {
+		renderAccountEvent(ctx, event)
+	}
+	ctx.Writer.Flush()
+
+	keepAlive := time.NewTicker(streamKeepAlive)
+	defer keepAlive.Stop()
+	ctx.Stream(func(w io.Writer) bool {
+		select {
+		case <-ctx.Request.Context().Done():
+			return false
+		case event, ok := <-sub.Events:
+			// A closed subscription fell behind; the client reconnects and resumes
+			if !ok {
+				return false
+			}
+			renderAccountEvent(ctx, event)
+			return true
+		case <-keepAlive.C:
+			_, err := io.WriteString(w, ": keep-alive\n\n")
+			return err == nil
+		}
+	})
+}
+
+func renderAccountEvent(ctx *gin.Context, event dto.AccountEventDTO) {
+	ctx.Render(-1, sse.Event{Id: strconv.FormatUint(event.ID, 10), Event: event.Type, Data: event.Data})
+}
+
+// FreezeAccount freezes an account
+// @Summary Freeze an account
+// @Description  Block debits from an account. A frozen account keeps receiving money.
+// @Tags Account
+// @Accept json
+// @Produce json
+// @Param account_id path int true "Account ID"
+// @Success 200 {object} dto.AccountDTO
+// @Failure 400 {object} apperr.AppError
+// @Failure 404 {object} apperr.AppError
+// @Failure 500 {object} apperr.AppError
+// @Router /accounts/{account_id}/freeze [POST]
+func (hdl *AccountHandler) FreezeAccount(ctx *gin.Context) {
+	var (
+		accountID uint64
+		res       dto.AccountDTO
+		err       error
+	)
+	defer func() {
+		if err != nil {
+			hdl.RenderError(ctx, err)
+		} else {
+			hdl.RenderResponse(ctx, http.StatusOK, res, nil)
+		}
+	}()
+
+	if accountID, err = hdl.parseAccountID(ctx); err != nil {
+		return
+	}
+
+	res, err = hdl.accountUC.FreezeAccount(ctx, accountID)
+}
+
+// UnfreezeAccount unfreezes an account
+// @Summary Unfreeze an account
+// @Description  Make a frozen account active again.
+// @Tags Account
+// @Accept json
+// @Produce json
+// @Param account_id path int true "Account ID"
+// @Success 200 {object} dto.AccountDTO
+// @Failure 400 {object} apperr.AppError
+// @Failure 404 {object} apperr.AppError
+// @Failure 500 {object} apperr.AppError
+// @Router /accounts/{account_id}/unfreeze [POST]
+func (hdl *AccountHandler) UnfreezeAccount(ctx *gin.Context) {
+	var (
+		accountID uint64
+		res       dto.AccountDTO
+		err       error
+	)
+	defer func() {
+		if err != nil {
+			hdl.RenderError(ctx, err)
+		} else {
+			hdl.RenderResponse(ctx, http.StatusOK, res, nil)
+		}
+	}()
+
+	if accountID, err = hdl.parseAccountID(ctx); err != nil {
+		return
+	}
+
+	res, err = hdl.accountUC.UnfreezeAccount(ctx, accountID)
+}
+
+// CloseAccount closes an account
+// @Summary Close an account
+// @Description  Close an account for good. The balance must be zero, or is swept to sweep_account_id.
+// @Tags Account
+// @Accept json
+// @Produce json
+// @Param Idempotency-Key header string false "Makes the request safe to retry"
+// @Param account_id path int true "Account ID"
+// @Param request body dto.CloseAccountDTO false "Account receiving the remaining balance"
+// @Success 200 {object} dto.AccountDTO
+// @Failure 400 {object} apperr.AppError
+// @Failure 404 {object} apperr.AppError
+// @Failure 409 {object} apperr.AppError
+// @Failure 422 {object} apperr.AppError
+// @Failure 500 {object} apperr.AppError
+// @Router /accounts/{account_id}/close [POST]
+func (hdl *AccountHandler) CloseAccount(ctx *gin.Context) {
+	var (
+		req dto.CloseAccountDTO
+		res dto.IdempotentResponseDTO
+		err error
+	)
+	defer func() {
+		if err != nil {
+			hdl.RenderError(ctx, err)
+		} else {
+			hdl.RenderIdempotentResponse(ctx, res)
+		}
+	}()
+
+	// The body is optional: an account with a zero balance needs no sweep account
+	if ctx.Request.ContentLength != 0 {
+		if err = ctx.ShouldBindJSON(&req); err != nil {
+			err = apperr.ErrInvalidInput.WithError(err).WithMessage("Invalid request body")
+			return
+		}
+	}
+
+	if req.AccountID, err = hdl.parseAccountID(ctx); err != nil {
+		return
+	}
+
+	res, err = executeIdempotent(ctx, hdl.idempotencyUC, req, func(txCtx context.Context) (int, interface{}, error) {
+		account, err := hdl.accountUC.CloseAccount(txCtx, req)
+		return http.StatusOK, account, err
+	})
+}
+
+// SetOverdraftLimit changes the overdraft limit of an account
+// @Summary Set an account overdraft limit
+// @Description  Change how far below zero the balance of an account may go. Every change is recorded in the account audit trail.
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Param account_id path int true "Account ID"
+// @Param request body dto.OverdraftDTO true "New overdraft limit"
+// @Success 200 {object} dto.AccountDTO
+// @Failure 400 {object} apperr.AppError
+// @Failure 404 {object} apperr.AppError
+// @Failure 409 {object} apperr.AppError
+// @Failure 500 {object} apperr.AppError
+// @Router /admin/accounts/{account_id}/overdraft [PUT]
+func (hdl *AccountHandler) SetOverdraftLimit(ctx *gin.Context) {
+	var (
+		req dto.OverdraftDTO
+		res dto.AccountDTO
+		err error
+	)
+	defer func() {
+		if err != nil {
+			hdl.RenderError(ctx, err)
+		} else {
+			hdl.RenderResponse(ctx, http.StatusOK, res, nil)
+		}
+	}()
+
+	if err = ctx.ShouldBindJSON(&req); err != nil {
+		err = apperr.ErrInvalidInput.WithError(err).WithMessage("Invalid request body")
+		return
+	}
+
+	if req.AccountID, err = hdl.parseAccountID(ctx); err != nil {
+		return
+	}
+
+	res, err = hdl.accountUC.SetOverdraftLimit(ctx, req)
+}
+
+// ListAccountAudits lists the administrative changes made to an account
+// @Summary List account audit trail
+// @Description  List the administrative changes made to an account, oldest first.
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Param account_id path int true "Account ID"
+// @Success 200 {array} dto.AccountAuditDTO
+// @Failure 400 {object} apperr.AppError
+// @Failure 404 {object} apperr.AppError
+// @Failure 500 {object} apperr.AppError
+// @Router /admin/accounts/{account_id}/audits [GET]
+func (hdl *AccountHandler) ListAccountAudits(ctx *gin.Context) {
+	var (
+		accountID uint64
+		res       []dto.AccountAuditDTO
+		err       error
+	)
+	defer func() {
+		if err != nil {
+			hdl.RenderError(ctx, err)
+		} else {
+			hdl.RenderResponse(ctx, http.StatusOK, res, nil)
+		}
+	}()
+
+	if accountID, err = hdl.parseAccountID(ctx); err != nil {
+		return
+	}
+
+	res, err = hdl.accountUC.ListAccountAudits(ctx, accountID)
+}
+
+// ListRiskReviews lists transfers held for risk review
+// @Summary List risk reviews
+// @Description  List risk reviews by status, oldest first. Open reviews are listed by default.
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Param status query string false "open, released or rejected" default(open)
+// @Param limit query int false "Page size, 1 to 100" default(50)
+// @Success 200 {array} dto.RiskReviewDTO
+// @Failure 400 {object} apperr.AppError
+// @Failure 500 {object} apperr.AppError
+// @Router /admin/reviews [GET]
+func (hdl *AccountHandler) ListRiskReviews(ctx *gin.Context) {
+	var (
+		req dto.RiskReviewListDTO
+		res []dto.RiskReviewDTO
+		err error
+	)
+	defer func() {
+		if err != nil {
+			hdl.RenderError(ctx, err)
+		} else {
+			hdl.RenderResponse(ctx, http.StatusOK, res, nil)
+		}
+	}()
+
+	if err = ctx.ShouldBindQuery(&req); err != nil {
+		err = apperr.ErrInvalidInput.WithError(err).WithMessage("Invalid query parameters")
+		return
+	}
+
+	res, err = hdl.accountUC.ListRiskReviews(ctx, req)
+}
+
+// ReleaseTransaction posts a transfer held for risk review
+// @Summary Release a held transfer
+// @Description  Post a transfer held for risk review. Account status and funds are checked again at release.
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Param Idempotency-Key header string false "Makes the request safe to retry"
+// @Param transaction_id path int true "Transaction ID"
+// @Param request body dto.ReviewDecisionDTO true "Review decision"
+// @Success 200 {object} dto.TransactionRecordDTO
+// @Failure 400 {object} apperr.AppError
+// @Failure 404 {object} apperr.AppError
+// @Failure 409 {object} apperr.AppError
+// @Failure 422 {object} apperr.AppError
+// @Failure 500 {object} apperr.AppError
+// @Router /admin/transactions/{transaction_id}/release [POST]
+func (hdl *AccountHandler) ReleaseTransaction(ctx *gin.Context) {
+	hdl.decideReview(ctx, hdl.accountUC.ReleaseTransaction)
+}
+
+// RejectTransaction fails a transfer held for risk review
+// @Summary Reject a held transfer
+// @Description  Fail a transfer held for risk review. No money moves.
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Param Idempotency-Key header string false "Makes the request safe to retry"
+// @Param transaction_id path int true "Transaction ID"
+// @Param request body dto.ReviewDecisionDTO true "Review decision"
+// @Success 200 {object} dto.TransactionRecordDTO
+// @Failure 400 {object} apperr.AppError
+// @Failure 404 {object} apperr.AppError
+// @Failure 500 {object} apperr.AppError
+// @Router /admin/transactions/{transaction_id}/reject [POST]
+func (hdl *AccountHandler) RejectTransaction(ctx *gin.Context) {
+	hdl.decideReview(ctx, hdl.accountUC.RejectTransaction)
+}
+
+// decideReview binds a review decision and applies it with decide.
+func (hdl *AccountHandler) decideReview(ctx *gin.Context,
+	decide func(context.Context, dto.ReviewDecisionDTO) (dto.TransactionRecordDTO, error)) {
+	var (
+		req dto.ReviewDecisionDTO
+		res dto.IdempotentResponseDTO
+		err error
+	)
+	defer func() {
+		if err != nil {
+			hdl.RenderError(ctx, err)
+		} else {
+			hdl.RenderIdempotentResponse(ctx, res)
+		}
+	}()
+
+	if err = ctx.ShouldBindJSON(&req); err != nil {
+		err = apperr.ErrInvalidInput.WithError(err).WithMessage("Invalid request body")
+		return
+	}
+
+	transactionIDStr := ctx.Param("transaction_id")
+	req.TransactionID, err = strconv.ParseUint(transactionIDStr, 10, 64)
+	if err != nil || req.TransactionID == 0 {
+		fmt.Println("Invalid transaction_id", transactionIDStr)
+		err = apperr.ErrInvalidInput.WithMessage("Transaction ID must be a positive integer")
+		return
+	}
+
+	res, err = executeIdempotent(ctx, hdl.idempotencyUC, req, func(txCtx context.Context) (int, interface{}, error) {
+		transaction, err := decide(txCtx, req)
+		return http.StatusOK, transaction, err
+	})
+}
+
+// ListTransactions lists the transaction history of an account
+// @Summary List account transactions
+// @Description  List the transfers an account sent or received, newest first. Pass meta.next_cursor as cursor to fetch the next page.
+// @Tags Account
+// @Accept json
+// @Produce json
+// @Param account_id path int true "Account ID"
+// @Param direction query string false "incoming or outgoing"
+// @Param from query string false "Earliest transaction time (RFC 3339), inclusive"
+// @Param to query string false "Latest transaction time (RFC 3339), exclusive"
+// @Param min_amount query string false "Minimum amount in the account currency"
+// @Param max_amount query string false "Maximum amount in the account currency"
+// @Param cursor query string false "Cursor of the next page"
+// @Param limit query int false "Page size, 1 to 100" default(20)
+// @Success 200 {array} dto.TransactionRecordDTO
+// @Failure 400 {object} apperr.AppError
+// @Failure 404 {object} apperr.AppError
+// @Failure 500 {object} apperr.AppError
+// @Router /accounts/{account_id}/transactions [GET]
+func (hdl *AccountHandler) ListTransactions(ctx *gin.Context) {
+	var (
+		req  dto.TransactionListDTO
+		res  []dto.TransactionRecordDTO
+		meta dto.PageMetaDTO
+		err  error
+	)
+	defer func() {
+		if err != nil {
+			hdl.RenderError(ctx, err)
+		} else {
+			hdl.RenderResponse(ctx, http.StatusOK, res, meta)
+		}
+	}()
+
+	if err = ctx.ShouldBindQuery(&req); err != nil {
+		err = apperr.ErrInvalidInput.WithError(err).WithMessage("Invalid query parameters")
+		return
+	}
+
+	accountIDStr := ctx.Param("account_id")
+	req.AccountID, err = strconv.ParseUint(accountIDStr, 10, 64)
+	if err != nil || req.AccountID == 0 {
+		fmt.Println("Invalid account_id", accountIDStr)
+		err = apperr.ErrInvalidInput.WithMessage("Account ID must be a positive integer")
+		return
+	}
+
+	res, meta, err = hdl.accountUC.ListTransactions(ctx, req)
+}
+
 // MakeTransaction  performs a transaction on an account
 // @Summary Make a transaction
 // @Description  Perform a transaction on an account, updating its balance.
 // @Tags Transaction
 // @Accept json
 // @Produce json
-// @Success 200
+// @Param Idempotency-Key header string false "Makes the request safe to retry"
+// @Success 201 {object} dto.TransactionRecordDTO
+// @Success 202 {object} dto.TransactionRecordDTO "Transfer held for risk review"
 // @Failure 400 {object} apperr.AppError
 // @Failure 404 {object} apperr.AppError
+// @Failure 422 {object} apperr.AppError
 // @Failure 500 {object} apperr.AppError
-// @Router /transaction [POST]
-func (hdl *AccountHandler) MakeTransaction(context *gin.Context) {
+// @Router /transactions [POST]
+func (hdl *AccountHandler) MakeTransaction(ctx *gin.Context) {
 	var (
 		req dto.TransactionDTO
+		res dto.IdempotentResponseDTO
+		err error
+	)
+
+	defer func() {
+		if err != nil {
+			hdl.RenderError(ctx, err)
+		} else {
+			hdl.RenderIdempotentResponse(ctx, res)
+		}
+	}()
+
+	if err = ctx.ShouldBindJSON(&req); err != nil {
+		err = apperr.ErrInvalidInput.WithError(err).WithMessage("Invalid request body")
+		return
+	}
+
+	res, err = executeIdempotent(ctx, hdl.idempotencyUC, req, func(txCtx context.Context) (int, interface{}, error) {
+		transaction, err := hdl.accountUC.MakeTransaction(txCtx, req)
+		if transaction.Status == entity.TransactionPending {
+			return http.StatusAccepted, transaction, err
+		}
+		return http.StatusCreated, transaction, err
+	})
+}
+
+// MakeSplitPayment debits one account and credits several
+// @Summary Make a split payment
+// @Description  Debit the source account once and credit several accounts, e.g. the seller, the platform fee and the tax. The leg amounts must add up to the amount, and every account must use the source account currency. Split payments are never held for risk review: a leg that would be held refuses the whole payment.
+// @Tags Transaction
+// @Accept json
+// @Produce json
+// @Param Idempotency-Key header string false "Makes the request safe to retry"
+// @Param request body dto.SplitPaymentDTO true "Split payment"
+// @Success 201 {object} dto.SplitPaymentResultDTO
+// @Failure 400 {object} apperr.AppError
+// @Failure 404 {object} apperr.AppError
+// @Failure 409 {object} apperr.AppError
+// @Failure 422 {object} apperr.AppError
+// @Failure 500 {object} apperr.AppError
+// @Router /transactions/split [POST]
+func (hdl *AccountHandler) MakeSplitPayment(ctx *gin.Context) {
+	var (
+		req dto.SplitPaymentDTO
+		res dto.IdempotentResponseDTO
+		err error
+	)
+	defer func() {
+		if err != nil {
+			hdl.RenderError(ctx, err)
+		} else {
+			hdl.RenderIdempotentResponse(ctx, res)
+		}
+	}()
+
+	if err = ctx.ShouldBindJSON(&req); err != nil {
+		err = apperr.ErrInvalidInput.WithError(err).WithMessage("Invalid request body")
+		return
+	}
+
+	res, err = executeIdempotent(ctx, hdl.idempotencyUC, req, func(txCtx context.Context) (int, interface{}, error) {
+		split, err := hdl.accountUC.MakeSplitPayment(txCtx, req)
+		return http.StatusCreated, split, err
+	})
+}
+
+// MakeBatchTransaction performs many transfers in one request
+// @Summary Make a batch of transfers
+// @Description  Perform up to 1000 transfers in one request. In atomic mode every transfer is made or none is, and the first failing one is returned as the error. In best_effort mode each transfer is made on its own and failures are reported per item with status 207.
+// @Tags Transaction
+// @Accept json
+// @Produce json
+// @Param Idempotency-Key header string false "Makes the request safe to retry"
+// @Param request body dto.BatchTransactionDTO true "Transfers to make"
+// @Success 201 {object} dto.BatchResultDTO
+// @Success 207 {object} dto.BatchResultDTO "Some best_effort transfers failed"
+// @Failure 400 {object} apperr.AppError
+// @Failure 404 {object} apperr.AppError
+// @Failure 422 {object} apperr.AppError
+// @Failure 500 {object} apperr.AppError
+// @Router /transactions/batch [POST]
+func (hdl *AccountHandler) MakeBatchTransaction(ctx *gin.Context) {
+	var (
+		req dto.BatchTransactionDTO
+		res dto.IdempotentResponseDTO
+		err error
+	)
+	defer func() {
+		if err != nil {
+			hdl.RenderError(ctx, err)
+		} else {
+			hdl.RenderIdempotentResponse(ctx, res)
+		}
+	}()
+
+	if err = ctx.ShouldBindJSON(&req); err != nil {
+		err = apperr.ErrInvalidInput.WithError(err).WithMessage("Invalid request body")
+		return
+	}
+
+	res, err = executeIdempotent(ctx, hdl.idempotencyUC, req, func(txCtx context.Context) (int, interface{}, error) {
+		result, err := hdl.accountUC.MakeBatchTransaction(txCtx, req)
+		if result.Failed > 0 {
+			return http.StatusMultiStatus, result, err
+		}
+		return http.StatusCreated, result, err
+	})
+}
+
+// GetTransaction retrieves a transaction
+// @Summary Get a transaction
+// @Description  Retrieve a transaction by its ID, including its status.
+// @Tags Transaction
+// @Accept json
+// @Produce json
+// @Param transaction_id path int true "Transaction ID"
+// @Success 200 {object} dto.TransactionRecordDTO
+// @Failure 400 {object} apperr.AppError
+// @Failure 404 {object} apperr.AppError
+// @Failure 500 {object} apperr.AppError
+// @Router /transactions/{transaction_id} [GET]
+func (hdl *AccountHandler) GetTransaction(ctx *gin.Context) {
+	var (
+		transactionID uint64
+		res           dto.TransactionRecordDTO
+		err           error
+	)
+	defer func() {
+		if err != nil {
+			hdl.RenderError(ctx, err)
+		} else {
+			hdl.RenderResponse(ctx, http.StatusOK, res, nil)
+		}
+	}()
+
+	transactionIDStr := ctx.Param("transaction_id")
+	transactionID, err = strconv.ParseUint(transactionIDStr, 10, 64)
+	if err != nil || transactionID == 0 {
+		fmt.Println("Invalid transaction_id", transactionIDStr)
+		err = apperr.ErrInvalidInput.WithMessage("Transaction ID must be a positive integer")
+		return
+	}
+
+	res, err = hdl.accountUC.GetTransaction(ctx, transactionID)
+}
+
+// ReverseTransaction reverses a transaction
+// @Summary Reverse a transaction
+// @Description  Return all or part of a transfer to its source account. The reversal is a new transaction linked to the original one.
+// @Tags Transaction
+// @Accept json
+// @Produce json
+// @Param Idempotency-Key header string false "Makes the request safe to retry"
+// @Param transaction_id path int true "Transaction ID"
+// @Param request body dto.ReversalDTO false "Amount to reverse"
+// @Success 201 {object} dto.ReversalResultDTO
+// @Failure 400 {object} apperr.AppError
+// @Failure 404 {object} apperr.AppError
+// @Failure 422 {object} apperr.AppError
+// @Failure 500 {object} apperr.AppError
+// @Router /transactions/{transaction_id}/reversals [POST]
+func (hdl *AccountHandler) ReverseTransaction(ctx *gin.Context) {
+	var (
+		req dto.ReversalDTO
+		res dto.IdempotentResponseDTO
 		err error
 	)
+	defer func() {
+		if err != nil {
+			hdl.RenderError(ctx, err)
+		} else {
+			hdl.RenderIdempotentResponse(ctx, res)
+		}
+	}()
+
+	// The body is optional: an empty body reverses everything not yet reversed
+	if ctx.Request.ContentLength != 0 {
+		if err = ctx.ShouldBindJSON(&req); err != nil {
+			err = apperr.ErrInvalidInput.WithError(err).WithMessage("Invalid request body")
+			return
+		}
+	}
+
+	transactionIDStr := ctx.Param("transaction_id")
+	req.TransactionID, err = strconv.ParseUint(transactionIDStr, 10, 64)
+	if err != nil || req.TransactionID == 0 {
+		fmt.Println("Invalid transaction_id", transactionIDStr)
+		err = apperr.ErrInvalidInput.WithMessage("Transaction ID must be a positive integer")
+		return
+	}
+
+	res, err = executeIdempotent(ctx, hdl.idempotencyUC, req, func(txCtx context.Context) (int, interface{}, error) {
+		reversal, err := hdl.accountUC.ReverseTransaction(txCtx, req)
+		return http.StatusCreated, reversal, err
+	})
+}
 
+// AuthorizeTransaction reserves funds for a transfer
+// @Summary Authorize a transfer
+// @Description  Place a hold on the source account for a transfer that is captured or voided later. Held funds are excluded from the available balance until the hold is captured, voided or expires.
+// @Tags Hold
+// @Accept json
+// @Produce json
+// @Param Idempotency-Key header string false "Makes the request safe to retry"
+// @Param request body dto.AuthorizeDTO true "Transfer to authorize"
+// @Success 201 {object} dto.HoldDTO
+// @Failure 400 {object} apperr.AppError
+// @Failure 422 {object} apperr.AppError
+// @Failure 500 {object} apperr.AppError
+// @Router /holds [POST]
+func (hdl *AccountHandler) AuthorizeTransaction(ctx *gin.Context) {
+	var (
+		req dto.AuthorizeDTO
+		res dto.IdempotentResponseDTO
+		err error
+	)
 	defer func() {
 		if err != nil {
-			hdl.RenderError(context, err)
+			hdl.RenderError(ctx, err)
 		} else {
-			hdl.RenderResponse(context, http.StatusCreated, nil, nil)
+			hdl.RenderIdempotentResponse(ctx, res)
 		}
 	}()
 
-	if err = context.ShouldBindJSON(&req); err != nil {
+	if err = ctx.ShouldBindJSON(&req); err != nil {
 		err = apperr.ErrInvalidInput.WithError(err).WithMessage("Invalid request body")
 		return
 	}
 
-	err = hdl.accountUC.MakeTransaction(context, req)
+	res, err = executeIdempotent(ctx, hdl.idempotencyUC, req, func(txCtx context.Context) (int, interface{}, error) {
+		hold, err := hdl.accountUC.AuthorizeTransaction(txCtx, req)
+		return http.StatusCreated, hold, err
+	})
+}
+
+// CaptureHold settles a hold
+// @Summary Capture a hold
+// @Description  Settle an authorized hold as a transfer, for the full held amount or a lower one. Any remainder is released.
+// @Tags Hold
+// @Accept json
+// @Produce json
+// @Param Idempotency-Key header string false "Makes the request safe to retry"
+// @Param hold_id path int true "Hold ID"
+// @Param request body dto.CaptureDTO false "Amount to capture"
+// @Success 200 {object} dto.HoldDTO
+// @Failure 400 {object} apperr.AppError
+// @Failure 404 {object} apperr.AppError
+// @Failure 409 {object} apperr.AppError
+// @Failure 422 {object} apperr.AppError
+// @Failure 500 {object} apperr.AppError
+// @Router /holds/{hold_id}/capture [POST]
+func (hdl *AccountHandler) CaptureHold(ctx *gin.Context) {
+	var (
+		req dto.CaptureDTO
+		res dto.IdempotentResponseDTO
+		err error
+	)
+	defer func() {
+		if err != nil {
+			hdl.RenderError(ctx, err)
+		} else {
+			hdl.RenderIdempotentResponse(ctx, res)
+		}
+	}()
+
+	// The body is optional: an empty body captures the full amount
+	if ctx.Request.ContentLength != 0 {
+		if err = ctx.ShouldBindJSON(&req); err != nil {
+			err = apperr.ErrInvalidInput.WithError(err).WithMessage("Invalid request body")
+			return
+		}
+	}
+
+	if req.HoldID, err = hdl.parseHoldID(ctx); err != nil {
+		return
+	}
+
+	res, err = executeIdempotent(ctx, hdl.idempotencyUC, req, func(txCtx context.Context) (int, interface{}, error) {
+		hold, err := hdl.accountUC.CaptureHold(txCtx, req)
+		return http.StatusOK, hold, err
+	})
+}
+
+// VoidHold releases a hold
+// @Summary Void a hold
+// @Description  Release an authorized hold without moving money.
+// @Tags Hold
+// @Accept json
+// @Produce json
+// @Param hold_id path int true "Hold ID"
+// @Success 200 {object} dto.HoldDTO
+// @Failure 400 {object} apperr.AppError
+// @Failure 404 {object} apperr.AppError
+// @Failure 409 {object} apperr.AppError
+// @Failure 500 {object} apperr.AppError
+// @Router /holds/{hold_id}/void [POST]
+func (hdl *AccountHandler) VoidHold(ctx *gin.Context) {
+	var (
+		holdID uint64
+		res    dto.HoldDTO
+		err    error
+	)
+	defer func() {
+		if err != nil {
+			hdl.RenderError(ctx, err)
+		} else {
+			hdl.RenderResponse(ctx, http.StatusOK, res, nil)
+		}
+	}()
+
+	if holdID, err = hdl.parseHoldID(ctx); err != nil {
+		return
+	}
+
+	res, err = hdl.accountUC.VoidHold(ctx, holdID)
+}
+
+// parseAccountID reads the account_id path parameter.
+func (hdl *AccountHandler) parseAccountID(ctx *gin.Context) (uint64, error) {
+	accountIDStr := ctx.Param("account_id")
+	accountID, err := strconv.ParseUint(accountIDStr, 10, 64)
+	if err != nil || accountID == 0 {
+		fmt.Println("Invalid account_id", accountIDStr)
+		return 0, apperr.ErrInvalidInput.WithMessage("Account ID must be a positive integer")
+	}
+	return accountID, nil
+}
+
+// parseHoldID reads the hold_id path parameter.
+func (hdl *AccountHandler) parseHoldID(ctx *gin.Context) (uint64, error) {
+	holdIDStr := ctx.Param("hold_id")
+	holdID, err := strconv.ParseUint(holdIDStr, 10, 64)
+	if err != nil || holdID == 0 {
+		fmt.Println("Invalid hold_id", holdIDStr)
+		return 0, apperr.ErrInvalidInput.WithMessage("Hold ID must be a positive integer")
+	}
+	return holdID, nil
 }
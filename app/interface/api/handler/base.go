@@ -2,29 +2,67 @@
 package handler
 
 import (
+	"encoding/json"
+
 	"transaction_demo/app/apperr"
+	"transaction_demo/app/usecase"
+	"transaction_demo/app/usecase/dto"
 
 	"github.com/gin-gonic/gin"
 )
 
+// IdempotencyKeyHeader is the request header clients use to make POST requests safe to retry.
+const IdempotencyKeyHeader = "Idempotency-Key"
+
+// idempotentReplayedHeader marks a response that was replayed from an earlier request.
+const idempotentReplayedHeader = "Idempotent-Replayed"
+
 // BaseHandler provides common functionality for HTTP handlers in the application.
 type BaseHandler struct{}
 
 // RenderResponse renders a successful HTTP response with the provided status code and data.
 // It standardizes the JSON response format across the application.
+// Without meta the payload is the response body; with meta the body is {"data": ..., "meta": ...}.
 //
 // Parameters:
 //   - ctx: The Gin context for the HTTP request
 //   - status: HTTP status code to return
 //   - data: The response payload to be serialized as JSON
-//   - meta: Additional metadata (currently unused but available for future extension)
+//   - meta: Additional metadata such as pagination, or nil
 func (h *BaseHandler) RenderResponse(
 	ctx *gin.Context,
 	status int,
 	data interface{},
 	meta interface{},
 ) {
-	ctx.JSON(status, data)
+	if meta == nil {
+		ctx.JSON(status, data)
+		return
+	}
+	ctx.JSON(status, dataWithMeta{Data: data, Meta: meta})
+}
+
+// dataWithMeta is the response envelope used when a response carries metadata.
+type dataWithMeta struct {
+	Data interface{} `json:"data"`
+	Meta interface{} `json:"meta"`
+}
+
+// RenderIdempotentResponse renders a response produced under an idempotency key.
+// The recorded JSON body is written as-is so replays are byte-for-byte identical
+// to the original response.
+//
+// Parameters:
+//   - ctx: The Gin context for the HTTP request
+//   - res: The recorded status code and body
+func (h *BaseHandler) RenderIdempotentResponse(
+	ctx *gin.Context,
+	res dto.IdempotentResponseDTO,
+) {
+	if res.Replayed {
+		ctx.Header(idempotentReplayedHeader, "true")
+	}
+	ctx.Data(res.Status, "application/json; charset=utf-8", res.Body)
 }
 
 // RenderError handles error responses by converting errors to a standardized format.
@@ -50,3 +88,28 @@ func (h *BaseHandler) RenderError(
 
 	ctx.JSON(appErr.Status, appErr)
 }
+
+// executeIdempotent runs fn under the request's Idempotency-Key header when one is sent,
+// so a retried request replays the original response instead of repeating the operation.
+// Without the header fn simply runs once.
+func executeIdempotent(ctx *gin.Context, idempotencyUC usecase.IdempotencyUC, payload interface{},
+	fn usecase.IdempotentFunc) (dto.IdempotentResponseDTO, error) {
+	key := ctx.GetHeader(IdempotencyKeyHeader)
+	if key == "" {
+		status, body, err := fn(ctx)
+		if err != nil {
+			return dto.IdempotentResponseDTO{}, err
+		}
+		data, err := json.Marshal(body)
+		if err != nil {
+			return dto.IdempotentResponseDTO{}, err
+		}
+		return dto.IdempotentResponseDTO{Status: status, Body: data}, nil
+	}
+
+	return idempotencyUC.Execute(ctx, dto.IdempotencyDTO{
+		Key:     key,
+		Scope:   ctx.Request.Method + " " + ctx.FullPath(),
+		Payload: payload,
+	}, fn)
+}
@@ -0,0 +1,89 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"transaction_demo/app/apperr"
+	"transaction_demo/app/usecase"
+	"transaction_demo/app/usecase/dto"
+)
+
+type FXHandler struct {
+	BaseHandler
+	fxUC usecase.FXUC
+}
+
+func NewFXHandler(fxUC usecase.FXUC) *FXHandler {
+	return &FXHandler{
+		fxUC: fxUC,
+	}
+}
+
+// CreateQuote locks an exchange rate for a cross-currency transfer
+// @Summary Create an FX quote
+// @Description  Lock the current rate of a currency pair for a short time. Pass the returned quote_id to the transaction endpoint.
+// @Tags FX
+// @Accept json
+// @Produce json
+// @Param request body dto.QuoteRequestDTO true "Currency pair"
+// @Success 201 {object} dto.QuoteDTO
+// @Failure 400 {object} apperr.AppError
+// @Failure 404 {object} apperr.AppError
+// @Failure 500 {object} apperr.AppError
+// @Router /fx/quotes [POST]
+func (hdl *FXHandler) CreateQuote(ctx *gin.Context) {
+	var (
+		req dto.QuoteRequestDTO
+		res dto.QuoteDTO
+		err error
+	)
+	defer func() {
+		if err != nil {
+			hdl.RenderError(ctx, err)
+		} else {
+			hdl.RenderResponse(ctx, http.StatusCreated, res, nil)
+		}
+	}()
+
+	if err = ctx.ShouldBindJSON(&req); err != nil {
+		err = apperr.ErrInvalidInput.WithError(err).WithMessage("Invalid request body")
+		return
+	}
+
+	res, err = hdl.fxUC.CreateQuote(ctx, req)
+}
+
+// SetRate creates or replaces the rate of a currency pair
+// @Summary Set an exchange rate
+// @Description  Create or replace the current rate of a currency pair.
+// @Tags FX
+// @Accept json
+// @Produce json
+// @Param request body dto.RateDTO true "Exchange rate"
+// @Success 200 {object} dto.RateDTO
+// @Failure 400 {object} apperr.AppError
+// @Failure 500 {object} apperr.AppError
+// @Router /fx/rates [PUT]
+func (hdl *FXHandler) SetRate(ctx *gin.Context) {
+	var (
+		req dto.RateDTO
+		res dto.RateDTO
+		err error
+	)
+	defer func() {
+		if err != nil {
+			hdl.RenderError(ctx, err)
+		} else {
+			hdl.RenderResponse(ctx, http.StatusOK, res, nil)
+		}
+	}()
+
+	if err = ctx.ShouldBindJSON(&req); err != nil {
+		err = apperr.ErrInvalidInput.WithError(err).WithMessage("Invalid request body")
+		return
+	}
+
+	res, err = hdl.fxUC.SetRate(ctx, req)
+}
@@ -0,0 +1,127 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"transaction_demo/app/apperr"
+	"transaction_demo/app/usecase"
+	"transaction_demo/app/usecase/dto"
+)
+
+type InterestHandler struct {
+	BaseHandler
+	interestUC usecase.InterestUC
+}
+
+func NewInterestHandler(interestUC usecase.InterestUC) *InterestHandler {
+	return &InterestHandler{
+		interestUC: interestUC,
+	}
+}
+
+// AccrueInterest accrues the interest of one day
+// @Summary Accrue interest for a day
+// @Description  Accrue the interest earned on an ended day by every account with a configured rate, on its end-of-day balance. Running a day again accrues nothing.
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Param request body dto.InterestAccrualRequestDTO true "Day to accrue"
+// @Success 200 {object} dto.InterestRunDTO
+// @Failure 400 {object} apperr.AppError
+// @Failure 500 {object} apperr.AppError
+// @Router /admin/interest/accruals [POST]
+func (hdl *InterestHandler) AccrueInterest(ctx *gin.Context) {
+	var (
+		req dto.InterestAccrualRequestDTO
+		res dto.InterestRunDTO
+		err error
+	)
+	defer func() {
+		if err != nil {
+			hdl.RenderError(ctx, err)
+		} else {
+			hdl.RenderResponse(ctx, http.StatusOK, res, nil)
+		}
+	}()
+
+	if err = ctx.ShouldBindJSON(&req); err != nil {
+		err = apperr.ErrInvalidInput.WithError(err).WithMessage("Invalid request body")
+		return
+	}
+
+	res, err = hdl.interestUC.AccrueInterest(ctx, req)
+}
+
+// PostInterest posts the interest accrued in one month
+// @Summary Post interest for a month
+// @Description  Credit every account with the interest it accrued in an ended month, from the interest expense account. Running a month again credits nothing.
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Param request body dto.InterestPostingRequestDTO true "Month to post"
+// @Success 200 {object} dto.InterestRunDTO
+// @Failure 400 {object} apperr.AppError
+// @Failure 500 {object} apperr.AppError
+// @Router /admin/interest/postings [POST]
+func (hdl *InterestHandler) PostInterest(ctx *gin.Context) {
+	var (
+		req dto.InterestPostingRequestDTO
+		res dto.InterestRunDTO
+		err error
+	)
+	defer func() {
+		if err != nil {
+			hdl.RenderError(ctx, err)
+		} else {
+			hdl.RenderResponse(ctx, http.StatusOK, res, nil)
+		}
+	}()
+
+	if err = ctx.ShouldBindJSON(&req); err != nil {
+		err = apperr.ErrInvalidInput.WithError(err).WithMessage("Invalid request body")
+		return
+	}
+
+	res, err = hdl.interestUC.PostInterest(ctx, req)
+}
+
+// ListInterestAccruals lists the daily interest accruals of an account
+// @Summary List interest accruals
+// @Description  List the latest daily interest accruals of an account, newest first, posted or not.
+// @Tags Accounts
+// @Accept json
+// @Produce json
+// @Param account_id path int true "Account ID"
+// @Success 200 {array} dto.InterestAccrualDTO
+// @Failure 400 {object} apperr.AppError
+// @Failure 404 {object} apperr.AppError
+// @Failure 500 {object} apperr.AppError
+// @Router /accounts/{account_id}/interest-accruals [GET]
+func (hdl *InterestHandler) ListInterestAccruals(ctx *gin.Context) {
+	var (
+		accountID uint64
+		res       []dto.InterestAccrualDTO
+		err       error
+	)
+	defer func() {
+		if err != nil {
+			hdl.RenderError(ctx, err)
+		} else {
+			hdl.RenderResponse(ctx, http.StatusOK, res, nil)
+		}
+	}()
+
+	accountIDStr := ctx.Param("account_id")
+	accountID, err = strconv.ParseUint(accountIDStr, 10, 64)
+	if err != nil || accountID == 0 {
+		fmt.Println("Invalid account_id", accountIDStr)
+		err = apperr.ErrInvalidInput.WithMessage("Account ID must be a positive integer")
+		return
+	}
+
+	res, err = hdl.interestUC.ListAccruals(ctx, accountID)
+}
@@ -0,0 +1,174 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"transaction_demo/app/apperr"
+	"transaction_demo/app/usecase"
+	"transaction_demo/app/usecase/dto"
+)
+
+type LedgerHandler struct {
+	BaseHandler
+	ledgerUC usecase.LedgerUC
+}
+
+func NewLedgerHandler(ledgerUC usecase.LedgerUC) *LedgerHandler {
+	return &LedgerHandler{
+		ledgerUC: ledgerUC,
+	}
+}
+
+// ReconcileAccount compares an account balance with its ledger postings
+// @Summary Reconcile an account
+// @Description  Compare the stored balance of an account with the balance derived from its postings.
+// @Tags Ledger
+// @Accept json
+// @Produce json
+// @Param account_id path int true "Account ID"
+// @Success 200 {object} dto.ReconciliationDTO
+// @Failure 400 {object} apperr.AppError
+// @Failure 404 {object} apperr.AppError
+// @Failure 500 {object} apperr.AppError
+// @Router /accounts/{account_id}/reconciliation [GET]
+func (hdl *LedgerHandler) ReconcileAccount(ctx *gin.Context) {
+	var (
+		accountID uint64
+		res       dto.ReconciliationDTO
+		err       error
+	)
+	defer func() {
+		if err != nil {
+			hdl.RenderError(ctx, err)
+		} else {
+			hdl.RenderResponse(ctx, http.StatusOK, res, nil)
+		}
+	}()
+
+	accountIDStr := ctx.Param("account_id")
+	accountID, err = strconv.ParseUint(accountIDStr, 10, 64)
+	if err != nil || accountID == 0 {
+		fmt.Println("Invalid account_id", accountIDStr)
+		err = apperr.ErrInvalidInput.WithMessage("Account ID must be a positive integer")
+		return
+	}
+
+	res, err = hdl.ledgerUC.ReconcileAccount(ctx, accountID)
+}
+
+// GetTransactionPostings lists the ledger postings of a transaction
+// @Summary Get transaction postings
+// @Description  List the debit and credit postings booked for a transaction.
+// @Tags Ledger
+// @Accept json
+// @Produce json
+// @Param transaction_id path int true "Transaction ID"
+// @Success 200 {array} dto.PostingDTO
+// @Failure 400 {object} apperr.AppError
+// @Failure 404 {object} apperr.AppError
+// @Failure 500 {object} apperr.AppError
+// @Router /transactions/{transaction_id}/postings [GET]
+func (hdl *LedgerHandler) GetTransactionPostings(ctx *gin.Context) {
+	var (
+		transactionID uint64
+		res           []dto.PostingDTO
+		err           error
+	)
+	defer func() {
+		if err != nil {
+			hdl.RenderError(ctx, err)
+		} else {
+			hdl.RenderResponse(ctx, http.StatusOK, res, nil)
+		}
+	}()
+
+	transactionIDStr := ctx.Param("transaction_id")
+	transactionID, err = strconv.ParseUint(transactionIDStr, 10, 64)
+	if err != nil || transactionID == 0 {
+		fmt.Println("Invalid transaction_id", transactionIDStr)
+		err = apperr.ErrInvalidInput.WithMessage("Transaction ID must be a positive integer")
+		return
+	}
+
+	res, err = hdl.ledgerUC.GetTransactionPostings(ctx, transactionID)
+}
+
+// GetBalanceAsOf returns the balance of an account at a point in time
+// @Summary Get an account balance as of a time
+// @Description  Return the balance an account had at as_of, derived from its ledger postings made before it. Without as_of the current ledger balance is returned.
+// @Tags Ledger
+// @Accept json
+// @Produce json
+// @Param account_id path int true "Account ID"
+// @Param as_of query string false "RFC 3339 timestamp, e.g. 2025-04-01T00:00:00Z for the closing balance of March 31"
+// @Success 200 {object} dto.BalanceAsOfDTO
+// @Failure 400 {object} apperr.AppError
+// @Failure 404 {object} apperr.AppError
+// @Failure 500 {object} apperr.AppError
+// @Router /accounts/{account_id}/balance [GET]
+func (hdl *LedgerHandler) GetBalanceAsOf(ctx *gin.Context) {
+	var (
+		req dto.BalanceAsOfRequestDTO
+		res dto.BalanceAsOfDTO
+		err error
+	)
+	defer func() {
+		if err != nil {
+			hdl.RenderError(ctx, err)
+		} else {
+			hdl.RenderResponse(ctx, http.StatusOK, res, nil)
+		}
+	}()
+
+	if err = ctx.ShouldBindQuery(&req); err != nil {
+		err = apperr.ErrInvalidInput.WithError(err).WithMessage("Invalid query parameters")
+		return
+	}
+
+	accountIDStr := ctx.Param("account_id")
+	req.AccountID, err = strconv.ParseUint(accountIDStr, 10, 64)
+	if err != nil || req.AccountID == 0 {
+		fmt.Println("Invalid account_id", accountIDStr)
+		err = apperr.ErrInvalidInput.WithMessage("Account ID must be a positive integer")
+		return
+	}
+
+	res, err = hdl.ledgerUC.GetBalanceAsOf(ctx, req)
+}
+
+// SnapshotBalances snapshots the closing balances of one day
+// @Summary Snapshot balances for a day
+// @Description  Record the closing balance of an ended day for every account, once 10 minutes have passed since its end so that late postings have committed, to speed up balance-as-of queries. Snapshotting a day again only adds the accounts missing from it.
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Param request body dto.BalanceSnapshotRequestDTO true "Day to snapshot"
+// @Success 200 {object} dto.BalanceSnapshotRunDTO
+// @Failure 400 {object} apperr.AppError
+// @Failure 500 {object} apperr.AppError
+// @Router /admin/balance-snapshots [POST]
+func (hdl *LedgerHandler) SnapshotBalances(ctx *gin.Context) {
+	var (
+		req dto.BalanceSnapshotRequestDTO
+		res dto.BalanceSnapshotRunDTO
+		err error
+	)
+	defer func() {
+		if err != nil {
+			hdl.RenderError(ctx, err)
+		} else {
+			hdl.RenderResponse(ctx, http.StatusOK, res, nil)
+		}
+	}()
+
+	if err = ctx.ShouldBindJSON(&req); err != nil {
+		err = apperr.ErrInvalidInput.WithError(err).WithMessage("Invalid request body")
+		return
+	}
+
+	res, err = hdl.ledgerUC.SnapshotBalances(ctx, req)
+}
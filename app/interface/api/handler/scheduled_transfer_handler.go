@@ -0,0 +1,177 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"transaction_demo/app/apperr"
+	"transaction_demo/app/usecase"
+	"transaction_demo/app/usecase/dto"
+)
+
+type ScheduledTransferHandler struct {
+	BaseHandler
+	scheduleUC    usecase.ScheduledTransferUC
+	idempotencyUC usecase.IdempotencyUC
+}
+
+func NewScheduledTransferHandler(scheduleUC usecase.ScheduledTransferUC,
+	idempotencyUC usecase.IdempotencyUC) *ScheduledTransferHandler {
+	return &ScheduledTransferHandler{
+		scheduleUC:    scheduleUC,
+		idempotencyUC: idempotencyUC,
+	}
+}
+
+// ScheduleTransfer schedules a transfer
+// @Summary Schedule a transfer
+// @Description  Make a transfer at a future time, once or on a daily, weekly or monthly recurrence with an optional end date. Each run goes through the same checks as an immediate transfer and its outcome is recorded.
+// @Tags Scheduled transfer
+// @Accept json
+// @Produce json
+// @Param Idempotency-Key header string false "Makes the request safe to retry"
+// @Param request body dto.ScheduleTransferDTO true "Transfer to schedule"
+// @Success 201 {object} dto.ScheduledTransferDTO
+// @Failure 400 {object} apperr.AppError
+// @Failure 404 {object} apperr.AppError
+// @Failure 409 {object} apperr.AppError
+// @Failure 500 {object} apperr.AppError
+// @Router /scheduled-transfers [POST]
+func (hdl *ScheduledTransferHandler) ScheduleTransfer(ctx *gin.Context) {
+	var (
+		req dto.ScheduleTransferDTO
+		res dto.IdempotentResponseDTO
+		err error
+	)
+	defer func() {
+		if err != nil {
+			hdl.RenderError(ctx, err)
+		} else {
+			hdl.RenderIdempotentResponse(ctx, res)
+		}
+	}()
+
+	if err = ctx.ShouldBindJSON(&req); err != nil {
+		err = apperr.ErrInvalidInput.WithError(err).WithMessage("Invalid request body")
+		return
+	}
+
+	res, err = executeIdempotent(ctx, hdl.idempotencyUC, req, func(txCtx context.Context) (int, interface{}, error) {
+		schedule, err := hdl.scheduleUC.Schedule(txCtx, req)
+		return http.StatusCreated, schedule, err
+	})
+}
+
+// GetScheduledTransfer retrieves a scheduled transfer
+// @Summary Get a scheduled transfer
+// @Description  Retrieve a scheduled transfer, including its status and next run time.
+// @Tags Scheduled transfer
+// @Accept json
+// @Produce json
+// @Param schedule_id path int true "Schedule ID"
+// @Success 200 {object} dto.ScheduledTransferDTO
+// @Failure 400 {object} apperr.AppError
+// @Failure 404 {object} apperr.AppError
+// @Failure 500 {object} apperr.AppError
+// @Router /scheduled-transfers/{schedule_id} [GET]
+func (hdl *ScheduledTransferHandler) GetScheduledTransfer(ctx *gin.Context) {
+	var (
+		scheduleID uint64
+		res        dto.ScheduledTransferDTO
+		err        error
+	)
+	defer func() {
+		if err != nil {
+			hdl.RenderError(ctx, err)
+		} else {
+			hdl.RenderResponse(ctx, http.StatusOK, res, nil)
+		}
+	}()
+
+	if scheduleID, err = hdl.parseScheduleID(ctx); err != nil {
+		return
+	}
+
+	res, err = hdl.scheduleUC.GetSchedule(ctx, scheduleID)
+}
+
+// CancelScheduledTransfer cancels a scheduled transfer
+// @Summary Cancel a scheduled transfer
+// @Description  Stop an active scheduled transfer. Runs already made are not undone.
+// @Tags Scheduled transfer
+// @Accept json
+// @Produce json
+// @Param schedule_id path int true "Schedule ID"
+// @Success 200 {object} dto.ScheduledTransferDTO
+// @Failure 400 {object} apperr.AppError
+// @Failure 404 {object} apperr.AppError
+// @Failure 500 {object} apperr.AppError
+// @Router /scheduled-transfers/{schedule_id}/cancel [POST]
+func (hdl *ScheduledTransferHandler) CancelScheduledTransfer(ctx *gin.Context) {
+	var (
+		scheduleID uint64
+		res        dto.ScheduledTransferDTO
+		err        error
+	)
+	defer func() {
+		if err != nil {
+			hdl.RenderError(ctx, err)
+		} else {
+			hdl.RenderResponse(ctx, http.StatusOK, res, nil)
+		}
+	}()
+
+	if scheduleID, err = hdl.parseScheduleID(ctx); err != nil {
+		return
+	}
+
+	res, err = hdl.scheduleUC.CancelSchedule(ctx, scheduleID)
+}
+
+// ListScheduledTransferRuns lists the runs of a scheduled transfer
+// @Summary List scheduled transfer runs
+// @Description  List the latest runs of a scheduled transfer, newest first, with the transaction made or the error.
+// @Tags Scheduled transfer
+// @Accept json
+// @Produce json
+// @Param schedule_id path int true "Schedule ID"
+// @Success 200 {array} dto.ScheduledRunDTO
+// @Failure 400 {object} apperr.AppError
+// @Failure 404 {object} apperr.AppError
+// @Failure 500 {object} apperr.AppError
+// @Router /scheduled-transfers/{schedule_id}/runs [GET]
+func (hdl *ScheduledTransferHandler) ListScheduledTransferRuns(ctx *gin.Context) {
+	var (
+		scheduleID uint64
+		res        []dto.ScheduledRunDTO
+		err        error
+	)
+	defer func() {
+		if err != nil {
+			hdl.RenderError(ctx, err)
+		} else {
+			hdl.RenderResponse(ctx, http.StatusOK, res, nil)
+		}
+	}()
+
+	if scheduleID, err = hdl.parseScheduleID(ctx); err != nil {
+		return
+	}
+
+	res, err = hdl.scheduleUC.ListRuns(ctx, scheduleID)
+}
+
+// parseScheduleID reads the schedule_id path parameter.
+func (hdl *ScheduledTransferHandler) parseScheduleID(ctx *gin.Context) (uint64, error) {
+	scheduleIDStr := ctx.Param("schedule_id")
+	scheduleID, err := strconv.ParseUint(scheduleIDStr, 10, 64)
+	if err != nil || scheduleID == 0 {
+		fmt.Println("Invalid schedule_id", scheduleIDStr)
+		return 0, apperr.ErrInvalidInput.WithMessage("Schedule ID must be a positive integer")
+	}
+	return scheduleID, nil
+}
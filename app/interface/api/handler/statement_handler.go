@@ -0,0 +1,103 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"transaction_demo/app/apperr"
+	"transaction_demo/app/domain/entity"
+	"transaction_demo/app/interface/statement"
+	"transaction_demo/app/usecase"
+	"transaction_demo/app/usecase/dto"
+)
+
+type StatementHandler struct {
+	BaseHandler
+	statementUC usecase.StatementUC
+}
+
+func NewStatementHandler(statementUC usecase.StatementUC) *StatementHandler {
+	return &StatementHandler{
+		statementUC: statementUC,
+	}
+}
+
+// GetStatement streams an account statement
+// @Summary Download an account statement
+// @Description  Stream the statement of an account for a period: opening balance, every movement and closing balance, as CSV, JSON Lines or ISO 20022 camt.053 XML.
+// @Tags Accounts
+// @Produce text/csv
+// @Produce application/x-ndjson
+// @Produce application/xml
+// @Param account_id path int true "Account ID"
+// @Param from query string true "Start of the period, RFC 3339, inclusive"
+// @Param to query string true "End of the period, RFC 3339, exclusive"
+// @Param format query string false "Statement format" Enums(csv, jsonl, camt053) default(csv)
+// @Success 200 {file} file
+// @Failure 400 {object} apperr.AppError
+// @Failure 404 {object} apperr.AppError
+// @Failure 500 {object} apperr.AppError
+// @Router /accounts/{account_id}/statement [GET]
+func (hdl *StatementHandler) GetStatement(ctx *gin.Context) {
+	var (
+		req dto.StatementRequestDTO
+		err error
+	)
+	defer func() {
+		if err == nil {
+			return
+		}
+		// Once streaming started the status is sent; the truncated download is all the client gets
+		if ctx.Writer.Written() {
+			fmt.Println("statement stream aborted", "account_id", req.AccountID, "error", err)
+			ctx.Abort()
+			return
+		}
+		hdl.RenderError(ctx, err)
+	}()
+
+	if err = ctx.ShouldBindQuery(&req); err != nil {
+		err = apperr.ErrInvalidInput.WithError(err).WithMessage("Invalid query parameters")
+		return
+	}
+
+	accountIDStr := ctx.Param("account_id")
+	req.AccountID, err = strconv.ParseUint(accountIDStr, 10, 64)
+	if err != nil || req.AccountID == 0 {
+		fmt.Println("Invalid account_id", accountIDStr)
+		err = apperr.ErrInvalidInput.WithMessage("Account ID must be a positive integer")
+		return
+	}
+	if req.Format == "" {
+		req.Format = entity.StatementCSV
+	}
+
+	out := &statementResponse{ctx: ctx, req: req}
+	w, err := statement.NewWriter(req.Format, out)
+	if err != nil {
+		err = apperr.ErrInvalidInput.WithError(err).WithMessage(err.Error())
+		return
+	}
+
+	err = hdl.statementUC.WriteStatement(ctx, req, w)
+}
+
+// statementResponse sends the download headers right before the first byte of a statement,
+// so that an error found before streaming starts is still rendered as JSON.
+type statementResponse struct {
+	ctx *gin.Context
+	req dto.StatementRequestDTO
+}
+
+func (r *statementResponse) Write(p []byte) (int, error) {
+	if !r.ctx.Writer.Written() {
+		fileName := statement.FileName(r.req.AccountID, *r.req.From, *r.req.To, r.req.Format)
+		r.ctx.Header("Content-Type", statement.ContentType(r.req.Format))
+		r.ctx.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", fileName))
+		r.ctx.Status(http.StatusOK)
+	}
+	return r.ctx.Writer.Write(p)
+}
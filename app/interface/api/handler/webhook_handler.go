@@ -0,0 +1,222 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"transaction_demo/app/apperr"
+	"transaction_demo/app/usecase"
+	"transaction_demo/app/usecase/dto"
+)
+
+type WebhookHandler struct {
+	BaseHandler
+	webhookUC usecase.WebhookUC
+}
+
+func NewWebhookHandler(webhookUC usecase.WebhookUC) *WebhookHandler {
+	return &WebhookHandler{
+		webhookUC: webhookUC,
+	}
+}
+
+// RegisterWebhook registers a webhook endpoint
+// @Summary Register a webhook
+// @Description  Register a URL to receive the events of the given types as signed POST requests. The signing secret is generated when omitted and is only returned in this response.
+// @Tags Webhook
+// @Accept json
+// @Produce json
+// @Param request body dto.RegisterWebhookDTO true "Endpoint to register"
+// @Success 201 {object} dto.WebhookEndpointDTO
+// @Failure 400 {object} apperr.AppError
+// @Failure 500 {object} apperr.AppError
+// @Router /webhooks [POST]
+func (hdl *WebhookHandler) RegisterWebhook(ctx *gin.Context) {
+	var (
+		req dto.RegisterWebhookDTO
+		res dto.WebhookEndpointDTO
+		err error
+	)
+	defer func() {
+		if err != nil {
+			hdl.RenderError(ctx, err)
+		} else {
+			hdl.RenderResponse(ctx, http.StatusCreated, res, nil)
+		}
+	}()
+
+	if err = ctx.ShouldBindJSON(&req); err != nil {
+		err = apperr.ErrInvalidInput.WithError(err).WithMessage("Invalid request body")
+		return
+	}
+
+	res, err = hdl.webhookUC.RegisterEndpoint(ctx, req)
+}
+
+// ListWebhooks lists the webhook endpoints
+// @Summary List webhooks
+// @Description  List the registered webhook endpoints, oldest first, without their secrets.
+// @Tags Webhook
+// @Accept json
+// @Produce json
+// @Success 200 {array} dto.WebhookEndpointDTO
+// @Failure 500 {object} apperr.AppError
+// @Router /webhooks [GET]
+func (hdl *WebhookHandler) ListWebhooks(ctx *gin.Context) {
+	var (
+		res []dto.WebhookEndpointDTO
+		err error
+	)
+	defer func() {
+		if err != nil {
+			hdl.RenderError(ctx, err)
+		} else {
+			hdl.RenderResponse(ctx, http.StatusOK, res, nil)
+		}
+	}()
+
+	res, err = hdl.webhookUC.ListEndpoints(ctx)
+}
+
+// GetWebhook retrieves a webhook endpoint
+// @Summary Get a webhook
+// @Description  Retrieve a registered webhook endpoint, without its secret.
+// @Tags Webhook
+// @Accept json
+// @Produce json
+// @Param webhook_id path int true "Webhook ID"
+// @Success 200 {object} dto.WebhookEndpointDTO
+// @Failure 400 {object} apperr.AppError
+// @Failure 404 {object} apperr.AppError
+// @Failure 500 {object} apperr.AppError
+// @Router /webhooks/{webhook_id} [GET]
+func (hdl *WebhookHandler) GetWebhook(ctx *gin.Context) {
+	var (
+		webhookID uint64
+		res       dto.WebhookEndpointDTO
+		err       error
+	)
+	defer func() {
+		if err != nil {
+			hdl.RenderError(ctx, err)
+		} else {
+			hdl.RenderResponse(ctx, http.StatusOK, res, nil)
+		}
+	}()
+
+	if webhookID, err = hdl.parseWebhookID(ctx); err != nil {
+		return
+	}
+
+	res, err = hdl.webhookUC.GetEndpoint(ctx, webhookID)
+}
+
+// DisableWebhook disables a webhook endpoint
+// @Summary Disable a webhook
+// @Description  Stop delivering events to a webhook endpoint. Its pending deliveries are moved to the dead letters.
+// @Tags Webhook
+// @Accept json
+// @Produce json
+// @Param webhook_id path int true "Webhook ID"
+// @Success 200 {object} dto.WebhookEndpointDTO
+// @Failure 400 {object} apperr.AppError
+// @Failure 404 {object} apperr.AppError
+// @Failure 500 {object} apperr.AppError
+// @Router /webhooks/{webhook_id}/disable [POST]
+func (hdl *WebhookHandler) DisableWebhook(ctx *gin.Context) {
+	var (
+		webhookID uint64
+		res       dto.WebhookEndpointDTO
+		err       error
+	)
+	defer func() {
+		if err != nil {
+			hdl.RenderError(ctx, err)
+		} else {
+			hdl.RenderResponse(ctx, http.StatusOK, res, nil)
+		}
+	}()
+
+	if webhookID, err = hdl.parseWebhookID(ctx); err != nil {
+		return
+	}
+
+	res, err = hdl.webhookUC.DisableEndpoint(ctx, webhookID)
+}
+
+// ListWebhookDeadLetters lists the webhook deliveries that failed permanently
+// @Summary List webhook dead letters
+// @Description  List the latest webhook deliveries that exhausted their retries and were not replayed, newest first.
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Success 200 {array} dto.WebhookDeadLetterDTO
+// @Failure 500 {object} apperr.AppError
+// @Router /admin/webhooks/dead-letters [GET]
+func (hdl *WebhookHandler) ListWebhookDeadLetters(ctx *gin.Context) {
+	var (
+		res []dto.WebhookDeadLetterDTO
+		err error
+	)
+	defer func() {
+		if err != nil {
+			hdl.RenderError(ctx, err)
+		} else {
+			hdl.RenderResponse(ctx, http.StatusOK, res, nil)
+		}
+	}()
+
+	res, err = hdl.webhookUC.ListDeadLetters(ctx)
+}
+
+// ReplayWebhookDeadLetter queues a dead letter for delivery again
+// @Summary Replay a webhook dead letter
+// @Description  Queue a dead-lettered delivery again, due now and with a fresh retry budget. A dead letter is replayed at most once, and only to an active webhook.
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Param dead_letter_id path int true "Dead letter ID"
+// @Success 200 {object} dto.WebhookDeliveryDTO
+// @Failure 400 {object} apperr.AppError
+// @Failure 404 {object} apperr.AppError
+// @Failure 409 {object} apperr.AppError
+// @Failure 500 {object} apperr.AppError
+// @Router /admin/webhooks/dead-letters/{dead_letter_id}/replay [POST]
+func (hdl *WebhookHandler) ReplayWebhookDeadLetter(ctx *gin.Context) {
+	var (
+		deadLetterID uint64
+		res          dto.WebhookDeliveryDTO
+		err          error
+	)
+	defer func() {
+		if err != nil {
+			hdl.RenderError(ctx, err)
+		} else {
+			hdl.RenderResponse(ctx, http.StatusOK, res, nil)
+		}
+	}()
+
+	deadLetterIDStr := ctx.Param("dead_letter_id")
+	deadLetterID, err = strconv.ParseUint(deadLetterIDStr, 10, 64)
+	if err != nil || deadLetterID == 0 {
+		fmt.Println("Invalid dead_letter_id", deadLetterIDStr)
+		err = apperr.ErrInvalidInput.WithMessage("Dead letter ID must be a positive integer")
+		return
+	}
+
+	res, err = hdl.webhookUC.ReplayDeadLetter(ctx, deadLetterID)
+}
+
+// parseWebhookID reads the webhook_id path parameter.
+func (hdl *WebhookHandler) parseWebhookID(ctx *gin.Context) (uint64, error) {
+	webhookIDStr := ctx.Param("webhook_id")
+	webhookID, err := strconv.ParseUint(webhookIDStr, 10, 64)
+	if err != nil || webhookID == 0 {
+		fmt.Println("Invalid webhook_id", webhookIDStr)
+		return 0, apperr.ErrInvalidInput.WithMessage("Webhook ID must be a positive integer")
+	}
+	return webhookID, nil
+}
@@ -12,11 +12,36 @@ func RegisterAccountRoutes(router *gin.Engine, accountHdl *handler.AccountHandle
 	accountGroup := apiGroup.Group("/accounts")
 	{
 		accountGroup.GET("/:account_id", accountHdl.GetAccountBalance)
+		accountGroup.GET("/:account_id/transactions", accountHdl.ListTransactions)
+		accountGroup.GET("/:account_id/events", accountHdl.StreamAccountEvents)
+		accountGroup.POST("/:account_id/freeze", accountHdl.FreezeAccount)
+		accountGroup.POST("/:account_id/unfreeze", accountHdl.UnfreezeAccount)
+		accountGroup.POST("/:account_id/close", accountHdl.CloseAccount)
 		accountGroup.POST("", accountHdl.CreateAccount)
 	}
 
 	txGroup := apiGroup.Group("/transactions")
 	{
 		txGroup.POST("/", accountHdl.MakeTransaction)
+		txGroup.POST("/batch", accountHdl.MakeBatchTransaction)
+		txGroup.POST("/split", accountHdl.MakeSplitPayment)
+		txGroup.GET("/:transaction_id", accountHdl.GetTransaction)
+		txGroup.POST("/:transaction_id/reversals", accountHdl.ReverseTransaction)
+	}
+
+	holdGroup := apiGroup.Group("/holds")
+	{
+		holdGroup.POST("", accountHdl.AuthorizeTransaction)
+		holdGroup.POST("/:hold_id/capture", accountHdl.CaptureHold)
+		holdGroup.POST("/:hold_id/void", accountHdl.VoidHold)
+	}
+
+	adminGroup := apiGroup.Group("/admin")
+	{
+		adminGroup.PUT("/accounts/:account_id/overdraft", accountHdl.SetOverdraftLimit)
+		adminGroup.GET("/accounts/:account_id/audits", accountHdl.ListAccountAudits)
+		adminGroup.GET("/reviews", accountHdl.ListRiskReviews)
+		adminGroup.POST("/transactions/:transaction_id/release", accountHdl.ReleaseTransaction)
+		adminGroup.POST("/transactions/:transaction_id/reject", accountHdl.RejectTransaction)
 	}
 }
@@ -0,0 +1,17 @@
+package route
+
+import (
+	"transaction_demo/app/interface/api/handler"
+
+	"github.com/gin-gonic/gin"
+)
+
+func RegisterFXRoutes(router *gin.Engine, fxHdl *handler.FXHandler) {
+	apiGroup := router.Group("/api/v1")
+
+	fxGroup := apiGroup.Group("/fx")
+	{
+		fxGroup.POST("/quotes", fxHdl.CreateQuote)
+		fxGroup.PUT("/rates", fxHdl.SetRate)
+	}
+}
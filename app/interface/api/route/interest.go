@@ -0,0 +1,19 @@
+package route
+
+import (
+	"transaction_demo/app/interface/api/handler"
+
+	"github.com/gin-gonic/gin"
+)
+
+func RegisterInterestRoutes(router *gin.Engine, interestHdl *handler.InterestHandler) {
+	apiGroup := router.Group("/api/v1")
+
+	apiGroup.GET("/accounts/:account_id/interest-accruals", interestHdl.ListInterestAccruals)
+
+	adminGroup := apiGroup.Group("/admin/interest")
+	{
+		adminGroup.POST("/accruals", interestHdl.AccrueInterest)
+		adminGroup.POST("/postings", interestHdl.PostInterest)
+	}
+}
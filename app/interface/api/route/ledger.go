@@ -0,0 +1,16 @@
+package route
+
+import (
+	"transaction_demo/app/interface/api/handler"
+
+	"github.com/gin-gonic/gin"
+)
+
+func RegisterLedgerRoutes(router *gin.Engine, ledgerHdl *handler.LedgerHandler) {
+	apiGroup := router.Group("/api/v1")
+
+	apiGroup.GET("/accounts/:account_id/reconciliation", ledgerHdl.ReconcileAccount)
+	apiGroup.GET("/accounts/:account_id/balance", ledgerHdl.GetBalanceAsOf)
+	apiGroup.GET("/transactions/:transaction_id/postings", ledgerHdl.GetTransactionPostings)
+	apiGroup.POST("/admin/balance-snapshots", ledgerHdl.SnapshotBalances)
+}
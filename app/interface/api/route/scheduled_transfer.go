@@ -0,0 +1,19 @@
+package route
+
+import (
+	"transaction_demo/app/interface/api/handler"
+
+	"github.com/gin-gonic/gin"
+)
+
+func RegisterScheduledTransferRoutes(router *gin.Engine, scheduleHdl *handler.ScheduledTransferHandler) {
+	apiGroup := router.Group("/api/v1")
+
+	scheduleGroup := apiGroup.Group("/scheduled-transfers")
+	{
+		scheduleGroup.POST("", scheduleHdl.ScheduleTransfer)
+		scheduleGroup.GET("/:schedule_id", scheduleHdl.GetScheduledTransfer)
+		scheduleGroup.GET("/:schedule_id/runs", scheduleHdl.ListScheduledTransferRuns)
+		scheduleGroup.POST("/:schedule_id/cancel", scheduleHdl.CancelScheduledTransfer)
+	}
+}
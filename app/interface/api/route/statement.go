@@ -0,0 +1,13 @@
+package route
+
+import (
+	"transaction_demo/app/interface/api/handler"
+
+	"github.com/gin-gonic/gin"
+)
+
+func RegisterStatementRoutes(router *gin.Engine, statementHdl *handler.StatementHandler) {
+	apiGroup := router.Group("/api/v1")
+
+	apiGroup.GET("/accounts/:account_id/statement", statementHdl.GetStatement)
+}
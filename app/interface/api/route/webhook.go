@@ -0,0 +1,25 @@
+package route
+
+import (
+	"transaction_demo/app/interface/api/handler"
+
+	"github.com/gin-gonic/gin"
+)
+
+func RegisterWebhookRoutes(router *gin.Engine, webhookHdl *handler.WebhookHandler) {
+	apiGroup := router.Group("/api/v1")
+
+	webhookGroup := apiGroup.Group("/webhooks")
+	{
+		webhookGroup.POST("", webhookHdl.RegisterWebhook)
+		webhookGroup.GET("", webhookHdl.ListWebhooks)
+		webhookGroup.GET("/:webhook_id", webhookHdl.GetWebhook)
+		webhookGroup.POST("/:webhook_id/disable", webhookHdl.DisableWebhook)
+	}
+
+	adminGroup := apiGroup.Group("/admin/webhooks")
+	{
+		adminGroup.GET("/dead-letters", webhookHdl.ListWebhookDeadLetters)
+		adminGroup.POST("/dead-letters/:dead_letter_id/replay", webhookHdl.ReplayWebhookDeadLetter)
+	}
+}
@@ -0,0 +1,192 @@
+package rpc
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"transaction_demo/app/apperr"
+	"transaction_demo/app/domain/entity"
+	"transaction_demo/app/interface/rpc/accountpb"
+	"transaction_demo/app/usecase"
+	"transaction_demo/app/usecase/dto"
+)
+
+// AccountServer serves the account API over gRPC with the same usecases as the HTTP account handler.
+type AccountServer struct {
+	accountpb.UnimplementedAccountServiceServer
+	accountUC usecase.AccountUC
+}
+
+func NewAccountServer(accountUC usecase.AccountUC) *AccountServer {
+	return &AccountServer{accountUC: accountUC}
+}
+
+// CreateAccount opens an account with an initial balance.
+func (srv *AccountServer) CreateAccount(ctx context.Context, req *accountpb.CreateAccountRequest,
+) (*accountpb.Account, error) {
+	balance, err := parseMoney("balance", req.GetBalance())
+	if err != nil {
+		return nil, err
+	}
+
+	account, err := srv.accountUC.Create(ctx, dto.AccountDTO{
+		AccountID: req.GetAccountId(),
+		Balance:   balance,
+		Currency:  entity.Currency(req.GetCurrency()),
+		Type:      entity.AccountType(req.GetType()),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return toAccount(account), nil
+}
+
+// GetBalance returns an account with its current balance.
+func (srv *AccountServer) GetBalance(ctx context.Context, req *accountpb.GetBalanceRequest,
+) (*accountpb.Account, error) {
+	if req.GetAccountId() == 0 {
+		fmt.Println("Invalid account_id", req.GetAccountId())
+		return nil, apperr.ErrInvalidInput.WithMessage("Account ID must be a positive integer")
+	}
+
+	account, err := srv.accountUC.GetBalance(ctx, req.GetAccountId())
+	if err != nil {
+		return nil, err
+	}
+	return toAccount(account), nil
+}
+
+// MakeTransaction transfers money between two accounts.
+func (srv *AccountServer) MakeTransaction(ctx context.Context, req *accountpb.MakeTransactionRequest,
+) (*accountpb.Transaction, error) {
+	amount, err := parseMoney("amount", req.GetAmount())
+	if err != nil {
+		return nil, err
+	}
+
+	transaction, err := srv.accountUC.MakeTransaction(ctx, dto.TransactionDTO{
+		SourceAccountID:      req.GetSourceAccountId(),
+		DestinationAccountID: req.GetDestinationAccountId(),
+		Amount:               amount,
+		Currency:             entity.Currency(req.GetCurrency()),
+		QuoteID:              req.GetQuoteId(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return toTransaction(transaction), nil
+}
+
+// ListTransactions returns a page of the transaction history of an account, newest first.
+func (srv *AccountServer) ListTransactions(ctx context.Context, req *accountpb.ListTransactionsRequest,
+) (*accountpb.ListTransactionsResponse, error) {
+	if req.GetAccountId() == 0 {
+		fmt.Println("Invalid account_id", req.GetAccountId())
+		return nil, apperr.ErrInvalidInput.WithMessage("Account ID must be a positive integer")
+	}
+
+	listReq := dto.TransactionListDTO{
+		AccountID: req.GetAccountId(),
+		Direction: entity.TransactionDirection(req.GetDirection()),
+		From:      toTimePtr(req.GetFrom()),
+		To:        toTimePtr(req.GetTo()),
+		Cursor:    req.GetCursor(),
+		Limit:     int(req.GetLimit()),
+	}
+	if req.MinAmount != nil {
+		minAmount, err := parseMoney("min_amount", req.GetMinAmount())
+		if err != nil {
+			return nil, err
+		}
+		listReq.MinAmount = &minAmount
+	}
+	if req.MaxAmount != nil {
+		maxAmount, err := parseMoney("max_amount", req.GetMaxAmount())
+		if err != nil {
+			return nil, err
+		}
+		listReq.MaxAmount = &maxAmount
+	}
+
+	transactions, meta, err := srv.accountUC.ListTransactions(ctx, listReq)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &accountpb.ListTransactionsResponse{
+		Transactions: make([]*accountpb.Transaction, 0, len(transactions)),
+		NextCursor:   meta.NextCursor,
+		HasMore:      meta.HasMore,
+		Limit:        int32(meta.Limit),
+	}
+	for _, transaction := range transactions {
+		res.Transactions = append(res.Transactions, toTransaction(transaction))
+	}
+	return res, nil
+}
+
+// parseMoney parses an amount field of a request; an empty amount is zero and left to the usecase validation.
+func parseMoney(field, value string) (entity.Money, error) {
+	if value == "" {
+		return entity.Money{}, nil
+	}
+	amount, err := entity.ParseMoney(value)
+	if err != nil {
+		fmt.Println("Invalid amount", "field", field, "value", value)
+		return entity.Money{}, apperr.ErrInvalidInput.WithError(err).WithMessage("Invalid " + field)
+	}
+	return amount, nil
+}
+
+func toTimePtr(ts *timestamppb.Timestamp) *time.Time {
+	if ts == nil {
+		return nil
+	}
+	t := ts.AsTime()
+	return &t
+}
+
+func toAccount(account dto.AccountDTO) *accountpb.Account {
+	return &accountpb.Account{
+		AccountId:        account.AccountID,
+		Balance:          account.Balance.String(),
+		Currency:         string(account.Currency),
+		Type:             string(account.Type),
+		AvailableBalance: account.AvailableBalance.String(),
+		Status:           string(account.Status),
+		OverdraftLimit:   account.OverdraftLimit.String(),
+	}
+}
+
+func toTransaction(transaction dto.TransactionRecordDTO) *accountpb.Transaction {
+	res := &accountpb.Transaction{
+		TransactionId:         transaction.TransactionID,
+		SourceAccountId:       transaction.SourceAccountID,
+		DestinationAccountId:  transaction.DestinationAccountID,
+		Direction:             string(transaction.Direction),
+		Amount:                transaction.Amount.String(),
+		Currency:              string(transaction.Currency),
+		DestinationAmount:     transaction.DestinationAmount.String(),
+		DestinationCurrency:   string(transaction.DestinationCurrency),
+		ExchangeRate:          transaction.ExchangeRate.String(),
+		OriginalTransactionId: transaction.OriginalTransactionID,
+		SplitPaymentId:        transaction.SplitPaymentID,
+		Status:                string(transaction.Status),
+		TransactionTime:       timestamppb.New(transaction.TransactionTime),
+		UpdatedAt:             timestamppb.New(transaction.UpdatedAt),
+	}
+	if fee := transaction.Fee; fee != nil {
+		res.Fee = &accountpb.Fee{
+			Schedule:     fee.Schedule,
+			Fixed:        fee.Fixed.String(),
+			Percentage:   fee.Percentage.String(),
+			Amount:       fee.Amount.String(),
+			Currency:     string(fee.Currency),
+			TotalDebited: fee.TotalDebited.String(),
+		}
+	}
+	return res
+}
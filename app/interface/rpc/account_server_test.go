@@ -0,0 +1,331 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"transaction_demo/app/apperr"
+	"transaction_demo/app/domain/entity"
+	"transaction_demo/app/interface/rpc/accountpb"
+	"transaction_demo/app/usecase"
+	"transaction_demo/app/usecase/dto"
+)
+
+// stubAccountUC implements the AccountUC methods served over gRPC; the others are not called.
+type stubAccountUC struct {
+	usecase.AccountUC
+	create           func(dto.AccountDTO) (dto.AccountDTO, error)
+	getBalance       func(uint64) (dto.AccountDTO, error)
+	makeTransaction  func(dto.TransactionDTO) (dto.TransactionRecordDTO, error)
+	listTransactions func(dto.TransactionListDTO) ([]dto.TransactionRecordDTO, dto.PageMetaDTO, error)
+}
+
+func (s *stubAccountUC) Create(_ context.Context, account dto.AccountDTO) (dto.AccountDTO, error) {
+	return s.create(account)
+}
+
+func (s *stubAccountUC) GetBalance(_ context.Context, id uint64) (dto.AccountDTO, error) {
+	return s.getBalance(id)
+}
+
+func (s *stubAccountUC) MakeTransaction(_ context.Context, req dto.TransactionDTO) (dto.TransactionRecordDTO, error) {
+	return s.makeTransaction(req)
+}
+
+func (s *stubAccountUC) ListTransactions(_ context.Context, req dto.TransactionListDTO,
+) ([]dto.TransactionRecordDTO, dto.PageMetaDTO, error) {
+	return s.listTransactions(req)
+}
+
+// newTestClient serves accountUC on an in-memory connection and returns a client for it.
+func newTestClient(t *testing.T, accountUC usecase.AccountUC) accountpb.AccountServiceClient {
+	lis := bufconn.Listen(1 << 20)
+	server := NewServer(NewAccountServer(accountUC))
+	go func() {
+		_ = server.Serve(lis)
+	}()
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	t.Cleanup(func() { _ = conn.Close() })
+	return accountpb.NewAccountServiceClient(conn)
+}
+
+// errorReason returns the reason of the ErrorInfo detail of a status error.
+func errorReason(err error) string {
+	for _, detail := range status.Convert(err).Details() {
+		if info, ok := detail.(*errdetails.ErrorInfo); ok {
+			return info.GetReason()
+		}
+	}
+	return ""
+}
+
+func TestAccountServer_CreateAccount(t *testing.T) {
+	tests := []struct {
+		name       string
+		req        *accountpb.CreateAccountRequest
+		create     func(dto.AccountDTO) (dto.AccountDTO, error)
+		want       *accountpb.Account
+		wantCode   codes.Code
+		wantReason string
+	}{
+		{
+			name: "success",
+			req:  &accountpb.CreateAccountRequest{AccountId: 1, Balance: "1000.50", Currency: "EUR", Type: "savings"},
+			create: func(account dto.AccountDTO) (dto.AccountDTO, error) {
+				if account.AccountID != 1 || account.Balance != entity.MustParseMoney("1000.50") ||
+					account.Currency != "EUR" || account.Type != entity.AccountSavings {
+					t.Errorf("Create() got %+v", account)
+				}
+				account.AvailableBalance = account.Balance
+				account.Status = entity.AccountActive
+				return account, nil
+			},
+			want: &accountpb.Account{AccountId: 1, Balance: "1000.50", Currency: "EUR", Type: "savings",
+				AvailableBalance: "1000.50", Status: "active", OverdraftLimit: "0.00"},
+			wantCode: codes.OK,
+		},
+		{
+			name:       "invalid_balance",
+			req:        &accountpb.CreateAccountRequest{AccountId: 1, Balance: "1e3"},
+			wantCode:   codes.InvalidArgument,
+			wantReason: apperr.ErrInvalidInput.Code,
+		},
+		{
+			name: "already_exists",
+			req:  &accountpb.CreateAccountRequest{AccountId: 1, Balance: "10"},
+			create: func(dto.AccountDTO) (dto.AccountDTO, error) {
+				return dto.AccountDTO{}, apperr.ErrAlreadyExists.WithMessage("account ID already exists")
+			},
+			wantCode:   codes.AlreadyExists,
+			wantReason: apperr.ErrAlreadyExists.Code,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := newTestClient(t, &stubAccountUC{create: func(account dto.AccountDTO) (dto.AccountDTO, error) {
+				if tt.create == nil {
+					t.Fatal("Create() should not be called")
+				}
+				return tt.create(account)
+			}})
+
+			got, err := client.CreateAccount(context.Background(), tt.req)
+			if code := status.Code(err); code != tt.wantCode {
+				t.Fatalf("CreateAccount() code = %v, want %v (%v)", code, tt.wantCode, err)
+			}
+			if reason := errorReason(err); reason != tt.wantReason {
+				t.Errorf("CreateAccount() reason = %q, want %q", reason, tt.wantReason)
+			}
+			if tt.want != nil && got.String() != tt.want.String() {
+				t.Errorf("CreateAccount() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAccountServer_GetBalance(t *testing.T) {
+	tests := []struct {
+		name       string
+		accountID  uint64
+		getBalance func(uint64) (dto.AccountDTO, error)
+		wantCode   codes.Code
+		wantReason string
+	}{
+		{
+			name:      "success",
+			accountID: 1,
+			getBalance: func(id uint64) (dto.AccountDTO, error) {
+				return dto.AccountDTO{AccountID: id, Balance: entity.NewMoney(100), Currency: entity.DefaultCurrency}, nil
+			},
+			wantCode: codes.OK,
+		},
+		{
+			name:       "zero_account_id",
+			wantCode:   codes.InvalidArgument,
+			wantReason: apperr.ErrInvalidInput.Code,
+		},
+		{
+			name:      "not_found",
+			accountID: 2,
+			getBalance: func(uint64) (dto.AccountDTO, error) {
+				return dto.AccountDTO{}, apperr.ErrNotFound.WithMessage("account not found")
+			},
+			wantCode:   codes.NotFound,
+			wantReason: apperr.ErrNotFound.Code,
+		},
+		{
+			name:      "unexpected_error",
+			accountID: 3,
+			getBalance: func(uint64) (dto.AccountDTO, error) {
+				return dto.AccountDTO{}, errors.New("connection refused")
+			},
+			wantCode: codes.Internal,
+		},
+		{
+			name:      "panic",
+			accountID: 4,
+			getBalance: func(uint64) (dto.AccountDTO, error) {
+				panic("boom")
+			},
+			wantCode: codes.Internal,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := newTestClient(t, &stubAccountUC{getBalance: func(id uint64) (dto.AccountDTO, error) {
+				if tt.getBalance == nil {
+					t.Fatal("GetBalance() should not be called")
+				}
+				return tt.getBalance(id)
+			}})
+
+			got, err := client.GetBalance(context.Background(), &accountpb.GetBalanceRequest{AccountId: tt.accountID})
+			if code := status.Code(err); code != tt.wantCode {
+				t.Fatalf("GetBalance() code = %v, want %v (%v)", code, tt.wantCode, err)
+			}
+			if reason := errorReason(err); reason != tt.wantReason {
+				t.Errorf("GetBalance() reason = %q, want %q", reason, tt.wantReason)
+			}
+			if err == nil && (got.GetAccountId() != tt.accountID || got.GetBalance() != "100.00" || got.GetCurrency() != "USD") {
+				t.Errorf("GetBalance() = %v", got)
+			}
+		})
+	}
+}
+
+func TestAccountServer_MakeTransaction(t *testing.T) {
+	txTime := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name            string
+		req             *accountpb.MakeTransactionRequest
+		makeTransaction func(dto.TransactionDTO) (dto.TransactionRecordDTO, error)
+		wantCode        codes.Code
+		wantReason      string
+	}{
+		{
+			name: "success",
+			req:  &accountpb.MakeTransactionRequest{SourceAccountId: 1, DestinationAccountId: 2, Amount: "100.50"},
+			makeTransaction: func(req dto.TransactionDTO) (dto.TransactionRecordDTO, error) {
+				if req.SourceAccountID != 1 || req.DestinationAccountID != 2 || req.Amount != entity.MustParseMoney("100.50") {
+					t.Errorf("MakeTransaction() got %+v", req)
+				}
+				return dto.TransactionRecordDTO{
+					TransactionID: 9, SourceAccountID: 1, DestinationAccountID: 2,
+					Amount: req.Amount, Currency: entity.DefaultCurrency, DestinationAmount: req.Amount,
+					DestinationCurrency: entity.DefaultCurrency, ExchangeRate: entity.MustParseRate("1"),
+					Fee:    &dto.FeeDTO{Schedule: "internal_usd", Amount: entity.NewMoney(1), TotalDebited: entity.MustParseMoney("101.50")},
+					Status: entity.TransactionPosted, TransactionTime: txTime, UpdatedAt: txTime,
+				}, nil
+			},
+			wantCode: codes.OK,
+		},
+		{
+			name:       "invalid_amount",
+			req:        &accountpb.MakeTransactionRequest{SourceAccountId: 1, DestinationAccountId: 2, Amount: "ten"},
+			wantCode:   codes.InvalidArgument,
+			wantReason: apperr.ErrInvalidInput.Code,
+		},
+		{
+			name: "insufficient_funds",
+			req:  &accountpb.MakeTransactionRequest{SourceAccountId: 1, DestinationAccountId: 2, Amount: "100"},
+			makeTransaction: func(dto.TransactionDTO) (dto.TransactionRecordDTO, error) {
+				return dto.TransactionRecordDTO{}, apperr.ErrInsufficientFunds.WithMessage("insufficient funds")
+			},
+			wantCode:   codes.FailedPrecondition,
+			wantReason: apperr.ErrInsufficientFunds.Code,
+		},
+		{
+			name: "limit_exceeded",
+			req:  &accountpb.MakeTransactionRequest{SourceAccountId: 1, DestinationAccountId: 2, Amount: "100"},
+			makeTransaction: func(dto.TransactionDTO) (dto.TransactionRecordDTO, error) {
+				return dto.TransactionRecordDTO{}, apperr.ErrLimitExceeded.WithMessage("limit exceeded")
+			},
+			wantCode:   codes.ResourceExhausted,
+			wantReason: apperr.ErrLimitExceeded.Code,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := newTestClient(t, &stubAccountUC{makeTransaction: func(req dto.TransactionDTO) (dto.TransactionRecordDTO, error) {
+				if tt.makeTransaction == nil {
+					t.Fatal("MakeTransaction() should not be called")
+				}
+				return tt.makeTransaction(req)
+			}})
+
+			got, err := client.MakeTransaction(context.Background(), tt.req)
+			if code := status.Code(err); code != tt.wantCode {
+				t.Fatalf("MakeTransaction() code = %v, want %v (%v)", code, tt.wantCode, err)
+			}
+			if reason := errorReason(err); reason != tt.wantReason {
+				t.Errorf("MakeTransaction() reason = %q, want %q", reason, tt.wantReason)
+			}
+			if err != nil {
+				return
+			}
+			if got.GetTransactionId() != 9 || got.GetAmount() != "100.50" || got.GetStatus() != "posted" ||
+				got.GetFee().GetTotalDebited() != "101.50" || !got.GetTransactionTime().AsTime().Equal(txTime) ||
+				got.OriginalTransactionId != nil {
+				t.Errorf("MakeTransaction() = %v", got)
+			}
+		})
+	}
+}
+
+func TestAccountServer_ListTransactions(t *testing.T) {
+	from := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	minAmount := "10.00"
+	original := uint64(3)
+	client := newTestClient(t, &stubAccountUC{listTransactions: func(req dto.TransactionListDTO,
+	) ([]dto.TransactionRecordDTO, dto.PageMetaDTO, error) {
+		if req.AccountID != 1 || req.Direction != entity.TransactionOutgoing || req.From == nil || !req.From.Equal(from) ||
+			req.To != nil || req.MinAmount == nil || *req.MinAmount != entity.NewMoney(10) || req.MaxAmount != nil ||
+			req.Cursor != "abc" || req.Limit != 2 {
+			t.Errorf("ListTransactions() got %+v", req)
+		}
+		return []dto.TransactionRecordDTO{
+			{TransactionID: 5, Direction: entity.TransactionOutgoing, OriginalTransactionID: &original},
+			{TransactionID: 4, Direction: entity.TransactionOutgoing},
+		}, dto.PageMetaDTO{NextCursor: "def", HasMore: true, Limit: 2}, nil
+	}})
+
+	got, err := client.ListTransactions(context.Background(), &accountpb.ListTransactionsRequest{
+		AccountId: 1, Direction: "outgoing", From: timestamppb.New(from), MinAmount: &minAmount, Cursor: "abc", Limit: 2,
+	})
+	if err != nil {
+		t.Fatalf("ListTransactions() error = %v", err)
+	}
+	if len(got.GetTransactions()) != 2 || got.GetTransactions()[0].GetOriginalTransactionId() != original ||
+		got.GetNextCursor() != "def" || !got.GetHasMore() || got.GetLimit() != 2 {
+		t.Errorf("ListTransactions() = %v", got)
+	}
+
+	invalid := "-"
+	_, err = client.ListTransactions(context.Background(), &accountpb.ListTransactionsRequest{AccountId: 1, MaxAmount: &invalid})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("ListTransactions() code = %v, want %v", status.Code(err), codes.InvalidArgument)
+	}
+}
@@ -0,0 +1,889 @@
+// AccountService is the gRPC form of the account operations of the HTTP API, for internal services.
+// Amounts are decimal strings such as "100.50", exactly as in the HTTP API.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        v5.29.3
+// source: app/interface/rpc/accountpb/account.proto
+
+package accountpb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Account struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	AccountId uint64                 `protobuf:"varint,1,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	Balance   string                 `protobuf:"bytes,2,opt,name=balance,proto3" json:"balance,omitempty"`
+	Currency  string                 `protobuf:"bytes,3,opt,name=currency,proto3" json:"currency,omitempty"`
+	// type is checking or savings.
+	Type string `protobuf:"bytes,4,opt,name=type,proto3" json:"type,omitempty"`
+	// available_balance is the balance minus the funds reserved by open holds.
+	AvailableBalance string `protobuf:"bytes,5,opt,name=available_balance,json=availableBalance,proto3" json:"available_balance,omitempty"`
+	// status is active, frozen or closed.
+	Status         string `protobuf:"bytes,6,opt,name=status,proto3" json:"status,omitempty"`
+	OverdraftLimit string `protobuf:"bytes,7,opt,name=overdraft_limit,json=overdraftLimit,proto3" json:"overdraft_limit,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *Account) Reset() {
+	*x = Account{}
+	mi := &file_app_interface_rpc_accountpb_account_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Account) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Account) ProtoMessage() {}
+
+func (x *Account) ProtoReflect() protoreflect.Message {
+	mi := &file_app_interface_rpc_accountpb_account_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Account.ProtoReflect.Descriptor instead.
+func (*Account) Descriptor() ([]byte, []int) {
+	return file_app_interface_rpc_accountpb_account_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Account) GetAccountId() uint64 {
+	if x != nil {
+		return x.AccountId
+	}
+	return 0
+}
+
+func (x *Account) GetBalance() string {
+	if x != nil {
+		return x.Balance
+	}
+	return ""
+}
+
+func (x *Account) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *Account) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *Account) GetAvailableBalance() string {
+	if x != nil {
+		return x.AvailableBalance
+	}
+	return ""
+}
+
+func (x *Account) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *Account) GetOverdraftLimit() string {
+	if x != nil {
+		return x.OverdraftLimit
+	}
+	return ""
+}
+
+type CreateAccountRequest struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	AccountId uint64                 `protobuf:"varint,1,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	Balance   string                 `protobuf:"bytes,2,opt,name=balance,proto3" json:"balance,omitempty"`
+	// currency defaults to USD.
+	Currency string `protobuf:"bytes,3,opt,name=currency,proto3" json:"currency,omitempty"`
+	// type is checking, the default, or savings.
+	Type          string `protobuf:"bytes,4,opt,name=type,proto3" json:"type,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateAccountRequest) Reset() {
+	*x = CreateAccountRequest{}
+	mi := &file_app_interface_rpc_accountpb_account_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateAccountRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateAccountRequest) ProtoMessage() {}
+
+func (x *CreateAccountRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_app_interface_rpc_accountpb_account_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateAccountRequest.ProtoReflect.Descriptor instead.
+func (*CreateAccountRequest) Descriptor() ([]byte, []int) {
+	return file_app_interface_rpc_accountpb_account_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *CreateAccountRequest) GetAccountId() uint64 {
+	if x != nil {
+		return x.AccountId
+	}
+	return 0
+}
+
+func (x *CreateAccountRequest) GetBalance() string {
+	if x != nil {
+		return x.Balance
+	}
+	return ""
+}
+
+func (x *CreateAccountRequest) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *CreateAccountRequest) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+type GetBalanceRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AccountId     uint64                 `protobuf:"varint,1,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetBalanceRequest) Reset() {
+	*x = GetBalanceRequest{}
+	mi := &file_app_interface_rpc_accountpb_account_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetBalanceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetBalanceRequest) ProtoMessage() {}
+
+func (x *GetBalanceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_app_interface_rpc_accountpb_account_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetBalanceRequest.ProtoReflect.Descriptor instead.
+func (*GetBalanceRequest) Descriptor() ([]byte, []int) {
+	return file_app_interface_rpc_accountpb_account_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *GetBalanceRequest) GetAccountId() uint64 {
+	if x != nil {
+		return x.AccountId
+	}
+	return 0
+}
+
+type MakeTransactionRequest struct {
+	state                protoimpl.MessageState `protogen:"open.v1"`
+	SourceAccountId      uint64                 `protobuf:"varint,1,opt,name=source_account_id,json=sourceAccountId,proto3" json:"source_account_id,omitempty"`
+	DestinationAccountId uint64                 `protobuf:"varint,2,opt,name=destination_account_id,json=destinationAccountId,proto3" json:"destination_account_id,omitempty"`
+	Amount               string                 `protobuf:"bytes,3,opt,name=amount,proto3" json:"amount,omitempty"`
+	// currency of amount; defaults to the source account currency and must match it when set.
+	Currency string `protobuf:"bytes,4,opt,name=currency,proto3" json:"currency,omitempty"`
+	// quote_id requests a cross-currency conversion at the rate locked by an FX quote.
+	QuoteId       string `protobuf:"bytes,5,opt,name=quote_id,json=quoteId,proto3" json:"quote_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MakeTransactionRequest) Reset() {
+	*x = MakeTransactionRequest{}
+	mi := &file_app_interface_rpc_accountpb_account_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MakeTransactionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MakeTransactionRequest) ProtoMessage() {}
+
+func (x *MakeTransactionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_app_interface_rpc_accountpb_account_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MakeTransactionRequest.ProtoReflect.Descriptor instead.
+func (*MakeTransactionRequest) Descriptor() ([]byte, []int) {
+	return file_app_interface_rpc_accountpb_account_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *MakeTransactionRequest) GetSourceAccountId() uint64 {
+	if x != nil {
+		return x.SourceAccountId
+	}
+	return 0
+}
+
+func (x *MakeTransactionRequest) GetDestinationAccountId() uint64 {
+	if x != nil {
+		return x.DestinationAccountId
+	}
+	return 0
+}
+
+func (x *MakeTransactionRequest) GetAmount() string {
+	if x != nil {
+		return x.Amount
+	}
+	return ""
+}
+
+func (x *MakeTransactionRequest) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *MakeTransactionRequest) GetQuoteId() string {
+	if x != nil {
+		return x.QuoteId
+	}
+	return ""
+}
+
+type Transaction struct {
+	state                protoimpl.MessageState `protogen:"open.v1"`
+	TransactionId        uint64                 `protobuf:"varint,1,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"`
+	SourceAccountId      uint64                 `protobuf:"varint,2,opt,name=source_account_id,json=sourceAccountId,proto3" json:"source_account_id,omitempty"`
+	DestinationAccountId uint64                 `protobuf:"varint,3,opt,name=destination_account_id,json=destinationAccountId,proto3" json:"destination_account_id,omitempty"`
+	// direction is incoming or outgoing, relative to the account whose history is listed.
+	Direction             string  `protobuf:"bytes,4,opt,name=direction,proto3" json:"direction,omitempty"`
+	Amount                string  `protobuf:"bytes,5,opt,name=amount,proto3" json:"amount,omitempty"`
+	Currency              string  `protobuf:"bytes,6,opt,name=currency,proto3" json:"currency,omitempty"`
+	DestinationAmount     string  `protobuf:"bytes,7,opt,name=destination_amount,json=destinationAmount,proto3" json:"destination_amount,omitempty"`
+	DestinationCurrency   string  `protobuf:"bytes,8,opt,name=destination_currency,json=destinationCurrency,proto3" json:"destination_currency,omitempty"`
+	ExchangeRate          string  `protobuf:"bytes,9,opt,name=exchange_rate,json=exchangeRate,proto3" json:"exchange_rate,omitempty"`
+	OriginalTransactionId *uint64 `protobuf:"varint,10,opt,name=original_transaction_id,json=originalTransactionId,proto3,oneof" json:"original_transaction_id,omitempty"`
+	SplitPaymentId        *uint64 `protobuf:"varint,11,opt,name=split_payment_id,json=splitPaymentId,proto3,oneof" json:"split_payment_id,omitempty"`
+	// fee is charged to the source account on top of amount; unset when the transfer was free.
+	Fee *Fee `protobuf:"bytes,12,opt,name=fee,proto3" json:"fee,omitempty"`
+	// status is pending, posted, failed or reversed.
+	Status          string                 `protobuf:"bytes,13,opt,name=status,proto3" json:"status,omitempty"`
+	TransactionTime *timestamppb.Timestamp `protobuf:"bytes,14,opt,name=transaction_time,json=transactionTime,proto3" json:"transaction_time,omitempty"`
+	UpdatedAt       *timestamppb.Timestamp `protobuf:"bytes,15,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *Transaction) Reset() {
+	*x = Transaction{}
+	mi := &file_app_interface_rpc_accountpb_account_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Transaction) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Transaction) ProtoMessage() {}
+
+func (x *Transaction) ProtoReflect() protoreflect.Message {
+	mi := &file_app_interface_rpc_accountpb_account_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Transaction.ProtoReflect.Descriptor instead.
+func (*Transaction) Descriptor() ([]byte, []int) {
+	return file_app_interface_rpc_accountpb_account_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *Transaction) GetTransactionId() uint64 {
+	if x != nil {
+		return x.TransactionId
+	}
+	return 0
+}
+
+func (x *Transaction) GetSourceAccountId() uint64 {
+	if x != nil {
+		return x.SourceAccountId
+	}
+	return 0
+}
+
+func (x *Transaction) GetDestinationAccountId() uint64 {
+	if x != nil {
+		return x.DestinationAccountId
+	}
+	return 0
+}
+
+func (x *Transaction) GetDirection() string {
+	if x != nil {
+		return x.Direction
+	}
+	return ""
+}
+
+func (x *Transaction) GetAmount() string {
+	if x != nil {
+		return x.Amount
+	}
+	return ""
+}
+
+func (x *Transaction) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *Transaction) GetDestinationAmount() string {
+	if x != nil {
+		return x.DestinationAmount
+	}
+	return ""
+}
+
+func (x *Transaction) GetDestinationCurrency() string {
+	if x != nil {
+		return x.DestinationCurrency
+	}
+	return ""
+}
+
+func (x *Transaction) GetExchangeRate() string {
+	if x != nil {
+		return x.ExchangeRate
+	}
+	return ""
+}
+
+func (x *Transaction) GetOriginalTransactionId() uint64 {
+	if x != nil && x.OriginalTransactionId != nil {
+		return *x.OriginalTransactionId
+	}
+	return 0
+}
+
+func (x *Transaction) GetSplitPaymentId() uint64 {
+	if x != nil && x.SplitPaymentId != nil {
+		return *x.SplitPaymentId
+	}
+	return 0
+}
+
+func (x *Transaction) GetFee() *Fee {
+	if x != nil {
+		return x.Fee
+	}
+	return nil
+}
+
+func (x *Transaction) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *Transaction) GetTransactionTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.TransactionTime
+	}
+	return nil
+}
+
+func (x *Transaction) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+// Fee is the breakdown of a transfer fee.
+type Fee struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	Schedule   string                 `protobuf:"bytes,1,opt,name=schedule,proto3" json:"schedule,omitempty"`
+	Fixed      string                 `protobuf:"bytes,2,opt,name=fixed,proto3" json:"fixed,omitempty"`
+	Percentage string                 `protobuf:"bytes,3,opt,name=percentage,proto3" json:"percentage,omitempty"`
+	Amount     string                 `protobuf:"bytes,4,opt,name=amount,proto3" json:"amount,omitempty"`
+	Currency   string                 `protobuf:"bytes,5,opt,name=currency,proto3" json:"currency,omitempty"`
+	// total_debited is the transfer amount plus the fee.
+	TotalDebited  string `protobuf:"bytes,6,opt,name=total_debited,json=totalDebited,proto3" json:"total_debited,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Fee) Reset() {
+	*x = Fee{}
+	mi := &file_app_interface_rpc_accountpb_account_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Fee) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Fee) ProtoMessage() {}
+
+func (x *Fee) ProtoReflect() protoreflect.Message {
+	mi := &file_app_interface_rpc_accountpb_account_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Fee.ProtoReflect.Descriptor instead.
+func (*Fee) Descriptor() ([]byte, []int) {
+	return file_app_interface_rpc_accountpb_account_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *Fee) GetSchedule() string {
+	if x != nil {
+		return x.Schedule
+	}
+	return ""
+}
+
+func (x *Fee) GetFixed() string {
+	if x != nil {
+		return x.Fixed
+	}
+	return ""
+}
+
+func (x *Fee) GetPercentage() string {
+	if x != nil {
+		return x.Percentage
+	}
+	return ""
+}
+
+func (x *Fee) GetAmount() string {
+	if x != nil {
+		return x.Amount
+	}
+	return ""
+}
+
+func (x *Fee) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *Fee) GetTotalDebited() string {
+	if x != nil {
+		return x.TotalDebited
+	}
+	return ""
+}
+
+type ListTransactionsRequest struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	AccountId uint64                 `protobuf:"varint,1,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	// direction is incoming or outgoing; both when empty.
+	Direction string `protobuf:"bytes,2,opt,name=direction,proto3" json:"direction,omitempty"`
+	// from and to bound the transaction time; to is exclusive.
+	From *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=from,proto3" json:"from,omitempty"`
+	To   *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=to,proto3" json:"to,omitempty"`
+	// min_amount and max_amount bound the amount in the account currency, inclusive.
+	MinAmount *string `protobuf:"bytes,5,opt,name=min_amount,json=minAmount,proto3,oneof" json:"min_amount,omitempty"`
+	MaxAmount *string `protobuf:"bytes,6,opt,name=max_amount,json=maxAmount,proto3,oneof" json:"max_amount,omitempty"`
+	// cursor is the next_cursor of the previous page.
+	Cursor string `protobuf:"bytes,7,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	// limit is the page size, 1 to 100; 20 when zero.
+	Limit         int32 `protobuf:"varint,8,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTransactionsRequest) Reset() {
+	*x = ListTransactionsRequest{}
+	mi := &file_app_interface_rpc_accountpb_account_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTransactionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTransactionsRequest) ProtoMessage() {}
+
+func (x *ListTransactionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_app_interface_rpc_accountpb_account_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTransactionsRequest.ProtoReflect.Descriptor instead.
+func (*ListTransactionsRequest) Descriptor() ([]byte, []int) {
+	return file_app_interface_rpc_accountpb_account_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ListTransactionsRequest) GetAccountId() uint64 {
+	if x != nil {
+		return x.AccountId
+	}
+	return 0
+}
+
+func (x *ListTransactionsRequest) GetDirection() string {
+	if x != nil {
+		return x.Direction
+	}
+	return ""
+}
+
+func (x *ListTransactionsRequest) GetFrom() *timestamppb.Timestamp {
+	if x != nil {
+		return x.From
+	}
+	return nil
+}
+
+func (x *ListTransactionsRequest) GetTo() *timestamppb.Timestamp {
+	if x != nil {
+		return x.To
+	}
+	return nil
+}
+
+func (x *ListTransactionsRequest) GetMinAmount() string {
+	if x != nil && x.MinAmount != nil {
+		return *x.MinAmount
+	}
+	return ""
+}
+
+func (x *ListTransactionsRequest) GetMaxAmount() string {
+	if x != nil && x.MaxAmount != nil {
+		return *x.MaxAmount
+	}
+	return ""
+}
+
+func (x *ListTransactionsRequest) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+func (x *ListTransactionsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type ListTransactionsResponse struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	Transactions []*Transaction         `protobuf:"bytes,1,rep,name=transactions,proto3" json:"transactions,omitempty"`
+	// next_cursor fetches the following page; empty on the last page.
+	NextCursor    string `protobuf:"bytes,2,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"`
+	HasMore       bool   `protobuf:"varint,3,opt,name=has_more,json=hasMore,proto3" json:"has_more,omitempty"`
+	Limit         int32  `protobuf:"varint,4,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTransactionsResponse) Reset() {
+	*x = ListTransactionsResponse{}
+	mi := &file_app_interface_rpc_accountpb_account_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTransactionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTransactionsResponse) ProtoMessage() {}
+
+func (x *ListTransactionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_app_interface_rpc_accountpb_account_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTransactionsResponse.ProtoReflect.Descriptor instead.
+func (*ListTransactionsResponse) Descriptor() ([]byte, []int) {
+	return file_app_interface_rpc_accountpb_account_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ListTransactionsResponse) GetTransactions() []*Transaction {
+	if x != nil {
+		return x.Transactions
+	}
+	return nil
+}
+
+func (x *ListTransactionsResponse) GetNextCursor() string {
+	if x != nil {
+		return x.NextCursor
+	}
+	return ""
+}
+
+func (x *ListTransactionsResponse) GetHasMore() bool {
+	if x != nil {
+		return x.HasMore
+	}
+	return false
+}
+
+func (x *ListTransactionsResponse) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+var File_app_interface_rpc_accountpb_account_proto protoreflect.FileDescriptor
+
+const file_app_interface_rpc_accountpb_account_proto_rawDesc = "" +
+	"\n" +
+	")app/interface/rpc/accountpb/account.proto\x12\x1btransaction_demo.account.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"\xe0\x01\n" +
+	"\aAccount\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\x01 \x01(\x04R\taccountId\x12\x18\n" +
+	"\abalance\x18\x02 \x01(\tR\abalance\x12\x1a\n" +
+	"\bcurrency\x18\x03 \x01(\tR\bcurrency\x12\x12\n" +
+	"\x04type\x18\x04 \x01(\tR\x04type\x12+\n" +
+	"\x11available_balance\x18\x05 \x01(\tR\x10availableBalance\x12\x16\n" +
+	"\x06status\x18\x06 \x01(\tR\x06status\x12'\n" +
+	"\x0foverdraft_limit\x18\a \x01(\tR\x0eoverdraftLimit\"\x7f\n" +
+	"\x14CreateAccountRequest\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\x01 \x01(\x04R\taccountId\x12\x18\n" +
+	"\abalance\x18\x02 \x01(\tR\abalance\x12\x1a\n" +
+	"\bcurrency\x18\x03 \x01(\tR\bcurrency\x12\x12\n" +
+	"\x04type\x18\x04 \x01(\tR\x04type\"2\n" +
+	"\x11GetBalanceRequest\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\x01 \x01(\x04R\taccountId\"\xc9\x01\n" +
+	"\x16MakeTransactionRequest\x12*\n" +
+	"\x11source_account_id\x18\x01 \x01(\x04R\x0fsourceAccountId\x124\n" +
+	"\x16destination_account_id\x18\x02 \x01(\x04R\x14destinationAccountId\x12\x16\n" +
+	"\x06amount\x18\x03 \x01(\tR\x06amount\x12\x1a\n" +
+	"\bcurrency\x18\x04 \x01(\tR\bcurrency\x12\x19\n" +
+	"\bquote_id\x18\x05 \x01(\tR\aquoteId\"\xda\x05\n" +
+	"\vTransaction\x12%\n" +
+	"\x0etransaction_id\x18\x01 \x01(\x04R\rtransactionId\x12*\n" +
+	"\x11source_account_id\x18\x02 \x01(\x04R\x0fsourceAccountId\x124\n" +
+	"\x16destination_account_id\x18\x03 \x01(\x04R\x14destinationAccountId\x12\x1c\n" +
+	"\tdirection\x18\x04 \x01(\tR\tdirection\x12\x16\n" +
+	"\x06amount\x18\x05 \x01(\tR\x06amount\x12\x1a\n" +
+	"\bcurrency\x18\x06 \x01(\tR\bcurrency\x12-\n" +
+	"\x12destination_amount\x18\a \x01(\tR\x11destinationAmount\x121\n" +
+	"\x14destination_currency\x18\b \x01(\tR\x13destinationCurrency\x12#\n" +
+	"\rexchange_rate\x18\t \x01(\tR\fexchangeRate\x12;\n" +
+	"\x17original_transaction_id\x18\n" +
+	" \x01(\x04H\x00R\x15originalTransactionId\x88\x01\x01\x12-\n" +
+	"\x10split_payment_id\x18\v \x01(\x04H\x01R\x0esplitPaymentId\x88\x01\x01\x122\n" +
+	"\x03fee\x18\f \x01(\v2 .transaction_demo.account.v1.FeeR\x03fee\x12\x16\n" +
+	"\x06status\x18\r \x01(\tR\x06status\x12E\n" +
+	"\x10transaction_time\x18\x0e \x01(\v2\x1a.google.protobuf.TimestampR\x0ftransactionTime\x129\n" +
+	"\n" +
+	"updated_at\x18\x0f \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAtB\x1a\n" +
+	"\x18_original_transaction_idB\x13\n" +
+	"\x11_split_payment_id\"\xb0\x01\n" +
+	"\x03Fee\x12\x1a\n" +
+	"\bschedule\x18\x01 \x01(\tR\bschedule\x12\x14\n" +
+	"\x05fixed\x18\x02 \x01(\tR\x05fixed\x12\x1e\n" +
+	"\n" +
+	"percentage\x18\x03 \x01(\tR\n" +
+	"percentage\x12\x16\n" +
+	"\x06amount\x18\x04 \x01(\tR\x06amount\x12\x1a\n" +
+	"\bcurrency\x18\x05 \x01(\tR\bcurrency\x12#\n" +
+	"\rtotal_debited\x18\x06 \x01(\tR\ftotalDebited\"\xc6\x02\n" +
+	"\x17ListTransactionsRequest\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\x01 \x01(\x04R\taccountId\x12\x1c\n" +
+	"\tdirection\x18\x02 \x01(\tR\tdirection\x12.\n" +
+	"\x04from\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\x04from\x12*\n" +
+	"\x02to\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\x02to\x12\"\n" +
+	"\n" +
+	"min_amount\x18\x05 \x01(\tH\x00R\tminAmount\x88\x01\x01\x12\"\n" +
+	"\n" +
+	"max_amount\x18\x06 \x01(\tH\x01R\tmaxAmount\x88\x01\x01\x12\x16\n" +
+	"\x06cursor\x18\a \x01(\tR\x06cursor\x12\x14\n" +
+	"\x05limit\x18\b \x01(\x05R\x05limitB\r\n" +
+	"\v_min_amountB\r\n" +
+	"\v_max_amount\"\xba\x01\n" +
+	"\x18ListTransactionsResponse\x12L\n" +
+	"\ftransactions\x18\x01 \x03(\v2(.transaction_demo.account.v1.TransactionR\ftransactions\x12\x1f\n" +
+	"\vnext_cursor\x18\x02 \x01(\tR\n" +
+	"nextCursor\x12\x19\n" +
+	"\bhas_more\x18\x03 \x01(\bR\ahasMore\x12\x14\n" +
+	"\x05limit\x18\x04 \x01(\x05R\x05limit2\xd1\x03\n" +
+	"\x0eAccountService\x12h\n" +
+	"\rCreateAccount\x121.transaction_demo.account.v1.CreateAccountRequest\x1a$.transaction_demo.account.v1.Account\x12b\n" +
+	"\n" +
+	"GetBalance\x12..transaction_demo.account.v1.GetBalanceRequest\x1a$.transaction_demo.account.v1.Account\x12p\n" +
+	"\x0fMakeTransaction\x123.transaction_demo.account.v1.MakeTransactionRequest\x1a(.transaction_demo.account.v1.Transaction\x12\x7f\n" +
+	"\x10ListTransactions\x124.transaction_demo.account.v1.ListTransactionsRequest\x1a5.transaction_demo.account.v1.ListTransactionsResponseB.Z,transaction_demo/app/interface/rpc/accountpbb\x06proto3"
+
+var (
+	file_app_interface_rpc_accountpb_account_proto_rawDescOnce sync.Once
+	file_app_interface_rpc_accountpb_account_proto_rawDescData []byte
+)
+
+func file_app_interface_rpc_accountpb_account_proto_rawDescGZIP() []byte {
+	file_app_interface_rpc_accountpb_account_proto_rawDescOnce.Do(func() {
+		file_app_interface_rpc_accountpb_account_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_app_interface_rpc_accountpb_account_proto_rawDesc), len(file_app_interface_rpc_accountpb_account_proto_rawDesc)))
+	})
+	return file_app_interface_rpc_accountpb_account_proto_rawDescData
+}
+
+var file_app_interface_rpc_accountpb_account_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
+var file_app_interface_rpc_accountpb_account_proto_goTypes = []any{
+	(*Account)(nil),                  // 0: transaction_demo.account.v1.Account
+	(*CreateAccountRequest)(nil),     // 1: transaction_demo.account.v1.CreateAccountRequest
+	(*GetBalanceRequest)(nil),        // 2: transaction_demo.account.v1.GetBalanceRequest
+	(*MakeTransactionRequest)(nil),   // 3: transaction_demo.account.v1.MakeTransactionRequest
+	(*Transaction)(nil),              // 4: transaction_demo.account.v1.Transaction
+	(*Fee)(nil),                      // 5: transaction_demo.account.v1.Fee
+	(*ListTransactionsRequest)(nil),  // 6: transaction_demo.account.v1.ListTransactionsRequest
+	(*ListTransactionsResponse)(nil), // 7: transaction_demo.account.v1.ListTransactionsResponse
+	(*timestamppb.Timestamp)(nil),    // 8: google.protobuf.Timestamp
+}
+var file_app_interface_rpc_accountpb_account_proto_depIdxs = []int32{
+	5,  // 0: transaction_demo.account.v1.Transaction.fee:type_name -> transaction_demo.account.v1.Fee
+	8,  // 1: transaction_demo.account.v1.Transaction.transaction_time:type_name -> google.protobuf.Timestamp
+	8,  // 2: transaction_demo.account.v1.Transaction.updated_at:type_name -> google.protobuf.Timestamp
+	8,  // 3: transaction_demo.account.v1.ListTransactionsRequest.from:type_name -> google.protobuf.Timestamp
+	8,  // 4: transaction_demo.account.v1.ListTransactionsRequest.to:type_name -> google.protobuf.Timestamp
+	4,  // 5: transaction_demo.account.v1.ListTransactionsResponse.transactions:type_name -> transaction_demo.account.v1.Transaction
+	1,  // 6: transaction_demo.account.v1.AccountService.CreateAccount:input_type -> transaction_demo.account.v1.CreateAccountRequest
+	2,  // 7: transaction_demo.account.v1.AccountService.GetBalance:input_type -> transaction_demo.account.v1.GetBalanceRequest
+	3,  // 8: transaction_demo.account.v1.AccountService.MakeTransaction:input_type -> transaction_demo.account.v1.MakeTransactionRequest
+	6,  // 9: transaction_demo.account.v1.AccountService.ListTransactions:input_type -> transaction_demo.account.v1.ListTransactionsRequest
+	0,  // 10: transaction_demo.account.v1.AccountService.CreateAccount:output_type -> transaction_demo.account.v1.Account
+	0,  // 11: transaction_demo.account.v1.AccountService.GetBalance:output_type -> transaction_demo.account.v1.Account
+	4,  // 12: transaction_demo.account.v1.AccountService.MakeTransaction:output_type -> transaction_demo.account.v1.Transaction
+	7,  // 13: transaction_demo.account.v1.AccountService.ListTransactions:output_type -> transaction_demo.account.v1.ListTransactionsResponse
+	10, // [10:14] is the sub-list for method output_type
+	6,  // [6:10] is the sub-list for method input_type
+	6,  // [6:6] is the sub-list for extension type_name
+	6,  // [6:6] is the sub-list for extension extendee
+	0,  // [0:6] is the sub-list for field type_name
+}
+
+func init() { file_app_interface_rpc_accountpb_account_proto_init() }
+func file_app_interface_rpc_accountpb_account_proto_init() {
+	if File_app_interface_rpc_accountpb_account_proto != nil {
+		return
+	}
+	file_app_interface_rpc_accountpb_account_proto_msgTypes[4].OneofWrappers = []any{}
+	file_app_interface_rpc_accountpb_account_proto_msgTypes[6].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_app_interface_rpc_accountpb_account_proto_rawDesc), len(file_app_interface_rpc_accountpb_account_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   8,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_app_interface_rpc_accountpb_account_proto_goTypes,
+		DependencyIndexes: file_app_interface_rpc_accountpb_account_proto_depIdxs,
+		MessageInfos:      file_app_interface_rpc_accountpb_account_proto_msgTypes,
+	}.Build()
+	File_app_interface_rpc_accountpb_account_proto = out.File
+	file_app_interface_rpc_accountpb_account_proto_goTypes = nil
+	file_app_interface_rpc_accountpb_account_proto_depIdxs = nil
+}
@@ -0,0 +1,50 @@
+// Package rpc serves the account API over gRPC for internal services, alongside the HTTP API and
+// on top of the same usecases.
+package rpc
+
+import (
+	"context"
+	"fmt"
+	"runtime/debug"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/reflection"
+	"google.golang.org/grpc/status"
+
+	"transaction_demo/app/interface/rpc/accountpb"
+)
+
+// NewServer creates the gRPC server with the account service registered. Server reflection is enabled
+// so that tools such as grpcurl can list and call the services without the proto files.
+func NewServer(accountServer *AccountServer) *grpc.Server {
+	server := grpc.NewServer(grpc.ChainUnaryInterceptor(recoverInterceptor, errorInterceptor))
+	accountpb.RegisterAccountServiceServer(server, accountServer)
+	reflection.Register(server)
+	return server
+}
+
+// errorInterceptor converts the errors returned by the services to gRPC statuses.
+func errorInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler) (interface{}, error) {
+	res, err := handler(ctx, req)
+	if err != nil {
+		st := toStatus(err)
+		fmt.Println("grpc request fail", "method", info.FullMethod, "code", st.Code(), "message", st.Message())
+		return nil, st.Err()
+	}
+	return res, nil
+}
+
+// recoverInterceptor turns a panic in a service into an internal error, as the recovery middleware does
+// for the HTTP API.
+func recoverInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler) (res interface{}, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			fmt.Println("panic, stack: ", string(debug.Stack()), "method", info.FullMethod, "error", r)
+			res, err = nil, status.Error(codes.Internal, "internal server error")
+		}
+	}()
+	return handler(ctx, req)
+}
@@ -0,0 +1,82 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"transaction_demo/app/apperr"
+)
+
+// errorDomain is the domain of the ErrorInfo detail attached to the statuses of application errors.
+const errorDomain = "transaction_demo"
+
+// mapHTTPStatusCode maps the HTTP status of an application error to a gRPC code.
+var mapHTTPStatusCode = map[int]codes.Code{
+	http.StatusBadRequest:          codes.InvalidArgument,
+	http.StatusNotFound:            codes.NotFound,
+	http.StatusConflict:            codes.AlreadyExists,
+	http.StatusUnprocessableEntity: codes.FailedPrecondition,
+	http.StatusInternalServerError: codes.Internal,
+}
+
+// mapErrCodeCode maps the application errors whose HTTP status is too coarse for gRPC clients, such as
+// business rule failures reported as bad requests, to a more precise gRPC code.
+var mapErrCodeCode = map[string]codes.Code{
+	apperr.ErrInsufficientFunds.Code: codes.FailedPrecondition,
+	apperr.ErrCurrencyMismatch.Code:  codes.FailedPrecondition,
+	apperr.ErrQuoteExpired.Code:      codes.FailedPrecondition,
+	apperr.ErrQuoteUsed.Code:         codes.FailedPrecondition,
+	apperr.ErrHoldExpired.Code:       codes.FailedPrecondition,
+	apperr.ErrHoldClosed.Code:        codes.FailedPrecondition,
+	apperr.ErrAccountFrozen.Code:     codes.FailedPrecondition,
+	apperr.ErrAccountClosed.Code:     codes.FailedPrecondition,
+	apperr.ErrLimitExceeded.Code:     codes.ResourceExhausted,
+	apperr.ErrRiskDenied.Code:        codes.PermissionDenied,
+	apperr.ErrResourceBusy.Code:      codes.Aborted,
+}
+
+// toStatus converts an error returned by the usecases to a gRPC status, the way RenderError converts it
+// to an HTTP response. The code of an AppError is kept as the reason of an ErrorInfo detail, so that
+// clients can tell apart errors sharing a gRPC code. Any other error is an internal error.
+func toStatus(err error) *status.Status {
+	// AppError is checked first: its Error method requires a wrapped error, which not all of them have
+	var appErr apperr.AppError
+	if errors.As(err, &appErr) {
+		return appErrStatus(appErr)
+	}
+	if st, ok := status.FromError(err); ok {
+		return st
+	}
+	switch {
+	case errors.Is(err, context.Canceled):
+		return status.New(codes.Canceled, err.Error())
+	case errors.Is(err, context.DeadlineExceeded):
+		return status.New(codes.DeadlineExceeded, err.Error())
+	}
+	return status.New(codes.Internal, "internal server error")
+}
+
+func appErrStatus(appErr apperr.AppError) *status.Status {
+	code, ok := mapErrCodeCode[appErr.Code]
+	if !ok {
+		code, ok = mapHTTPStatusCode[appErr.Status]
+	}
+	if !ok {
+		code = codes.Unknown
+	}
+	message := appErr.Message
+	if message == "" {
+		message = appErr.Code
+	}
+
+	st := status.New(code, message)
+	if detailed, err := st.WithDetails(&errdetails.ErrorInfo{Reason: appErr.Code, Domain: errorDomain}); err == nil {
+		st = detailed
+	}
+	return st
+}
@@ -0,0 +1,191 @@
+package statement
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"time"
+
+	"transaction_demo/app/domain/entity"
+	"transaction_demo/app/usecase/dto"
+)
+
+// camt053Namespace is the ISO 20022 bank-to-customer statement message, version 2.
+const camt053Namespace = "urn:iso:std:iso:20022:tech:xsd:camt.053.001.02"
+
+// Balance types and credit/debit indicators of camt.053.
+const (
+	camtOpeningBooked = "OPBD"
+	camtClosingBooked = "CLBD"
+	camtCredit        = "CRDT"
+	camtDebit         = "DBIT"
+	camtBooked        = "BOOK"
+)
+
+type camtAmount struct {
+	Currency string `xml:"Ccy,attr"`
+	Value    string `xml:",chardata"`
+}
+
+type camtDateTime struct {
+	DtTm string `xml:"DtTm"`
+}
+
+type camtPeriod struct {
+	XMLName xml.Name `xml:"FrToDt"`
+	From    string   `xml:"FrDtTm"`
+	To      string   `xml:"ToDtTm"`
+}
+
+type camtAccount struct {
+	XMLName  xml.Name `xml:"Acct"`
+	ID       string   `xml:"Id>Othr>Id"`
+	Currency string   `xml:"Ccy"`
+}
+
+type camtBalance struct {
+	XMLName   xml.Name     `xml:"Bal"`
+	Type      string       `xml:"Tp>CdOrPrtry>Cd"`
+	Amount    camtAmount   `xml:"Amt"`
+	CdtDbtInd string       `xml:"CdtDbtInd"`
+	Date      camtDateTime `xml:"Dt"`
+}
+
+type camtCharges struct {
+	Amount camtAmount `xml:"Amt"`
+}
+
+type camtEntry struct {
+	XMLName      xml.Name     `xml:"Ntry"`
+	Ref          string       `xml:"NtryRef"`
+	Amount       camtAmount   `xml:"Amt"`
+	CdtDbtInd    string       `xml:"CdtDbtInd"`
+	Reversal     bool         `xml:"RvslInd,omitempty"`
+	Status       string       `xml:"Sts"`
+	BookingDate  camtDateTime `xml:"BookgDt"`
+	ValueDate    camtDateTime `xml:"ValDt"`
+	ServicerRef  string       `xml:"AcctSvcrRef,omitempty"`
+	BankTxCode   string       `xml:"BkTxCd>Prtry>Cd"`
+	Charges      *camtCharges `xml:"Chrgs,omitempty"`
+	AdditionInfo string       `xml:"AddtlNtryInf,omitempty"`
+}
+
+// camt053Writer streams a camt.053 document: the statement envelope and balances are
+// written by Begin, one Ntry element per movement, and the envelope is closed by End.
+type camt053Writer struct {
+	out io.Writer
+	enc *xml.Encoder
+}
+
+func newCamt053Writer(out io.Writer) *camt053Writer {
+	enc := xml.NewEncoder(out)
+	enc.Indent("", "  ")
+	return &camt053Writer{out: out, enc: enc}
+}
+
+func (c *camt053Writer) Begin(header dto.StatementHeaderDTO) error {
+	if _, err := io.WriteString(c.out, xml.Header); err != nil {
+		return err
+	}
+	id := fmt.Sprintf("%d-%d", header.AccountID, header.GeneratedAt.Unix())
+	created := formatTime(header.GeneratedAt)
+
+	if err := c.start("Document", xml.Attr{Name: xml.Name{Local: "xmlns"}, Value: camt053Namespace}); err != nil {
+		return err
+	}
+	if err := c.start("BkToCstmrStmt"); err != nil {
+		return err
+	}
+	groupHeader := struct {
+		XMLName xml.Name `xml:"GrpHdr"`
+		MsgID   string   `xml:"MsgId"`
+		Created string   `xml:"CreDtTm"`
+	}{MsgID: id, Created: created}
+	if err := c.enc.Encode(groupHeader); err != nil {
+		return err
+	}
+
+	if err := c.start("Stmt"); err != nil {
+		return err
+	}
+	if err := c.element("Id", id); err != nil {
+		return err
+	}
+	if err := c.element("CreDtTm", created); err != nil {
+		return err
+	}
+	period := camtPeriod{From: formatTime(header.From), To: formatTime(header.To)}
+	if err := c.enc.Encode(period); err != nil {
+		return err
+	}
+	account := camtAccount{ID: fmt.Sprint(header.AccountID), Currency: string(header.Currency)}
+	if err := c.enc.Encode(account); err != nil {
+		return err
+	}
+
+	opening := newCamtBalance(camtOpeningBooked, header.OpeningBalance, header.Currency, header.From)
+	if err := c.enc.Encode(opening); err != nil {
+		return err
+	}
+	closing := newCamtBalance(camtClosingBooked, header.ClosingBalance, header.Currency, header.To)
+	return c.enc.Encode(closing)
+}
+
+func (c *camt053Writer) WriteLine(line dto.StatementLineDTO) error {
+	amount, indicator := camtSigned(line.Amount)
+	bookedAt := camtDateTime{DtTm: formatTime(line.BookedAt)}
+	entry := camtEntry{
+		Ref:          fmt.Sprint(line.JournalEntryID),
+		Amount:       camtAmount{Currency: string(line.Currency), Value: amount.String()},
+		CdtDbtInd:    indicator,
+		Reversal:     line.Description == "reversal",
+		Status:       camtBooked,
+		BookingDate:  bookedAt,
+		ValueDate:    bookedAt,
+		ServicerRef:  formatID(line.TransactionID),
+		BankTxCode:   line.Description,
+		AdditionInfo: line.Description,
+	}
+	if line.Fee.IsPositive() {
+		entry.Charges = &camtCharges{Amount: camtAmount{Currency: string(line.Currency), Value: line.Fee.String()}}
+	}
+	if line.CounterpartyAccountID != nil {
+		entry.AdditionInfo = fmt.Sprintf("%s, counterparty account %d", line.Description, *line.CounterpartyAccountID)
+	}
+	return c.enc.Encode(entry)
+}
+
+func (c *camt053Writer) End(dto.StatementSummaryDTO) error {
+	for _, name := range []string{"Stmt", "BkToCstmrStmt", "Document"} {
+		if err := c.enc.EncodeToken(xml.EndElement{Name: xml.Name{Local: name}}); err != nil {
+			return err
+		}
+	}
+	return c.enc.Flush()
+}
+
+func (c *camt053Writer) start(name string, attrs ...xml.Attr) error {
+	return c.enc.EncodeToken(xml.StartElement{Name: xml.Name{Local: name}, Attr: attrs})
+}
+
+func (c *camt053Writer) element(name string, value string) error {
+	return c.enc.EncodeElement(value, xml.StartElement{Name: xml.Name{Local: name}})
+}
+
+func newCamtBalance(balanceType string, balance entity.Money, currency entity.Currency, at time.Time) camtBalance {
+	amount, indicator := camtSigned(balance)
+	return camtBalance{
+		Type:      balanceType,
+		Amount:    camtAmount{Currency: string(currency), Value: amount.String()},
+		CdtDbtInd: indicator,
+		Date:      camtDateTime{DtTm: formatTime(at)},
+	}
+}
+
+// camtSigned splits a signed amount into the unsigned amount and credit/debit indicator camt.053 expects.
+func camtSigned(amount entity.Money) (entity.Money, string) {
+	if amount.IsNegative() {
+		return amount.Neg(), camtDebit
+	}
+	return amount, camtCredit
+}
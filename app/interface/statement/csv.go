@@ -0,0 +1,68 @@
+package statement
+
+import (
+	"encoding/csv"
+	"io"
+	"strconv"
+	"time"
+
+	"transaction_demo/app/usecase/dto"
+)
+
+// csvHeader lists the columns of a CSV statement. The first row is the opening balance and
+// the last one the closing balance; movement rows sit in between.
+var csvHeader = []string{
+	"record_type", "booked_at", "journal_entry_id", "transaction_id", "description",
+	"counterparty_account_id", "amount", "fee", "balance", "currency",
+}
+
+type csvWriter struct {
+	w      *csv.Writer
+	header dto.StatementHeaderDTO
+}
+
+func newCSVWriter(out io.Writer) *csvWriter {
+	return &csvWriter{w: csv.NewWriter(out)}
+}
+
+func (c *csvWriter) Begin(header dto.StatementHeaderDTO) error {
+	c.header = header
+	if err := c.w.Write(csvHeader); err != nil {
+		return err
+	}
+	return c.w.Write([]string{
+		"opening_balance", formatTime(header.From), "", "", "", "", "", "",
+		header.OpeningBalance.String(), string(header.Currency),
+	})
+}
+
+func (c *csvWriter) WriteLine(line dto.StatementLineDTO) error {
+	return c.w.Write([]string{
+		"movement", formatTime(line.BookedAt), strconv.FormatUint(line.JournalEntryID, 10),
+		formatID(line.TransactionID), line.Description, formatID(line.CounterpartyAccountID),
+		line.Amount.String(), line.Fee.String(), line.Balance.String(), string(line.Currency),
+	})
+}
+
+func (c *csvWriter) End(summary dto.StatementSummaryDTO) error {
+	err := c.w.Write([]string{
+		"closing_balance", formatTime(c.header.To), "", "", "", "", "", "",
+		summary.ClosingBalance.String(), string(c.header.Currency),
+	})
+	if err != nil {
+		return err
+	}
+	c.w.Flush()
+	return c.w.Error()
+}
+
+func formatTime(t time.Time) string {
+	return t.UTC().Format(time.RFC3339)
+}
+
+func formatID(id *uint64) string {
+	if id == nil {
+		return ""
+	}
+	return strconv.FormatUint(*id, 10)
+}
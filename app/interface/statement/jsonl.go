@@ -0,0 +1,51 @@
+package statement
+
+import (
+	"encoding/json"
+	"io"
+
+	"transaction_demo/app/usecase/dto"
+)
+
+// JSON Lines statements write one object per line, tagged with its record type:
+// the header first, one movement per line, then the summary.
+const (
+	jsonlHeader   = "header"
+	jsonlMovement = "movement"
+	jsonlSummary  = "summary"
+)
+
+type jsonlHeaderRecord struct {
+	RecordType string `json:"record_type"`
+	dto.StatementHeaderDTO
+}
+
+type jsonlMovementRecord struct {
+	RecordType string `json:"record_type"`
+	dto.StatementLineDTO
+}
+
+type jsonlSummaryRecord struct {
+	RecordType string `json:"record_type"`
+	dto.StatementSummaryDTO
+}
+
+type jsonlWriter struct {
+	enc *json.Encoder
+}
+
+func newJSONLWriter(out io.Writer) *jsonlWriter {
+	return &jsonlWriter{enc: json.NewEncoder(out)}
+}
+
+func (j *jsonlWriter) Begin(header dto.StatementHeaderDTO) error {
+	return j.enc.Encode(jsonlHeaderRecord{RecordType: jsonlHeader, StatementHeaderDTO: header})
+}
+
+func (j *jsonlWriter) WriteLine(line dto.StatementLineDTO) error {
+	return j.enc.Encode(jsonlMovementRecord{RecordType: jsonlMovement, StatementLineDTO: line})
+}
+
+func (j *jsonlWriter) End(summary dto.StatementSummaryDTO) error {
+	return j.enc.Encode(jsonlSummaryRecord{RecordType: jsonlSummary, StatementSummaryDTO: summary})
+}
@@ -0,0 +1,45 @@
+// Package statement renders account statements in the supported file formats.
+package statement
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"transaction_demo/app/domain/entity"
+	"transaction_demo/app/usecase"
+)
+
+// NewWriter returns a writer rendering a statement in format to out.
+func NewWriter(format entity.StatementFormat, out io.Writer) (usecase.StatementWriter, error) {
+	switch format {
+	case entity.StatementCSV:
+		return newCSVWriter(out), nil
+	case entity.StatementJSONL:
+		return newJSONLWriter(out), nil
+	case entity.StatementCamt053:
+		return newCamt053Writer(out), nil
+	}
+	return nil, fmt.Errorf("unsupported statement format %q", format)
+}
+
+// ContentType returns the MIME type of a statement format.
+func ContentType(format entity.StatementFormat) string {
+	switch format {
+	case entity.StatementJSONL:
+		return "application/x-ndjson"
+	case entity.StatementCamt053:
+		return "application/xml"
+	}
+	return "text/csv"
+}
+
+// FileName returns the file name of an account statement, e.g. statement_111_20250301_20250401.csv.
+func FileName(accountID uint64, from time.Time, to time.Time, format entity.StatementFormat) string {
+	ext := string(format)
+	if format == entity.StatementCamt053 {
+		ext = "xml"
+	}
+	return fmt.Sprintf("statement_%d_%s_%s.%s", accountID,
+		from.UTC().Format("20060102"), to.UTC().Format("20060102"), ext)
+}
@@ -0,0 +1,41 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"transaction_demo/app/config"
+	"transaction_demo/app/usecase"
+)
+
+const defaultSnapshotPollInterval = time.Hour
+
+// BalanceSnapshotWorker snapshots the closing balances of the days that ended.
+// Snapshots are idempotent, so several instances may run side by side.
+type BalanceSnapshotWorker struct {
+	*poller
+	ledgerUC usecase.LedgerUC
+}
+
+func NewBalanceSnapshotWorker(ledgerUC usecase.LedgerUC, cf *config.Config) *BalanceSnapshotWorker {
+	interval := time.Duration(cf.Snapshots.PollIntervalSeconds) * time.Second
+	if interval <= 0 {
+		interval = defaultSnapshotPollInterval
+	}
+	return &BalanceSnapshotWorker{
+		poller:   newPoller(interval),
+		ledgerUC: ledgerUC,
+	}
+}
+
+// Start runs the polling loop in the background until Stop is called.
+func (w *BalanceSnapshotWorker) Start() {
+	w.start(w.poll)
+}
+
+func (w *BalanceSnapshotWorker) poll() {
+	if err := w.ledgerUC.CatchUpSnapshots(context.Background(), time.Now()); err != nil {
+		fmt.Println("balance snapshot poll failed", "error", err)
+	}
+}
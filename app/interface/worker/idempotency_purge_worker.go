@@ -0,0 +1,46 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"transaction_demo/app/config"
+	"transaction_demo/app/usecase"
+)
+
+const defaultIdempotencyPurgeInterval = time.Hour
+
+// IdempotencyPurgeWorker deletes the idempotency keys whose TTL has passed.
+// Deletes skip the keys another instance is deleting, so several instances may run side by side.
+type IdempotencyPurgeWorker struct {
+	*poller
+	idempotencyUC usecase.IdempotencyUC
+}
+
+func NewIdempotencyPurgeWorker(idempotencyUC usecase.IdempotencyUC, cf *config.Config) *IdempotencyPurgeWorker {
+	interval := time.Duration(cf.Idempotency.PurgeIntervalSeconds) * time.Second
+	if interval <= 0 {
+		interval = defaultIdempotencyPurgeInterval
+	}
+	return &IdempotencyPurgeWorker{
+		poller:        newPoller(interval),
+		idempotencyUC: idempotencyUC,
+	}
+}
+
+// Start runs the polling loop in the background until Stop is called.
+func (w *IdempotencyPurgeWorker) Start() {
+	w.start(w.poll)
+}
+
+func (w *IdempotencyPurgeWorker) poll() {
+	deleted, err := w.idempotencyUC.PurgeExpired(context.Background(), time.Now())
+	if err != nil {
+		fmt.Println("idempotency purge poll failed", "error", err)
+		return
+	}
+	if deleted > 0 {
+		fmt.Println("purged expired idempotency keys", "count", deleted)
+	}
+}
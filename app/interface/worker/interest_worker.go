@@ -0,0 +1,41 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"transaction_demo/app/config"
+	"transaction_demo/app/usecase"
+)
+
+const defaultInterestPollInterval = time.Hour
+
+// InterestWorker accrues interest for the days that ended and posts it for the months that ended.
+// Accruals and postings are idempotent, so several instances may run side by side.
+type InterestWorker struct {
+	*poller
+	interestUC usecase.InterestUC
+}
+
+func NewInterestWorker(interestUC usecase.InterestUC, cf *config.Config) *InterestWorker {
+	interval := time.Duration(cf.Interest.PollIntervalSeconds) * time.Second
+	if interval <= 0 {
+		interval = defaultInterestPollInterval
+	}
+	return &InterestWorker{
+		poller:     newPoller(interval),
+		interestUC: interestUC,
+	}
+}
+
+// Start runs the polling loop in the background until Stop is called.
+func (w *InterestWorker) Start() {
+	w.start(w.poll)
+}
+
+func (w *InterestWorker) poll() {
+	if err := w.interestUC.CatchUp(context.Background(), time.Now()); err != nil {
+		fmt.Println("interest poll failed", "error", err)
+	}
+}
@@ -0,0 +1,63 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"transaction_demo/app/config"
+	"transaction_demo/app/usecase"
+)
+
+const defaultRelayPollInterval = 500 * time.Millisecond
+
+// OutboxRelay publishes the domain events recorded in the outbox and queues them for webhooks.
+// Several instances may run against the same database: each batch of events is claimed
+// with SELECT ... FOR UPDATE SKIP LOCKED and leased, so it is published by one of them at a time.
+type OutboxRelay struct {
+	*poller
+	outboxUC  usecase.OutboxUC
+	batchSize int
+}
+
+func NewOutboxRelay(outboxUC usecase.OutboxUC, cf *config.Config) *OutboxRelay {
+	interval := time.Duration(cf.Events.PollIntervalMillis) * time.Millisecond
+	if interval <= 0 {
+		interval = defaultRelayPollInterval
+	}
+	batchSize := cf.Events.BatchSize
+	if batchSize <= 0 {
+		batchSize = defaultBatchSize
+	}
+	return &OutboxRelay{
+		poller:    newPoller(interval),
+		outboxUC:  outboxUC,
+		batchSize: batchSize,
+	}
+}
+
+// Start runs the polling loop in the background until Stop is called.
+func (w *OutboxRelay) Start() {
+	w.start(w.poll)
+}
+
+// poll queues the pending events for webhooks, then publishes them, each in batches until none is left,
+// a batch fails or the relay is stopped. Webhooks are queued first and apart, so that a broker outage
+// does not hold them back.
+func (w *OutboxRelay) poll() {
+	w.drain("queue webhooks", w.outboxUC.QueueWebhooks)
+	w.drain("publish", w.outboxUC.PublishPending)
+}
+
+func (w *OutboxRelay) drain(step string, run func(ctx context.Context, limit int) (int, error)) {
+	for {
+		done, err := run(context.Background(), w.batchSize)
+		if err != nil {
+			fmt.Println("outbox relay poll failed", "step", step, "error", err)
+			return
+		}
+		if done < w.batchSize || w.stopped() {
+			return
+		}
+	}
+}
@@ -0,0 +1,62 @@
+// Package worker provides the background jobs that run alongside the HTTP server.
+package worker
+
+import (
+	"context"
+	"time"
+)
+
+// poller calls a poll function right away and then every interval, until stopped.
+// A poll in progress is not interrupted; a long poll should check stopped between steps.
+type poller struct {
+	interval time.Duration
+	stop     chan struct{}
+	done     chan struct{}
+}
+
+func newPoller(interval time.Duration) *poller {
+	return &poller{
+		interval: interval,
+		stop:     make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+}
+
+// start runs the polling loop in the background.
+func (p *poller) start(poll func()) {
+	go func() {
+		defer close(p.done)
+
+		ticker := time.NewTicker(p.interval)
+		defer ticker.Stop()
+		for {
+			poll()
+			select {
+			case <-p.stop:
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// stopped reports whether Stop was called.
+func (p *poller) stopped() bool {
+	select {
+	case <-p.stop:
+		return true
+	default:
+		return false
+	}
+}
+
+// Stop ends the polling loop, waiting for the poll in progress unless ctx is done first.
+func (p *poller) Stop(ctx context.Context) error {
+	close(p.stop)
+	select {
+	case <-p.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
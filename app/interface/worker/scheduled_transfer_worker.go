@@ -0,0 +1,62 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"transaction_demo/app/config"
+	"transaction_demo/app/usecase"
+)
+
+const (
+	defaultPollInterval = 10 * time.Second
+	defaultBatchSize    = 100
+)
+
+// ScheduledTransferWorker polls for due scheduled transfers and makes them.
+// Several instances may run against the same database: each due schedule is claimed
+// with SELECT ... FOR UPDATE SKIP LOCKED, so it is made by exactly one of them.
+type ScheduledTransferWorker struct {
+	*poller
+	scheduleUC usecase.ScheduledTransferUC
+	batchSize  int
+}
+
+func NewScheduledTransferWorker(scheduleUC usecase.ScheduledTransferUC, cf *config.Config) *ScheduledTransferWorker {
+	interval := time.Duration(cf.Scheduler.PollIntervalSeconds) * time.Second
+	if interval <= 0 {
+		interval = defaultPollInterval
+	}
+	batchSize := cf.Scheduler.BatchSize
+	if batchSize <= 0 {
+		batchSize = defaultBatchSize
+	}
+	return &ScheduledTransferWorker{
+		poller:     newPoller(interval),
+		scheduleUC: scheduleUC,
+		batchSize:  batchSize,
+	}
+}
+
+// Start runs the polling loop in the background until Stop is called.
+func (w *ScheduledTransferWorker) Start() {
+	w.start(w.poll)
+}
+
+// poll makes the transfers due now, in batches, until none is left or the worker is stopped.
+func (w *ScheduledTransferWorker) poll() {
+	for {
+		ran, err := w.scheduleUC.RunDue(context.Background(), time.Now(), w.batchSize)
+		if err != nil {
+			fmt.Println("scheduled transfer poll failed", "error", err)
+			return
+		}
+		if ran > 0 {
+			fmt.Println("scheduled transfers run", "count", ran)
+		}
+		if ran < w.batchSize || w.stopped() {
+			return
+		}
+	}
+}
@@ -0,0 +1,59 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"transaction_demo/app/config"
+	"transaction_demo/app/usecase"
+)
+
+const defaultWebhookPollInterval = 5 * time.Second
+
+// WebhookDeliveryWorker sends the due webhook deliveries and schedules their retries.
+// Several instances may run against the same database: each due delivery is claimed
+// with SELECT ... FOR UPDATE SKIP LOCKED and leased, so it is sent by one of them at a time.
+type WebhookDeliveryWorker struct {
+	*poller
+	webhookUC usecase.WebhookUC
+	batchSize int
+}
+
+func NewWebhookDeliveryWorker(webhookUC usecase.WebhookUC, cf *config.Config) *WebhookDeliveryWorker {
+	interval := time.Duration(cf.Webhooks.PollIntervalSeconds) * time.Second
+	if interval <= 0 {
+		interval = defaultWebhookPollInterval
+	}
+	batchSize := cf.Webhooks.BatchSize
+	if batchSize <= 0 {
+		batchSize = defaultBatchSize
+	}
+	return &WebhookDeliveryWorker{
+		poller:    newPoller(interval),
+		webhookUC: webhookUC,
+		batchSize: batchSize,
+	}
+}
+
+// Start runs the polling loop in the background until Stop is called.
+func (w *WebhookDeliveryWorker) Start() {
+	w.start(w.poll)
+}
+
+// poll sends the deliveries due now, in batches, until none is left or the worker is stopped.
+func (w *WebhookDeliveryWorker) poll() {
+	for {
+		attempted, err := w.webhookUC.DeliverDue(context.Background(), time.Now(), w.batchSize)
+		if err != nil {
+			fmt.Println("webhook delivery poll failed", "error", err)
+			return
+		}
+		if attempted > 0 {
+			fmt.Println("webhook deliveries attempted", "count", attempted)
+		}
+		if attempted < w.batchSize || w.stopped() {
+			return
+		}
+	}
+}
@@ -7,4 +7,20 @@ import (
 )
 
 // ProvideRepositories provides the repository instances for DI
-var ProvideRepositories = fx.Provide(postgres.NewAccountRepository, postgres.NewTransactionRepository)
+var ProvideRepositories = fx.Provide(
+	postgres.NewAccountRepository,
+	postgres.NewTransactionRepository,
+	postgres.NewRateRepository,
+	postgres.NewQuoteRepository,
+	postgres.NewIdempotencyRepository,
+	postgres.NewLedgerRepository,
+	postgres.NewHoldRepository,
+	postgres.NewAccountAuditRepository,
+	postgres.NewRiskReviewRepository,
+	postgres.NewSplitPaymentRepository,
+	postgres.NewScheduledTransferRepository,
+	postgres.NewInterestRepository,
+	postgres.NewBalanceSnapshotRepository,
+	postgres.NewOutboxRepository,
+	postgres.NewWebhookRepository,
+)
@@ -4,6 +4,8 @@ import (
 	"go.uber.org/fx"
 
 	"transaction_demo/app/config"
+	"transaction_demo/app/external/publisher"
+	"transaction_demo/app/external/webhook"
 	"transaction_demo/app/interface/api/route"
 	"transaction_demo/cmd/shared/db"
 )
@@ -15,4 +17,6 @@ var ProvideSingletons = fx.Provide(
 	db.GetDB,
 	db.GetTrmGormCtxGetter,
 	db.GetTxManager,
+	publisher.NewEventPublisher,
+	webhook.NewHTTPSender,
 )
@@ -7,4 +7,18 @@ import (
 )
 
 // ProvideUsecases provides the usecase instances for DI
-var ProvideUsecases = fx.Provide(usecase.NewAccountUsecase)
+var ProvideUsecases = fx.Provide(
+	usecase.NewAccountUsecase,
+	usecase.NewAccountBroadcaster,
+	usecase.NewLimitEvaluator,
+	usecase.NewRiskEvaluator,
+	usecase.NewFeeCalculator,
+	usecase.NewFXUsecase,
+	usecase.NewIdempotencyUsecase,
+	usecase.NewLedgerUsecase,
+	usecase.NewScheduledTransferUsecase,
+	usecase.NewInterestUsecase,
+	usecase.NewStatementUsecase,
+	usecase.NewOutboxUsecase,
+	usecase.NewWebhookUsecase,
+)
@@ -0,0 +1,184 @@
+package usecase
+
+import (
+	"sync"
+	"time"
+
+	"transaction_demo/app/config"
+	"transaction_demo/app/usecase/dto"
+)
+
+// Stream settings used when the configuration does not set streams.*.
+const (
+	defaultStreamHistorySize      = 1000
+	defaultStreamSubscriberBuffer = 64
+)
+
+// AccountBroadcaster fans the activity of accounts out to the streams subscribed to them, within
+// this process. It keeps the latest events so that a client reconnecting with the ID of the last
+// event it received gets the events it missed.
+//
+// Event IDs start from the start-up time in microseconds, so the IDs received from a previous run
+// of the server are always older than the retained events and never mistaken for current ones.
+type AccountBroadcaster struct {
+	mu          sync.Mutex
+	lastID      uint64
+	history     []accountEvent // ring buffer of the latest events, oldest at next once full
+	next        int
+	full        bool
+	bufferSize  int
+	subscribers map[uint64]map[*AccountSubscription]struct{}
+	// balanceVersions is the last state published per account, while its event is retained
+	balanceVersions map[uint64]balanceVersion
+}
+
+// balanceVersion is the version of the last state published for an account, and the ID of its event.
+type balanceVersion struct {
+	version uint64
+	eventID uint64
+}
+
+// accountEvent is a retained event with the account it belongs to.
+type accountEvent struct {
+	accountID uint64
+	event     dto.AccountEventDTO
+}
+
+// AccountSubscription receives the events of one account as they are broadcast.
+type AccountSubscription struct {
+	// Events is closed when the subscription is closed or when the subscriber falls too far
+	// behind; the client then reconnects and resumes from the last event it received.
+	Events      <-chan dto.AccountEventDTO
+	events      chan dto.AccountEventDTO
+	accountID   uint64
+	broadcaster *AccountBroadcaster
+}
+
+func NewAccountBroadcaster(cf *config.Config) *AccountBroadcaster {
+	historySize := cf.Streams.HistorySize
+	if historySize <= 0 {
+		historySize = defaultStreamHistorySize
+	}
+	bufferSize := cf.Streams.SubscriberBuffer
+	if bufferSize <= 0 {
+		bufferSize = defaultStreamSubscriberBuffer
+	}
+	return &AccountBroadcaster{
+		lastID:          uint64(time.Now().UnixMicro()),
+		history:         make([]accountEvent, historySize),
+		bufferSize:      bufferSize,
+		subscribers:     make(map[uint64]map[*AccountSubscription]struct{}),
+		balanceVersions: make(map[uint64]balanceVersion),
+	}
+}
+
+// Publish assigns the next ID to an event of an account, retains it and sends it to the subscribers
+// of the account. A subscriber whose buffer is full is disconnected rather than waited for.
+func (b *AccountBroadcaster) Publish(accountID uint64, eventType string, data interface{}) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.publish(accountID, eventType, data)
+}
+
+// PublishBalance publishes the state of an account as a balance event, unless a state of the same or
+// a later version was published already: the changes of an account commit in order, but the functions
+// publishing them after the commit may run in any order.
+//
+// The version of an account is forgotten with its last balance event, once the history drops it, so
+// that the versions kept are bounded by the history size. The states published out of order are
+// published moments apart, long before the history drops the later one.
+func (b *AccountBroadcaster) PublishBalance(state dto.AccountBalanceEventDTO) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if last, ok := b.balanceVersions[state.AccountID]; ok && state.Version <= last.version {
+		return
+	}
+	event := b.publish(state.AccountID, dto.AccountEventBalance, state)
+	b.balanceVersions[state.AccountID] = balanceVersion{version: state.Version, eventID: event.ID}
+}
+
+// publish publishes an event and returns it; the caller holds the lock.
+func (b *AccountBroadcaster) publish(accountID uint64, eventType string, data interface{}) dto.AccountEventDTO {
+	b.lastID++
+	event := dto.AccountEventDTO{ID: b.lastID, Type: eventType, Data: data}
+	if b.full {
+		b.forget(b.history[b.next])
+	}
+	b.history[b.next] = accountEvent{accountID: accountID, event: event}
+	b.next = (b.next + 1) % len(b.history)
+	b.full = b.full || b.next == 0
+
+	for sub := range b.subscribers[accountID] {
+		select {
+		case sub.events <- event:
+		default:
+			b.unsubscribe(sub)
+		}
+	}
+	return event
+}
+
+// forget drops the version of an account when the history drops the event that published it;
+// the caller holds the lock.
+func (b *AccountBroadcaster) forget(dropped accountEvent) {
+	if last, ok := b.balanceVersions[dropped.accountID]; ok && last.eventID == dropped.event.ID {
+		delete(b.balanceVersions, dropped.accountID)
+	}
+}
+
+// Subscribe subscribes to the events of an account published from now on, and returns the retained
+// events of the account after lastEventID. complete reports whether these are all the events published
+// after lastEventID; it is false on a first connection, and when lastEventID is from a previous run or
+// older than the retained events. current is the ID of the last event published, of any account.
+func (b *AccountBroadcaster) Subscribe(accountID uint64, lastEventID uint64,
+) (sub *AccountSubscription, missed []dto.AccountEventDTO, complete bool, current uint64) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	oldest := b.lastID + 1
+	if b.full {
+		oldest = b.history[b.next].event.ID
+	} else if b.next > 0 {
+		oldest = b.history[0].event.ID
+	}
+	complete = lastEventID > 0 && lastEventID+1 >= oldest && lastEventID <= b.lastID
+	if complete {
+		for i := 0; i < len(b.history); i++ {
+			e := b.history[(b.next+i)%len(b.history)]
+			if e.event.ID > lastEventID && e.accountID == accountID {
+				missed = append(missed, e.event)
+			}
+		}
+	}
+
+	events := make(chan dto.AccountEventDTO, b.bufferSize)
+	sub = &AccountSubscription{Events: events, events: events, accountID: accountID, broadcaster: b}
+	if b.subscribers[accountID] == nil {
+		b.subscribers[accountID] = make(map[*AccountSubscription]struct{})
+	}
+	b.subscribers[accountID][sub] = struct{}{}
+	return sub, missed, complete, b.lastID
+}
+
+// Close stops the subscription and closes its Events channel. It may be called more than once.
+func (s *AccountSubscription) Close() {
+	s.broadcaster.mu.Lock()
+	defer s.broadcaster.mu.Unlock()
+	s.broadcaster.unsubscribe(s)
+}
+
+// unsubscribe removes a subscriber and closes its channel; the caller holds the lock.
+func (b *AccountBroadcaster) unsubscribe(sub *AccountSubscription) {
+	subs, ok := b.subscribers[sub.accountID]
+	if !ok {
+		return
+	}
+	if _, ok = subs[sub]; !ok {
+		return
+	}
+	delete(subs, sub)
+	if len(subs) == 0 {
+		delete(b.subscribers, sub.accountID)
+	}
+	close(sub.events)
+}
@@ -0,0 +1,176 @@
+package usecase
+
+import (
+	"reflect"
+	"testing"
+
+	"transaction_demo/app/config"
+	"transaction_demo/app/usecase/dto"
+)
+
+// eventIDs returns the IDs of events, relative to base.
+func eventIDs(events []dto.AccountEventDTO, base uint64) []uint64 {
+	var ids []uint64
+	for _, e := range events {
+		ids = append(ids, e.ID-base)
+	}
+	return ids
+}
+
+func TestAccountBroadcaster_Subscribe(t *testing.T) {
+	// Events 1 to 5 are published, alternating between accounts 111 and 222, with room for 4 of them
+	tests := []struct {
+		name         string
+		lastEventID  func(base uint64) uint64
+		wantComplete bool
+		wantMissed   []uint64
+	}{
+		{
+			name:        "first_connection",
+			lastEventID: func(base uint64) uint64 { return 0 },
+		},
+		{
+			name:         "resume",
+			lastEventID:  func(base uint64) uint64 { return base + 2 },
+			wantComplete: true,
+			wantMissed:   []uint64{3, 5},
+		},
+		{
+			// Event 1 was evicted, but it is the one the client received last
+			name:         "resume_at_oldest",
+			lastEventID:  func(base uint64) uint64 { return base + 1 },
+			wantComplete: true,
+			wantMissed:   []uint64{3, 5},
+		},
+		{
+			// Event 1 was evicted before the client received it
+			name:        "evicted",
+			lastEventID: func(base uint64) uint64 { return base },
+		},
+		{
+			name:         "up_to_date",
+			lastEventID:  func(base uint64) uint64 { return base + 5 },
+			wantComplete: true,
+		},
+		{
+			// Events from before a restart have smaller IDs than any event of this run
+			name:        "previous_run",
+			lastEventID: func(base uint64) uint64 { return base - 1000 },
+		},
+		{
+			name:        "unknown_future_event",
+			lastEventID: func(base uint64) uint64 { return base + 6 },
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := NewAccountBroadcaster(&config.Config{Streams: config.Streams{HistorySize: 4}})
+			base := b.lastID
+			for i := 1; i <= 5; i++ {
+				accountID := uint64(222)
+				if i%2 == 1 {
+					accountID = 111
+				}
+				b.Publish(accountID, dto.AccountEventBalance, i)
+			}
+
+			sub, missed, complete, current := b.Subscribe(111, tt.lastEventID(base))
+			defer sub.Close()
+			if complete != tt.wantComplete {
+				t.Errorf("Subscribe() complete = %v, want %v", complete, tt.wantComplete)
+			}
+			if got := eventIDs(missed, base); !reflect.DeepEqual(got, tt.wantMissed) {
+				t.Errorf("Subscribe() missed = %v, want %v", got, tt.wantMissed)
+			}
+			if current != base+5 {
+				t.Errorf("Subscribe() current = %d, want %d", current-base, 5)
+			}
+		})
+	}
+}
+
+func TestAccountBroadcaster_Publish(t *testing.T) {
+	b := NewAccountBroadcaster(&config.Config{Streams: config.Streams{SubscriberBuffer: 2}})
+	sub, _, _, _ := b.Subscribe(111, 0)
+	other, _, _, _ := b.Subscribe(222, 0)
+	defer other.Close()
+
+	b.Publish(111, dto.AccountEventTransaction, "a")
+	b.Publish(222, dto.AccountEventTransaction, "b")
+	if e := <-sub.Events; e.Data != "a" || e.Type != dto.AccountEventTransaction {
+		t.Errorf("received %+v, want the event of account 111", e)
+	}
+	if e := <-other.Events; e.Data != "b" {
+		t.Errorf("received %+v, want the event of account 222", e)
+	}
+
+	// A subscriber that does not keep up is disconnected instead of blocking the publisher
+	for i := 0; i < 3; i++ {
+		b.Publish(111, dto.AccountEventBalance, i)
+	}
+	received := 0
+	for range sub.Events {
+		received++
+	}
+	if received != 2 {
+		t.Errorf("received %d events before being disconnected, want 2", received)
+	}
+	sub.Close()
+
+	// A closed subscription receives nothing, and closing it again is harmless
+	sub, _, _, _ = b.Subscribe(111, 0)
+	sub.Close()
+	sub.Close()
+	b.Publish(111, dto.AccountEventBalance, "c")
+	if _, ok := <-sub.Events; ok {
+		t.Error("closed subscription received an event")
+	}
+}
+
+func TestAccountBroadcaster_PublishBalance(t *testing.T) {
+	b := NewAccountBroadcaster(&config.Config{})
+	sub, _, _, _ := b.Subscribe(111, 0)
+	defer sub.Close()
+
+	// Versions 2 and 3 are published out of order, and version 1 arrives last
+	for _, version := range []uint64{2, 3, 2, 1, 4} {
+		b.PublishBalance(dto.AccountBalanceEventDTO{AccountID: 111, Version: version})
+	}
+	// Another account has its own versions
+	b.PublishBalance(dto.AccountBalanceEventDTO{AccountID: 222, Version: 1})
+
+	var versions []uint64
+	for len(versions) < 3 {
+		e := <-sub.Events
+		versions = append(versions, e.Data.(dto.AccountBalanceEventDTO).Version)
+	}
+	if !reflect.DeepEqual(versions, []uint64{2, 3, 4}) {
+		t.Errorf("published versions %v, want [2 3 4]", versions)
+	}
+	select {
+	case e := <-sub.Events:
+		t.Errorf("received %+v, want no more events", e)
+	default:
+	}
+	if b.balanceVersions[222].version != 1 {
+		t.Errorf("version of account 222 = %d, want 1", b.balanceVersions[222].version)
+	}
+}
+
+func TestAccountBroadcaster_PublishBalance_VersionsBounded(t *testing.T) {
+	b := NewAccountBroadcaster(&config.Config{Streams: config.Streams{HistorySize: 2}})
+
+	b.PublishBalance(dto.AccountBalanceEventDTO{AccountID: 111, Version: 1})
+	b.PublishBalance(dto.AccountBalanceEventDTO{AccountID: 111, Version: 2})
+	// Dropping an older balance event of the account keeps the version of the retained one
+	b.Publish(222, dto.AccountEventTransaction, "a")
+	if b.balanceVersions[111].version != 2 {
+		t.Errorf("version of account 111 = %d, want 2", b.balanceVersions[111].version)
+	}
+
+	// Dropping its last balance event forgets the account
+	b.Publish(222, dto.AccountEventTransaction, "b")
+	if len(b.balanceVersions) != 0 {
+		t.Errorf("versions kept = %v, want none", b.balanceVersions)
+	}
+}
@@ -2,16 +2,21 @@ package usecase
 
 import (
 	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"sort"
 	"time"
 
 	"github.com/avito-tech/go-transaction-manager/trm/v2"
-	"github.com/gin-gonic/gin"
 
 	"transaction_demo/app/apperr"
+	"transaction_demo/app/config"
 	"transaction_demo/app/domain/entity"
 	"transaction_demo/app/domain/repository"
 	"transaction_demo/app/usecase/dto"
+	"transaction_demo/cmd/shared/db/txhook"
 )
 
 // AccountUC defines the interface for account-related business operations.
@@ -21,134 +26,1823 @@ type AccountUC interface {
 	Create(ctx context.Context, account dto.AccountDTO) (dto.AccountDTO, error)
 
 	// GetBalance retrieves the current balance of an account.
-	GetBalance(ctx *gin.Context, id uint64) (dto.AccountDTO, error)
+	GetBalance(ctx context.Context, id uint64) (dto.AccountDTO, error)
 
-	// MakeTransaction performs atomic money transfer between accounts.
-	MakeTransaction(c *gin.Context, req dto.TransactionDTO) error
+	// FreezeAccount blocks debits from an account.
+	FreezeAccount(ctx context.Context, id uint64) (dto.AccountDTO, error)
+
+	// UnfreezeAccount makes a frozen account active again.
+	UnfreezeAccount(ctx context.Context, id uint64) (dto.AccountDTO, error)
+
+	// CloseAccount closes an account, optionally sweeping its balance to another account.
+	CloseAccount(ctx context.Context, req dto.CloseAccountDTO) (dto.AccountDTO, error)
+
+	// SetOverdraftLimit changes how far below zero an account may go and records the change.
+	SetOverdraftLimit(ctx context.Context, req dto.OverdraftDTO) (dto.AccountDTO, error)
+
+	// ListAccountAudits returns the administrative changes made to an account.
+	ListAccountAudits(ctx context.Context, accountID uint64) ([]dto.AccountAuditDTO, error)
+
+	// ListRiskReviews returns the risk reviews in a status, oldest first.
+	ListRiskReviews(ctx context.Context, req dto.RiskReviewListDTO) ([]dto.RiskReviewDTO, error)
+
+	// ReleaseTransaction posts a transfer held for risk review.
+	ReleaseTransaction(ctx context.Context, req dto.ReviewDecisionDTO) (dto.TransactionRecordDTO, error)
+
+	// RejectTransaction fails a transfer held for risk review.
+	RejectTransaction(ctx context.Context, req dto.ReviewDecisionDTO) (dto.TransactionRecordDTO, error)
+
+	// MakeTransaction performs atomic money transfer between accounts and returns the created transaction.
+	MakeTransaction(ctx context.Context, req dto.TransactionDTO) (dto.TransactionRecordDTO, error)
+
+	// MakeSplitPayment debits one account once and credits several, recording the legs under one split payment.
+	MakeSplitPayment(ctx context.Context, req dto.SplitPaymentDTO) (dto.SplitPaymentResultDTO, error)
+
+	// MakeBatchTransaction performs many transfers, all or nothing or each on its own, and returns per-item results.
+	MakeBatchTransaction(ctx context.Context, req dto.BatchTransactionDTO) (dto.BatchResultDTO, error)
+
+	// GetTransaction retrieves a single transaction.
+	GetTransaction(ctx context.Context, id uint64) (dto.TransactionRecordDTO, error)
+
+	// AuthorizeTransaction reserves funds on the source account for a transfer settled later.
+	AuthorizeTransaction(ctx context.Context, req dto.AuthorizeDTO) (dto.HoldDTO, error)
+
+	// CaptureHold settles a hold fully or partially as a transfer.
+	CaptureHold(ctx context.Context, req dto.CaptureDTO) (dto.HoldDTO, error)
+
+	// VoidHold releases a hold without moving money.
+	VoidHold(ctx context.Context, holdID uint64) (dto.HoldDTO, error)
+
+	// ReverseTransaction returns all or part of a transfer to its source account.
+	ReverseTransaction(ctx context.Context, req dto.ReversalDTO) (dto.ReversalResultDTO, error)
+
+	// ListTransactions returns a page of an account's transaction history, newest first.
+	ListTransactions(ctx context.Context, req dto.TransactionListDTO) ([]dto.TransactionRecordDTO, dto.PageMetaDTO, error)
+
+	// SubscribeEvents subscribes to the balance changes and new transactions of an account, and returns the
+	// events to send before the live ones.
+	SubscribeEvents(ctx context.Context, req dto.AccountEventsDTO) ([]dto.AccountEventDTO, *AccountSubscription, error)
+}
+
+// Page sizes of transaction history listings.
+const (
+	defaultHistoryLimit = 20
+	maxHistoryLimit     = 100
+)
+
+// defaultReviewLimit is the page size of risk review listings.
+const defaultReviewLimit = 50
+
+// defaultHoldTTL is used when the configuration does not set hold.expiry_seconds.
+const defaultHoldTTL = 7 * 24 * time.Hour
+
+type accountUsecase struct {
+	accountRepo     repository.AccountRepository
+	transactionRepo repository.TransactionRepository
+	quoteRepo       repository.QuoteRepository
+	ledgerRepo      repository.LedgerRepository
+	holdRepo        repository.HoldRepository
+	auditRepo       repository.AccountAuditRepository
+	reviewRepo      repository.RiskReviewRepository
+	splitRepo       repository.SplitPaymentRepository
+	outboxRepo      repository.OutboxRepository
+	limits          LimitEvaluator
+	risk            RiskEvaluator
+	fees            FeeCalculator
+	broadcaster     *AccountBroadcaster
+	txManager       trm.Manager
+	holdTTL         time.Duration
+}
+
+func NewAccountUsecase(
+	accountRepo repository.AccountRepository,
+	transactionRepo repository.TransactionRepository,
+	quoteRepo repository.QuoteRepository,
+	ledgerRepo repository.LedgerRepository,
+	holdRepo repository.HoldRepository,
+	auditRepo repository.AccountAuditRepository,
+	reviewRepo repository.RiskReviewRepository,
+	splitRepo repository.SplitPaymentRepository,
+	outboxRepo repository.OutboxRepository,
+	limits LimitEvaluator,
+	risk RiskEvaluator,
+	fees FeeCalculator,
+	broadcaster *AccountBroadcaster,
+	txManager trm.Manager,
+	cf *config.Config) AccountUC {
+	holdTTL := time.Duration(cf.Hold.ExpirySeconds) * time.Second
+	if holdTTL <= 0 {
+		holdTTL = defaultHoldTTL
+	}
+	return &accountUsecase{
+		accountRepo:     accountRepo,
+		transactionRepo: transactionRepo,
+		quoteRepo:       quoteRepo,
+		ledgerRepo:      ledgerRepo,
+		holdRepo:        holdRepo,
+		auditRepo:       auditRepo,
+		reviewRepo:      reviewRepo,
+		splitRepo:       splitRepo,
+		outboxRepo:      outboxRepo,
+		limits:          limits,
+		risk:            risk,
+		fees:            fees,
+		broadcaster:     broadcaster,
+		txManager:       txManager,
+		holdTTL:         holdTTL,
+	}
+}
+
+// Create validates input, checks for duplicates, and creates a new account.
+// Ensures no two accounts can have the same ID through database constraints.
+func (uc accountUsecase) Create(ctx context.Context, account dto.AccountDTO) (dto.AccountDTO, error) {
+	// Accounts opened without a currency keep the pre-multi-currency behaviour
+	if account.Currency == "" {
+		account.Currency = entity.DefaultCurrency
+	}
+	if account.Type == "" {
+		account.Type = entity.AccountChecking
+	}
+
+	// Validate input data according to business rules
+	err := account.Validate()
+	if err != nil {
+		fmt.Println("account validation failed", "error", err)
+		return dto.AccountDTO{}, apperr.ErrInvalidInput.WithError(err)
+	}
+
+	// Check for existing account to provide clear error message
+	existingAccount, err := uc.accountRepo.FindOne(ctx, account.AccountID)
+	if err != nil {
+		fmt.Println("failed to find account", "error", err)
+		return dto.AccountDTO{}, apperr.ErrNotFound.WithMessage("account not found")
+	}
+	if existingAccount != nil {
+		fmt.Println("account ID already exists", "account_id", account.AccountID)
+		return dto.AccountDTO{}, apperr.ErrAlreadyExists.WithMessage("account ID already exists")
+	}
+
+	ent := entity.Account{
+		ID:       account.AccountID,
+		Balance:  account.Balance,
+		Currency: account.Currency,
+		Type:     account.Type,
+		Status:   entity.AccountActive,
+	}
+
+	// The account row, the ledger entry funding its opening balance and the account.created
+	// event are written together
+	var createdAcc *entity.Account
+	err = uc.txManager.Do(ctx, func(ctx context.Context) error {
+		createdAcc, err = uc.accountRepo.Create(ctx, &ent)
+		if err != nil {
+			fmt.Println("failed to create account", "error", err)
+			return apperr.ErrInternalServer.WithError(err).WithMessage("failed to create account")
+		}
+		if err = uc.postEntry(ctx, entity.NewOpeningEntry(createdAcc)); err != nil {
+			return err
+		}
+		if err = uc.notifyAccount(ctx, createdAcc); err != nil {
+			return err
+		}
+		event, err := entity.NewAccountCreatedEvent(createdAcc)
+		return uc.recordEvent(ctx, event, err)
+	})
+	if err != nil {
+		return dto.AccountDTO{}, err
+	}
+
+	// A new account has no holds yet
+	return dto.AccountDTO{
+		AccountID:        createdAcc.ID,
+		Balance:          createdAcc.Balance,
+		Currency:         createdAcc.Currency,
+		Type:             createdAcc.Type,
+		AvailableBalance: createdAcc.Balance,
+		Status:           createdAcc.Status,
+	}, nil
+}
+
+// GetBalance returns account balance and details.
+// Provides point-in-time snapshot without locking.
+// Balance is the current (ledger) balance; AvailableBalance excludes funds reserved by open holds.
+func (uc accountUsecase) GetBalance(ctx context.Context, id uint64) (dto.AccountDTO, error) {
+	account, err := uc.accountRepo.FindOne(ctx, id)
+	if err != nil {
+		fmt.Println("failed to find account", "error", err)
+		return dto.AccountDTO{}, apperr.ErrNotFound.WithMessage("account not found")
+	}
+	if account == nil {
+		fmt.Println("account not found", "account_id", id)
+		return dto.AccountDTO{}, apperr.ErrNotFound.WithMessage("account not found")
+	}
+
+	available, err := uc.availableBalance(ctx, account, time.Now())
+	if err != nil {
+		return dto.AccountDTO{}, err
+	}
+
+	return dto.AccountDTO{
+		AccountID:        account.ID,
+		Balance:          account.Balance,
+		Currency:         account.Currency,
+		Type:             account.Type,
+		AvailableBalance: available,
+		Status:           account.Status,
+		OverdraftLimit:   account.OverdraftLimit,
+	}, nil
+}
+
+// SubscribeEvents subscribes to the activity of an account committed from now on.
+//
+// A client resuming with the ID of the last event it received first gets the events it missed.
+// When they are no longer all retained, or on a first connection, it gets the current balance
+// instead; missed transactions are then found in the transaction history.
+// The account is read after subscribing, so that no change falls between the balance and the stream.
+func (uc accountUsecase) SubscribeEvents(ctx context.Context, req dto.AccountEventsDTO,
+) ([]dto.AccountEventDTO, *AccountSubscription, error) {
+	sub, missed, complete, current := uc.broadcaster.Subscribe(req.AccountID, req.LastEventID)
+	account, err := uc.accountRepo.FindOne(ctx, req.AccountID)
+	if err != nil {
+		sub.Close()
+		fmt.Println("failed to find account", "error", err)
+		return nil, nil, apperr.ErrInternalServer.WithError(err).WithMessage("failed to find account")
+	}
+	if account == nil {
+		sub.Close()
+		fmt.Println("account not found", "account_id", req.AccountID)
+		return nil, nil, apperr.ErrNotFound.WithMessage("account not found")
+	}
+	if complete {
+		return missed, sub, nil
+	}
+
+	available, err := uc.availableBalance(ctx, account, time.Now())
+	if err != nil {
+		sub.Close()
+		return nil, nil, err
+	}
+	balance := dto.AccountEventDTO{ID: current, Type: dto.AccountEventBalance,
+		Data: toAccountBalanceEventDTO(account, available)}
+	return []dto.AccountEventDTO{balance}, sub, nil
+}
+
+// FreezeAccount freezes an active account. A frozen account keeps receiving money
+// but cannot be debited, so its funds stay in place until it is unfrozen or closed.
+func (uc accountUsecase) FreezeAccount(ctx context.Context, id uint64) (dto.AccountDTO, error) {
+	return uc.changeAccountStatus(ctx, id, entity.AccountFrozen)
+}
+
+// UnfreezeAccount makes a frozen account active again.
+func (uc accountUsecase) UnfreezeAccount(ctx context.Context, id uint64) (dto.AccountDTO, error) {
+	return uc.changeAccountStatus(ctx, id, entity.AccountActive)
+}
+
+// changeAccountStatus moves an account to a new status under the account lock,
+// so the change is serialized with transfers touching the account.
+func (uc accountUsecase) changeAccountStatus(ctx context.Context, id uint64, status entity.AccountStatus,
+) (dto.AccountDTO, error) {
+	var account *entity.Account
+	err := uc.txManager.Do(ctx, func(ctx context.Context) error {
+		var err error
+		account, err = uc.lockAccount(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		if err = account.TransitionTo(status); err != nil {
+			fmt.Println("invalid account status change", "account_id", id, "from", account.Status, "to", status)
+			return apperr.ErrInvalidInput.WithError(err).
+				WithMessage("account is " + string(account.Status) + " and cannot become " + string(status))
+		}
+		return uc.updateAccount(ctx, account)
+	})
+	if err != nil {
+		fmt.Println("account status change failed", "error", err)
+		return dto.AccountDTO{}, err
+	}
+
+	return uc.GetBalance(ctx, id)
+}
+
+// CloseAccount closes an account for good.
+//
+// Closing rules:
+// - The account must not have open holds
+// - A zero balance is closed as is
+// - A positive balance must be swept to an active account of the same currency, in the same DB transaction
+// - A frozen account can be closed only with a zero balance, since a sweep debits it
+func (uc accountUsecase) CloseAccount(ctx context.Context, req dto.CloseAccountDTO) (dto.AccountDTO, error) {
+	err := req.Validate()
+	if err != nil {
+		fmt.Println("close validation failed", "error", err)
+		return dto.AccountDTO{}, apperr.ErrInvalidInput.WithError(err).WithMessage(err.Error())
+	}
+
+	err = uc.txManager.Do(ctx, func(ctx context.Context) error {
+		var account, sweepAcc *entity.Account
+		if req.SweepAccountID != 0 {
+			// The sweep is a regular transfer, so both accounts must accept it
+			account, sweepAcc, err = uc.retrieveAccounts(ctx, req.AccountID, req.SweepAccountID)
+		} else {
+			account, err = uc.lockAccount(ctx, req.AccountID)
+		}
+		if err != nil {
+			return err
+		}
+		if account.Status == entity.AccountClosed {
+			fmt.Println("account already closed", "account_id", account.ID)
+			return apperr.ErrAccountClosed.WithMessage("account is already closed")
+		}
+
+		now := time.Now()
+		held, err := uc.holdRepo.SumActive(ctx, account.ID, now)
+		if err != nil {
+			fmt.Println("failed to sum holds", "error", err)
+			return apperr.ErrInternalServer.WithError(err).WithMessage("failed to read account holds")
+		}
+		if held.IsPositive() {
+			fmt.Println("account has open holds", "account_id", account.ID, "held", held)
+			return apperr.ErrInvalidInput.WithMessage("account has open holds; capture or void them first")
+		}
+
+		if account.Balance.IsNegative() {
+			fmt.Println("account balance is negative", "account_id", account.ID, "balance", account.Balance)
+			return apperr.ErrInvalidInput.WithMessage("account with a negative balance cannot be closed")
+		}
+		if account.Balance.IsPositive() {
+			if sweepAcc == nil {
+				fmt.Println("balance left on closing account", "account_id", account.ID, "balance", account.Balance)
+				return apperr.ErrInvalidInput.WithMessage("account balance must be zero or swept to another account")
+			}
+			if sweepAcc.Currency != account.Currency {
+				fmt.Println("sweep currency mismatch", "currency", account.Currency, "sweep_currency", sweepAcc.Currency)
+				return apperr.ErrCurrencyMismatch.WithMessage("sweep account must use the same currency")
+			}
+			sweep := &entity.Transaction{
+				SourceAccountID:      account.ID,
+				DestinationAccountID: sweepAcc.ID,
+				Amount:               account.Balance,
+				Currency:             account.Currency,
+				DestinationAmount:    account.Balance,
+				DestinationCurrency:  sweepAcc.Currency,
+				ExchangeRate:         entity.OneRate,
+				Status:               entity.TransactionPending,
+			}
+			if err = uc.doTransaction(ctx, account, sweepAcc, sweep); err != nil {
+				return err
+			}
+		}
+
+		if err = account.TransitionTo(entity.AccountClosed); err != nil {
+			fmt.Println("invalid account status change", "account_id", account.ID, "from", account.Status)
+			return apperr.ErrInvalidInput.WithError(err).WithMessage("account cannot be closed")
+		}
+		account.ClosedAt = &now
+		return uc.updateAccount(ctx, account)
+	})
+	if err != nil {
+		fmt.Println("account close failed", "error", err)
+		return dto.AccountDTO{}, err
+	}
+
+	return uc.GetBalance(ctx, req.AccountID)
+}
+
+// SetOverdraftLimit changes the overdraft limit of an account.
+//
+// The change and its audit record are written in the same DB transaction, under the account
+// lock, so every limit ever applied to a transfer has an audit record. Lowering the limit
+// below the current overdrawn amount is allowed: the account then only accepts credits
+// until it is back within the limit.
+func (uc accountUsecase) SetOverdraftLimit(ctx context.Context, req dto.OverdraftDTO) (dto.AccountDTO, error) {
+	err := req.Validate()
+	if err != nil {
+		fmt.Println("overdraft validation failed", "error", err)
+		return dto.AccountDTO{}, apperr.ErrInvalidInput.WithError(err).WithMessage(err.Error())
+	}
+
+	err = uc.txManager.Do(ctx, func(ctx context.Context) error {
+		account, err := uc.lockAccount(ctx, req.AccountID)
+		if err != nil {
+			return err
+		}
+		if account.Status == entity.AccountClosed {
+			fmt.Println("account is closed", "account_id", account.ID)
+			return apperr.ErrAccountClosed.WithMessage("account is closed")
+		}
+		if !account.Currency.Fits(*req.OverdraftLimit) {
+			fmt.Println("amount exceeds currency precision", "amount", *req.OverdraftLimit, "currency", account.Currency)
+			return apperr.ErrInvalidInput.WithMessage("overdraft limit has more decimal places than the account currency allows")
+		}
+
+		audit := &entity.AccountAudit{
+			AccountID: account.ID,
+			Field:     entity.AuditFieldOverdraftLimit,
+			OldValue:  account.OverdraftLimit.String(),
+			NewValue:  req.OverdraftLimit.String(),
+			ChangedBy: req.ChangedBy,
+			Reason:    req.Reason,
+			CreatedAt: time.Now(),
+		}
+		account.OverdraftLimit = *req.OverdraftLimit
+		if err = uc.updateAccount(ctx, account); err != nil {
+			return err
+		}
+
+		if _, err = uc.auditRepo.Create(ctx, audit); err != nil {
+			fmt.Println("failed to create account audit", "error", err)
+			return apperr.ErrInternalServer.WithError(err).WithMessage("failed to record account audit")
+		}
+		return nil
+	})
+	if err != nil {
+		fmt.Println("overdraft change failed", "error", err)
+		return dto.AccountDTO{}, err
+	}
+
+	return uc.GetBalance(ctx, req.AccountID)
+}
+
+// ListAccountAudits returns the audit trail of an account, oldest first.
+func (uc accountUsecase) ListAccountAudits(ctx context.Context, accountID uint64) ([]dto.AccountAuditDTO, error) {
+	account, err := uc.accountRepo.FindOne(ctx, accountID)
+	if err != nil {
+		fmt.Println("failed to find account", "error", err)
+		return nil, apperr.ErrInternalServer.WithError(err).WithMessage("failed to find account")
+	}
+	if account == nil {
+		fmt.Println("account not found", "account_id", accountID)
+		return nil, apperr.ErrNotFound.WithMessage("account not found")
+	}
+
+	audits, err := uc.auditRepo.FindByAccount(ctx, accountID)
+	if err != nil {
+		fmt.Println("failed to find account audits", "error", err)
+		return nil, apperr.ErrInternalServer.WithError(err).WithMessage("failed to find account audits")
+	}
+
+	res := make([]dto.AccountAuditDTO, 0, len(audits))
+	for _, audit := range audits {
+		res = append(res, dto.AccountAuditDTO{
+			AuditID:   audit.ID,
+			AccountID: audit.AccountID,
+			Field:     audit.Field,
+			OldValue:  audit.OldValue,
+			NewValue:  audit.NewValue,
+			ChangedBy: audit.ChangedBy,
+			Reason:    audit.Reason,
+			CreatedAt: audit.CreatedAt,
+		})
+	}
+	return res, nil
+}
+
+// lockAccount locks a single account for a status or settings change.
+func (uc accountUsecase) lockAccount(ctx context.Context, id uint64) (*entity.Account, error) {
+	accounts, err := uc.accountRepo.FindForUpdate(ctx, []uint64{id})
+	if err != nil {
+		fmt.Println("failed to query account for update", "error", err)
+		return nil, apperr.ErrInternalServer.WithError(err).WithMessage("failed to find account for update")
+	}
+	if len(accounts) == 0 {
+		fmt.Println("account not found", "account_id", id)
+		return nil, apperr.ErrNotFound.WithMessage("account not found")
+	}
+	return accounts[0], nil
+}
+
+// updateAccount persists an account change.
+func (uc accountUsecase) updateAccount(ctx context.Context, account *entity.Account) error {
+	if err := uc.accountRepo.Update(ctx, account); err != nil {
+		fmt.Println("failed to update account", "error", err)
+		return apperr.ErrInternalServer.WithError(err).WithMessage("failed to update account")
+	}
+	return uc.notifyAccount(ctx, account)
+}
+
+// MakeTransaction performs atomic money transfer with deadlock prevention.
+//
+// Uses atomic multi-row locking strategy to handle high concurrency:
+// - Locks both accounts simultaneously with single SELECT FOR UPDATE
+// - Prevents deadlocks that occur with sequential account locking
+// - Uses default READ COMMITTED isolation for optimal performance
+// - Validates business rules within transaction boundary, transfer limits included
+// - Charges the fee of the matching fee schedule on top of the amount, credited to the fee revenue account
+// - Runs the risk rules, which may refuse the transfer or record it as pending review
+// - Creates audit trail for all money movements
+func (uc accountUsecase) MakeTransaction(ctx context.Context, req dto.TransactionDTO) (dto.TransactionRecordDTO, error) {
+	if err := validateTransfer(req); err != nil {
+		return dto.TransactionRecordDTO{}, err
+	}
+
+	// Execute transaction with READ COMMITTED isolation
+	// SERIALIZABLE is not needed since we explicitly lock required rows in a single operation
+	var transaction *entity.Transaction
+	err := uc.txManager.Do(ctx, func(ctx context.Context) error {
+		// Lock both accounts atomically to prevent deadlocks
+		sourceAcc, destAcc, err := uc.retrieveAccounts(ctx, req.SourceAccountID, req.DestinationAccountID)
+		if err != nil {
+			return err
+		}
+
+		transaction, err = uc.transfer(ctx, req, sourceAcc, destAcc)
+		return err
+	})
+
+	if err != nil {
+		fmt.Println("transaction failed", "error", err)
+		return dto.TransactionRecordDTO{}, err
+	}
+
+	return toTransactionRecordDTO(transaction), nil
+}
+
+// validateTransfer checks a transfer request before any account is locked.
+func validateTransfer(req dto.TransactionDTO) error {
+	// Validate transaction data
+	err := req.Validate()
+	if err != nil {
+		fmt.Println("transaction validation failed", "error", err)
+		return apperr.ErrInvalidInput.WithError(err).WithMessage(err.Error())
+	}
+
+	// Prevent self-transfers (business rule)
+	if req.SourceAccountID == req.DestinationAccountID {
+		fmt.Println("source and destination accounts have the same ID")
+		return apperr.ErrInvalidInput.WithMessage("source and destination account IDs cannot be the same")
+	}
+	return nil
+}
+
+// transfer makes a transfer between two accounts locked by the caller.
+// The transfer is posted, or recorded as pending when the risk rules hold it for review.
+func (uc accountUsecase) transfer(ctx context.Context, req dto.TransactionDTO, sourceAcc *entity.Account,
+	destAcc *entity.Account) (*entity.Transaction, error) {
+	// Resolve currencies and the credited amount before any balance check
+	transaction, err := uc.buildTransaction(ctx, req, sourceAcc, destAcc)
+	if err != nil {
+		return nil, err
+	}
+
+	// The fee is debited on top of the amount, so funds are checked for both
+	if transaction.Fee, err = uc.fees.Calculate(transaction); err != nil {
+		return nil, err
+	}
+
+	// Validate business rules within transaction boundary
+	// Transfer limits are evaluated under the source account lock, on the amount without the fee
+	now := time.Now()
+	if err = uc.limits.Evaluate(ctx, transaction, now); err != nil {
+		return nil, err
+	}
+
+	// Funds reserved by open holds cannot be spent; the overdraft limit can
+	if err = uc.checkFunds(ctx, sourceAcc, transaction.TotalDebit(), entity.Money{}, now); err != nil {
+		return nil, err
+	}
+
+	// Risk rules run last, on a transfer that is otherwise allowed and funded
+	assessment, err := uc.risk.Evaluate(ctx, RiskInput{
+		Transaction: transaction,
+		Source:      sourceAcc,
+		Destination: destAcc,
+		Now:         now,
+	})
+	if err != nil {
+		fmt.Println("risk evaluation failed", "error", err)
+		return nil, apperr.ErrInternalServer.WithError(err).WithMessage("failed to evaluate transfer risk")
+	}
+	switch assessment.Decision {
+	case entity.RiskDecisionDeny:
+		fmt.Println("transfer denied", "rule", assessment.Rule, "reason", assessment.Reason)
+		return nil, apperr.ErrRiskDenied.WithMessage(assessment.Reason)
+	case entity.RiskDecisionReview:
+		return transaction, uc.holdForReview(ctx, transaction, assessment, now)
+	}
+
+	// Execute the money transfer
+	return transaction, uc.doTransaction(ctx, sourceAcc, destAcc, transaction)
+}
+
+// MakeBatchTransaction makes many transfers in one request.
+//
+// All accounts of the batch are locked up front in one FindForUpdate call, in ascending ID
+// order, so that two batches sharing accounts cannot deadlock. The transfers then run in
+// request order inside the same DB transaction:
+// - atomic: the first failing transfer rolls back the whole batch and is returned as the error
+// - best_effort: each transfer runs in its own savepoint; a failing one is rolled back and reported in its result
+func (uc accountUsecase) MakeBatchTransaction(ctx context.Context, req dto.BatchTransactionDTO,
+) (dto.BatchResultDTO, error) {
+	err := req.Validate()
+	if err != nil {
+		fmt.Println("batch validation failed", "error", err)
+		return dto.BatchResultDTO{}, apperr.ErrInvalidInput.WithError(err).WithMessage(err.Error())
+	}
+
+	// Invalid items fail the whole batch only in atomic mode
+	invalid := make([]error, len(req.Transactions))
+	for i, item := range req.Transactions {
+		invalid[i] = validateTransfer(item)
+		if invalid[i] != nil && req.Mode == dto.BatchAtomic {
+			return dto.BatchResultDTO{}, batchItemError(i, invalid[i])
+		}
+	}
+
+	res := dto.BatchResultDTO{Mode: req.Mode, Results: make([]dto.BatchItemResultDTO, len(req.Transactions))}
+	err = uc.txManager.Do(ctx, func(ctx context.Context) error {
+		accounts, err := uc.lockBatchAccounts(ctx, req.Transactions, invalid)
+		if err != nil {
+			return err
+		}
+
+		for i, item := range req.Transactions {
+			res.Results[i].Index = i
+			err = invalid[i]
+			var transaction *entity.Transaction
+			if err == nil {
+				transaction, err = uc.batchTransfer(ctx, item, accounts)
+			}
+			if err != nil {
+				if req.Mode == dto.BatchAtomic {
+					return batchItemError(i, err)
+				}
+				appErr := toAppError(err)
+				res.Results[i].Error = &appErr
+				res.Failed++
+				continue
+			}
+
+			record := toTransactionRecordDTO(transaction)
+			res.Results[i].Transaction = &record
+			res.Succeeded++
+		}
+		return nil
+	})
+	if err != nil {
+		fmt.Println("batch failed", "error", err)
+		return dto.BatchResultDTO{}, err
+	}
+
+	fmt.Println("batch done", "mode", req.Mode, "succeeded", res.Succeeded, "failed", res.Failed)
+	return res, nil
+}
+
+// lockBatchAccounts locks every account referenced by the valid items of a batch in a single
+// SELECT FOR UPDATE, with the IDs sorted, and returns them by ID.
+func (uc accountUsecase) lockBatchAccounts(ctx context.Context, items []dto.TransactionDTO, invalid []error,
+) (map[uint64]*entity.Account, error) {
+	seen := make(map[uint64]bool)
+	var ids []uint64
+	for i, item := range items {
+		if invalid[i] != nil {
+			continue
+		}
+		for _, id := range []uint64{item.SourceAccountID, item.DestinationAccountID} {
+			if !seen[id] {
+				seen[id] = true
+				ids = append(ids, id)
+			}
+		}
+	}
+	if len(ids) == 0 {
+		return map[uint64]*entity.Account{}, nil
+	}
+	return uc.lockAccountSet(ctx, ids)
+}
+
+// lockAccountSet locks a set of accounts with the strategy of retrieveAccounts: a single
+// SELECT FOR UPDATE over all of them, here with the IDs sorted. Accounts that do not
+// exist are missing from the returned map.
+func (uc accountUsecase) lockAccountSet(ctx context.Context, ids []uint64) (map[uint64]*entity.Account, error) {
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+	found, err := uc.accountRepo.FindForUpdate(ctx, ids)
+	if err != nil {
+		fmt.Println("failed to query accounts for update", "error", err)
+		return nil, apperr.ErrInternalServer.WithError(err).WithMessage("failed to find accounts for update")
+	}
+
+	accounts := make(map[uint64]*entity.Account, len(found))
+	for _, acc := range found {
+		accounts[acc.ID] = acc
+	}
+	return accounts, nil
+}
+
+// batchTransfer makes one transfer of a batch between accounts already locked.
+//
+// The transfer runs in a nested transaction, i.e. a savepoint, so a failure undoes its
+// writes without touching the rest of the batch. The in-memory accounts are restored too,
+// since later transfers of the batch keep working on them.
+func (uc accountUsecase) batchTransfer(ctx context.Context, req dto.TransactionDTO,
+	accounts map[uint64]*entity.Account) (*entity.Transaction, error) {
+	sourceAcc, destAcc := accounts[req.SourceAccountID], accounts[req.DestinationAccountID]
+	if sourceAcc == nil || destAcc == nil {
+		fmt.Println("accounts not found", "source_account_id", req.SourceAccountID,
+			"destination_account_id", req.DestinationAccountID)
+		return nil, apperr.ErrNotFound.WithMessage("account not found")
+	}
+	if err := checkTransferAccounts(sourceAcc, destAcc); err != nil {
+		return nil, err
+	}
+
+	sourceSnapshot, destSnapshot := *sourceAcc, *destAcc
+	var transaction *entity.Transaction
+	err := uc.txManager.Do(ctx, func(ctx context.Context) error {
+		var err error
+		transaction, err = uc.transfer(ctx, req, sourceAcc, destAcc)
+		return err
+	})
+	if err != nil {
+		*sourceAcc, *destAcc = sourceSnapshot, destSnapshot
+		return nil, err
+	}
+	return transaction, nil
+}
+
+// batchItemError prefixes the message of a failing batch item with its index.
+func batchItemError(index int, err error) error {
+	appErr := toAppError(err)
+	return appErr.WithMessage(fmt.Sprintf("transaction %d: %s", index, appErr.Message))
+}
+
+// toAppError returns err as an AppError, wrapping unexpected errors as internal errors.
+func toAppError(err error) apperr.AppError {
+	var appErr apperr.AppError
+	if errors.As(err, &appErr) {
+		return appErr
+	}
+	return apperr.ErrInternalServer.WithError(err).WithMessage("internal error")
+}
+
+// MakeSplitPayment debits one source account once and credits several destination accounts.
+//
+// Rules:
+// - Destinations are distinct and differ from the source
+// - Every account uses the source currency; split payments do not convert
+// - The legs add up exactly to the total amount
+// - Limits and funds are checked once, on the total; risk rules run on every leg
+//
+// All accounts are locked in one SELECT FOR UPDATE. The parent split payment is recorded
+// first and each leg is a transaction linked to it. Split payments are never held for review:
+// a leg a risk rule would hold refuses the whole payment, so that no leg is posted without the others.
+func (uc accountUsecase) MakeSplitPayment(ctx context.Context, req dto.SplitPaymentDTO,
+) (dto.SplitPaymentResultDTO, error) {
+	err := req.Validate()
+	if err != nil {
+		fmt.Println("split payment validation failed", "error", err)
+		return dto.SplitPaymentResultDTO{}, apperr.ErrInvalidInput.WithError(err).WithMessage(err.Error())
+	}
+
+	ids := []uint64{req.SourceAccountID}
+	seen := map[uint64]bool{req.SourceAccountID: true}
+	var total entity.Money
+	for _, leg := range req.Legs {
+		if seen[leg.DestinationAccountID] {
+			fmt.Println("duplicate split payment account", "account_id", leg.DestinationAccountID)
+			return dto.SplitPaymentResultDTO{}, apperr.ErrInvalidInput.WithMessage(
+				fmt.Sprintf("account %d appears more than once in the split payment", leg.DestinationAccountID))
+		}
+		seen[leg.DestinationAccountID] = true
+		ids = append(ids, leg.DestinationAccountID)
+		total = total.Add(leg.Amount)
+	}
+	if total != req.Amount {
+		fmt.Println("split payment legs do not add up", "total", req.Amount, "legs", total)
+		return dto.SplitPaymentResultDTO{}, apperr.ErrInvalidInput.WithMessage(
+			fmt.Sprintf("legs add up to %s, not to the amount %s", total, req.Amount))
+	}
+
+	var (
+		split *entity.SplitPayment
+		legs  []*entity.Transaction
+	)
+	err = uc.txManager.Do(ctx, func(ctx context.Context) error {
+		accounts, err := uc.lockAccountSet(ctx, ids)
+		if err != nil {
+			return err
+		}
+		if len(accounts) < len(ids) {
+			fmt.Println("accounts not found for update")
+			return apperr.ErrNotFound.WithMessage("account not found")
+		}
+
+		sourceAcc := accounts[req.SourceAccountID]
+		legs, err = buildSplitLegs(req, sourceAcc, accounts)
+		if err != nil {
+			return err
+		}
+
+		// Limits see the split payment as a single transfer of the total
+		now := time.Now()
+		debit := &entity.Transaction{SourceAccountID: sourceAcc.ID, Amount: req.Amount, Currency: sourceAcc.Currency}
+		if err = uc.limits.Evaluate(ctx, debit, now); err != nil {
+			return err
+		}
+		if err = uc.checkFunds(ctx, sourceAcc, req.Amount, entity.Money{}, now); err != nil {
+			return err
+		}
+
+		if err = uc.assessSplitLegs(ctx, sourceAcc, legs, accounts, now); err != nil {
+			return err
+		}
+
+		split, err = uc.splitRepo.Create(ctx, &entity.SplitPayment{
+			SourceAccountID: sourceAcc.ID,
+			Amount:          req.Amount,
+			Currency:        sourceAcc.Currency,
+			CreatedAt:       now,
+		})
+		if err != nil {
+			fmt.Println("failed to create split payment", "error", err)
+			return apperr.ErrInternalServer.WithError(err).WithMessage("failed to create split payment")
+		}
+		for _, leg := range legs {
+			leg.SplitPaymentID = &split.ID
+		}
+		return uc.doSplitPayment(ctx, sourceAcc, legs, accounts)
+	})
+	if err != nil {
+		fmt.Println("split payment failed", "error", err)
+		return dto.SplitPaymentResultDTO{}, err
+	}
+
+	res := dto.SplitPaymentResultDTO{
+		SplitPaymentID:  split.ID,
+		SourceAccountID: split.SourceAccountID,
+		Amount:          split.Amount,
+		Currency:        split.Currency,
+		Status:          legs[0].Status,
+		Legs:            make([]dto.TransactionRecordDTO, 0, len(legs)),
+		CreatedAt:       split.CreatedAt,
+	}
+	for _, leg := range legs {
+		res.Legs = append(res.Legs, toTransactionRecordDTO(leg))
+	}
+	return res, nil
+}
+
+// buildSplitLegs checks the accounts of a split payment and builds one pending transaction per leg.
+func buildSplitLegs(req dto.SplitPaymentDTO, sourceAcc *entity.Account, accounts map[uint64]*entity.Account,
+) ([]*entity.Transaction, error) {
+	if req.Currency != "" && req.Currency != sourceAcc.Currency {
+		fmt.Println("amount currency does not match source account", "currency", req.Currency, "account_currency", sourceAcc.Currency)
+		return nil, apperr.ErrCurrencyMismatch.WithMessage("amount currency does not match source account currency")
+	}
+
+	legs := make([]*entity.Transaction, 0, len(req.Legs))
+	for _, leg := range req.Legs {
+		destAcc := accounts[leg.DestinationAccountID]
+		if err := checkTransferAccounts(sourceAcc, destAcc); err != nil {
+			return nil, err
+		}
+		if destAcc.Currency != sourceAcc.Currency {
+			fmt.Println("split payment across currencies", "account_id", destAcc.ID, "currency", destAcc.Currency)
+			return nil, apperr.ErrCurrencyMismatch.WithMessage(
+				fmt.Sprintf("account %d uses %s; split payments cannot convert currencies", destAcc.ID, destAcc.Currency))
+		}
+		if !sourceAcc.Currency.Fits(leg.Amount) {
+			fmt.Println("amount exceeds currency precision", "amount", leg.Amount, "currency", sourceAcc.Currency)
+			return nil, apperr.ErrInvalidInput.WithMessage("leg amount has more decimal places than the source account currency allows")
+		}
+
+		legs = append(legs, &entity.Transaction{
+			SourceAccountID:      sourceAcc.ID,
+			DestinationAccountID: destAcc.ID,
+			Amount:               leg.Amount,
+			Currency:             sourceAcc.Currency,
+			DestinationAmount:    leg.Amount,
+			DestinationCurrency:  destAcc.Currency,
+			ExchangeRate:         entity.OneRate,
+			Status:               entity.TransactionPending,
+		})
+	}
+	return legs, nil
+}
+
+// assessSplitLegs runs the risk rules on every leg.
+// A leg denied or sent to review refuses the whole split payment: its legs are posted together
+// or not at all, and a review of one leg could not hold back the others.
+func (uc accountUsecase) assessSplitLegs(ctx context.Context, sourceAcc *entity.Account, legs []*entity.Transaction,
+	accounts map[uint64]*entity.Account, now time.Time) error {
+	for _, leg := range legs {
+		assessment, err := uc.risk.Evaluate(ctx, RiskInput{
+			Transaction: leg,
+			Source:      sourceAcc,
+			Destination: accounts[leg.DestinationAccountID],
+			Now:         now,
+		})
+		if err != nil {
+			fmt.Println("risk evaluation failed", "error", err)
+			return apperr.ErrInternalServer.WithError(err).WithMessage("failed to evaluate transfer risk")
+		}
+		switch assessment.Decision {
+		case entity.RiskDecisionDeny:
+			fmt.Println("split payment denied", "rule", assessment.Rule, "reason", assessment.Reason)
+			return apperr.ErrRiskDenied.WithMessage(assessment.Reason)
+		case entity.RiskDecisionReview:
+			fmt.Println("split payment denied for review", "rule", assessment.Rule, "reason", assessment.Reason)
+			return apperr.ErrRiskDenied.WithMessage(
+				"split payments cannot be held for review: " + assessment.Reason)
+		}
+	}
+	return nil
+}
+
+// doSplitPayment posts the legs of a split payment.
+// The source account is debited the total and updated once; each leg is recorded, booked in
+// the ledger with its own journal entry, published with its own transfer.posted event and
+// credited to its destination account.
+func (uc accountUsecase) doSplitPayment(ctx context.Context, sourceAccount *entity.Account,
+	legs []*entity.Transaction, accounts map[uint64]*entity.Account) error {
+	now := time.Now()
+	for _, leg := range legs {
+		if err := leg.TransitionTo(entity.TransactionPosted); err != nil {
+			fmt.Println("cannot post transaction", "status", leg.Status)
+			return apperr.ErrInternalServer.WithError(err).WithMessage("failed to post transaction")
+		}
+		leg.TransactionTime = now
+		leg.UpdatedAt = now
+		if _, err := uc.transactionRepo.Create(ctx, leg); err != nil {
+			fmt.Println("transaction failed", "error", err)
+			return apperr.ErrInternalServer.WithError(err).WithMessage("failed to create transaction")
+		}
+		if err := uc.postEntry(ctx, entity.NewTransferEntry(leg)); err != nil {
+			return err
+		}
+		event, err := entity.NewTransferPostedEvent(leg)
+		if err = uc.recordEvent(ctx, event, err); err != nil {
+			return err
+		}
+
+		sourceAccount.Balance = sourceAccount.Balance.Sub(leg.Amount)
+		destinationAccount := accounts[leg.DestinationAccountID]
+		destinationAccount.Balance = destinationAccount.Balance.Add(leg.DestinationAmount)
+		if err := uc.accountRepo.Update(ctx, destinationAccount); err != nil {
+			fmt.Println("failed to update destination account", "error", err)
+			return apperr.ErrInternalServer.WithError(err).WithMessage("failed to update destination account")
+		}
+		uc.notifyTransaction(ctx, leg)
+		if err := uc.notifyAccount(ctx, destinationAccount); err != nil {
+			return err
+		}
+	}
+
+	if err := uc.accountRepo.Update(ctx, sourceAccount); err != nil {
+		fmt.Println("failed to update source account", "error", err)
+		return apperr.ErrInternalServer.WithError(err).WithMessage("failed to update source account")
+	}
+	return uc.notifyAccount(ctx, sourceAccount)
+}
+
+// GetTransaction retrieves a transaction by ID.
+func (uc accountUsecase) GetTransaction(ctx context.Context, id uint64) (dto.TransactionRecordDTO, error) {
+	transaction, err := uc.transactionRepo.FindOne(ctx, id)
+	if err != nil {
+		fmt.Println("failed to find transaction", "error", err)
+		return dto.TransactionRecordDTO{}, apperr.ErrInternalServer.WithError(err).WithMessage("failed to find transaction")
+	}
+	if transaction == nil {
+		fmt.Println("transaction not found", "transaction_id", id)
+		return dto.TransactionRecordDTO{}, apperr.ErrNotFound.WithMessage("transaction not found")
+	}
+
+	return toTransactionRecordDTO(transaction), nil
+}
+
+// ListTransactions lists the transfers an account sent or received.
+//
+// Pagination is keyset based rather than offset based:
+// - Rows are ordered by (transaction_time, id) descending
+// - The cursor encodes the position of the last row of a page
+// - The next page starts strictly after that position, so new rows never shift pages
+// - Deep pages cost the same as the first one
+func (uc accountUsecase) ListTransactions(ctx context.Context, req dto.TransactionListDTO,
+) ([]dto.TransactionRecordDTO, dto.PageMetaDTO, error) {
+	err := req.Validate()
+	if err != nil {
+		fmt.Println("transaction list validation failed", "error", err)
+		return nil, dto.PageMetaDTO{}, apperr.ErrInvalidInput.WithError(err).WithMessage(err.Error())
+	}
+	if req.From != nil && req.To != nil && !req.From.Before(*req.To) {
+		return nil, dto.PageMetaDTO{}, apperr.ErrInvalidInput.WithMessage("from must be before to")
+	}
+	if req.MinAmount != nil && req.MaxAmount != nil && req.MinAmount.GreaterThan(*req.MaxAmount) {
+		return nil, dto.PageMetaDTO{}, apperr.ErrInvalidInput.WithMessage("min_amount must not exceed max_amount")
+	}
+
+	limit := req.Limit
+	if limit <= 0 {
+		limit = defaultHistoryLimit
+	}
+	if limit > maxHistoryLimit {
+		limit = maxHistoryLimit
+	}
+
+	filter := entity.TransactionFilter{
+		AccountID: req.AccountID,
+		Direction: req.Direction,
+		From:      req.From,
+		To:        req.To,
+		MinAmount: req.MinAmount,
+		MaxAmount: req.MaxAmount,
+		// One extra row tells whether another page follows
+		Limit: limit + 1,
+	}
+	if req.Cursor != "" {
+		cursor, err := decodeHistoryCursor(req.Cursor)
+		if err != nil {
+			fmt.Println("invalid history cursor", "error", err)
+			return nil, dto.PageMetaDTO{}, apperr.ErrInvalidInput.WithError(err).WithMessage("invalid cursor")
+		}
+		filter.AfterTime = &cursor.Time
+		filter.AfterID = cursor.ID
+	}
+
+	account, err := uc.accountRepo.FindOne(ctx, req.AccountID)
+	if err != nil {
+		fmt.Println("failed to find account", "error", err)
+		return nil, dto.PageMetaDTO{}, apperr.ErrInternalServer.WithError(err).WithMessage("failed to find account")
+	}
+	if account == nil {
+		fmt.Println("account not found", "account_id", req.AccountID)
+		return nil, dto.PageMetaDTO{}, apperr.ErrNotFound.WithMessage("account not found")
+	}
+
+	transactions, err := uc.transactionRepo.FindByAccount(ctx, filter)
+	if err != nil {
+		fmt.Println("failed to list transactions", "error", err)
+		return nil, dto.PageMetaDTO{}, apperr.ErrInternalServer.WithError(err).WithMessage("failed to list transactions")
+	}
+
+	meta := dto.PageMetaDTO{Limit: limit}
+	if len(transactions) > limit {
+		transactions = transactions[:limit]
+		last := transactions[limit-1]
+		meta.HasMore = true
+		meta.NextCursor = encodeHistoryCursor(historyCursor{Time: last.TransactionTime, ID: last.ID})
+	}
+
+	res := make([]dto.TransactionRecordDTO, 0, len(transactions))
+	for _, tx := range transactions {
+		record := toTransactionRecordDTO(tx)
+		record.Direction = entity.TransactionIncoming
+		if tx.SourceAccountID == req.AccountID {
+			record.Direction = entity.TransactionOutgoing
+		}
+		res = append(res, record)
+	}
+
+	return res, meta, nil
+}
+
+// historyCursor is the keyset position of the last transaction of a history page.
+type historyCursor struct {
+	Time time.Time `json:"t"`
+	ID   uint64    `json:"id"`
+}
+
+// encodeHistoryCursor makes a cursor opaque to clients.
+func encodeHistoryCursor(c historyCursor) string {
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// decodeHistoryCursor parses a cursor produced by encodeHistoryCursor.
+func decodeHistoryCursor(s string) (historyCursor, error) {
+	var c historyCursor
+	data, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return c, err
+	}
+	if err = json.Unmarshal(data, &c); err != nil {
+		return c, err
+	}
+	if c.ID == 0 || c.Time.IsZero() {
+		return c, fmt.Errorf("incomplete cursor")
+	}
+	return c, nil
+}
+
+// toTransactionRecordDTO maps a transaction to its API representation.
+func toTransactionRecordDTO(tx *entity.Transaction) dto.TransactionRecordDTO {
+	return dto.TransactionRecordDTO{
+		TransactionID:         tx.ID,
+		SourceAccountID:       tx.SourceAccountID,
+		DestinationAccountID:  tx.DestinationAccountID,
+		Amount:                tx.Amount,
+		Currency:              tx.Currency,
+		DestinationAmount:     tx.DestinationAmount,
+		DestinationCurrency:   tx.DestinationCurrency,
+		ExchangeRate:          tx.ExchangeRate,
+		OriginalTransactionID: tx.OriginalTransactionID,
+		SplitPaymentID:        tx.SplitPaymentID,
+		Fee:                   toFeeDTO(tx),
+		Status:                tx.Status,
+		TransactionTime:       tx.TransactionTime,
+		UpdatedAt:             tx.UpdatedAt,
+	}
+}
+
+// toFeeDTO maps the fee breakdown of a transaction, nil when it was free.
+func toFeeDTO(tx *entity.Transaction) *dto.FeeDTO {
+	if tx.Fee.Amount.IsZero() {
+		return nil
+	}
+	return &dto.FeeDTO{
+		Schedule:     tx.Fee.Schedule,
+		Fixed:        tx.Fee.Fixed,
+		Percentage:   tx.Fee.Percentage,
+		Amount:       tx.Fee.Amount,
+		Currency:     tx.Currency,
+		TotalDebited: tx.TotalDebit(),
+	}
+}
+
+// AuthorizeTransaction reserves the amount of a transfer, and its fee, on the source account.
+//
+// The hold is created under the same account lock as transfers, so the available balance
+// and the transfer limits checked here cannot be used up concurrently. The transfer itself
+// only happens on capture.
+// Holds follow the currency rules of transfers without an FX quote: both accounts must
+// use the same currency. A transfer denied by risk rules cannot be authorized; one they
+// send to review is authorized, and its capture is held for review instead of posted.
+func (uc accountUsecase) AuthorizeTransaction(ctx context.Context, req dto.AuthorizeDTO) (dto.HoldDTO, error) {
+	err := req.Validate()
+	if err != nil {
+		fmt.Println("authorization validation failed", "error", err)
+		return dto.HoldDTO{}, apperr.ErrInvalidInput.WithError(err).WithMessage(err.Error())
+	}
+
+	if req.SourceAccountID == req.DestinationAccountID {
+		fmt.Println("source and destination accounts have the same ID")
+		return dto.HoldDTO{}, apperr.ErrInvalidInput.WithMessage("source and destination account IDs cannot be the same")
+	}
+
+	var hold *entity.Hold
+	err = uc.txManager.Do(ctx, func(ctx context.Context) error {
+		sourceAcc, destAcc, err := uc.retrieveAccounts(ctx, req.SourceAccountID, req.DestinationAccountID)
+		if err != nil {
+			return err
+		}
+
+		transaction, err := uc.buildTransaction(ctx, dto.TransactionDTO{
+			SourceAccountID:      req.SourceAccountID,
+			DestinationAccountID: req.DestinationAccountID,
+			Amount:               req.Amount,
+			Currency:             req.Currency,
+		}, sourceAcc, destAcc)
+		if err != nil {
+			return err
+		}
+
+		// The fee is reserved with the amount, so that the capture can charge it
+		if transaction.Fee, err = uc.fees.Calculate(transaction); err != nil {
+			return err
+		}
+
+		// Limits apply when the hold is authorized, and the hold counts towards them until it closes
+		now := time.Now()
+		if err = uc.limits.Evaluate(ctx, transaction, now); err != nil {
+			return err
+		}
+		if err = uc.checkFunds(ctx, sourceAcc, transaction.TotalDebit(), entity.Money{}, now); err != nil {
+			return err
+		}
+
+		hold = &entity.Hold{
+			SourceAccountID:      transaction.SourceAccountID,
+			DestinationAccountID: transaction.DestinationAccountID,
+			Amount:               transaction.Amount,
+			Currency:             transaction.Currency,
+			Fee:                  transaction.Fee,
+			Status:               entity.HoldAuthorized,
+			ExpiresAt:            now.Add(uc.holdTTL),
+			CreatedAt:            now,
+			UpdatedAt:            now,
+		}
+
+		// Risk rules run on the transfer the hold reserves funds for, as on any transfer
+		assessment, err := uc.risk.Evaluate(ctx, RiskInput{
+			Transaction: transaction,
+			Source:      sourceAcc,
+			Destination: destAcc,
+			Now:         now,
+		})
+		if err != nil {
+			fmt.Println("risk evaluation failed", "error", err)
+			return apperr.ErrInternalServer.WithError(err).WithMessage("failed to evaluate transfer risk")
+		}
+		switch assessment.Decision {
+		case entity.RiskDecisionDeny:
+			fmt.Println("authorization denied", "rule", assessment.Rule, "reason", assessment.Reason)
+			return apperr.ErrRiskDenied.WithMessage(assessment.Reason)
+		case entity.RiskDecisionReview:
+			fmt.Println("hold flagged for review", "rule", assessment.Rule, "reason", assessment.Reason)
+			hold.RiskRule = assessment.Rule
+			hold.RiskReason = assessment.Reason
+		}
+
+		hold, err = uc.holdRepo.Create(ctx, hold)
+		if err != nil {
+			fmt.Println("failed to create hold", "error", err)
+			return apperr.ErrInternalServer.WithError(err).WithMessage("failed to create hold")
+		}
+		// The hold lowers the available balance: a new version of the account is streamed
+		return uc.updateAccount(ctx, sourceAcc)
+	})
+	if err != nil {
+		fmt.Println("authorization failed", "error", err)
+		return dto.HoldDTO{}, err
+	}
+
+	return toHoldDTO(hold, time.Now()), nil
+}
+
+// CaptureHold settles an authorized hold as a transfer.
+//
+// Capture rules:
+// - The hold must still be authorized and not expired
+// - The captured amount defaults to the held amount and may be lower, never higher
+// - Any uncaptured remainder is released; a hold is captured at most once
+// - The fee is charged on the captured amount, never more than the fee reserved at authorization
+// - A hold flagged by risk rules at authorization creates a transfer pending review, posted on release
+// - The transfer, its ledger entry and the hold update share one DB transaction
+func (uc accountUsecase) CaptureHold(ctx context.Context, req dto.CaptureDTO) (dto.HoldDTO, error) {
+	err := req.Validate()
+	if err != nil {
+		fmt.Println("capture validation failed", "error", err)
+		return dto.HoldDTO{}, apperr.ErrInvalidInput.WithError(err).WithMessage(err.Error())
+	}
+
+	var hold *entity.Hold
+	err = uc.txManager.Do(ctx, func(ctx context.Context) error {
+		now := time.Now()
+		hold, err = uc.lockOpenHold(ctx, req.HoldID, now)
+		if err != nil {
+			return err
+		}
+
+		amount := hold.Amount
+		if req.Amount != nil {
+			amount = *req.Amount
+		}
+		if amount.GreaterThan(hold.Amount) {
+			fmt.Println("capture exceeds hold", "hold_id", hold.ID, "amount", amount, "held", hold.Amount)
+			return apperr.ErrInvalidInput.WithMessage("capture amount exceeds the held amount")
+		}
+		if !hold.Currency.Fits(amount) {
+			fmt.Println("amount exceeds currency precision", "amount", amount, "currency", hold.Currency)
+			return apperr.ErrInvalidInput.WithMessage("amount has more decimal places than the hold currency allows")
+		}
+
+		sourceAcc, destAcc, err := uc.retrieveAccounts(ctx, hold.SourceAccountID, hold.DestinationAccountID)
+		if err != nil {
+			return err
+		}
+
+		transaction := &entity.Transaction{
+			SourceAccountID:      sourceAcc.ID,
+			DestinationAccountID: destAcc.ID,
+			Amount:               amount,
+			Currency:             hold.Currency,
+			DestinationAmount:    amount,
+			DestinationCurrency:  destAcc.Currency,
+			ExchangeRate:         entity.OneRate,
+			Status:               entity.TransactionPending,
+		}
+		if transaction.Fee, err = uc.captureFee(transaction, hold); err != nil {
+			return err
+		}
+
+		// The funds reserved by this hold are released by the capture itself
+		if err = uc.checkFunds(ctx, sourceAcc, transaction.TotalDebit(), hold.Reserved(), now); err != nil {
+			return err
+		}
+		// The hold is closed first, so that the available balance streamed with the transfer no longer counts it
+		hold.Status = entity.HoldCaptured
+		hold.CapturedAmount = amount
+		hold.UpdatedAt = now
+		if err = uc.updateHold(ctx, hold); err != nil {
+			return err
+		}
+		if hold.NeedsReview() {
+			err = uc.holdForReview(ctx, transaction, hold.RiskAssessment(), now)
+			if err == nil {
+				// The balance only changes on release, but the funds of the hold are no longer reserved
+				err = uc.updateAccount(ctx, sourceAcc)
+			}
+		} else {
+			err = uc.doTransaction(ctx, sourceAcc, destAcc, transaction)
+		}
+		if err != nil {
+			return err
+		}
+
+		hold.TransactionID = &transaction.ID
+		return uc.updateHold(ctx, hold)
+	})
+	if err != nil {
+		fmt.Println("capture failed", "error", err)
+		return dto.HoldDTO{}, err
+	}
+
+	return toHoldDTO(hold, time.Now()), nil
+}
+
+// captureFee prices the capture of a hold. The fee is computed for the captured amount with the
+// current schedules, and capped at the fee reserved at authorization, which the payer agreed to.
+func (uc accountUsecase) captureFee(transaction *entity.Transaction, hold *entity.Hold) (entity.FeeBreakdown, error) {
+	fee, err := uc.fees.Calculate(transaction)
+	if err != nil {
+		return entity.FeeBreakdown{}, err
+	}
+	if fee.Amount.GreaterThan(hold.Fee.Amount) {
+		return hold.Fee, nil
+	}
+	return fee, nil
+}
+
+// VoidHold releases an authorized hold, making its funds available again.
+func (uc accountUsecase) VoidHold(ctx context.Context, holdID uint64) (dto.HoldDTO, error) {
+	var hold *entity.Hold
+	err := uc.txManager.Do(ctx, func(ctx context.Context) error {
+		now := time.Now()
+		var err error
+		hold, err = uc.lockOpenHold(ctx, holdID, now)
+		if err != nil {
+			return err
+		}
+		// The account is locked after the hold, in the order captures lock them
+		account, err := uc.lockAccount(ctx, hold.SourceAccountID)
+		if err != nil {
+			return err
+		}
+
+		hold.Status = entity.HoldVoided
+		hold.UpdatedAt = now
+		if err = uc.updateHold(ctx, hold); err != nil {
+			return err
+		}
+		// The released funds are available again: a new version of the account is streamed
+		return uc.updateAccount(ctx, account)
+	})
+	if err != nil {
+		fmt.Println("void failed", "error", err)
+		return dto.HoldDTO{}, err
+	}
+
+	return toHoldDTO(hold, time.Now()), nil
 }
 
-type accountUsecase struct {
-	accountRepo     repository.AccountRepository
-	transactionRepo repository.TransactionRepository
-	txManager       trm.Manager
-}
+// ReverseTransaction undoes a transfer, fully or partially, with a linked reversal transfer.
+//
+// Reversal rules:
+// - Money flows back from the original destination to the original source account
+// - The amount is in the original transaction currency and defaults to everything not yet reversed
+// - Only posted transactions can be reversed; one fully reversed becomes reversed
+// - All reversals of a transaction together never exceed its amount
+// - Cross-currency transfers are reversed at their original rate
+// - The final reversal debits exactly what is left of the credited amount, so rounding leaves no residue
+// - A reversal itself cannot be reversed
+func (uc accountUsecase) ReverseTransaction(ctx context.Context, req dto.ReversalDTO) (dto.ReversalResultDTO, error) {
+	err := req.Validate()
+	if err != nil {
+		fmt.Println("reversal validation failed", "error", err)
+		return dto.ReversalResultDTO{}, apperr.ErrInvalidInput.WithError(err).WithMessage(err.Error())
+	}
+
+	var res dto.ReversalResultDTO
+	err = uc.txManager.Do(ctx, func(ctx context.Context) error {
+		// Lock the original first so concurrent reversals are checked one after another
+		original, err := uc.transactionRepo.FindForUpdate(ctx, req.TransactionID)
+		if err != nil {
+			fmt.Println("failed to find transaction", "error", err)
+			return apperr.ErrInternalServer.WithError(err).WithMessage("failed to find transaction")
+		}
+		if original == nil {
+			fmt.Println("transaction not found", "transaction_id", req.TransactionID)
+			return apperr.ErrNotFound.WithMessage("transaction not found")
+		}
+		if original.OriginalTransactionID != nil {
+			fmt.Println("cannot reverse a reversal", "transaction_id", original.ID)
+			return apperr.ErrInvalidInput.WithMessage("a reversal cannot be reversed")
+		}
+		if original.Status != entity.TransactionPosted {
+			fmt.Println("transaction is not posted", "transaction_id", original.ID, "status", original.Status)
+			return apperr.ErrInvalidInput.WithMessage("only posted transactions can be reversed, transaction is " + string(original.Status))
+		}
+
+		reversal, remaining, err := uc.buildReversal(ctx, original, req.Amount)
+		if err != nil {
+			return err
+		}
 
-func NewAccountUsecase(
-	accountRepo repository.AccountRepository,
-	transactionRepo repository.TransactionRepository,
-	txManager trm.Manager) AccountUC {
-	return &accountUsecase{
-		accountRepo:     accountRepo,
-		transactionRepo: transactionRepo,
-		txManager:       txManager,
+		sourceAcc, destAcc, err := uc.retrieveAccounts(ctx, reversal.SourceAccountID, reversal.DestinationAccountID)
+		if err != nil {
+			return err
+		}
+
+		if err = uc.checkFunds(ctx, sourceAcc, reversal.Amount, entity.Money{}, time.Now()); err != nil {
+			return err
+		}
+
+		if err = uc.doTransaction(ctx, sourceAcc, destAcc, reversal); err != nil {
+			return err
+		}
+
+		res = dto.ReversalResultDTO{
+			TransactionID:         reversal.ID,
+			OriginalTransactionID: original.ID,
+			Amount:                reversal.DestinationAmount,
+			Currency:              reversal.DestinationCurrency,
+			RemainingAmount:       remaining.Sub(reversal.DestinationAmount),
+		}
+		if res.RemainingAmount.IsPositive() {
+			return nil
+		}
+
+		// Nothing is left to reverse: the original transaction is now reversed
+		if err = original.TransitionTo(entity.TransactionReversed); err != nil {
+			fmt.Println("cannot mark transaction reversed", "transaction_id", original.ID, "status", original.Status)
+			return apperr.ErrInternalServer.WithError(err).WithMessage("failed to mark transaction reversed")
+		}
+		original.UpdatedAt = reversal.TransactionTime
+		if err = uc.transactionRepo.Update(ctx, original); err != nil {
+			fmt.Println("failed to update transaction", "error", err)
+			return apperr.ErrInternalServer.WithError(err).WithMessage("failed to update transaction")
+		}
+		uc.notifyTransaction(ctx, original)
+		return nil
+	})
+	if err != nil {
+		fmt.Println("reversal failed", "error", err)
+		return dto.ReversalResultDTO{}, err
 	}
+
+	return res, nil
 }
 
-// Create validates input, checks for duplicates, and creates a new account.
-// Ensures no two accounts can have the same ID through database constraints.
-func (uc accountUsecase) Create(ctx context.Context, account dto.AccountDTO) (dto.AccountDTO, error) {
-	// Validate input data according to business rules
-	err := account.Validate()
+// buildReversal computes the reversal transfer of original for the requested amount,
+// and returns it together with the amount that was still reversible before it.
+func (uc accountUsecase) buildReversal(ctx context.Context, original *entity.Transaction, requested *entity.Money,
+) (*entity.Transaction, entity.Money, error) {
+	reversals, err := uc.transactionRepo.FindReversals(ctx, original.ID)
 	if err != nil {
-		fmt.Println("account validation failed", "error", err)
-		return dto.AccountDTO{}, apperr.ErrInvalidInput.WithError(err)
+		fmt.Println("failed to find reversals", "error", err)
+		return nil, entity.Money{}, apperr.ErrInternalServer.WithError(err).WithMessage("failed to find reversals")
 	}
 
-	// Check for existing account to provide clear error message
-	existingAccount, err := uc.accountRepo.FindOne(ctx, account.AccountID)
-	if err != nil {
-		fmt.Println("failed to find account", "error", err)
-		return dto.AccountDTO{}, apperr.ErrNotFound.WithMessage("account not found")
+	// A reversal credits the original source (DestinationAmount) and debits the original destination (Amount)
+	var reversed, reversedDest entity.Money
+	for _, r := range reversals {
+		reversed = reversed.Add(r.DestinationAmount)
+		reversedDest = reversedDest.Add(r.Amount)
 	}
-	if existingAccount != nil {
-		fmt.Println("account ID already exists", "account_id", account.AccountID)
-		return dto.AccountDTO{}, apperr.ErrAlreadyExists.WithMessage("account ID already exists")
+	remaining := original.Amount.Sub(reversed)
+	remainingDest := original.DestinationAmount.Sub(reversedDest)
+
+	if !remaining.IsPositive() {
+		fmt.Println("transaction already fully reversed", "transaction_id", original.ID)
+		return nil, entity.Money{}, apperr.ErrInvalidInput.WithMessage("transaction is already fully reversed")
 	}
 
-	ent := entity.Account{
-		ID:      account.AccountID,
-		Balance: account.Balance,
+	amount := remaining
+	if requested != nil {
+		amount = *requested
+	}
+	if amount.GreaterThan(remaining) {
+		fmt.Println("reversal exceeds remaining amount", "transaction_id", original.ID, "amount", amount, "remaining", remaining)
+		return nil, entity.Money{}, apperr.ErrInvalidInput.WithMessage("reversal amount exceeds the amount not yet reversed")
+	}
+	if !original.Currency.Fits(amount) {
+		fmt.Println("amount exceeds currency precision", "amount", amount, "currency", original.Currency)
+		return nil, entity.Money{}, apperr.ErrInvalidInput.WithMessage("amount has more decimal places than the transaction currency allows")
+	}
+
+	reversal := &entity.Transaction{
+		SourceAccountID:       original.DestinationAccountID,
+		DestinationAccountID:  original.SourceAccountID,
+		Amount:                remainingDest,
+		Currency:              original.DestinationCurrency,
+		DestinationAmount:     amount,
+		DestinationCurrency:   original.Currency,
+		ExchangeRate:          entity.OneRate,
+		OriginalTransactionID: &original.ID,
+		Status:                entity.TransactionPending,
+	}
+	if original.Currency == original.DestinationCurrency {
+		reversal.Amount = amount
+		return reversal, remaining, nil
 	}
-	createdAcc, err := uc.accountRepo.Create(ctx, &ent)
+
+	reversal.ExchangeRate, err = original.ExchangeRate.Inverse()
 	if err != nil {
-		fmt.Println("failed to create account", "error", err)
-		return dto.AccountDTO{}, apperr.ErrInternalServer.WithError(err).WithMessage("failed to create account")
+		fmt.Println("invalid original rate", "transaction_id", original.ID, "rate", original.ExchangeRate)
+		return nil, entity.Money{}, apperr.ErrInternalServer.WithError(err).WithMessage("failed to compute reversal rate")
+	}
+	if amount != remaining {
+		debit, err := original.DestinationCurrency.Convert(amount, original.ExchangeRate)
+		if err != nil || !debit.IsPositive() {
+			fmt.Println("invalid converted amount", "amount", amount, "rate", original.ExchangeRate, "error", err)
+			return nil, entity.Money{}, apperr.ErrInvalidInput.WithMessage("reversal amount is too small to convert")
+		}
+		if debit.LessThan(remainingDest) {
+			reversal.Amount = debit
+		}
 	}
+	return reversal, remaining, nil
+}
 
-	return dto.AccountDTO{
-		AccountID: createdAcc.ID,
-		Balance:   createdAcc.Balance,
-	}, nil
+// holdForReview records a transfer as pending with an open risk review.
+// Balances and the ledger are left untouched until the review is released.
+func (uc accountUsecase) holdForReview(ctx context.Context, transaction *entity.Transaction,
+	assessment entity.RiskAssessment, now time.Time) error {
+	fmt.Println("transfer held for review", "rule", assessment.Rule, "reason", assessment.Reason)
+
+	transaction.TransactionTime = now
+	transaction.UpdatedAt = now
+	if _, err := uc.transactionRepo.Create(ctx, transaction); err != nil {
+		fmt.Println("transaction failed", "error", err)
+		return apperr.ErrInternalServer.WithError(err).WithMessage("failed to create transaction")
+	}
+	uc.notifyTransaction(ctx, transaction)
+
+	_, err := uc.reviewRepo.Create(ctx, &entity.RiskReview{
+		TransactionID: transaction.ID,
+		Rule:          assessment.Rule,
+		Reason:        assessment.Reason,
+		Status:        entity.RiskReviewOpen,
+		CreatedAt:     now,
+	})
+	if err != nil {
+		fmt.Println("failed to create risk review", "error", err)
+		return apperr.ErrInternalServer.WithError(err).WithMessage("failed to create risk review")
+	}
+	return nil
 }
 
-// GetBalance returns account balance and details.
-// Provides point-in-time snapshot without locking.
-func (uc accountUsecase) GetBalance(ctx *gin.Context, id uint64) (dto.AccountDTO, error) {
-	account, err := uc.accountRepo.FindOne(ctx, id)
+// ListRiskReviews lists risk reviews, open ones by default.
+func (uc accountUsecase) ListRiskReviews(ctx context.Context, req dto.RiskReviewListDTO) ([]dto.RiskReviewDTO, error) {
+	err := req.Validate()
 	if err != nil {
-		fmt.Println("failed to find account", "error", err)
-		return dto.AccountDTO{}, apperr.ErrNotFound.WithMessage("account not found")
+		fmt.Println("review list validation failed", "error", err)
+		return nil, apperr.ErrInvalidInput.WithError(err).WithMessage(err.Error())
 	}
-	if account == nil {
-		fmt.Println("account not found", "account_id", id)
-		return dto.AccountDTO{}, apperr.ErrNotFound.WithMessage("account not found")
+
+	status := req.Status
+	if status == "" {
+		status = entity.RiskReviewOpen
+	}
+	limit := req.Limit
+	if limit == 0 {
+		limit = defaultReviewLimit
 	}
 
-	return dto.AccountDTO{
-		AccountID: account.ID,
-		Balance:   account.Balance,
-	}, nil
+	reviews, err := uc.reviewRepo.FindByStatus(ctx, status, limit)
+	if err != nil {
+		fmt.Println("failed to find risk reviews", "error", err)
+		return nil, apperr.ErrInternalServer.WithError(err).WithMessage("failed to find risk reviews")
+	}
+
+	res := make([]dto.RiskReviewDTO, 0, len(reviews))
+	for _, review := range reviews {
+		res = append(res, dto.RiskReviewDTO{
+			ReviewID:      review.ID,
+			TransactionID: review.TransactionID,
+			Rule:          review.Rule,
+			Reason:        review.Reason,
+			Status:        review.Status,
+			DecidedBy:     review.DecidedBy,
+			Note:          review.DecisionNote,
+			DecidedAt:     review.DecidedAt,
+			CreatedAt:     review.CreatedAt,
+		})
+	}
+	return res, nil
 }
 
-// MakeTransaction performs atomic money transfer with deadlock prevention.
+// ReleaseTransaction posts a transfer held for risk review.
 //
-// Uses atomic multi-row locking strategy to handle high concurrency:
-// - Locks both accounts simultaneously with single SELECT FOR UPDATE
-// - Prevents deadlocks that occur with sequential account locking
-// - Uses default READ COMMITTED isolation for optimal performance
-// - Validates business rules within transaction boundary
-// - Creates audit trail for all money movements
-func (uc accountUsecase) MakeTransaction(ctx *gin.Context, req dto.TransactionDTO) error {
-	// Validate transaction data
+// Funds are not reserved while a transfer waits for review, so the account status and
+// funds checks run again as of the release. Limits and risk rules are not evaluated
+// again: the review is the decision on them.
+func (uc accountUsecase) ReleaseTransaction(ctx context.Context, req dto.ReviewDecisionDTO,
+) (dto.TransactionRecordDTO, error) {
 	err := req.Validate()
 	if err != nil {
-		fmt.Println("transaction validation failed", "error", err)
-		return apperr.ErrInvalidInput.WithError(err).WithMessage(err.Error())
+		fmt.Println("review decision validation failed", "error", err)
+		return dto.TransactionRecordDTO{}, apperr.ErrInvalidInput.WithError(err).WithMessage(err.Error())
 	}
 
-	// Prevent self-transfers (business rule)
-	if req.SourceAccountID == req.DestinationAccountID {
-		fmt.Println("source and destination accounts have the same ID")
-		return apperr.ErrInvalidInput.WithMessage("source and destination account IDs cannot be the same")
+	var transaction *entity.Transaction
+	err = uc.txManager.Do(ctx, func(ctx context.Context) error {
+		var review *entity.RiskReview
+		transaction, review, err = uc.lockPendingReview(ctx, req.TransactionID)
+		if err != nil {
+			return err
+		}
+
+		sourceAcc, destAcc, err := uc.retrieveAccounts(ctx, transaction.SourceAccountID, transaction.DestinationAccountID)
+		if err != nil {
+			return err
+		}
+		if err = uc.checkFunds(ctx, sourceAcc, transaction.TotalDebit(), entity.Money{}, time.Now()); err != nil {
+			return err
+		}
+		if err = uc.doTransaction(ctx, sourceAcc, destAcc, transaction); err != nil {
+			return err
+		}
+
+		return uc.decideReview(ctx, review, entity.RiskReviewReleased, req)
+	})
+	if err != nil {
+		fmt.Println("release failed", "error", err)
+		return dto.TransactionRecordDTO{}, err
 	}
 
-	// Execute transaction with READ COMMITTED isolation
-	// SERIALIZABLE is not needed since we explicitly lock required rows in a single operation
+	return toTransactionRecordDTO(transaction), nil
+}
+
+// RejectTransaction fails a transfer held for risk review. No money moves.
+func (uc accountUsecase) RejectTransaction(ctx context.Context, req dto.ReviewDecisionDTO,
+) (dto.TransactionRecordDTO, error) {
+	err := req.Validate()
+	if err != nil {
+		fmt.Println("review decision validation failed", "error", err)
+		return dto.TransactionRecordDTO{}, apperr.ErrInvalidInput.WithError(err).WithMessage(err.Error())
+	}
+
+	var transaction *entity.Transaction
 	err = uc.txManager.Do(ctx, func(ctx context.Context) error {
-		// Lock both accounts atomically to prevent deadlocks
-		sourceAcc, destAcc, err := uc.retrieveAccounts(ctx, req.SourceAccountID, req.DestinationAccountID)
+		var review *entity.RiskReview
+		transaction, review, err = uc.lockPendingReview(ctx, req.TransactionID)
 		if err != nil {
 			return err
 		}
 
-		// Validate business rules within transaction boundary
-		if sourceAcc.Balance < req.Amount {
-			fmt.Println("insufficient balance", "account_id", sourceAcc.ID, "balance", sourceAcc.Balance, "required", req.Amount)
-			return apperr.ErrInvalidInput.WithMessage("insufficient balance")
+		if err = transaction.TransitionTo(entity.TransactionFailed); err != nil {
+			fmt.Println("cannot fail transaction", "transaction_id", transaction.ID, "status", transaction.Status)
+			return apperr.ErrInternalServer.WithError(err).WithMessage("failed to reject transaction")
+		}
+		transaction.UpdatedAt = time.Now()
+		if err = uc.transactionRepo.Update(ctx, transaction); err != nil {
+			fmt.Println("failed to update transaction", "error", err)
+			return apperr.ErrInternalServer.WithError(err).WithMessage("failed to update transaction")
+		}
+		event, err := entity.NewTransferFailedEvent(transaction, "rejected by risk review")
+		if err = uc.recordEvent(ctx, event, err); err != nil {
+			return err
 		}
+		uc.notifyTransaction(ctx, transaction)
 
-		// Execute the money transfer
-		return uc.doTransaction(ctx, sourceAcc, destAcc, req.Amount)
+		return uc.decideReview(ctx, review, entity.RiskReviewRejected, req)
 	})
+	if err != nil {
+		fmt.Println("reject failed", "error", err)
+		return dto.TransactionRecordDTO{}, err
+	}
+
+	return toTransactionRecordDTO(transaction), nil
+}
 
+// lockPendingReview locks a transaction held for review and returns it with its open review.
+// The transaction row is locked before any account, like reversals.
+func (uc accountUsecase) lockPendingReview(ctx context.Context, transactionID uint64,
+) (*entity.Transaction, *entity.RiskReview, error) {
+	transaction, err := uc.transactionRepo.FindForUpdate(ctx, transactionID)
+	if err != nil {
+		fmt.Println("failed to find transaction", "error", err)
+		return nil, nil, apperr.ErrInternalServer.WithError(err).WithMessage("failed to find transaction")
+	}
+	if transaction == nil {
+		fmt.Println("transaction not found", "transaction_id", transactionID)
+		return nil, nil, apperr.ErrNotFound.WithMessage("transaction not found")
+	}
+
+	review, err := uc.reviewRepo.FindByTransaction(ctx, transactionID)
+	if err != nil {
+		fmt.Println("failed to find risk review", "error", err)
+		return nil, nil, apperr.ErrInternalServer.WithError(err).WithMessage("failed to find risk review")
+	}
+	if review == nil || review.Status != entity.RiskReviewOpen || transaction.Status != entity.TransactionPending {
+		fmt.Println("transaction is not pending review", "transaction_id", transactionID, "status", transaction.Status)
+		return nil, nil, apperr.ErrInvalidInput.WithMessage("transaction is not pending review")
+	}
+	return transaction, review, nil
+}
+
+// decideReview closes a risk review with the operator's decision.
+func (uc accountUsecase) decideReview(ctx context.Context, review *entity.RiskReview, status entity.RiskReviewStatus,
+	req dto.ReviewDecisionDTO) error {
+	now := time.Now()
+	review.Status = status
+	review.DecidedBy = req.DecidedBy
+	review.DecisionNote = req.Note
+	review.DecidedAt = &now
+	if err := uc.reviewRepo.Update(ctx, review); err != nil {
+		fmt.Println("failed to update risk review", "error", err)
+		return apperr.ErrInternalServer.WithError(err).WithMessage("failed to update risk review")
+	}
+	return nil
+}
+
+// lockOpenHold locks a hold and checks that it can still be captured or voided.
+// Holds are locked before accounts, never after, so capture cannot deadlock with transfers.
+func (uc accountUsecase) lockOpenHold(ctx context.Context, holdID uint64, now time.Time) (*entity.Hold, error) {
+	hold, err := uc.holdRepo.FindForUpdate(ctx, holdID)
+	if err != nil {
+		fmt.Println("failed to find hold", "error", err)
+		return nil, apperr.ErrInternalServer.WithError(err).WithMessage("failed to find hold")
+	}
+	if hold == nil {
+		fmt.Println("hold not found", "hold_id", holdID)
+		return nil, apperr.ErrNotFound.WithMessage("hold not found")
+	}
+	if hold.Status != entity.HoldAuthorized {
+		fmt.Println("hold is not authorized", "hold_id", holdID, "status", hold.Status)
+		return nil, apperr.ErrHoldClosed.WithMessage("hold is already " + string(hold.Status))
+	}
+	if hold.IsExpired(now) {
+		fmt.Println("hold expired", "hold_id", holdID, "expires_at", hold.ExpiresAt)
+		return nil, apperr.ErrHoldExpired.WithMessage("hold has expired")
+	}
+	return hold, nil
+}
+
+// updateHold persists a hold state change.
+func (uc accountUsecase) updateHold(ctx context.Context, hold *entity.Hold) error {
+	if err := uc.holdRepo.Update(ctx, hold); err != nil {
+		fmt.Println("failed to update hold", "error", err)
+		return apperr.ErrInternalServer.WithError(err).WithMessage("failed to update hold")
+	}
+	return nil
+}
+
+// availableBalance returns the balance of an account minus the funds reserved by its open holds.
+// Holds past their expiry no longer count, so they expire without any cleanup job.
+func (uc accountUsecase) availableBalance(ctx context.Context, account *entity.Account, now time.Time,
+) (entity.Money, error) {
+	return availableBalance(ctx, uc.holdRepo, account, now)
+}
+
+// availableBalance is the balance of an account minus the funds reserved by its open holds.
+func availableBalance(ctx context.Context, holdRepo repository.HoldRepository, account *entity.Account,
+	now time.Time) (entity.Money, error) {
+	held, err := holdRepo.SumActive(ctx, account.ID, now)
+	if err != nil {
+		fmt.Println("failed to sum holds", "error", err)
+		return entity.Money{}, apperr.ErrInternalServer.WithError(err).WithMessage("failed to read account holds")
+	}
+	return account.Balance.Sub(held), nil
+}
+
+// checkFunds checks that an account can be debited by amount.
+//
+// Funds rules:
+// - Funds reserved by open holds cannot be spent, except those of the hold being captured (released)
+// - The balance may go below zero down to the account's overdraft limit
+// - On failure the error carries the shortfall in the account currency
+func (uc accountUsecase) checkFunds(ctx context.Context, account *entity.Account, amount entity.Money,
+	released entity.Money, now time.Time) error {
+	available, err := uc.availableBalance(ctx, account, now)
 	if err != nil {
-		fmt.Println("transaction failed", "error", err)
 		return err
 	}
 
+	spendable := available.Add(released).Add(account.OverdraftLimit)
+	if spendable.LessThan(amount) {
+		shortfall := amount.Sub(spendable)
+		fmt.Println("insufficient funds", "account_id", account.ID, "spendable", spendable, "required", amount)
+		return apperr.ErrInsufficientFunds.WithMessage(
+			fmt.Sprintf("insufficient funds: %s %s short", shortfall, account.Currency))
+	}
 	return nil
 }
 
+// toHoldDTO maps a hold to its API representation.
+// An authorized hold past its expiry is reported as expired.
+func toHoldDTO(hold *entity.Hold, now time.Time) dto.HoldDTO {
+	status := hold.Status
+	if status == entity.HoldAuthorized && hold.IsExpired(now) {
+		status = entity.HoldExpired
+	}
+	return dto.HoldDTO{
+		HoldID:               hold.ID,
+		SourceAccountID:      hold.SourceAccountID,
+		DestinationAccountID: hold.DestinationAccountID,
+		Amount:               hold.Amount,
+		Fee:                  hold.Fee.Amount,
+		CapturedAmount:       hold.CapturedAmount,
+		Currency:             hold.Currency,
+		Status:               status,
+		TransactionID:        hold.TransactionID,
+		ReviewRequired:       hold.NeedsReview(),
+		ExpiresAt:            hold.ExpiresAt,
+	}
+}
+
 // retrieveAccounts locks both accounts atomically to prevent deadlocks.
 //
 // Deadlock Prevention Strategy:
@@ -156,6 +1850,9 @@ func (uc accountUsecase) MakeTransaction(ctx *gin.Context, req dto.TransactionDT
 // - Avoids sequential locking which can cause circular wait conditions
 // - Database locks both rows in consistent order regardless of parameter order
 // - Returns error if either account doesn't exist
+//
+// Once locked, the accounts are checked against their status: the source must be
+// active and the destination must not be closed.
 func (uc accountUsecase) retrieveAccounts(ctx context.Context, sourceAccID uint64, destAccID uint64,
 ) (*entity.Account, *entity.Account, error) {
 	var (
@@ -186,38 +1883,176 @@ func (uc accountUsecase) retrieveAccounts(ctx context.Context, sourceAccID uint6
 		}
 	}
 
+	if err = checkTransferAccounts(sourceAccount, destAccount); err != nil {
+		return nil, nil, err
+	}
+
 	return sourceAccount, destAccount, nil
 }
 
+// checkTransferAccounts checks that the statuses of both accounts allow a transfer between them.
+func checkTransferAccounts(sourceAccount *entity.Account, destAccount *entity.Account) error {
+	if !sourceAccount.CanDebit() {
+		fmt.Println("source account cannot be debited", "account_id", sourceAccount.ID, "status", sourceAccount.Status)
+		if sourceAccount.Status == entity.AccountFrozen {
+			return apperr.ErrAccountFrozen.WithMessage("source account is frozen")
+		}
+		return apperr.ErrAccountClosed.WithMessage("source account is " + string(sourceAccount.Status))
+	}
+	if !destAccount.CanCredit() {
+		fmt.Println("destination account cannot be credited", "account_id", destAccount.ID, "status", destAccount.Status)
+		return apperr.ErrAccountClosed.WithMessage("destination account is " + string(destAccount.Status))
+	}
+	return nil
+}
+
+// buildTransaction resolves the currencies of a transfer and the amount credited
+// to the destination account.
+//
+// Currency rules:
+// - The requested currency, when given, must match the source account currency
+// - The amount must fit the minor unit of the source account currency
+// - Cross-currency transfers require an FX quote; same-currency ones must not carry one
+func (uc accountUsecase) buildTransaction(
+	ctx context.Context,
+	req dto.TransactionDTO,
+	sourceAccount *entity.Account,
+	destinationAccount *entity.Account,
+) (*entity.Transaction, error) {
+	if req.Currency != "" && req.Currency != sourceAccount.Currency {
+		fmt.Println("amount currency does not match source account", "currency", req.Currency, "account_currency", sourceAccount.Currency)
+		return nil, apperr.ErrCurrencyMismatch.WithMessage("amount currency does not match source account currency")
+	}
+	if !sourceAccount.Currency.Fits(req.Amount) {
+		fmt.Println("amount exceeds currency precision", "amount", req.Amount, "currency", sourceAccount.Currency)
+		return nil, apperr.ErrInvalidInput.WithMessage("amount has more decimal places than the source account currency allows")
+	}
+
+	transaction := &entity.Transaction{
+		SourceAccountID:      sourceAccount.ID,
+		DestinationAccountID: destinationAccount.ID,
+		Amount:               req.Amount,
+		Currency:             sourceAccount.Currency,
+		DestinationAmount:    req.Amount,
+		DestinationCurrency:  destinationAccount.Currency,
+		ExchangeRate:         entity.OneRate,
+		Status:               entity.TransactionPending,
+	}
+
+	if sourceAccount.Currency == destinationAccount.Currency {
+		if req.QuoteID != "" {
+			fmt.Println("quote given for same-currency transfer", "currency", sourceAccount.Currency)
+			return nil, apperr.ErrInvalidInput.WithMessage("FX quote is only allowed for cross-currency transfers")
+		}
+		return transaction, nil
+	}
+
+	// Never convert implicitly: the caller has to ask for the conversion with a quote
+	if req.QuoteID == "" {
+		fmt.Println("cross-currency transfer without conversion", "source_currency", sourceAccount.Currency,
+			"destination_currency", destinationAccount.Currency)
+		return nil, apperr.ErrCurrencyMismatch.WithMessage("source and destination accounts use different currencies; an FX quote is required")
+	}
+
+	quote, err := uc.consumeQuote(ctx, req.QuoteID, sourceAccount.Currency, destinationAccount.Currency)
+	if err != nil {
+		return nil, err
+	}
+
+	destAmount, err := destinationAccount.Currency.Convert(req.Amount, quote.Rate)
+	if err != nil || !destAmount.IsPositive() {
+		fmt.Println("invalid converted amount", "amount", req.Amount, "rate", quote.Rate, "error", err)
+		return nil, apperr.ErrInvalidInput.WithMessage("converted amount is out of range")
+	}
+	transaction.DestinationAmount = destAmount
+	transaction.ExchangeRate = quote.Rate
+	transaction.QuoteID = &quote.ID
+
+	return transaction, nil
+}
+
+// consumeQuote locks an FX quote and marks it used.
+// Runs inside the transfer's DB transaction, so a failed transfer leaves the quote unused.
+func (uc accountUsecase) consumeQuote(
+	ctx context.Context,
+	quoteID string,
+	sourceCurrency entity.Currency,
+	destinationCurrency entity.Currency,
+) (*entity.FXQuote, error) {
+	quote, err := uc.quoteRepo.FindForUpdate(ctx, quoteID)
+	if err != nil {
+		fmt.Println("failed to find quote", "error", err)
+		return nil, apperr.ErrInternalServer.WithError(err).WithMessage("failed to find FX quote")
+	}
+	if quote == nil {
+		fmt.Println("quote not found", "quote_id", quoteID)
+		return nil, apperr.ErrNotFound.WithMessage("FX quote not found")
+	}
+	if quote.SourceCurrency != sourceCurrency || quote.DestinationCurrency != destinationCurrency {
+		fmt.Println("quote currency pair mismatch", "quote_id", quoteID)
+		return nil, apperr.ErrCurrencyMismatch.WithMessage("FX quote does not match the account currencies")
+	}
+	if quote.IsUsed() {
+		fmt.Println("quote already used", "quote_id", quoteID)
+		return nil, apperr.ErrQuoteUsed.WithMessage("FX quote has already been used")
+	}
+
+	now := time.Now()
+	if quote.IsExpired(now) {
+		fmt.Println("quote expired", "quote_id", quoteID, "expires_at", quote.ExpiresAt)
+		return nil, apperr.ErrQuoteExpired.WithMessage("FX quote has expired")
+	}
+
+	quote.UsedAt = &now
+	if err = uc.quoteRepo.Update(ctx, quote); err != nil {
+		fmt.Println("failed to mark quote used", "error", err)
+		return nil, apperr.ErrInternalServer.WithError(err).WithMessage("failed to use FX quote")
+	}
+
+	return quote, nil
+}
+
 // doTransaction updates account balances and creates transaction log record.
 // Operations performed atomically within the same database transaction:
-// - Debits/credits accounts
-// - Creates transaction record for audit trail
+// - Debits the source account in its currency, fee included, and credits the destination in its currency
+// - Creates transaction record for audit trail, already posted, or posts the pending record of a released transfer
+// - Writes the balanced journal entry and postings for the movement
+// - Records the transfer.posted event in the outbox
 func (uc accountUsecase) doTransaction(
 	ctx context.Context,
 	sourceAccount *entity.Account,
 	destinationAccount *entity.Account,
-	amount float64,
+	transaction *entity.Transaction,
 ) error {
 	// Update account balances in memory
-	sourceAccount.Balance -= amount
-	destinationAccount.Balance += amount
+	sourceAccount.Balance = sourceAccount.Balance.Sub(transaction.TotalDebit())
+	destinationAccount.Balance = destinationAccount.Balance.Add(transaction.DestinationAmount)
 
-	// Create transaction record for audit trail
-	transaction := entity.Transaction{
-		SourceAccountID:      sourceAccount.ID,
-		DestinationAccountID: destinationAccount.ID,
-		Amount:               amount,
-		TransactionTime:      time.Now(),
+	if err := transaction.TransitionTo(entity.TransactionPosted); err != nil {
+		fmt.Println("cannot post transaction", "status", transaction.Status)
+		return apperr.ErrInternalServer.WithError(err).WithMessage("failed to post transaction")
 	}
+	transaction.UpdatedAt = time.Now()
 
 	// Save transaction record first for audit trail
-	_, err := uc.transactionRepo.Create(ctx, &transaction)
+	// A transfer released from review was recorded when submitted and is only posted now
+	var err error
+	if transaction.ID == 0 {
+		transaction.TransactionTime = transaction.UpdatedAt
+		_, err = uc.transactionRepo.Create(ctx, transaction)
+	} else {
+		err = uc.transactionRepo.Update(ctx, transaction)
+	}
 	if err != nil {
 		fmt.Println("transaction failed", "error", err)
 		return apperr.ErrInternalServer.WithError(err).WithMessage("failed to create transaction")
 	}
 
+	// Book the movement in the double-entry ledger
+	if err = uc.postEntry(ctx, entity.NewTransferEntry(transaction)); err != nil {
+		return err
+	}
+
 	// Persist account balance changes
 	// Both updates occur within same DB transaction ensuring atomicity
 	if err = uc.accountRepo.Update(ctx, sourceAccount); err != nil {
@@ -230,5 +2065,94 @@ func (uc accountUsecase) doTransaction(
 		return apperr.ErrInternalServer.WithError(err).WithMessage("failed to update destination account")
 	}
 
+	uc.notifyTransaction(ctx, transaction)
+	if err = uc.notifyAccount(ctx, sourceAccount); err != nil {
+		return err
+	}
+	if err = uc.notifyAccount(ctx, destinationAccount); err != nil {
+		return err
+	}
+	event, err := entity.NewTransferPostedEvent(transaction)
+	return uc.recordEvent(ctx, event, err)
+}
+
+// postEntry checks that a journal entry balances and persists it with its postings.
+// Account balances must only change together with a balanced entry, so that they
+// can always be reconciled against the ledger.
+func (uc accountUsecase) postEntry(ctx context.Context, entry *entity.JournalEntry) error {
+	return postJournalEntry(ctx, uc.ledgerRepo, entry)
+}
+
+// postJournalEntry validates and persists a journal entry for the usecases that move balances.
+func postJournalEntry(ctx context.Context, ledgerRepo repository.LedgerRepository, entry *entity.JournalEntry) error {
+	if err := entry.Validate(); err != nil {
+		fmt.Println("journal entry validation failed", "error", err)
+		return apperr.ErrInternalServer.WithError(err).WithMessage("journal entry does not balance")
+	}
+
+	if _, err := ledgerRepo.CreateEntry(ctx, entry); err != nil {
+		fmt.Println("failed to create journal entry", "error", err)
+		return apperr.ErrInternalServer.WithError(err).WithMessage("failed to create journal entry")
+	}
+
+	return nil
+}
+
+// notifyAccount broadcasts the state of an account to its activity streams once the change commits.
+func (uc accountUsecase) notifyAccount(ctx context.Context, account *entity.Account) error {
+	return notifyAccountState(ctx, uc.holdRepo, uc.broadcaster, account)
+}
+
+// notifyAccountState broadcasts the state of an account, available balance included, to its activity
+// streams once the change commits. It is called within the DB transaction, after the account was
+// updated and its holds changed: the state is read now, since later changes in the same transaction
+// may modify the account.
+func notifyAccountState(ctx context.Context, holdRepo repository.HoldRepository, broadcaster *AccountBroadcaster,
+	account *entity.Account) error {
+	available, err := availableBalance(ctx, holdRepo, account, time.Now())
+	if err != nil {
+		return err
+	}
+	event := toAccountBalanceEventDTO(account, available)
+	txhook.AfterCommit(ctx, func() {
+		broadcaster.PublishBalance(event)
+	})
+	return nil
+}
+
+// notifyTransaction broadcasts a new or updated transaction to the activity streams of both of its
+// accounts once it commits.
+func (uc accountUsecase) notifyTransaction(ctx context.Context, transaction *entity.Transaction) {
+	record := toTransactionRecordDTO(transaction)
+	txhook.AfterCommit(ctx, func() {
+		uc.broadcaster.Publish(record.SourceAccountID, dto.AccountEventTransaction, record)
+		uc.broadcaster.Publish(record.DestinationAccountID, dto.AccountEventTransaction, record)
+	})
+}
+
+func toAccountBalanceEventDTO(account *entity.Account, available entity.Money) dto.AccountBalanceEventDTO {
+	return dto.AccountBalanceEventDTO{
+		AccountID:        account.ID,
+		Version:          account.Version,
+		Balance:          account.Balance,
+		AvailableBalance: available,
+		Currency:         account.Currency,
+		Status:           account.Status,
+		OverdraftLimit:   account.OverdraftLimit,
+	}
+}
+
+// recordEvent writes a domain event to the outbox. It must be called within the transaction
+// of the change the event describes, so that the event is published if and only if the change
+// is committed. encodeErr is the error of the constructor of the event, returned first.
+func (uc accountUsecase) recordEvent(ctx context.Context, event *entity.OutboxEvent, encodeErr error) error {
+	if encodeErr != nil {
+		fmt.Println("failed to encode event", "error", encodeErr)
+		return apperr.ErrInternalServer.WithError(encodeErr).WithMessage("failed to encode event")
+	}
+	if err := uc.outboxRepo.Create(ctx, event); err != nil {
+		fmt.Println("failed to record event", "type", event.Type, "error", err)
+		return apperr.ErrInternalServer.WithError(err).WithMessage("failed to record event")
+	}
 	return nil
 }
@@ -2,12 +2,17 @@ package usecase
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"reflect"
 	"testing"
+	"time"
 
+	"transaction_demo/app/apperr"
+	"transaction_demo/app/config"
 	"transaction_demo/app/domain/entity"
 	mock2 "transaction_demo/cmd/shared/db/mock"
+	"transaction_demo/cmd/shared/db/txhook"
 
 	"github.com/gin-gonic/gin"
 	"github.com/golang/mock/gomock"
@@ -19,9 +24,19 @@ import (
 type fields struct {
 	accountRepo     *mock.MockAccountRepository
 	transactionRepo *mock.MockTransactionRepository
+	quoteRepo       *mock.MockQuoteRepository
+	ledgerRepo      *mock.MockLedgerRepository
+	holdRepo        *mock.MockHoldRepository
+	auditRepo       *mock.MockAccountAuditRepository
+	reviewRepo      *mock.MockRiskReviewRepository
+	splitRepo       *mock.MockSplitPaymentRepository
+	outboxRepo      *mock.MockOutboxRepository
 	txManager       *mock2.MockTxManager
 }
 
+// testQuoteID is a well-formed FX quote ID
+const testQuoteID = "0123456789abcdef0123456789abcdef"
+
 func Test_accountUsecase_Create(t *testing.T) {
 	type args struct {
 		ctx     context.Context
@@ -38,23 +53,131 @@ func Test_accountUsecase_Create(t *testing.T) {
 			name: "success",
 			args: args{
 				ctx:     context.Background(),
-				account: dto.AccountDTO{AccountID: 111, Balance: 1000},
+				account: dto.AccountDTO{AccountID: 111, Balance: entity.MustParseMoney("1000")},
 			},
 			setup: func(fields fields) {
 				fields.accountRepo.EXPECT().FindOne(gomock.Any(), uint64(111)).Return(nil, nil)
-				fields.accountRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(&entity.Account{
-					ID:      111,
-					Balance: 1000,
-				}, nil)
+				// Accounts created without a currency default to USD, and without a type to checking
+				fields.accountRepo.EXPECT().Create(gomock.Any(), &entity.Account{
+					ID:       111,
+					Balance:  entity.MustParseMoney("1000"),
+					Currency: entity.CurrencyUSD,
+					Type:     entity.AccountChecking,
+					Status:   entity.AccountActive,
+				}).DoAndReturn(func(_ context.Context, acc *entity.Account) (*entity.Account, error) {
+					return acc, nil
+				})
+				// The opening balance is funded from the opening balance equity account
+				fields.ledgerRepo.EXPECT().CreateEntry(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, entry *entity.JournalEntry) (*entity.JournalEntry, error) {
+						if len(entry.Postings) != 2 || entry.Postings[0].SystemAccount != entity.SystemAccountOpeningBalance {
+							t.Errorf("unexpected opening entry: %+v", entry)
+						}
+						return entry, nil
+					})
+				// The account.created event is written in the same transaction
+				fields.outboxRepo.EXPECT().Create(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, event *entity.OutboxEvent) error {
+						if event.Type != entity.EventAccountCreated || event.AggregateID != "111" {
+							t.Errorf("unexpected event: %+v", event)
+						}
+						return nil
+					})
+			},
+			want: dto.AccountDTO{AccountID: 111, Balance: entity.MustParseMoney("1000"), Currency: entity.CurrencyUSD,
+				Type: entity.AccountChecking, AvailableBalance: entity.MustParseMoney("1000"), Status: entity.AccountActive},
+			wantErr: false,
+		},
+		{
+			name: "success_with_currency",
+			args: args{
+				ctx:     context.Background(),
+				account: dto.AccountDTO{AccountID: 111, Balance: entity.MustParseMoney("5000"), Currency: "JPY"},
+			},
+			setup: func(fields fields) {
+				fields.accountRepo.EXPECT().FindOne(gomock.Any(), uint64(111)).Return(nil, nil)
+				fields.accountRepo.EXPECT().Create(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, acc *entity.Account) (*entity.Account, error) {
+						return acc, nil
+					})
+				fields.ledgerRepo.EXPECT().CreateEntry(gomock.Any(), gomock.Any()).Return(&entity.JournalEntry{}, nil)
+				fields.outboxRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+			},
+			want: dto.AccountDTO{AccountID: 111, Balance: entity.MustParseMoney("5000"), Currency: "JPY",
+				Type: entity.AccountChecking, AvailableBalance: entity.MustParseMoney("5000"), Status: entity.AccountActive},
+			wantErr: false,
+		},
+		{
+			name: "success_savings",
+			args: args{
+				ctx: context.Background(),
+				account: dto.AccountDTO{AccountID: 111, Balance: entity.MustParseMoney("1000"),
+					Type: entity.AccountSavings},
+			},
+			setup: func(fields fields) {
+				fields.accountRepo.EXPECT().FindOne(gomock.Any(), uint64(111)).Return(nil, nil)
+				fields.accountRepo.EXPECT().Create(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, acc *entity.Account) (*entity.Account, error) {
+						return acc, nil
+					})
+				fields.ledgerRepo.EXPECT().CreateEntry(gomock.Any(), gomock.Any()).Return(&entity.JournalEntry{}, nil)
+				fields.outboxRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
 			},
-			want:    dto.AccountDTO{AccountID: 111, Balance: 1000},
+			want: dto.AccountDTO{AccountID: 111, Balance: entity.MustParseMoney("1000"), Currency: entity.CurrencyUSD,
+				Type: entity.AccountSavings, AvailableBalance: entity.MustParseMoney("1000"), Status: entity.AccountActive},
 			wantErr: false,
 		},
+		{
+			name: "validation_error_unknown_type",
+			args: args{
+				ctx:     context.Background(),
+				account: dto.AccountDTO{AccountID: 111, Balance: entity.MustParseMoney("1000"), Type: "brokerage"},
+			},
+			want:    dto.AccountDTO{},
+			wantErr: true,
+		},
+		{
+			name: "ledger_entry_error",
+			args: args{
+				ctx:     context.Background(),
+				account: dto.AccountDTO{AccountID: 111, Balance: entity.MustParseMoney("1000")},
+			},
+			setup: func(fields fields) {
+				fields.accountRepo.EXPECT().FindOne(gomock.Any(), uint64(111)).Return(nil, nil)
+				fields.accountRepo.EXPECT().Create(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, acc *entity.Account) (*entity.Account, error) {
+						return acc, nil
+					})
+				fields.ledgerRepo.EXPECT().CreateEntry(gomock.Any(), gomock.Any()).
+					Return(nil, errors.New("journal entry insert failed"))
+			},
+			want:    dto.AccountDTO{},
+			wantErr: true,
+		},
+		{
+			name: "validation_error_unknown_currency",
+			args: args{
+				ctx:     context.Background(),
+				account: dto.AccountDTO{AccountID: 111, Balance: entity.MustParseMoney("1000"), Currency: "XYZ"},
+			},
+			want:    dto.AccountDTO{},
+			wantErr: true,
+		},
+		{
+			name: "validation_error_currency_precision",
+			args: args{
+				ctx: context.Background(),
+				// JPY has no minor unit
+				account: dto.AccountDTO{AccountID: 111, Balance: entity.MustParseMoney("1000.50"), Currency: "JPY"},
+			},
+			want:    dto.AccountDTO{},
+			wantErr: true,
+		},
 		{
 			name: "validation_error_invalid_account_id",
 			args: args{
 				ctx:     context.Background(),
-				account: dto.AccountDTO{AccountID: 0, Balance: 1000}, // Invalid AccountID
+				account: dto.AccountDTO{AccountID: 0, Balance: entity.MustParseMoney("1000")}, // Invalid AccountID
 			},
 			want:    dto.AccountDTO{},
 			wantErr: true,
@@ -63,7 +186,7 @@ func Test_accountUsecase_Create(t *testing.T) {
 			name: "validation_error_invalid_balance",
 			args: args{
 				ctx:     context.Background(),
-				account: dto.AccountDTO{AccountID: 111, Balance: -100}, // Invalid Balance
+				account: dto.AccountDTO{AccountID: 111, Balance: entity.MustParseMoney("-100")}, // Invalid Balance
 			},
 			want:    dto.AccountDTO{},
 			wantErr: true,
@@ -72,7 +195,7 @@ func Test_accountUsecase_Create(t *testing.T) {
 			name: "find_one_error",
 			args: args{
 				ctx:     context.Background(),
-				account: dto.AccountDTO{AccountID: 111, Balance: 1000},
+				account: dto.AccountDTO{AccountID: 111, Balance: entity.MustParseMoney("1000")},
 			},
 			setup: func(fields fields) {
 				fields.accountRepo.EXPECT().FindOne(gomock.Any(), uint64(111)).
@@ -85,12 +208,12 @@ func Test_accountUsecase_Create(t *testing.T) {
 			name: "account_already_exists",
 			args: args{
 				ctx:     context.Background(),
-				account: dto.AccountDTO{AccountID: 111, Balance: 1000},
+				account: dto.AccountDTO{AccountID: 111, Balance: entity.MustParseMoney("1000")},
 			},
 			setup: func(fields fields) {
 				existingAccount := &entity.Account{
 					ID:      111,
-					Balance: 500,
+					Balance: entity.MustParseMoney("500"),
 				}
 				fields.accountRepo.EXPECT().FindOne(gomock.Any(), uint64(111)).Return(existingAccount, nil)
 			},
@@ -101,7 +224,7 @@ func Test_accountUsecase_Create(t *testing.T) {
 			name: "create_error",
 			args: args{
 				ctx:     context.Background(),
-				account: dto.AccountDTO{AccountID: 111, Balance: 1000},
+				account: dto.AccountDTO{AccountID: 111, Balance: entity.MustParseMoney("1000")},
 			},
 			setup: func(fields fields) {
 				fields.accountRepo.EXPECT().FindOne(gomock.Any(), uint64(111)).Return(nil, nil)
@@ -120,22 +243,33 @@ func Test_accountUsecase_Create(t *testing.T) {
 
 			mockAccountRepo := mock.NewMockAccountRepository(ctrl)
 			mockTransactionRepo := mock.NewMockTransactionRepository(ctrl)
+			mockLedgerRepo := mock.NewMockLedgerRepository(ctrl)
+			mockHoldRepo := mock.NewMockHoldRepository(ctrl)
+			mockOutboxRepo := mock.NewMockOutboxRepository(ctrl)
 
 			uc := accountUsecase{
 				accountRepo:     mockAccountRepo,
 				transactionRepo: mockTransactionRepo,
+				ledgerRepo:      mockLedgerRepo,
+				holdRepo:        mockHoldRepo,
+				outboxRepo:      mockOutboxRepo,
 				txManager:       &mock2.MockTxManager{},
+				broadcaster:     NewAccountBroadcaster(&config.Config{}),
 			}
 
 			testFields := fields{
 				accountRepo:     mockAccountRepo,
 				transactionRepo: mockTransactionRepo,
+				ledgerRepo:      mockLedgerRepo,
+				outboxRepo:      mockOutboxRepo,
 				txManager:       &mock2.MockTxManager{},
 			}
 
 			if tt.setup != nil {
 				tt.setup(testFields)
 			}
+			// A new account has no open holds
+			mockHoldRepo.EXPECT().SumActive(gomock.Any(), gomock.Any(), gomock.Any()).Return(entity.Money{}, nil).AnyTimes()
 
 			got, err := uc.Create(tt.args.ctx, tt.args.account)
 			if (err != nil) != tt.wantErr {
@@ -169,13 +303,21 @@ func Test_accountUsecase_GetBalance(t *testing.T) {
 			},
 			setup: func(fields fields) {
 				fields.accountRepo.EXPECT().FindOne(gomock.Any(), uint64(111)).Return(&entity.Account{
-					ID:      111,
-					Balance: 1500.75,
+					ID:       111,
+					Balance:  entity.MustParseMoney("1500.75"),
+					Currency: entity.CurrencyEUR,
+					Status:   entity.AccountFrozen,
 				}, nil)
+				// Open holds reduce the available balance but not the current balance
+				fields.holdRepo.EXPECT().SumActive(gomock.Any(), uint64(111), gomock.Any()).
+					Return(entity.MustParseMoney("500.25"), nil)
 			},
 			want: dto.AccountDTO{
-				AccountID: 111,
-				Balance:   1500.75,
+				AccountID:        111,
+				Balance:          entity.MustParseMoney("1500.75"),
+				Currency:         entity.CurrencyEUR,
+				AvailableBalance: entity.MustParseMoney("1000.50"),
+				Status:           entity.AccountFrozen,
 			},
 			wantErr: false,
 		},
@@ -212,16 +354,20 @@ func Test_accountUsecase_GetBalance(t *testing.T) {
 
 			mockAccountRepo := mock.NewMockAccountRepository(ctrl)
 			mockTransactionRepo := mock.NewMockTransactionRepository(ctrl)
+			mockHoldRepo := mock.NewMockHoldRepository(ctrl)
 
 			uc := accountUsecase{
 				accountRepo:     mockAccountRepo,
 				transactionRepo: mockTransactionRepo,
+				holdRepo:        mockHoldRepo,
 				txManager:       mock2.NewMockTxManager(),
+				broadcaster:     NewAccountBroadcaster(&config.Config{}),
 			}
 
 			testFields := fields{
 				accountRepo:     mockAccountRepo,
 				transactionRepo: mockTransactionRepo,
+				holdRepo:        mockHoldRepo,
 				txManager:       mock2.NewMockTxManager(),
 			}
 
@@ -247,10 +393,18 @@ func Test_accountUsecase_MakeTransaction(t *testing.T) {
 		req dto.TransactionDTO
 	}
 	tests := []struct {
-		name    string
-		args    args
-		setup   func(fields fields)
+		name  string
+		args  args
+		setup func(fields fields)
+		// risk, when set, builds the risk evaluator instead of an empty chain
+		risk func(fields fields) RiskEvaluator
+		// fees, when set, prices the transfer instead of charging no fee
+		fees    FeeCalculator
 		wantErr bool
+		// wantErrMsg, when set, is the expected apperr message
+		wantErrMsg string
+		// wantStatus, when set, is the expected status instead of posted
+		wantStatus entity.TransactionStatus
 	}{
 		{
 			name: "success",
@@ -259,236 +413,2945 @@ func Test_accountUsecase_MakeTransaction(t *testing.T) {
 				req: dto.TransactionDTO{
 					SourceAccountID:      111,
 					DestinationAccountID: 222,
-					Amount:               100.50,
+					Amount:               entity.MustParseMoney("100.50"),
 				},
 			},
 			setup: func(fields fields) {
 				// Mock FindForUpdate to return both accounts
 				accounts := []*entity.Account{
-					{ID: 111, Balance: 1000.00},
-					{ID: 222, Balance: 500.00},
+					{ID: 111, Balance: entity.MustParseMoney("1000.00"), Currency: entity.CurrencyUSD, Status: entity.AccountActive},
+					{ID: 222, Balance: entity.MustParseMoney("500.00"), Currency: entity.CurrencyUSD, Status: entity.AccountActive},
 				}
 
 				fields.txManager.ShouldFail = false
 				fields.accountRepo.EXPECT().FindForUpdate(gomock.Any(), []uint64{111, 222}).Return(accounts, nil)
 				fields.transactionRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(&entity.Transaction{}, nil)
+				fields.ledgerRepo.EXPECT().CreateEntry(gomock.Any(), gomock.Any()).Return(&entity.JournalEntry{}, nil)
+				fields.outboxRepo.EXPECT().Create(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, event *entity.OutboxEvent) error {
+						var payload entity.TransferPostedEvent
+						if err := json.Unmarshal(event.Payload, &payload); err != nil || event.Type != entity.EventTransferPosted ||
+							payload.SourceAccountID != 111 || payload.Amount.String() != "100.50" {
+							t.Errorf("unexpected event: %+v", event)
+						}
+						return nil
+					})
 				fields.accountRepo.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil).Times(2)
 			},
 			wantErr: false,
 		},
 		{
-			name: "validation_error_invalid_source_account_id",
+			name: "success_exact_balances",
 			args: args{
 				ctx: &gin.Context{},
 				req: dto.TransactionDTO{
-					SourceAccountID:      0, // Invalid
+					SourceAccountID:      111,
 					DestinationAccountID: 222,
-					Amount:               100.50,
+					Amount:               entity.MustParseMoney("0.10"),
 				},
 			},
-			wantErr: true,
+			setup: func(fields fields) {
+				accounts := []*entity.Account{
+					{ID: 111, Balance: entity.MustParseMoney("0.30"), Currency: entity.CurrencyUSD, Status: entity.AccountActive},
+					{ID: 222, Balance: entity.MustParseMoney("0.20"), Currency: entity.CurrencyUSD, Status: entity.AccountActive},
+				}
+
+				fields.accountRepo.EXPECT().FindForUpdate(gomock.Any(), []uint64{111, 222}).Return(accounts, nil)
+				fields.transactionRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(&entity.Transaction{}, nil)
+				fields.ledgerRepo.EXPECT().CreateEntry(gomock.Any(), gomock.Any()).Return(&entity.JournalEntry{}, nil)
+				fields.outboxRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+				// Balances must move by exactly one cent amount, without float drift
+				fields.accountRepo.EXPECT().Update(gomock.Any(),
+					&entity.Account{ID: 111, Balance: entity.MustParseMoney("0.20"), Currency: entity.CurrencyUSD, Status: entity.AccountActive}).Return(nil)
+				fields.accountRepo.EXPECT().Update(gomock.Any(),
+					&entity.Account{ID: 222, Balance: entity.MustParseMoney("0.30"), Currency: entity.CurrencyUSD, Status: entity.AccountActive}).Return(nil)
+			},
+			wantErr: false,
 		},
 		{
-			name: "validation_error_invalid_destination_account_id",
+			name: "success_cross_currency_with_quote",
 			args: args{
 				ctx: &gin.Context{},
 				req: dto.TransactionDTO{
 					SourceAccountID:      111,
-					DestinationAccountID: 0, // Invalid
-					Amount:               100.50,
+					DestinationAccountID: 222,
+					Amount:               entity.MustParseMoney("100.00"),
+					Currency:             entity.CurrencyUSD,
+					QuoteID:              testQuoteID,
+				},
+			},
+			setup: func(fields fields) {
+				accounts := []*entity.Account{
+					{ID: 111, Balance: entity.MustParseMoney("1000.00"), Currency: entity.CurrencyUSD, Status: entity.AccountActive},
+					{ID: 222, Balance: entity.MustParseMoney("10.00"), Currency: entity.CurrencyEUR, Status: entity.AccountActive},
+				}
+				quote := &entity.FXQuote{
+					ID:                  testQuoteID,
+					SourceCurrency:      entity.CurrencyUSD,
+					DestinationCurrency: entity.CurrencyEUR,
+					Rate:                entity.MustParseRate("0.92345"),
+					ExpiresAt:           time.Now().Add(time.Minute),
+				}
+
+				fields.accountRepo.EXPECT().FindForUpdate(gomock.Any(), []uint64{111, 222}).Return(accounts, nil)
+				fields.quoteRepo.EXPECT().FindForUpdate(gomock.Any(), testQuoteID).Return(quote, nil)
+				// The quote is consumed in the same DB transaction as the transfer
+				fields.quoteRepo.EXPECT().Update(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, q *entity.FXQuote) error {
+						if !q.IsUsed() {
+							t.Errorf("quote was not marked used")
+						}
+						return nil
+					})
+				fields.transactionRepo.EXPECT().Create(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, tx *entity.Transaction) (*entity.Transaction, error) {
+						if tx.ExchangeRate != quote.Rate || tx.QuoteID == nil || *tx.QuoteID != testQuoteID {
+							t.Errorf("applied rate not recorded on transaction: %+v", tx)
+						}
+						return tx, nil
+					})
+				// The USD and EUR legs each balance through the FX position account
+				fields.ledgerRepo.EXPECT().CreateEntry(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, entry *entity.JournalEntry) (*entity.JournalEntry, error) {
+						if len(entry.Postings) != 4 {
+							t.Errorf("expected 4 postings, got %d", len(entry.Postings))
+						}
+						return entry, nil
+					})
+				fields.outboxRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+				// Source is debited in USD, destination credited in EUR rounded half up to cents
+				fields.accountRepo.EXPECT().Update(gomock.Any(), &entity.Account{
+					ID: 111, Balance: entity.MustParseMoney("900.00"), Currency: entity.CurrencyUSD, Status: entity.AccountActive,
+				}).Return(nil)
+				fields.accountRepo.EXPECT().Update(gomock.Any(), &entity.Account{
+					ID: 222, Balance: entity.MustParseMoney("102.35"), Currency: entity.CurrencyEUR, Status: entity.AccountActive,
+				}).Return(nil)
+			},
+			wantErr: false,
+		},
+		{
+			name: "cross_currency_without_quote",
+			args: args{
+				ctx: &gin.Context{},
+				req: dto.TransactionDTO{
+					SourceAccountID:      111,
+					DestinationAccountID: 222,
+					Amount:               entity.MustParseMoney("100.00"),
 				},
 			},
+			setup: func(fields fields) {
+				accounts := []*entity.Account{
+					{ID: 111, Balance: entity.MustParseMoney("1000.00"), Currency: entity.CurrencyUSD, Status: entity.AccountActive},
+					{ID: 222, Balance: entity.MustParseMoney("10.00"), Currency: entity.CurrencyEUR, Status: entity.AccountActive},
+				}
+				fields.accountRepo.EXPECT().FindForUpdate(gomock.Any(), []uint64{111, 222}).Return(accounts, nil)
+			},
 			wantErr: true,
 		},
 		{
-			name: "validation_error_invalid_amount",
+			name: "expired_quote",
 			args: args{
 				ctx: &gin.Context{},
 				req: dto.TransactionDTO{
 					SourceAccountID:      111,
 					DestinationAccountID: 222,
-					Amount:               -100.50, // Invalid
+					Amount:               entity.MustParseMoney("100.00"),
+					QuoteID:              testQuoteID,
 				},
 			},
+			setup: func(fields fields) {
+				accounts := []*entity.Account{
+					{ID: 111, Balance: entity.MustParseMoney("1000.00"), Currency: entity.CurrencyUSD, Status: entity.AccountActive},
+					{ID: 222, Balance: entity.MustParseMoney("10.00"), Currency: entity.CurrencyEUR, Status: entity.AccountActive},
+				}
+				fields.accountRepo.EXPECT().FindForUpdate(gomock.Any(), []uint64{111, 222}).Return(accounts, nil)
+				fields.quoteRepo.EXPECT().FindForUpdate(gomock.Any(), testQuoteID).Return(&entity.FXQuote{
+					ID:                  testQuoteID,
+					SourceCurrency:      entity.CurrencyUSD,
+					DestinationCurrency: entity.CurrencyEUR,
+					Rate:                entity.MustParseRate("0.92"),
+					ExpiresAt:           time.Now().Add(-time.Second),
+				}, nil)
+			},
 			wantErr: true,
 		},
 		{
-			name: "self_transfer_error",
+			name: "quote_already_used",
 			args: args{
 				ctx: &gin.Context{},
 				req: dto.TransactionDTO{
 					SourceAccountID:      111,
-					DestinationAccountID: 111, // Same as source
-					Amount:               100.50,
+					DestinationAccountID: 222,
+					Amount:               entity.MustParseMoney("100.00"),
+					QuoteID:              testQuoteID,
 				},
 			},
+			setup: func(fields fields) {
+				usedAt := time.Now().Add(-time.Second)
+				accounts := []*entity.Account{
+					{ID: 111, Balance: entity.MustParseMoney("1000.00"), Currency: entity.CurrencyUSD, Status: entity.AccountActive},
+					{ID: 222, Balance: entity.MustParseMoney("10.00"), Currency: entity.CurrencyEUR, Status: entity.AccountActive},
+				}
+				fields.accountRepo.EXPECT().FindForUpdate(gomock.Any(), []uint64{111, 222}).Return(accounts, nil)
+				fields.quoteRepo.EXPECT().FindForUpdate(gomock.Any(), testQuoteID).Return(&entity.FXQuote{
+					ID:                  testQuoteID,
+					SourceCurrency:      entity.CurrencyUSD,
+					DestinationCurrency: entity.CurrencyEUR,
+					Rate:                entity.MustParseRate("0.92"),
+					ExpiresAt:           time.Now().Add(time.Minute),
+					UsedAt:              &usedAt,
+				}, nil)
+			},
 			wantErr: true,
 		},
 		{
-			name: "tx_manager_error",
+			name: "quote_currency_pair_mismatch",
 			args: args{
 				ctx: &gin.Context{},
 				req: dto.TransactionDTO{
 					SourceAccountID:      111,
 					DestinationAccountID: 222,
-					Amount:               100.50,
+					Amount:               entity.MustParseMoney("100.00"),
+					QuoteID:              testQuoteID,
 				},
 			},
 			setup: func(fields fields) {
-				fields.txManager.ShouldFail = true
+				accounts := []*entity.Account{
+					{ID: 111, Balance: entity.MustParseMoney("1000.00"), Currency: entity.CurrencyUSD, Status: entity.AccountActive},
+					{ID: 222, Balance: entity.MustParseMoney("10.00"), Currency: entity.CurrencyEUR, Status: entity.AccountActive},
+				}
+				fields.accountRepo.EXPECT().FindForUpdate(gomock.Any(), []uint64{111, 222}).Return(accounts, nil)
+				fields.quoteRepo.EXPECT().FindForUpdate(gomock.Any(), testQuoteID).Return(&entity.FXQuote{
+					ID:                  testQuoteID,
+					SourceCurrency:      entity.CurrencyEUR,
+					DestinationCurrency: entity.CurrencyUSD,
+					Rate:                entity.MustParseRate("1.08"),
+					ExpiresAt:           time.Now().Add(time.Minute),
+				}, nil)
 			},
 			wantErr: true,
 		},
 		{
-			name: "find_for_update_error",
+			name: "quote_on_same_currency_transfer",
 			args: args{
 				ctx: &gin.Context{},
 				req: dto.TransactionDTO{
 					SourceAccountID:      111,
 					DestinationAccountID: 222,
-					Amount:               100.50,
+					Amount:               entity.MustParseMoney("100.00"),
+					QuoteID:              testQuoteID,
 				},
 			},
 			setup: func(fields fields) {
-				fields.accountRepo.EXPECT().FindForUpdate(gomock.Any(), []uint64{111, 222}).
-					Return(nil, errors.New("database error"))
+				accounts := []*entity.Account{
+					{ID: 111, Balance: entity.MustParseMoney("1000.00"), Currency: entity.CurrencyUSD, Status: entity.AccountActive},
+					{ID: 222, Balance: entity.MustParseMoney("10.00"), Currency: entity.CurrencyUSD, Status: entity.AccountActive},
+				}
+				fields.accountRepo.EXPECT().FindForUpdate(gomock.Any(), []uint64{111, 222}).Return(accounts, nil)
 			},
 			wantErr: true,
 		},
 		{
-			name: "accounts_not_found",
+			name: "amount_currency_differs_from_source_account",
 			args: args{
 				ctx: &gin.Context{},
 				req: dto.TransactionDTO{
 					SourceAccountID:      111,
 					DestinationAccountID: 222,
-					Amount:               100.50,
+					Amount:               entity.MustParseMoney("100.00"),
+					Currency:             entity.CurrencyEUR,
 				},
 			},
 			setup: func(fields fields) {
-				// Return only one account (less than 2)
 				accounts := []*entity.Account{
-					{ID: 111, Balance: 1000.00},
+					{ID: 111, Balance: entity.MustParseMoney("1000.00"), Currency: entity.CurrencyUSD, Status: entity.AccountActive},
+					{ID: 222, Balance: entity.MustParseMoney("10.00"), Currency: entity.CurrencyUSD, Status: entity.AccountActive},
 				}
 				fields.accountRepo.EXPECT().FindForUpdate(gomock.Any(), []uint64{111, 222}).Return(accounts, nil)
 			},
 			wantErr: true,
 		},
 		{
-			name: "insufficient_balance",
+			name: "source_account_frozen",
 			args: args{
 				ctx: &gin.Context{},
 				req: dto.TransactionDTO{
 					SourceAccountID:      111,
 					DestinationAccountID: 222,
-					Amount:               2000.00, // More than available balance
+					Amount:               entity.MustParseMoney("100.00"),
 				},
 			},
 			setup: func(fields fields) {
 				accounts := []*entity.Account{
-					{ID: 111, Balance: 1000.00}, // Only 1000 available
-					{ID: 222, Balance: 500.00},
+					{ID: 111, Balance: entity.MustParseMoney("1000.00"), Currency: entity.CurrencyUSD, Status: entity.AccountFrozen},
+					{ID: 222, Balance: entity.MustParseMoney("10.00"), Currency: entity.CurrencyUSD, Status: entity.AccountActive},
 				}
 				fields.accountRepo.EXPECT().FindForUpdate(gomock.Any(), []uint64{111, 222}).Return(accounts, nil)
 			},
 			wantErr: true,
 		},
 		{
-			name: "transaction_create_error",
+			name: "destination_account_closed",
 			args: args{
 				ctx: &gin.Context{},
 				req: dto.TransactionDTO{
 					SourceAccountID:      111,
 					DestinationAccountID: 222,
-					Amount:               100.50,
+					Amount:               entity.MustParseMoney("100.00"),
 				},
 			},
 			setup: func(fields fields) {
 				accounts := []*entity.Account{
-					{ID: 111, Balance: 1000.00},
-					{ID: 222, Balance: 500.00},
+					{ID: 111, Balance: entity.MustParseMoney("1000.00"), Currency: entity.CurrencyUSD, Status: entity.AccountActive},
+					{ID: 222, Balance: entity.MustParseMoney("0.00"), Currency: entity.CurrencyUSD, Status: entity.AccountClosed},
 				}
 				fields.accountRepo.EXPECT().FindForUpdate(gomock.Any(), []uint64{111, 222}).Return(accounts, nil)
-				fields.transactionRepo.EXPECT().Create(gomock.Any(), gomock.Any()).
-					Return(nil, errors.New("transaction record creation failed"))
 			},
 			wantErr: true,
 		},
 		{
-			name: "source_account_update_error",
+			name: "success_to_frozen_destination",
 			args: args{
 				ctx: &gin.Context{},
 				req: dto.TransactionDTO{
 					SourceAccountID:      111,
 					DestinationAccountID: 222,
-					Amount:               100.50,
+					Amount:               entity.MustParseMoney("100.00"),
 				},
 			},
 			setup: func(fields fields) {
+				// Frozen accounts still receive money
 				accounts := []*entity.Account{
-					{ID: 111, Balance: 1000.00},
-					{ID: 222, Balance: 500.00},
+					{ID: 111, Balance: entity.MustParseMoney("1000.00"), Currency: entity.CurrencyUSD, Status: entity.AccountActive},
+					{ID: 222, Balance: entity.MustParseMoney("10.00"), Currency: entity.CurrencyUSD, Status: entity.AccountFrozen},
 				}
 				fields.accountRepo.EXPECT().FindForUpdate(gomock.Any(), []uint64{111, 222}).Return(accounts, nil)
 				fields.transactionRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(&entity.Transaction{}, nil)
-				// First update (source account) fails
-				fields.accountRepo.EXPECT().Update(gomock.Any(), gomock.Any()).Return(errors.New("source account update failed"))
+				fields.ledgerRepo.EXPECT().CreateEntry(gomock.Any(), gomock.Any()).Return(&entity.JournalEntry{}, nil)
+				fields.outboxRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+				fields.accountRepo.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil).Times(2)
+			},
+			wantErr: false,
+		},
+		{
+			name: "amount_exceeds_source_currency_precision",
+			args: args{
+				ctx: &gin.Context{},
+				req: dto.TransactionDTO{
+					SourceAccountID:      111,
+					DestinationAccountID: 222,
+					Amount:               entity.MustParseMoney("100.001"),
+				},
+			},
+			setup: func(fields fields) {
+				accounts := []*entity.Account{
+					{ID: 111, Balance: entity.MustParseMoney("1000.00"), Currency: entity.CurrencyUSD, Status: entity.AccountActive},
+					{ID: 222, Balance: entity.MustParseMoney("10.00"), Currency: entity.CurrencyUSD, Status: entity.AccountActive},
+				}
+				fields.accountRepo.EXPECT().FindForUpdate(gomock.Any(), []uint64{111, 222}).Return(accounts, nil)
 			},
 			wantErr: true,
 		},
 		{
-			name: "destination_account_update_error",
+			name: "validation_error_invalid_source_account_id",
+			args: args{
+				ctx: &gin.Context{},
+				req: dto.TransactionDTO{
+					SourceAccountID:      0, // Invalid
+					DestinationAccountID: 222,
+					Amount:               entity.MustParseMoney("100.50"),
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "validation_error_invalid_destination_account_id",
+			args: args{
+				ctx: &gin.Context{},
+				req: dto.TransactionDTO{
+					SourceAccountID:      111,
+					DestinationAccountID: 0, // Invalid
+					Amount:               entity.MustParseMoney("100.50"),
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "validation_error_invalid_amount",
+			args: args{
+				ctx: &gin.Context{},
+				req: dto.TransactionDTO{
+					SourceAccountID:      111,
+					DestinationAccountID: 222,
+					Amount:               entity.MustParseMoney("-100.50"), // Invalid
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "self_transfer_error",
+			args: args{
+				ctx: &gin.Context{},
+				req: dto.TransactionDTO{
+					SourceAccountID:      111,
+					DestinationAccountID: 111, // Same as source
+					Amount:               entity.MustParseMoney("100.50"),
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "tx_manager_error",
+			args: args{
+				ctx: &gin.Context{},
+				req: dto.TransactionDTO{
+					SourceAccountID:      111,
+					DestinationAccountID: 222,
+					Amount:               entity.MustParseMoney("100.50"),
+				},
+			},
+			setup: func(fields fields) {
+				fields.txManager.ShouldFail = true
+			},
+			wantErr: true,
+		},
+		{
+			name: "find_for_update_error",
+			args: args{
+				ctx: &gin.Context{},
+				req: dto.TransactionDTO{
+					SourceAccountID:      111,
+					DestinationAccountID: 222,
+					Amount:               entity.MustParseMoney("100.50"),
+				},
+			},
+			setup: func(fields fields) {
+				fields.accountRepo.EXPECT().FindForUpdate(gomock.Any(), []uint64{111, 222}).
+					Return(nil, errors.New("database error"))
+			},
+			wantErr: true,
+		},
+		{
+			name: "accounts_not_found",
 			args: args{
 				ctx: &gin.Context{},
 				req: dto.TransactionDTO{
 					SourceAccountID:      111,
 					DestinationAccountID: 222,
-					Amount:               100.50,
+					Amount:               entity.MustParseMoney("100.50"),
 				},
 			},
 			setup: func(fields fields) {
+				// Return only one account (less than 2)
 				accounts := []*entity.Account{
-					{ID: 111, Balance: 1000.00},
-					{ID: 222, Balance: 500.00},
+					{ID: 111, Balance: entity.MustParseMoney("1000.00"), Currency: entity.CurrencyUSD, Status: entity.AccountActive},
 				}
 				fields.accountRepo.EXPECT().FindForUpdate(gomock.Any(), []uint64{111, 222}).Return(accounts, nil)
-				fields.transactionRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(&entity.Transaction{}, nil)
-				// First update (source account) succeeds, second update (destination account) fails
-				fields.accountRepo.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil)
-				fields.accountRepo.EXPECT().Update(gomock.Any(), gomock.Any()).Return(errors.New("destination account update failed"))
 			},
 			wantErr: true,
 		},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			ctrl := gomock.NewController(t)
-			defer ctrl.Finish()
-
-			mockAccountRepo := mock.NewMockAccountRepository(ctrl)
-			mockTransactionRepo := mock.NewMockTransactionRepository(ctrl)
-			mockTxManager := &mock2.MockTxManager{}
-
-			testFields := fields{
-				accountRepo:     mockAccountRepo,
-				transactionRepo: mockTransactionRepo,
-				txManager:       mockTxManager,
-			}
-
-			uc := accountUsecase{
-				accountRepo:     mockAccountRepo,
-				transactionRepo: mockTransactionRepo,
-				txManager:       mockTxManager,
-			}
-
-			if tt.setup != nil {
-				tt.setup(testFields)
-			}
-
-			err := uc.MakeTransaction(tt.args.ctx, tt.args.req)
+		{
+			name: "success_with_fee",
+			args: args{
+				ctx: &gin.Context{},
+				req: dto.TransactionDTO{
+					SourceAccountID:      111,
+					DestinationAccountID: 222,
+					Amount:               entity.MustParseMoney("100.00"),
+				},
+			},
+			fees: &feeCalculator{schedules: []entity.FeeSchedule{{
+				Name: "internal_usd", TransferType: entity.TransferInternal, Currency: entity.CurrencyUSD,
+				Type: entity.FeePercentage, Flat: entity.MustParseMoney("0.25"), Rate: entity.MustParseRate("0.01"),
+			}}},
+			setup: func(fields fields) {
+				accounts := []*entity.Account{
+					{ID: 111, Balance: entity.MustParseMoney("1000.00"), Currency: entity.CurrencyUSD, Status: entity.AccountActive},
+					{ID: 222, Balance: entity.MustParseMoney("500.00"), Currency: entity.CurrencyUSD, Status: entity.AccountActive},
+				}
+				fields.accountRepo.EXPECT().FindForUpdate(gomock.Any(), []uint64{111, 222}).Return(accounts, nil)
+				fields.transactionRepo.EXPECT().Create(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, tx *entity.Transaction) (*entity.Transaction, error) {
+						want := entity.FeeBreakdown{Schedule: "internal_usd", Fixed: entity.MustParseMoney("0.25"),
+							Percentage: entity.MustParseMoney("1.00"), Amount: entity.MustParseMoney("1.25")}
+						if tx.Fee != want {
+							t.Errorf("unexpected fee: %+v", tx.Fee)
+						}
+						return tx, nil
+					})
+				// The fee is booked in the transfer's journal entry, credited to the fee revenue account
+				fields.ledgerRepo.EXPECT().CreateEntry(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, entry *entity.JournalEntry) (*entity.JournalEntry, error) {
+						last := entry.Postings[len(entry.Postings)-1]
+						if len(entry.Postings) != 4 || last.SystemAccount != entity.SystemAccountFeeRevenue ||
+							last.Amount != entity.MustParseMoney("1.25") {
+							t.Errorf("unexpected fee postings: %+v", entry.Postings)
+						}
+						return entry, nil
+					})
+				fields.outboxRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+				// The source account pays the amount and the fee; the destination receives the amount
+				fields.accountRepo.EXPECT().Update(gomock.Any(),
+					&entity.Account{ID: 111, Balance: entity.MustParseMoney("898.75"), Currency: entity.CurrencyUSD, Status: entity.AccountActive}).Return(nil)
+				fields.accountRepo.EXPECT().Update(gomock.Any(),
+					&entity.Account{ID: 222, Balance: entity.MustParseMoney("600.00"), Currency: entity.CurrencyUSD, Status: entity.AccountActive}).Return(nil)
+			},
+			wantErr: false,
+		},
+		{
+			name: "insufficient_balance_for_fee",
+			args: args{
+				ctx: &gin.Context{},
+				req: dto.TransactionDTO{
+					SourceAccountID:      111,
+					DestinationAccountID: 222,
+					Amount:               entity.MustParseMoney("1000.00"),
+				},
+			},
+			fees: &feeCalculator{schedules: []entity.FeeSchedule{{
+				Name: "internal_usd", TransferType: entity.TransferInternal, Currency: entity.CurrencyUSD,
+				Type: entity.FeeFlat, Flat: entity.MustParseMoney("2.00"),
+			}}},
+			setup: func(fields fields) {
+				accounts := []*entity.Account{
+					{ID: 111, Balance: entity.MustParseMoney("1000.00"), Currency: entity.CurrencyUSD, Status: entity.AccountActive},
+					{ID: 222, Balance: entity.MustParseMoney("500.00"), Currency: entity.CurrencyUSD, Status: entity.AccountActive},
+				}
+				fields.accountRepo.EXPECT().FindForUpdate(gomock.Any(), []uint64{111, 222}).Return(accounts, nil)
+			},
+			wantErr:    true,
+			wantErrMsg: "insufficient funds: 2.00 USD short",
+		},
+		{
+			name: "insufficient_balance",
+			args: args{
+				ctx: &gin.Context{},
+				req: dto.TransactionDTO{
+					SourceAccountID:      111,
+					DestinationAccountID: 222,
+					Amount:               entity.MustParseMoney("2000.00"), // More than available balance
+				},
+			},
+			setup: func(fields fields) {
+				accounts := []*entity.Account{
+					{ID: 111, Balance: entity.MustParseMoney("1000.00"), Currency: entity.CurrencyUSD, Status: entity.AccountActive}, // Only 1000 available
+					{ID: 222, Balance: entity.MustParseMoney("500.00"), Currency: entity.CurrencyUSD, Status: entity.AccountActive},
+				}
+				fields.accountRepo.EXPECT().FindForUpdate(gomock.Any(), []uint64{111, 222}).Return(accounts, nil)
+			},
+			wantErr:    true,
+			wantErrMsg: "insufficient funds: 1000.00 USD short",
+		},
+		{
+			name: "success_within_overdraft",
+			args: args{
+				ctx: &gin.Context{},
+				req: dto.TransactionDTO{
+					SourceAccountID:      111,
+					DestinationAccountID: 222,
+					Amount:               entity.MustParseMoney("150.00"),
+				},
+			},
+			setup: func(fields fields) {
+				accounts := []*entity.Account{
+					{ID: 111, Balance: entity.MustParseMoney("100.00"), Currency: entity.CurrencyUSD, Status: entity.AccountActive,
+						OverdraftLimit: entity.MustParseMoney("50.00")},
+					{ID: 222, Balance: entity.MustParseMoney("500.00"), Currency: entity.CurrencyUSD, Status: entity.AccountActive},
+				}
+				fields.accountRepo.EXPECT().FindForUpdate(gomock.Any(), []uint64{111, 222}).Return(accounts, nil)
+				fields.transactionRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(&entity.Transaction{}, nil)
+				fields.ledgerRepo.EXPECT().CreateEntry(gomock.Any(), gomock.Any()).Return(&entity.JournalEntry{}, nil)
+				fields.outboxRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+				// The whole overdraft is used: the balance goes to -50
+				fields.accountRepo.EXPECT().Update(gomock.Any(), &entity.Account{
+					ID: 111, Balance: entity.MustParseMoney("-50.00"), Currency: entity.CurrencyUSD, Status: entity.AccountActive,
+					OverdraftLimit: entity.MustParseMoney("50.00"),
+				}).Return(nil)
+				fields.accountRepo.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil)
+			},
+			wantErr: false,
+		},
+		{
+			name: "exceeds_overdraft",
+			args: args{
+				ctx: &gin.Context{},
+				req: dto.TransactionDTO{
+					SourceAccountID:      111,
+					DestinationAccountID: 222,
+					Amount:               entity.MustParseMoney("150.01"),
+				},
+			},
+			setup: func(fields fields) {
+				accounts := []*entity.Account{
+					{ID: 111, Balance: entity.MustParseMoney("100.00"), Currency: entity.CurrencyUSD, Status: entity.AccountActive,
+						OverdraftLimit: entity.MustParseMoney("50.00")},
+					{ID: 222, Balance: entity.MustParseMoney("500.00"), Currency: entity.CurrencyUSD, Status: entity.AccountActive},
+				}
+				fields.accountRepo.EXPECT().FindForUpdate(gomock.Any(), []uint64{111, 222}).Return(accounts, nil)
+			},
+			wantErr:    true,
+			wantErrMsg: "insufficient funds: 0.01 USD short",
+		},
+		{
+			name: "insufficient_available_balance",
+			args: args{
+				ctx: &gin.Context{},
+				req: dto.TransactionDTO{
+					SourceAccountID:      111,
+					DestinationAccountID: 222,
+					Amount:               entity.MustParseMoney("300.00"),
+				},
+			},
+			setup: func(fields fields) {
+				accounts := []*entity.Account{
+					{ID: 111, Balance: entity.MustParseMoney("1000.00"), Currency: entity.CurrencyUSD, Status: entity.AccountActive},
+					{ID: 222, Balance: entity.MustParseMoney("500.00"), Currency: entity.CurrencyUSD, Status: entity.AccountActive},
+				}
+				fields.accountRepo.EXPECT().FindForUpdate(gomock.Any(), []uint64{111, 222}).Return(accounts, nil)
+				// 800 of the 1000 balance is reserved by holds
+				fields.holdRepo.EXPECT().SumActive(gomock.Any(), uint64(111), gomock.Any()).
+					Return(entity.MustParseMoney("800.00"), nil)
+			},
+			wantErr: true,
+		},
+		{
+			name: "transaction_create_error",
+			args: args{
+				ctx: &gin.Context{},
+				req: dto.TransactionDTO{
+					SourceAccountID:      111,
+					DestinationAccountID: 222,
+					Amount:               entity.MustParseMoney("100.50"),
+				},
+			},
+			setup: func(fields fields) {
+				accounts := []*entity.Account{
+					{ID: 111, Balance: entity.MustParseMoney("1000.00"), Currency: entity.CurrencyUSD, Status: entity.AccountActive},
+					{ID: 222, Balance: entity.MustParseMoney("500.00"), Currency: entity.CurrencyUSD, Status: entity.AccountActive},
+				}
+				fields.accountRepo.EXPECT().FindForUpdate(gomock.Any(), []uint64{111, 222}).Return(accounts, nil)
+				fields.transactionRepo.EXPECT().Create(gomock.Any(), gomock.Any()).
+					Return(nil, errors.New("transaction record creation failed"))
+			},
+			wantErr: true,
+		},
+		{
+			name: "ledger_entry_error",
+			args: args{
+				ctx: &gin.Context{},
+				req: dto.TransactionDTO{
+					SourceAccountID:      111,
+					DestinationAccountID: 222,
+					Amount:               entity.MustParseMoney("100.50"),
+				},
+			},
+			setup: func(fields fields) {
+				accounts := []*entity.Account{
+					{ID: 111, Balance: entity.MustParseMoney("1000.00"), Currency: entity.CurrencyUSD, Status: entity.AccountActive},
+					{ID: 222, Balance: entity.MustParseMoney("500.00"), Currency: entity.CurrencyUSD, Status: entity.AccountActive},
+				}
+				fields.accountRepo.EXPECT().FindForUpdate(gomock.Any(), []uint64{111, 222}).Return(accounts, nil)
+				fields.transactionRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(&entity.Transaction{}, nil)
+				// No balance is updated when the journal entry cannot be written
+				fields.ledgerRepo.EXPECT().CreateEntry(gomock.Any(), gomock.Any()).
+					Return(nil, errors.New("journal entry insert failed"))
+			},
+			wantErr: true,
+		},
+		{
+			name: "source_account_update_error",
+			args: args{
+				ctx: &gin.Context{},
+				req: dto.TransactionDTO{
+					SourceAccountID:      111,
+					DestinationAccountID: 222,
+					Amount:               entity.MustParseMoney("100.50"),
+				},
+			},
+			setup: func(fields fields) {
+				accounts := []*entity.Account{
+					{ID: 111, Balance: entity.MustParseMoney("1000.00"), Currency: entity.CurrencyUSD, Status: entity.AccountActive},
+					{ID: 222, Balance: entity.MustParseMoney("500.00"), Currency: entity.CurrencyUSD, Status: entity.AccountActive},
+				}
+				fields.accountRepo.EXPECT().FindForUpdate(gomock.Any(), []uint64{111, 222}).Return(accounts, nil)
+				fields.transactionRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(&entity.Transaction{}, nil)
+				fields.ledgerRepo.EXPECT().CreateEntry(gomock.Any(), gomock.Any()).Return(&entity.JournalEntry{}, nil)
+				// First update (source account) fails
+				fields.accountRepo.EXPECT().Update(gomock.Any(), gomock.Any()).Return(errors.New("source account update failed"))
+			},
+			wantErr: true,
+		},
+		{
+			name: "destination_account_update_error",
+			args: args{
+				ctx: &gin.Context{},
+				req: dto.TransactionDTO{
+					SourceAccountID:      111,
+					DestinationAccountID: 222,
+					Amount:               entity.MustParseMoney("100.50"),
+				},
+			},
+			setup: func(fields fields) {
+				accounts := []*entity.Account{
+					{ID: 111, Balance: entity.MustParseMoney("1000.00"), Currency: entity.CurrencyUSD, Status: entity.AccountActive},
+					{ID: 222, Balance: entity.MustParseMoney("500.00"), Currency: entity.CurrencyUSD, Status: entity.AccountActive},
+				}
+				fields.accountRepo.EXPECT().FindForUpdate(gomock.Any(), []uint64{111, 222}).Return(accounts, nil)
+				fields.transactionRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(&entity.Transaction{}, nil)
+				fields.ledgerRepo.EXPECT().CreateEntry(gomock.Any(), gomock.Any()).Return(&entity.JournalEntry{}, nil)
+				// First update (source account) succeeds, second update (destination account) fails
+				fields.accountRepo.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil)
+				fields.accountRepo.EXPECT().Update(gomock.Any(), gomock.Any()).Return(errors.New("destination account update failed"))
+			},
+			wantErr: true,
+		},
+		{
+			name: "outbox_error",
+			args: args{
+				ctx: &gin.Context{},
+				req: dto.TransactionDTO{
+					SourceAccountID:      111,
+					DestinationAccountID: 222,
+					Amount:               entity.MustParseMoney("100.50"),
+				},
+			},
+			setup: func(fields fields) {
+				accounts := []*entity.Account{
+					{ID: 111, Balance: entity.MustParseMoney("1000.00"), Currency: entity.CurrencyUSD, Status: entity.AccountActive},
+					{ID: 222, Balance: entity.MustParseMoney("500.00"), Currency: entity.CurrencyUSD, Status: entity.AccountActive},
+				}
+				fields.accountRepo.EXPECT().FindForUpdate(gomock.Any(), []uint64{111, 222}).Return(accounts, nil)
+				fields.transactionRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(&entity.Transaction{}, nil)
+				fields.ledgerRepo.EXPECT().CreateEntry(gomock.Any(), gomock.Any()).Return(&entity.JournalEntry{}, nil)
+				fields.accountRepo.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil).Times(2)
+				// The transfer is rolled back when its event cannot be recorded
+				fields.outboxRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(errors.New("outbox insert failed"))
+			},
+			wantErr: true,
+		},
+		{
+			name: "held_for_review",
+			args: args{
+				ctx: &gin.Context{},
+				req: dto.TransactionDTO{
+					SourceAccountID:      111,
+					DestinationAccountID: 222,
+					Amount:               entity.MustParseMoney("5000.00"),
+				},
+			},
+			risk: func(fields) RiskEvaluator {
+				return &riskChain{rules: []RiskRule{newAccountRule{
+					maxAge:     24 * time.Hour,
+					thresholds: map[entity.Currency]entity.Money{entity.CurrencyUSD: entity.MustParseMoney("1000.00")},
+					action:     entity.RiskDecisionReview,
+				}}}
+			},
+			setup: func(fields fields) {
+				accounts := []*entity.Account{
+					{ID: 111, Balance: entity.MustParseMoney("10000.00"), Currency: entity.CurrencyUSD, Status: entity.AccountActive,
+						CreatedAt: time.Now().Add(-time.Hour)},
+					{ID: 222, Balance: entity.MustParseMoney("500.00"), Currency: entity.CurrencyUSD, Status: entity.AccountActive},
+				}
+
+				fields.accountRepo.EXPECT().FindForUpdate(gomock.Any(), []uint64{111, 222}).Return(accounts, nil)
+				// The transfer is stored pending; no posting and no balance update
+				fields.transactionRepo.EXPECT().Create(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, tx *entity.Transaction) (*entity.Transaction, error) {
+						if tx.Status != entity.TransactionPending {
+							t.Errorf("held transfer stored as %s", tx.Status)
+						}
+						tx.ID = 42
+						return tx, nil
+					})
+				fields.reviewRepo.EXPECT().Create(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, review *entity.RiskReview) (*entity.RiskReview, error) {
+						if review.TransactionID != 42 || review.Rule != "new_account" || review.Status != entity.RiskReviewOpen {
+							t.Errorf("unexpected risk review: %+v", review)
+						}
+						return review, nil
+					})
+			},
+			wantStatus: entity.TransactionPending,
+		},
+		{
+			name: "denied_by_risk",
+			args: args{
+				ctx: &gin.Context{},
+				req: dto.TransactionDTO{
+					SourceAccountID:      111,
+					DestinationAccountID: 222,
+					Amount:               entity.MustParseMoney("100.00"),
+				},
+			},
+			risk: func(fields fields) RiskEvaluator {
+				return &riskChain{rules: []RiskRule{roundTripRule{
+					transactionRepo: fields.transactionRepo,
+					window:          time.Hour,
+					action:          entity.RiskDecisionDeny,
+				}}}
+			},
+			setup: func(fields fields) {
+				accounts := []*entity.Account{
+					{ID: 111, Balance: entity.MustParseMoney("1000.00"), Currency: entity.CurrencyUSD, Status: entity.AccountActive},
+					{ID: 222, Balance: entity.MustParseMoney("500.00"), Currency: entity.CurrencyUSD, Status: entity.AccountActive},
+				}
+
+				fields.accountRepo.EXPECT().FindForUpdate(gomock.Any(), []uint64{111, 222}).Return(accounts, nil)
+				fields.transactionRepo.EXPECT().HasTransferSince(gomock.Any(), uint64(222), uint64(111), gomock.Any()).Return(true, nil)
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockAccountRepo := mock.NewMockAccountRepository(ctrl)
+			mockTransactionRepo := mock.NewMockTransactionRepository(ctrl)
+			mockQuoteRepo := mock.NewMockQuoteRepository(ctrl)
+			mockLedgerRepo := mock.NewMockLedgerRepository(ctrl)
+			mockHoldRepo := mock.NewMockHoldRepository(ctrl)
+			mockReviewRepo := mock.NewMockRiskReviewRepository(ctrl)
+			mockOutboxRepo := mock.NewMockOutboxRepository(ctrl)
+			mockTxManager := &mock2.MockTxManager{}
+
+			testFields := fields{
+				accountRepo:     mockAccountRepo,
+				transactionRepo: mockTransactionRepo,
+				quoteRepo:       mockQuoteRepo,
+				ledgerRepo:      mockLedgerRepo,
+				holdRepo:        mockHoldRepo,
+				reviewRepo:      mockReviewRepo,
+				outboxRepo:      mockOutboxRepo,
+				txManager:       mockTxManager,
+			}
+
+			var risk RiskEvaluator = &riskChain{}
+			if tt.risk != nil {
+				risk = tt.risk(testFields)
+			}
+			var fees FeeCalculator = &feeCalculator{}
+			if tt.fees != nil {
+				fees = tt.fees
+			}
+
+			uc := accountUsecase{
+				accountRepo:     mockAccountRepo,
+				transactionRepo: mockTransactionRepo,
+				quoteRepo:       mockQuoteRepo,
+				ledgerRepo:      mockLedgerRepo,
+				holdRepo:        mockHoldRepo,
+				reviewRepo:      mockReviewRepo,
+				outboxRepo:      mockOutboxRepo,
+				limits:          &limitEvaluator{transactionRepo: mockTransactionRepo},
+				risk:            risk,
+				fees:            fees,
+				txManager:       mockTxManager,
+				broadcaster:     NewAccountBroadcaster(&config.Config{}),
+			}
+
+			if tt.setup != nil {
+				tt.setup(testFields)
+			}
+			// Accounts have no open holds unless a case says otherwise
+			mockHoldRepo.EXPECT().SumActive(gomock.Any(), gomock.Any(), gomock.Any()).Return(entity.Money{}, nil).AnyTimes()
+
+			got, err := uc.MakeTransaction(tt.args.ctx, tt.args.req)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("MakeTransaction() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErrMsg != "" {
+				var appErr apperr.AppError
+				if !errors.As(err, &appErr) || appErr.Code != apperr.ErrInsufficientFunds.Code || appErr.Message != tt.wantErrMsg {
+					t.Errorf("MakeTransaction() error = %+v, want %s %q", err, apperr.ErrInsufficientFunds.Code, tt.wantErrMsg)
+				}
+			}
+			// A created transaction is returned already posted unless held for review
+			wantStatus := tt.wantStatus
+			if wantStatus == "" {
+				wantStatus = entity.TransactionPosted
+			}
+			if !tt.wantErr && (got.Status != wantStatus || got.TransactionTime.IsZero()) {
+				t.Errorf("MakeTransaction() got = %+v, want a %s transaction", got, wantStatus)
+			}
+		})
+	}
+}
+
+// newTestAccountUsecase wires an accountUsecase with fresh mocks.
+func newTestAccountUsecase(ctrl *gomock.Controller) (accountUsecase, fields) {
+	testFields := fields{
+		accountRepo:     mock.NewMockAccountRepository(ctrl),
+		transactionRepo: mock.NewMockTransactionRepository(ctrl),
+		ledgerRepo:      mock.NewMockLedgerRepository(ctrl),
+		holdRepo:        mock.NewMockHoldRepository(ctrl),
+		auditRepo:       mock.NewMockAccountAuditRepository(ctrl),
+		reviewRepo:      mock.NewMockRiskReviewRepository(ctrl),
+		splitRepo:       mock.NewMockSplitPaymentRepository(ctrl),
+		outboxRepo:      mock.NewMockOutboxRepository(ctrl),
+		txManager:       &mock2.MockTxManager{},
+	}
+	uc := accountUsecase{
+		accountRepo:     testFields.accountRepo,
+		transactionRepo: testFields.transactionRepo,
+		ledgerRepo:      testFields.ledgerRepo,
+		holdRepo:        testFields.holdRepo,
+		auditRepo:       testFields.auditRepo,
+		reviewRepo:      testFields.reviewRepo,
+		splitRepo:       testFields.splitRepo,
+		outboxRepo:      testFields.outboxRepo,
+		limits:          &limitEvaluator{transactionRepo: testFields.transactionRepo},
+		risk:            &riskChain{},
+		fees:            &feeCalculator{},
+		txManager:       testFields.txManager,
+		broadcaster:     NewAccountBroadcaster(&config.Config{}),
+		holdTTL:         time.Hour,
+	}
+	return uc, testFields
+}
+
+func Test_accountUsecase_AuthorizeTransaction(t *testing.T) {
+	flatFee := &feeCalculator{schedules: []entity.FeeSchedule{{
+		Name: "internal_usd", TransferType: entity.TransferInternal, Currency: entity.CurrencyUSD,
+		Type: entity.FeeFlat, Flat: entity.MustParseMoney("2.00"),
+	}}}
+
+	tests := []struct {
+		name    string
+		req     dto.AuthorizeDTO
+		fees    FeeCalculator
+		limits  []entity.LimitRule
+		risk    RiskEvaluator
+		setup   func(fields fields)
+		wantErr bool
+	}{
+		{
+			name: "success",
+			req:  dto.AuthorizeDTO{SourceAccountID: 111, DestinationAccountID: 222, Amount: entity.MustParseMoney("100.00")},
+			setup: func(fields fields) {
+				accounts := []*entity.Account{
+					{ID: 111, Balance: entity.MustParseMoney("1000.00"), Currency: entity.CurrencyUSD, Status: entity.AccountActive},
+					{ID: 222, Balance: entity.MustParseMoney("500.00"), Currency: entity.CurrencyUSD, Status: entity.AccountActive},
+				}
+				fields.accountRepo.EXPECT().FindForUpdate(gomock.Any(), []uint64{111, 222}).Return(accounts, nil)
+				fields.holdRepo.EXPECT().SumActive(gomock.Any(), uint64(111), gomock.Any()).
+					Return(entity.MustParseMoney("900.00"), nil)
+				// Authorizing moves no money: no transaction, posting or balance change
+				fields.holdRepo.EXPECT().Create(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, h *entity.Hold) (*entity.Hold, error) {
+						if h.Status != entity.HoldAuthorized || h.Amount != entity.MustParseMoney("100.00") ||
+							h.Currency != entity.CurrencyUSD || !h.ExpiresAt.After(time.Now()) {
+							t.Errorf("unexpected hold: %+v", h)
+						}
+						h.ID = 1
+						return h, nil
+					})
+				// The account is saved unchanged, to stream its lower available balance under a new version
+				fields.accountRepo.EXPECT().Update(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, acc *entity.Account) error {
+						if acc.ID != 111 || acc.Balance != entity.MustParseMoney("1000.00") {
+							t.Errorf("unexpected account update: %+v", acc)
+						}
+						return nil
+					})
+			},
+		},
+		{
+			name: "fee_reserved_with_amount",
+			req:  dto.AuthorizeDTO{SourceAccountID: 111, DestinationAccountID: 222, Amount: entity.MustParseMoney("100.00")},
+			fees: flatFee,
+			setup: func(fields fields) {
+				accounts := []*entity.Account{
+					{ID: 111, Balance: entity.MustParseMoney("1000.00"), Currency: entity.CurrencyUSD, Status: entity.AccountActive},
+					{ID: 222, Balance: entity.MustParseMoney("500.00"), Currency: entity.CurrencyUSD, Status: entity.AccountActive},
+				}
+				fields.accountRepo.EXPECT().FindForUpdate(gomock.Any(), []uint64{111, 222}).Return(accounts, nil)
+				fields.holdRepo.EXPECT().SumActive(gomock.Any(), uint64(111), gomock.Any()).
+					Return(entity.MustParseMoney("898.00"), nil)
+				fields.holdRepo.EXPECT().Create(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, h *entity.Hold) (*entity.Hold, error) {
+						if h.Amount != entity.MustParseMoney("100.00") || h.Fee.Amount != entity.MustParseMoney("2.00") ||
+							h.Reserved() != entity.MustParseMoney("102.00") {
+							t.Errorf("unexpected hold: %+v", h)
+						}
+						h.ID = 1
+						return h, nil
+					})
+				fields.accountRepo.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil)
+			},
+		},
+		{
+			name: "insufficient_funds_for_fee",
+			req:  dto.AuthorizeDTO{SourceAccountID: 111, DestinationAccountID: 222, Amount: entity.MustParseMoney("100.00")},
+			fees: flatFee,
+			setup: func(fields fields) {
+				accounts := []*entity.Account{
+					{ID: 111, Balance: entity.MustParseMoney("1000.00"), Currency: entity.CurrencyUSD, Status: entity.AccountActive},
+					{ID: 222, Balance: entity.MustParseMoney("500.00"), Currency: entity.CurrencyUSD, Status: entity.AccountActive},
+				}
+				fields.accountRepo.EXPECT().FindForUpdate(gomock.Any(), []uint64{111, 222}).Return(accounts, nil)
+				fields.holdRepo.EXPECT().SumActive(gomock.Any(), uint64(111), gomock.Any()).
+					Return(entity.MustParseMoney("899.00"), nil)
+			},
+			wantErr: true,
+		},
+		{
+			name: "flagged_for_review",
+			req:  dto.AuthorizeDTO{SourceAccountID: 111, DestinationAccountID: 222, Amount: entity.MustParseMoney("2000.00")},
+			risk: &riskChain{rules: []RiskRule{newAccountRule{
+				maxAge:     24 * time.Hour,
+				thresholds: map[entity.Currency]entity.Money{entity.CurrencyUSD: entity.MustParseMoney("1000.00")},
+				action:     entity.RiskDecisionReview,
+			}}},
+			setup: func(fields fields) {
+				accounts := []*entity.Account{
+					{ID: 111, Balance: entity.MustParseMoney("10000.00"), Currency: entity.CurrencyUSD, Status: entity.AccountActive,
+						CreatedAt: time.Now().Add(-time.Hour)},
+					{ID: 222, Balance: entity.MustParseMoney("500.00"), Currency: entity.CurrencyUSD, Status: entity.AccountActive},
+				}
+				fields.accountRepo.EXPECT().FindForUpdate(gomock.Any(), []uint64{111, 222}).Return(accounts, nil)
+				fields.holdRepo.EXPECT().SumActive(gomock.Any(), uint64(111), gomock.Any()).Return(entity.Money{}, nil)
+				// The hold reserves the funds; its capture waits for the review
+				fields.holdRepo.EXPECT().Create(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, h *entity.Hold) (*entity.Hold, error) {
+						if h.Status != entity.HoldAuthorized || !h.NeedsReview() || h.RiskRule != "new_account" {
+							t.Errorf("unexpected hold: %+v", h)
+						}
+						return h, nil
+					})
+				fields.accountRepo.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil)
+			},
+		},
+		{
+			name: "denied_by_risk",
+			req:  dto.AuthorizeDTO{SourceAccountID: 111, DestinationAccountID: 222, Amount: entity.MustParseMoney("2000.00")},
+			risk: &riskChain{rules: []RiskRule{newAccountRule{
+				maxAge:     24 * time.Hour,
+				thresholds: map[entity.Currency]entity.Money{entity.CurrencyUSD: entity.MustParseMoney("1000.00")},
+				action:     entity.RiskDecisionDeny,
+			}}},
+			setup: func(fields fields) {
+				accounts := []*entity.Account{
+					{ID: 111, Balance: entity.MustParseMoney("10000.00"), Currency: entity.CurrencyUSD, Status: entity.AccountActive,
+						CreatedAt: time.Now().Add(-time.Hour)},
+					{ID: 222, Balance: entity.MustParseMoney("500.00"), Currency: entity.CurrencyUSD, Status: entity.AccountActive},
+				}
+				fields.accountRepo.EXPECT().FindForUpdate(gomock.Any(), []uint64{111, 222}).Return(accounts, nil)
+				fields.holdRepo.EXPECT().SumActive(gomock.Any(), uint64(111), gomock.Any()).Return(entity.Money{}, nil)
+			},
+			wantErr: true,
+		},
+		{
+			name: "daily_limit_exceeded",
+			req:  dto.AuthorizeDTO{SourceAccountID: 111, DestinationAccountID: 222, Amount: entity.MustParseMoney("100.00")},
+			limits: []entity.LimitRule{{Name: "daily", Type: entity.LimitMaxTotal, Window: entity.LimitWindowDay,
+				Currency: entity.CurrencyUSD, Amount: entity.MustParseMoney("500.00")}},
+			setup: func(fields fields) {
+				accounts := []*entity.Account{
+					{ID: 111, Balance: entity.MustParseMoney("1000.00"), Currency: entity.CurrencyUSD, Status: entity.AccountActive},
+					{ID: 222, Balance: entity.MustParseMoney("500.00"), Currency: entity.CurrencyUSD, Status: entity.AccountActive},
+				}
+				fields.accountRepo.EXPECT().FindForUpdate(gomock.Any(), []uint64{111, 222}).Return(accounts, nil)
+				// Open holds count towards the limit like posted transfers
+				fields.transactionRepo.EXPECT().SumOutgoingSince(gomock.Any(), uint64(111), gomock.Any(), gomock.Any()).
+					Return(entity.TransferUsage{Total: entity.MustParseMoney("400.01"), Count: 2}, nil)
+			},
+			wantErr: true,
+		},
+		{
+			name: "insufficient_available_balance",
+			req:  dto.AuthorizeDTO{SourceAccountID: 111, DestinationAccountID: 222, Amount: entity.MustParseMoney("100.01")},
+			setup: func(fields fields) {
+				accounts := []*entity.Account{
+					{ID: 111, Balance: entity.MustParseMoney("1000.00"), Currency: entity.CurrencyUSD, Status: entity.AccountActive},
+					{ID: 222, Balance: entity.MustParseMoney("500.00"), Currency: entity.CurrencyUSD, Status: entity.AccountActive},
+				}
+				fields.accountRepo.EXPECT().FindForUpdate(gomock.Any(), []uint64{111, 222}).Return(accounts, nil)
+				fields.holdRepo.EXPECT().SumActive(gomock.Any(), uint64(111), gomock.Any()).
+					Return(entity.MustParseMoney("900.00"), nil)
+			},
+			wantErr: true,
+		},
+		{
+			name: "cross_currency_not_supported",
+			req:  dto.AuthorizeDTO{SourceAccountID: 111, DestinationAccountID: 222, Amount: entity.MustParseMoney("100.00")},
+			setup: func(fields fields) {
+				accounts := []*entity.Account{
+					{ID: 111, Balance: entity.MustParseMoney("1000.00"), Currency: entity.CurrencyUSD, Status: entity.AccountActive},
+					{ID: 222, Balance: entity.MustParseMoney("500.00"), Currency: entity.CurrencyEUR, Status: entity.AccountActive},
+				}
+				fields.accountRepo.EXPECT().FindForUpdate(gomock.Any(), []uint64{111, 222}).Return(accounts, nil)
+			},
+			wantErr: true,
+		},
+		{
+			name:    "self_authorization",
+			req:     dto.AuthorizeDTO{SourceAccountID: 111, DestinationAccountID: 111, Amount: entity.MustParseMoney("100.00")},
+			wantErr: true,
+		},
+		{
+			name:    "validation_error_invalid_amount",
+			req:     dto.AuthorizeDTO{SourceAccountID: 111, DestinationAccountID: 222, Amount: entity.MustParseMoney("-1")},
+			wantErr: true,
+		},
+		{
+			name: "create_error",
+			req:  dto.AuthorizeDTO{SourceAccountID: 111, DestinationAccountID: 222, Amount: entity.MustParseMoney("100.00")},
+			setup: func(fields fields) {
+				accounts := []*entity.Account{
+					{ID: 111, Balance: entity.MustParseMoney("1000.00"), Currency: entity.CurrencyUSD, Status: entity.AccountActive},
+					{ID: 222, Balance: entity.MustParseMoney("500.00"), Currency: entity.CurrencyUSD, Status: entity.AccountActive},
+				}
+				fields.accountRepo.EXPECT().FindForUpdate(gomock.Any(), []uint64{111, 222}).Return(accounts, nil)
+				fields.holdRepo.EXPECT().SumActive(gomock.Any(), uint64(111), gomock.Any()).Return(entity.Money{}, nil)
+				fields.holdRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil, errors.New("database error"))
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			uc, testFields := newTestAccountUsecase(ctrl)
+			if tt.fees != nil {
+				uc.fees = tt.fees
+			}
+			uc.limits = &limitEvaluator{transactionRepo: testFields.transactionRepo, rules: tt.limits}
+			if tt.risk != nil {
+				uc.risk = tt.risk
+			}
+			if tt.setup != nil {
+				tt.setup(testFields)
+			}
+			// Accounts have no open holds unless a case says otherwise
+			testFields.holdRepo.EXPECT().SumActive(gomock.Any(), gomock.Any(), gomock.Any()).Return(entity.Money{}, nil).AnyTimes()
+
+			got, err := uc.AuthorizeTransaction(context.Background(), tt.req)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("AuthorizeTransaction() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && got.Status != entity.HoldAuthorized {
+				t.Errorf("AuthorizeTransaction() status = %v, want %v", got.Status, entity.HoldAuthorized)
+			}
+		})
+	}
+}
+
+func Test_accountUsecase_CaptureHold(t *testing.T) {
+	openHold := func() *entity.Hold {
+		return &entity.Hold{
+			ID:                   1,
+			SourceAccountID:      111,
+			DestinationAccountID: 222,
+			Amount:               entity.MustParseMoney("100.00"),
+			Currency:             entity.CurrencyUSD,
+			Status:               entity.HoldAuthorized,
+			ExpiresAt:            time.Now().Add(time.Hour),
+		}
+	}
+	partial := entity.MustParseMoney("60.00")
+	tooMuch := entity.MustParseMoney("100.01")
+	percentageFee := &feeCalculator{schedules: []entity.FeeSchedule{{
+		Name: "internal_usd", TransferType: entity.TransferInternal, Currency: entity.CurrencyUSD,
+		Type: entity.FeePercentage, Rate: entity.MustParseRate("0.01"),
+	}}}
+	heldFee := entity.FeeBreakdown{Schedule: "internal_usd", Percentage: entity.MustParseMoney("1.00"),
+		Amount: entity.MustParseMoney("1.00")}
+
+	tests := []struct {
+		name         string
+		req          dto.CaptureDTO
+		fees         FeeCalculator
+		setup        func(fields fields)
+		wantCaptured entity.Money
+		wantErr      bool
+	}{
+		{
+			name: "full_capture",
+			req:  dto.CaptureDTO{HoldID: 1},
+			setup: func(fields fields) {
+				accounts := []*entity.Account{
+					{ID: 111, Balance: entity.MustParseMoney("100.00"), Currency: entity.CurrencyUSD, Status: entity.AccountActive},
+					{ID: 222, Balance: entity.MustParseMoney("0.00"), Currency: entity.CurrencyUSD, Status: entity.AccountActive},
+				}
+				fields.holdRepo.EXPECT().FindForUpdate(gomock.Any(), uint64(1)).Return(openHold(), nil)
+				fields.accountRepo.EXPECT().FindForUpdate(gomock.Any(), []uint64{111, 222}).Return(accounts, nil)
+				// The whole balance is reserved by the hold being captured
+				fields.holdRepo.EXPECT().SumActive(gomock.Any(), uint64(111), gomock.Any()).
+					Return(entity.MustParseMoney("100.00"), nil)
+				fields.transactionRepo.EXPECT().Create(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, tx *entity.Transaction) (*entity.Transaction, error) {
+						tx.ID = 42
+						return tx, nil
+					})
+				fields.ledgerRepo.EXPECT().CreateEntry(gomock.Any(), gomock.Any()).Return(&entity.JournalEntry{}, nil)
+				fields.outboxRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+				fields.accountRepo.EXPECT().Update(gomock.Any(), &entity.Account{
+					ID: 111, Balance: entity.MustParseMoney("0.00"), Currency: entity.CurrencyUSD, Status: entity.AccountActive,
+				}).Return(nil)
+				fields.accountRepo.EXPECT().Update(gomock.Any(), &entity.Account{
+					ID: 222, Balance: entity.MustParseMoney("100.00"), Currency: entity.CurrencyUSD, Status: entity.AccountActive,
+				}).Return(nil)
+				// The hold is closed before the transfer posts, then linked to the transfer
+				fields.holdRepo.EXPECT().Update(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, h *entity.Hold) error {
+						if h.Status != entity.HoldCaptured || h.TransactionID != nil {
+							t.Errorf("hold not captured: %+v", h)
+						}
+						return nil
+					})
+				fields.holdRepo.EXPECT().Update(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, h *entity.Hold) error {
+						if h.Status != entity.HoldCaptured || h.TransactionID == nil || *h.TransactionID != 42 {
+							t.Errorf("hold not linked to the transfer: %+v", h)
+						}
+						return nil
+					})
+			},
+			wantCaptured: entity.MustParseMoney("100.00"),
+		},
+		{
+			name: "partial_capture",
+			req:  dto.CaptureDTO{HoldID: 1, Amount: &partial},
+			setup: func(fields fields) {
+				accounts := []*entity.Account{
+					{ID: 111, Balance: entity.MustParseMoney("100.00"), Currency: entity.CurrencyUSD, Status: entity.AccountActive},
+					{ID: 222, Balance: entity.MustParseMoney("0.00"), Currency: entity.CurrencyUSD, Status: entity.AccountActive},
+				}
+				fields.holdRepo.EXPECT().FindForUpdate(gomock.Any(), uint64(1)).Return(openHold(), nil)
+				fields.accountRepo.EXPECT().FindForUpdate(gomock.Any(), []uint64{111, 222}).Return(accounts, nil)
+				fields.holdRepo.EXPECT().SumActive(gomock.Any(), uint64(111), gomock.Any()).
+					Return(entity.MustParseMoney("100.00"), nil)
+				fields.transactionRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(&entity.Transaction{}, nil)
+				fields.ledgerRepo.EXPECT().CreateEntry(gomock.Any(), gomock.Any()).Return(&entity.JournalEntry{}, nil)
+				fields.outboxRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+				// Only the captured amount moves; the remainder is released with the hold
+				fields.accountRepo.EXPECT().Update(gomock.Any(), &entity.Account{
+					ID: 111, Balance: entity.MustParseMoney("40.00"), Currency: entity.CurrencyUSD, Status: entity.AccountActive,
+				}).Return(nil)
+				fields.accountRepo.EXPECT().Update(gomock.Any(), &entity.Account{
+					ID: 222, Balance: entity.MustParseMoney("60.00"), Currency: entity.CurrencyUSD, Status: entity.AccountActive,
+				}).Return(nil)
+				fields.holdRepo.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil).Times(2)
+			},
+			wantCaptured: partial,
+		},
+		{
+			name: "partial_capture_with_fee",
+			req:  dto.CaptureDTO{HoldID: 1, Amount: &partial},
+			fees: percentageFee,
+			setup: func(fields fields) {
+				accounts := []*entity.Account{
+					{ID: 111, Balance: entity.MustParseMoney("101.00"), Currency: entity.CurrencyUSD, Status: entity.AccountActive},
+					{ID: 222, Balance: entity.MustParseMoney("0.00"), Currency: entity.CurrencyUSD, Status: entity.AccountActive},
+				}
+				hold := openHold()
+				hold.Fee = heldFee
+				fields.holdRepo.EXPECT().FindForUpdate(gomock.Any(), uint64(1)).Return(hold, nil)
+				fields.accountRepo.EXPECT().FindForUpdate(gomock.Any(), []uint64{111, 222}).Return(accounts, nil)
+				// The amount and the fee of the hold are both reserved, and both released by the capture
+				fields.holdRepo.EXPECT().SumActive(gomock.Any(), uint64(111), gomock.Any()).
+					Return(entity.MustParseMoney("101.00"), nil)
+				fields.transactionRepo.EXPECT().Create(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, tx *entity.Transaction) (*entity.Transaction, error) {
+						if tx.Fee.Amount != entity.MustParseMoney("0.60") {
+							t.Errorf("unexpected fee: %+v", tx.Fee)
+						}
+						return tx, nil
+					})
+				fields.ledgerRepo.EXPECT().CreateEntry(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, entry *entity.JournalEntry) (*entity.JournalEntry, error) {
+						last := entry.Postings[len(entry.Postings)-1]
+						if last.SystemAccount != entity.SystemAccountFeeRevenue || last.Amount != entity.MustParseMoney("0.60") {
+							t.Errorf("unexpected fee postings: %+v", entry.Postings)
+						}
+						return entry, nil
+					})
+				fields.outboxRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+				fields.accountRepo.EXPECT().Update(gomock.Any(), &entity.Account{
+					ID: 111, Balance: entity.MustParseMoney("40.40"), Currency: entity.CurrencyUSD, Status: entity.AccountActive,
+				}).Return(nil)
+				fields.accountRepo.EXPECT().Update(gomock.Any(), &entity.Account{
+					ID: 222, Balance: entity.MustParseMoney("60.00"), Currency: entity.CurrencyUSD, Status: entity.AccountActive,
+				}).Return(nil)
+				fields.holdRepo.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil).Times(2)
+			},
+			wantCaptured: partial,
+		},
+		{
+			name: "fee_capped_at_authorized_fee",
+			req:  dto.CaptureDTO{HoldID: 1},
+			fees: &feeCalculator{schedules: []entity.FeeSchedule{{
+				Name: "internal_usd", TransferType: entity.TransferInternal, Currency: entity.CurrencyUSD,
+				Type: entity.FeeFlat, Flat: entity.MustParseMoney("5.00"),
+			}}},
+			setup: func(fields fields) {
+				accounts := []*entity.Account{
+					{ID: 111, Balance: entity.MustParseMoney("101.00"), Currency: entity.CurrencyUSD, Status: entity.AccountActive},
+					{ID: 222, Balance: entity.MustParseMoney("0.00"), Currency: entity.CurrencyUSD, Status: entity.AccountActive},
+				}
+				hold := openHold()
+				hold.Fee = heldFee
+				fields.holdRepo.EXPECT().FindForUpdate(gomock.Any(), uint64(1)).Return(hold, nil)
+				fields.accountRepo.EXPECT().FindForUpdate(gomock.Any(), []uint64{111, 222}).Return(accounts, nil)
+				fields.holdRepo.EXPECT().SumActive(gomock.Any(), uint64(111), gomock.Any()).
+					Return(entity.MustParseMoney("101.00"), nil)
+				// A schedule raised since the authorization does not raise the fee of the hold
+				fields.transactionRepo.EXPECT().Create(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, tx *entity.Transaction) (*entity.Transaction, error) {
+						if tx.Fee != heldFee {
+							t.Errorf("unexpected fee: %+v", tx.Fee)
+						}
+						return tx, nil
+					})
+				fields.ledgerRepo.EXPECT().CreateEntry(gomock.Any(), gomock.Any()).Return(&entity.JournalEntry{}, nil)
+				fields.outboxRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+				fields.accountRepo.EXPECT().Update(gomock.Any(), &entity.Account{
+					ID: 111, Balance: entity.MustParseMoney("0.00"), Currency: entity.CurrencyUSD, Status: entity.AccountActive,
+				}).Return(nil)
+				fields.accountRepo.EXPECT().Update(gomock.Any(), &entity.Account{
+					ID: 222, Balance: entity.MustParseMoney("100.00"), Currency: entity.CurrencyUSD, Status: entity.AccountActive,
+				}).Return(nil)
+				fields.holdRepo.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil).Times(2)
+			},
+			wantCaptured: entity.MustParseMoney("100.00"),
+		},
+		{
+			name: "flagged_hold_held_for_review",
+			req:  dto.CaptureDTO{HoldID: 1},
+			setup: func(fields fields) {
+				accounts := []*entity.Account{
+					{ID: 111, Balance: entity.MustParseMoney("100.00"), Currency: entity.CurrencyUSD, Status: entity.AccountActive},
+					{ID: 222, Balance: entity.MustParseMoney("0.00"), Currency: entity.CurrencyUSD, Status: entity.AccountActive},
+				}
+				hold := openHold()
+				hold.RiskRule = "new_account"
+				hold.RiskReason = "large transfer from a new account"
+				fields.holdRepo.EXPECT().FindForUpdate(gomock.Any(), uint64(1)).Return(hold, nil)
+				fields.accountRepo.EXPECT().FindForUpdate(gomock.Any(), []uint64{111, 222}).Return(accounts, nil)
+				fields.holdRepo.EXPECT().SumActive(gomock.Any(), uint64(111), gomock.Any()).
+					Return(entity.MustParseMoney("100.00"), nil)
+				// The transfer is stored pending with an open review; no posting and no balance change
+				fields.transactionRepo.EXPECT().Create(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, tx *entity.Transaction) (*entity.Transaction, error) {
+						if tx.Status != entity.TransactionPending {
+							t.Errorf("held transfer stored as %s", tx.Status)
+						}
+						tx.ID = 42
+						return tx, nil
+					})
+				fields.reviewRepo.EXPECT().Create(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, review *entity.RiskReview) (*entity.RiskReview, error) {
+						if review.TransactionID != 42 || review.Rule != "new_account" || review.Status != entity.RiskReviewOpen {
+							t.Errorf("unexpected risk review: %+v", review)
+						}
+						return review, nil
+					})
+				// The account is saved unchanged, to stream the funds of the hold as no longer reserved
+				fields.accountRepo.EXPECT().Update(gomock.Any(), &entity.Account{
+					ID: 111, Balance: entity.MustParseMoney("100.00"), Currency: entity.CurrencyUSD, Status: entity.AccountActive,
+				}).Return(nil)
+				fields.holdRepo.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil).Times(2)
+			},
+			wantCaptured: entity.MustParseMoney("100.00"),
+		},
+		{
+			name: "capture_exceeds_hold",
+			req:  dto.CaptureDTO{HoldID: 1, Amount: &tooMuch},
+			setup: func(fields fields) {
+				fields.holdRepo.EXPECT().FindForUpdate(gomock.Any(), uint64(1)).Return(openHold(), nil)
+			},
+			wantErr: true,
+		},
+		{
+			name: "hold_expired",
+			req:  dto.CaptureDTO{HoldID: 1},
+			setup: func(fields fields) {
+				hold := openHold()
+				hold.ExpiresAt = time.Now().Add(-time.Second)
+				fields.holdRepo.EXPECT().FindForUpdate(gomock.Any(), uint64(1)).Return(hold, nil)
+			},
+			wantErr: true,
+		},
+		{
+			name: "hold_already_voided",
+			req:  dto.CaptureDTO{HoldID: 1},
+			setup: func(fields fields) {
+				hold := openHold()
+				hold.Status = entity.HoldVoided
+				fields.holdRepo.EXPECT().FindForUpdate(gomock.Any(), uint64(1)).Return(hold, nil)
+			},
+			wantErr: true,
+		},
+		{
+			name: "hold_not_found",
+			req:  dto.CaptureDTO{HoldID: 1},
+			setup: func(fields fields) {
+				fields.holdRepo.EXPECT().FindForUpdate(gomock.Any(), uint64(1)).Return(nil, nil)
+			},
+			wantErr: true,
+		},
+		{
+			name:    "validation_error_missing_hold_id",
+			req:     dto.CaptureDTO{},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			uc, testFields := newTestAccountUsecase(ctrl)
+			if tt.fees != nil {
+				uc.fees = tt.fees
+			}
+			if tt.setup != nil {
+				tt.setup(testFields)
+			}
+			// Accounts have no open holds unless a case says otherwise
+			testFields.holdRepo.EXPECT().SumActive(gomock.Any(), gomock.Any(), gomock.Any()).Return(entity.Money{}, nil).AnyTimes()
+
+			got, err := uc.CaptureHold(context.Background(), tt.req)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("CaptureHold() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && (got.Status != entity.HoldCaptured || got.CapturedAmount != tt.wantCaptured) {
+				t.Errorf("CaptureHold() got = %+v, want captured %v", got, tt.wantCaptured)
+			}
+		})
+	}
+}
+
+func Test_accountUsecase_VoidHold(t *testing.T) {
+	tests := []struct {
+		name    string
+		setup   func(fields fields)
+		wantErr bool
+	}{
+		{
+			name: "success",
+			setup: func(fields fields) {
+				fields.holdRepo.EXPECT().FindForUpdate(gomock.Any(), uint64(1)).Return(&entity.Hold{
+					ID: 1, SourceAccountID: 111, Status: entity.HoldAuthorized, ExpiresAt: time.Now().Add(time.Hour),
+				}, nil)
+				fields.accountRepo.EXPECT().FindForUpdate(gomock.Any(), []uint64{111}).Return([]*entity.Account{
+					{ID: 111, Balance: entity.MustParseMoney("100.00"), Status: entity.AccountActive}}, nil)
+				fields.holdRepo.EXPECT().Update(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, h *entity.Hold) error {
+						if h.Status != entity.HoldVoided {
+							t.Errorf("hold not voided: %+v", h)
+						}
+						return nil
+					})
+				// The account is saved unchanged, to stream its released funds under a new version
+				fields.accountRepo.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil)
+				fields.holdRepo.EXPECT().SumActive(gomock.Any(), uint64(111), gomock.Any()).Return(entity.Money{}, nil)
+			},
+		},
+		{
+			name: "already_captured",
+			setup: func(fields fields) {
+				fields.holdRepo.EXPECT().FindForUpdate(gomock.Any(), uint64(1)).Return(&entity.Hold{
+					ID: 1, Status: entity.HoldCaptured, ExpiresAt: time.Now().Add(time.Hour),
+				}, nil)
+			},
+			wantErr: true,
+		},
+		{
+			name: "update_error",
+			setup: func(fields fields) {
+				fields.holdRepo.EXPECT().FindForUpdate(gomock.Any(), uint64(1)).Return(&entity.Hold{
+					ID: 1, SourceAccountID: 111, Status: entity.HoldAuthorized, ExpiresAt: time.Now().Add(time.Hour),
+				}, nil)
+				fields.accountRepo.EXPECT().FindForUpdate(gomock.Any(), []uint64{111}).Return([]*entity.Account{
+					{ID: 111, Balance: entity.MustParseMoney("100.00"), Status: entity.AccountActive}}, nil)
+				fields.holdRepo.EXPECT().Update(gomock.Any(), gomock.Any()).Return(errors.New("database error"))
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			uc, testFields := newTestAccountUsecase(ctrl)
+			if tt.setup != nil {
+				tt.setup(testFields)
+			}
+
+			_, err := uc.VoidHold(context.Background(), 1)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("VoidHold() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_accountUsecase_ReverseTransaction(t *testing.T) {
+	var originalID uint64 = 10
+	sameCurrency := func() *entity.Transaction {
+		return &entity.Transaction{
+			ID:                   originalID,
+			SourceAccountID:      111,
+			DestinationAccountID: 222,
+			Amount:               entity.MustParseMoney("100.00"),
+			Currency:             entity.CurrencyUSD,
+			DestinationAmount:    entity.MustParseMoney("100.00"),
+			DestinationCurrency:  entity.CurrencyUSD,
+			ExchangeRate:         entity.OneRate,
+			Status:               entity.TransactionPosted,
+		}
+	}
+	crossCurrency := func() *entity.Transaction {
+		return &entity.Transaction{
+			ID:                   originalID,
+			SourceAccountID:      111,
+			DestinationAccountID: 222,
+			Amount:               entity.MustParseMoney("100.00"),
+			Currency:             entity.CurrencyUSD,
+			DestinationAmount:    entity.MustParseMoney("92.35"),
+			DestinationCurrency:  entity.CurrencyEUR,
+			ExchangeRate:         entity.MustParseRate("0.92345"),
+			Status:               entity.TransactionPosted,
+		}
+	}
+	// Accounts are locked in the reversal direction: original destination first
+	usdAccounts := func() []*entity.Account {
+		return []*entity.Account{
+			{ID: 111, Balance: entity.MustParseMoney("900.00"), Currency: entity.CurrencyUSD, Status: entity.AccountActive},
+			{ID: 222, Balance: entity.MustParseMoney("100.00"), Currency: entity.CurrencyUSD, Status: entity.AccountActive},
+		}
+	}
+	partial := entity.MustParseMoney("40.00")
+	tooMuch := entity.MustParseMoney("60.01")
+
+	tests := []struct {
+		name    string
+		req     dto.ReversalDTO
+		setup   func(fields fields)
+		want    dto.ReversalResultDTO
+		wantErr bool
+	}{
+		{
+			name: "full_reversal",
+			req:  dto.ReversalDTO{TransactionID: originalID},
+			setup: func(fields fields) {
+				fields.transactionRepo.EXPECT().FindForUpdate(gomock.Any(), originalID).Return(sameCurrency(), nil)
+				fields.transactionRepo.EXPECT().FindReversals(gomock.Any(), originalID).Return(nil, nil)
+				fields.accountRepo.EXPECT().FindForUpdate(gomock.Any(), []uint64{222, 111}).Return(usdAccounts(), nil)
+				fields.holdRepo.EXPECT().SumActive(gomock.Any(), uint64(222), gomock.Any()).Return(entity.Money{}, nil)
+				fields.transactionRepo.EXPECT().Create(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, tx *entity.Transaction) (*entity.Transaction, error) {
+						if tx.SourceAccountID != 222 || tx.DestinationAccountID != 111 ||
+							tx.OriginalTransactionID == nil || *tx.OriginalTransactionID != originalID {
+							t.Errorf("reversal not linked to original: %+v", tx)
+						}
+						tx.ID = 11
+						return tx, nil
+					})
+				fields.ledgerRepo.EXPECT().CreateEntry(gomock.Any(), gomock.Any()).Return(&entity.JournalEntry{}, nil)
+				fields.outboxRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+				fields.accountRepo.EXPECT().Update(gomock.Any(), &entity.Account{
+					ID: 222, Balance: entity.MustParseMoney("0.00"), Currency: entity.CurrencyUSD, Status: entity.AccountActive,
+				}).Return(nil)
+				fields.accountRepo.EXPECT().Update(gomock.Any(), &entity.Account{
+					ID: 111, Balance: entity.MustParseMoney("1000.00"), Currency: entity.CurrencyUSD, Status: entity.AccountActive,
+				}).Return(nil)
+				// Nothing is left to reverse, so the original is marked reversed
+				fields.transactionRepo.EXPECT().Update(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, tx *entity.Transaction) error {
+						if tx.ID != originalID || tx.Status != entity.TransactionReversed {
+							t.Errorf("original not marked reversed: %+v", tx)
+						}
+						return nil
+					})
+			},
+			want: dto.ReversalResultDTO{
+				TransactionID:         11,
+				OriginalTransactionID: originalID,
+				Amount:                entity.MustParseMoney("100.00"),
+				Currency:              entity.CurrencyUSD,
+				RemainingAmount:       entity.MustParseMoney("0.00"),
+			},
+		},
+		{
+			name: "partial_reversal",
+			req:  dto.ReversalDTO{TransactionID: originalID, Amount: &partial},
+			setup: func(fields fields) {
+				fields.transactionRepo.EXPECT().FindForUpdate(gomock.Any(), originalID).Return(sameCurrency(), nil)
+				fields.transactionRepo.EXPECT().FindReversals(gomock.Any(), originalID).Return(nil, nil)
+				fields.accountRepo.EXPECT().FindForUpdate(gomock.Any(), []uint64{222, 111}).Return(usdAccounts(), nil)
+				fields.holdRepo.EXPECT().SumActive(gomock.Any(), uint64(222), gomock.Any()).Return(entity.Money{}, nil)
+				fields.transactionRepo.EXPECT().Create(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, tx *entity.Transaction) (*entity.Transaction, error) {
+						tx.ID = 11
+						return tx, nil
+					})
+				fields.ledgerRepo.EXPECT().CreateEntry(gomock.Any(), gomock.Any()).Return(&entity.JournalEntry{}, nil)
+				fields.outboxRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+				fields.accountRepo.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil).Times(2)
+			},
+			want: dto.ReversalResultDTO{
+				TransactionID:         11,
+				OriginalTransactionID: originalID,
+				Amount:                partial,
+				Currency:              entity.CurrencyUSD,
+				RemainingAmount:       entity.MustParseMoney("60.00"),
+			},
+		},
+		{
+			name: "final_cross_currency_reversal_debits_remaining_credit",
+			req:  dto.ReversalDTO{TransactionID: originalID},
+			setup: func(fields fields) {
+				// 40 USD were already reversed for 36.94 EUR; 60 USD are left
+				previous := &entity.Transaction{
+					Amount:            entity.MustParseMoney("36.94"),
+					DestinationAmount: entity.MustParseMoney("40.00"),
+				}
+				accounts := []*entity.Account{
+					{ID: 111, Balance: entity.MustParseMoney("940.00"), Currency: entity.CurrencyUSD, Status: entity.AccountActive},
+					{ID: 222, Balance: entity.MustParseMoney("55.41"), Currency: entity.CurrencyEUR, Status: entity.AccountActive},
+				}
+				fields.transactionRepo.EXPECT().FindForUpdate(gomock.Any(), originalID).Return(crossCurrency(), nil)
+				fields.transactionRepo.EXPECT().FindReversals(gomock.Any(), originalID).
+					Return([]*entity.Transaction{previous}, nil)
+				fields.accountRepo.EXPECT().FindForUpdate(gomock.Any(), []uint64{222, 111}).Return(accounts, nil)
+				fields.holdRepo.EXPECT().SumActive(gomock.Any(), uint64(222), gomock.Any()).Return(entity.Money{}, nil)
+				fields.transactionRepo.EXPECT().Create(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, tx *entity.Transaction) (*entity.Transaction, error) {
+						if tx.Amount != entity.MustParseMoney("55.41") || tx.Currency != entity.CurrencyEUR ||
+							tx.ExchangeRate != entity.MustParseRate("1.08289566") {
+							t.Errorf("unexpected final reversal: %+v", tx)
+						}
+						tx.ID = 12
+						return tx, nil
+					})
+				fields.ledgerRepo.EXPECT().CreateEntry(gomock.Any(), gomock.Any()).Return(&entity.JournalEntry{}, nil)
+				fields.outboxRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+				fields.accountRepo.EXPECT().Update(gomock.Any(), &entity.Account{
+					ID: 222, Balance: entity.MustParseMoney("0.00"), Currency: entity.CurrencyEUR, Status: entity.AccountActive,
+				}).Return(nil)
+				fields.accountRepo.EXPECT().Update(gomock.Any(), &entity.Account{
+					ID: 111, Balance: entity.MustParseMoney("1000.00"), Currency: entity.CurrencyUSD, Status: entity.AccountActive,
+				}).Return(nil)
+				fields.transactionRepo.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil)
+			},
+			want: dto.ReversalResultDTO{
+				TransactionID:         12,
+				OriginalTransactionID: originalID,
+				Amount:                entity.MustParseMoney("60.00"),
+				Currency:              entity.CurrencyUSD,
+				RemainingAmount:       entity.MustParseMoney("0.00"),
+			},
+		},
+		{
+			name: "exceeds_remaining_amount",
+			req:  dto.ReversalDTO{TransactionID: originalID, Amount: &tooMuch},
+			setup: func(fields fields) {
+				fields.transactionRepo.EXPECT().FindForUpdate(gomock.Any(), originalID).Return(sameCurrency(), nil)
+				fields.transactionRepo.EXPECT().FindReversals(gomock.Any(), originalID).Return([]*entity.Transaction{
+					{Amount: entity.MustParseMoney("40.00"), DestinationAmount: entity.MustParseMoney("40.00")},
+				}, nil)
+			},
+			wantErr: true,
+		},
+		{
+			name: "already_fully_reversed",
+			req:  dto.ReversalDTO{TransactionID: originalID},
+			setup: func(fields fields) {
+				fields.transactionRepo.EXPECT().FindForUpdate(gomock.Any(), originalID).Return(sameCurrency(), nil)
+				fields.transactionRepo.EXPECT().FindReversals(gomock.Any(), originalID).Return([]*entity.Transaction{
+					{Amount: entity.MustParseMoney("100.00"), DestinationAmount: entity.MustParseMoney("100.00")},
+				}, nil)
+			},
+			wantErr: true,
+		},
+		{
+			name: "reversal_of_reversal",
+			req:  dto.ReversalDTO{TransactionID: originalID},
+			setup: func(fields fields) {
+				var earlier uint64 = 9
+				tx := sameCurrency()
+				tx.OriginalTransactionID = &earlier
+				fields.transactionRepo.EXPECT().FindForUpdate(gomock.Any(), originalID).Return(tx, nil)
+			},
+			wantErr: true,
+		},
+		{
+			name: "already_marked_reversed",
+			req:  dto.ReversalDTO{TransactionID: originalID},
+			setup: func(fields fields) {
+				tx := sameCurrency()
+				tx.Status = entity.TransactionReversed
+				fields.transactionRepo.EXPECT().FindForUpdate(gomock.Any(), originalID).Return(tx, nil)
+			},
+			wantErr: true,
+		},
+		{
+			name: "insufficient_balance_on_original_destination",
+			req:  dto.ReversalDTO{TransactionID: originalID},
+			setup: func(fields fields) {
+				fields.transactionRepo.EXPECT().FindForUpdate(gomock.Any(), originalID).Return(sameCurrency(), nil)
+				fields.transactionRepo.EXPECT().FindReversals(gomock.Any(), originalID).Return(nil, nil)
+				fields.accountRepo.EXPECT().FindForUpdate(gomock.Any(), []uint64{222, 111}).Return(usdAccounts(), nil)
+				fields.holdRepo.EXPECT().SumActive(gomock.Any(), uint64(222), gomock.Any()).
+					Return(entity.MustParseMoney("50.00"), nil)
+			},
+			wantErr: true,
+		},
+		{
+			name: "transaction_not_found",
+			req:  dto.ReversalDTO{TransactionID: originalID},
+			setup: func(fields fields) {
+				fields.transactionRepo.EXPECT().FindForUpdate(gomock.Any(), originalID).Return(nil, nil)
+			},
+			wantErr: true,
+		},
+		{
+			name:    "validation_error_missing_transaction_id",
+			req:     dto.ReversalDTO{},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			uc, testFields := newTestAccountUsecase(ctrl)
+			if tt.setup != nil {
+				tt.setup(testFields)
+			}
+			// Accounts have no open holds unless a case says otherwise
+			testFields.holdRepo.EXPECT().SumActive(gomock.Any(), gomock.Any(), gomock.Any()).Return(entity.Money{}, nil).AnyTimes()
+
+			got, err := uc.ReverseTransaction(context.Background(), tt.req)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ReverseTransaction() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ReverseTransaction() got = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_accountUsecase_ListTransactions(t *testing.T) {
+	base := time.Date(2025, 8, 20, 12, 0, 0, 0, time.UTC)
+	history := func(n int) []*entity.Transaction {
+		txs := make([]*entity.Transaction, 0, n)
+		for i := 0; i < n; i++ {
+			txs = append(txs, &entity.Transaction{
+				ID:                   uint64(100 - i),
+				SourceAccountID:      111,
+				DestinationAccountID: 222,
+				Amount:               entity.MustParseMoney("10.00"),
+				TransactionTime:      base.Add(-time.Duration(i) * time.Minute),
+			})
+		}
+		return txs
+	}
+	cursor := encodeHistoryCursor(historyCursor{Time: base, ID: 100})
+	minAmount := entity.MustParseMoney("50.00")
+	maxAmount := entity.MustParseMoney("10.00")
+	from := base
+	to := base.Add(-time.Hour)
+
+	tests := []struct {
+		name      string
+		req       dto.TransactionListDTO
+		setup     func(fields fields)
+		wantCount int
+		wantMeta  dto.PageMetaDTO
+		wantErr   bool
+	}{
+		{
+			name: "first_page_with_more",
+			req:  dto.TransactionListDTO{AccountID: 222, Limit: 2},
+			setup: func(fields fields) {
+				fields.accountRepo.EXPECT().FindOne(gomock.Any(), uint64(222)).Return(&entity.Account{ID: 222}, nil)
+				// One row more than the page size is requested to detect a next page
+				fields.transactionRepo.EXPECT().FindByAccount(gomock.Any(), entity.TransactionFilter{AccountID: 222, Limit: 3}).
+					Return(history(3), nil)
+			},
+			wantCount: 2,
+			wantMeta: dto.PageMetaDTO{
+				NextCursor: encodeHistoryCursor(historyCursor{Time: base.Add(-time.Minute), ID: 99}),
+				HasMore:    true,
+				Limit:      2,
+			},
+		},
+		{
+			name: "last_page_with_cursor_and_filters",
+			req: dto.TransactionListDTO{
+				AccountID: 111,
+				Direction: entity.TransactionOutgoing,
+				Cursor:    cursor,
+			},
+			setup: func(fields fields) {
+				fields.accountRepo.EXPECT().FindOne(gomock.Any(), uint64(111)).Return(&entity.Account{ID: 111}, nil)
+				fields.transactionRepo.EXPECT().FindByAccount(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, f entity.TransactionFilter) ([]*entity.Transaction, error) {
+						if f.Direction != entity.TransactionOutgoing || f.AfterTime == nil || !f.AfterTime.Equal(base) ||
+							f.AfterID != 100 || f.Limit != defaultHistoryLimit+1 {
+							t.Errorf("unexpected filter: %+v", f)
+						}
+						return history(1), nil
+					})
+			},
+			wantCount: 1,
+			wantMeta:  dto.PageMetaDTO{Limit: defaultHistoryLimit},
+		},
+		{
+			name:    "invalid_cursor",
+			req:     dto.TransactionListDTO{AccountID: 111, Cursor: "not-a-cursor"},
+			wantErr: true,
+		},
+		{
+			name:    "invalid_direction",
+			req:     dto.TransactionListDTO{AccountID: 111, Direction: "sideways"},
+			wantErr: true,
+		},
+		{
+			name:    "empty_time_range",
+			req:     dto.TransactionListDTO{AccountID: 111, From: &from, To: &to},
+			wantErr: true,
+		},
+		{
+			name:    "empty_amount_range",
+			req:     dto.TransactionListDTO{AccountID: 111, MinAmount: &minAmount, MaxAmount: &maxAmount},
+			wantErr: true,
+		},
+		{
+			name:    "limit_too_large",
+			req:     dto.TransactionListDTO{AccountID: 111, Limit: 101},
+			wantErr: true,
+		},
+		{
+			name: "account_not_found",
+			req:  dto.TransactionListDTO{AccountID: 999},
+			setup: func(fields fields) {
+				fields.accountRepo.EXPECT().FindOne(gomock.Any(), uint64(999)).Return(nil, nil)
+			},
+			wantErr: true,
+		},
+		{
+			name: "find_error",
+			req:  dto.TransactionListDTO{AccountID: 111},
+			setup: func(fields fields) {
+				fields.accountRepo.EXPECT().FindOne(gomock.Any(), uint64(111)).Return(&entity.Account{ID: 111}, nil)
+				fields.transactionRepo.EXPECT().FindByAccount(gomock.Any(), gomock.Any()).Return(nil, errors.New("database error"))
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			uc, testFields := newTestAccountUsecase(ctrl)
+			if tt.setup != nil {
+				tt.setup(testFields)
+			}
+
+			got, meta, err := uc.ListTransactions(context.Background(), tt.req)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ListTransactions() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if len(got) != tt.wantCount {
+				t.Errorf("ListTransactions() returned %d records, want %d", len(got), tt.wantCount)
+			}
+			if !reflect.DeepEqual(meta, tt.wantMeta) {
+				t.Errorf("ListTransactions() meta = %+v, want %+v", meta, tt.wantMeta)
+			}
+			for _, record := range got {
+				want := entity.TransactionIncoming
+				if record.SourceAccountID == tt.req.AccountID {
+					want = entity.TransactionOutgoing
+				}
+				if record.Direction != want {
+					t.Errorf("ListTransactions() direction = %v, want %v", record.Direction, want)
+				}
+			}
+		})
+	}
+}
+
+func Test_accountUsecase_GetTransaction(t *testing.T) {
+	transactionTime := time.Date(2025, 9, 1, 10, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		id      uint64
+		setup   func(fields fields)
+		want    dto.TransactionRecordDTO
+		wantErr bool
+	}{
+		{
+			name: "success",
+			id:   10,
+			setup: func(fields fields) {
+				fields.transactionRepo.EXPECT().FindOne(gomock.Any(), uint64(10)).Return(&entity.Transaction{
+					ID:                   10,
+					SourceAccountID:      111,
+					DestinationAccountID: 222,
+					Amount:               entity.MustParseMoney("100.00"),
+					Currency:             entity.CurrencyUSD,
+					DestinationAmount:    entity.MustParseMoney("100.00"),
+					DestinationCurrency:  entity.CurrencyUSD,
+					ExchangeRate:         entity.OneRate,
+					Status:               entity.TransactionReversed,
+					TransactionTime:      transactionTime,
+					UpdatedAt:            transactionTime.Add(time.Hour),
+				}, nil)
+			},
+			want: dto.TransactionRecordDTO{
+				TransactionID:        10,
+				SourceAccountID:      111,
+				DestinationAccountID: 222,
+				Amount:               entity.MustParseMoney("100.00"),
+				Currency:             entity.CurrencyUSD,
+				DestinationAmount:    entity.MustParseMoney("100.00"),
+				DestinationCurrency:  entity.CurrencyUSD,
+				ExchangeRate:         entity.OneRate,
+				Status:               entity.TransactionReversed,
+				TransactionTime:      transactionTime,
+				UpdatedAt:            transactionTime.Add(time.Hour),
+			},
+		},
+		{
+			name: "not_found",
+			id:   99,
+			setup: func(fields fields) {
+				fields.transactionRepo.EXPECT().FindOne(gomock.Any(), uint64(99)).Return(nil, nil)
+			},
+			wantErr: true,
+		},
+		{
+			name: "find_error",
+			id:   10,
+			setup: func(fields fields) {
+				fields.transactionRepo.EXPECT().FindOne(gomock.Any(), uint64(10)).Return(nil, errors.New("database error"))
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			uc, testFields := newTestAccountUsecase(ctrl)
+			tt.setup(testFields)
+
+			got, err := uc.GetTransaction(context.Background(), tt.id)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("GetTransaction() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GetTransaction() got = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_accountUsecase_FreezeAccount(t *testing.T) {
+	account := func(status entity.AccountStatus) []*entity.Account {
+		return []*entity.Account{
+			{ID: 111, Balance: entity.MustParseMoney("100.00"), Currency: entity.CurrencyUSD, Status: status},
+		}
+	}
+
+	tests := []struct {
+		name       string
+		freeze     bool
+		setup      func(fields fields)
+		wantStatus entity.AccountStatus
+		wantErr    bool
+	}{
+		{
+			name:   "freeze_active",
+			freeze: true,
+			setup: func(fields fields) {
+				fields.accountRepo.EXPECT().FindForUpdate(gomock.Any(), []uint64{111}).Return(account(entity.AccountActive), nil)
+				fields.accountRepo.EXPECT().Update(gomock.Any(), account(entity.AccountFrozen)[0]).Return(nil)
+				fields.accountRepo.EXPECT().FindOne(gomock.Any(), uint64(111)).Return(account(entity.AccountFrozen)[0], nil)
+			},
+			wantStatus: entity.AccountFrozen,
+		},
+		{
+			name: "unfreeze_frozen",
+			setup: func(fields fields) {
+				fields.accountRepo.EXPECT().FindForUpdate(gomock.Any(), []uint64{111}).Return(account(entity.AccountFrozen), nil)
+				fields.accountRepo.EXPECT().Update(gomock.Any(), account(entity.AccountActive)[0]).Return(nil)
+				fields.accountRepo.EXPECT().FindOne(gomock.Any(), uint64(111)).Return(account(entity.AccountActive)[0], nil)
+			},
+			wantStatus: entity.AccountActive,
+		},
+		{
+			name:   "freeze_frozen",
+			freeze: true,
+			setup: func(fields fields) {
+				fields.accountRepo.EXPECT().FindForUpdate(gomock.Any(), []uint64{111}).Return(account(entity.AccountFrozen), nil)
+			},
+			wantErr: true,
+		},
+		{
+			name: "unfreeze_closed",
+			setup: func(fields fields) {
+				fields.accountRepo.EXPECT().FindForUpdate(gomock.Any(), []uint64{111}).Return(account(entity.AccountClosed), nil)
+			},
+			wantErr: true,
+		},
+		{
+			name:   "account_not_found",
+			freeze: true,
+			setup: func(fields fields) {
+				fields.accountRepo.EXPECT().FindForUpdate(gomock.Any(), []uint64{111}).Return(nil, nil)
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			uc, testFields := newTestAccountUsecase(ctrl)
+			tt.setup(testFields)
+			testFields.holdRepo.EXPECT().SumActive(gomock.Any(), gomock.Any(), gomock.Any()).Return(entity.Money{}, nil).AnyTimes()
+
+			var (
+				got dto.AccountDTO
+				err error
+			)
+			if tt.freeze {
+				got, err = uc.FreezeAccount(context.Background(), 111)
+			} else {
+				got, err = uc.UnfreezeAccount(context.Background(), 111)
+			}
+			if (err != nil) != tt.wantErr {
+				t.Errorf("status change error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got.Status != tt.wantStatus {
+				t.Errorf("status change got = %v, want %v", got.Status, tt.wantStatus)
+			}
+		})
+	}
+}
+
+func Test_accountUsecase_CloseAccount(t *testing.T) {
+	accounts := func(balance string, status entity.AccountStatus) []*entity.Account {
+		return []*entity.Account{
+			{ID: 111, Balance: entity.MustParseMoney(balance), Currency: entity.CurrencyUSD, Status: status},
+			{ID: 222, Balance: entity.MustParseMoney("10.00"), Currency: entity.CurrencyUSD, Status: entity.AccountActive},
+		}
+	}
+	closedAccount := func(fields fields) {
+		fields.accountRepo.EXPECT().FindOne(gomock.Any(), uint64(111)).Return(&entity.Account{
+			ID: 111, Currency: entity.CurrencyUSD, Status: entity.AccountClosed,
+		}, nil)
+		fields.holdRepo.EXPECT().SumActive(gomock.Any(), uint64(111), gomock.Any()).Return(entity.Money{}, nil)
+	}
+
+	tests := []struct {
+		name    string
+		req     dto.CloseAccountDTO
+		setup   func(fields fields)
+		wantErr bool
+	}{
+		{
+			name: "zero_balance",
+			req:  dto.CloseAccountDTO{AccountID: 111},
+			setup: func(fields fields) {
+				fields.accountRepo.EXPECT().FindForUpdate(gomock.Any(), []uint64{111}).
+					Return(accounts("0.00", entity.AccountActive)[:1], nil)
+				fields.holdRepo.EXPECT().SumActive(gomock.Any(), uint64(111), gomock.Any()).Return(entity.Money{}, nil)
+				fields.accountRepo.EXPECT().Update(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, acc *entity.Account) error {
+						if acc.Status != entity.AccountClosed || acc.ClosedAt == nil {
+							t.Errorf("account not closed: %+v", acc)
+						}
+						return nil
+					})
+				closedAccount(fields)
+			},
+		},
+		{
+			name: "frozen_with_zero_balance",
+			req:  dto.CloseAccountDTO{AccountID: 111},
+			setup: func(fields fields) {
+				fields.accountRepo.EXPECT().FindForUpdate(gomock.Any(), []uint64{111}).
+					Return(accounts("0.00", entity.AccountFrozen)[:1], nil)
+				fields.holdRepo.EXPECT().SumActive(gomock.Any(), uint64(111), gomock.Any()).Return(entity.Money{}, nil)
+				fields.accountRepo.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil)
+				closedAccount(fields)
+			},
+		},
+		{
+			name: "sweep_balance",
+			req:  dto.CloseAccountDTO{AccountID: 111, SweepAccountID: 222},
+			setup: func(fields fields) {
+				fields.accountRepo.EXPECT().FindForUpdate(gomock.Any(), []uint64{111, 222}).
+					Return(accounts("90.00", entity.AccountActive), nil)
+				fields.holdRepo.EXPECT().SumActive(gomock.Any(), uint64(111), gomock.Any()).Return(entity.Money{}, nil)
+				fields.transactionRepo.EXPECT().Create(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, tx *entity.Transaction) (*entity.Transaction, error) {
+						if tx.SourceAccountID != 111 || tx.DestinationAccountID != 222 || tx.Amount != entity.MustParseMoney("90.00") {
+							t.Errorf("unexpected sweep: %+v", tx)
+						}
+						return tx, nil
+					})
+				fields.ledgerRepo.EXPECT().CreateEntry(gomock.Any(), gomock.Any()).Return(&entity.JournalEntry{}, nil)
+				fields.outboxRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+				fields.accountRepo.EXPECT().Update(gomock.Any(), &entity.Account{
+					ID: 222, Balance: entity.MustParseMoney("100.00"), Currency: entity.CurrencyUSD, Status: entity.AccountActive,
+				}).Return(nil)
+				// The swept account is written by the transfer, then closed
+				fields.accountRepo.EXPECT().Update(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, acc *entity.Account) error {
+						if acc.ID != 111 || !acc.Balance.IsZero() {
+							t.Errorf("unexpected account update: %+v", acc)
+						}
+						return nil
+					}).Times(2)
+				closedAccount(fields)
+			},
+		},
+		{
+			name: "balance_without_sweep",
+			req:  dto.CloseAccountDTO{AccountID: 111},
+			setup: func(fields fields) {
+				fields.accountRepo.EXPECT().FindForUpdate(gomock.Any(), []uint64{111}).
+					Return(accounts("90.00", entity.AccountActive)[:1], nil)
+				fields.holdRepo.EXPECT().SumActive(gomock.Any(), uint64(111), gomock.Any()).Return(entity.Money{}, nil)
+			},
+			wantErr: true,
+		},
+		{
+			name: "frozen_with_sweep",
+			req:  dto.CloseAccountDTO{AccountID: 111, SweepAccountID: 222},
+			setup: func(fields fields) {
+				fields.accountRepo.EXPECT().FindForUpdate(gomock.Any(), []uint64{111, 222}).
+					Return(accounts("90.00", entity.AccountFrozen), nil)
+			},
+			wantErr: true,
+		},
+		{
+			name: "open_holds",
+			req:  dto.CloseAccountDTO{AccountID: 111},
+			setup: func(fields fields) {
+				fields.accountRepo.EXPECT().FindForUpdate(gomock.Any(), []uint64{111}).
+					Return(accounts("0.00", entity.AccountActive)[:1], nil)
+				fields.holdRepo.EXPECT().SumActive(gomock.Any(), uint64(111), gomock.Any()).
+					Return(entity.MustParseMoney("5.00"), nil)
+			},
+			wantErr: true,
+		},
+		{
+			name: "already_closed",
+			req:  dto.CloseAccountDTO{AccountID: 111},
+			setup: func(fields fields) {
+				fields.accountRepo.EXPECT().FindForUpdate(gomock.Any(), []uint64{111}).
+					Return(accounts("0.00", entity.AccountClosed)[:1], nil)
+			},
+			wantErr: true,
+		},
+		{
+			name:    "sweep_to_itself",
+			req:     dto.CloseAccountDTO{AccountID: 111, SweepAccountID: 111},
+			setup:   func(fields fields) {},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			uc, testFields := newTestAccountUsecase(ctrl)
+			tt.setup(testFields)
+			// Accounts have no open holds unless a case says otherwise
+			testFields.holdRepo.EXPECT().SumActive(gomock.Any(), gomock.Any(), gomock.Any()).Return(entity.Money{}, nil).AnyTimes()
+
+			got, err := uc.CloseAccount(context.Background(), tt.req)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("CloseAccount() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && got.Status != entity.AccountClosed {
+				t.Errorf("CloseAccount() status = %v, want closed", got.Status)
+			}
+		})
+	}
+}
+
+func Test_accountUsecase_SetOverdraftLimit(t *testing.T) {
+	limit := entity.MustParseMoney("500.00")
+	tooPrecise := entity.MustParseMoney("500.001")
+	account := func(status entity.AccountStatus) []*entity.Account {
+		return []*entity.Account{
+			{ID: 111, Balance: entity.MustParseMoney("100.00"), Currency: entity.CurrencyUSD, Status: status,
+				OverdraftLimit: entity.MustParseMoney("50.00")},
+		}
+	}
+	req := func(limit *entity.Money) dto.OverdraftDTO {
+		return dto.OverdraftDTO{AccountID: 111, OverdraftLimit: limit, ChangedBy: "ops", Reason: "credit review"}
+	}
+
+	tests := []struct {
+		name    string
+		req     dto.OverdraftDTO
+		setup   func(fields fields)
+		wantErr bool
+	}{
+		{
+			name: "success",
+			req:  req(&limit),
+			setup: func(fields fields) {
+				fields.accountRepo.EXPECT().FindForUpdate(gomock.Any(), []uint64{111}).Return(account(entity.AccountActive), nil)
+				fields.accountRepo.EXPECT().Update(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, acc *entity.Account) error {
+						if acc.OverdraftLimit != limit {
+							t.Errorf("overdraft limit not updated: %+v", acc)
+						}
+						return nil
+					})
+				// The audit records the previous and the new limit
+				fields.auditRepo.EXPECT().Create(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, audit *entity.AccountAudit) (*entity.AccountAudit, error) {
+						if audit.AccountID != 111 || audit.Field != entity.AuditFieldOverdraftLimit ||
+							audit.OldValue != "50.00" || audit.NewValue != "500.00" ||
+							audit.ChangedBy != "ops" || audit.Reason != "credit review" {
+							t.Errorf("unexpected audit: %+v", audit)
+						}
+						return audit, nil
+					})
+				fields.accountRepo.EXPECT().FindOne(gomock.Any(), uint64(111)).Return(&entity.Account{
+					ID: 111, Balance: entity.MustParseMoney("100.00"), Currency: entity.CurrencyUSD,
+					Status: entity.AccountActive, OverdraftLimit: limit,
+				}, nil)
+				fields.holdRepo.EXPECT().SumActive(gomock.Any(), uint64(111), gomock.Any()).Return(entity.Money{}, nil)
+			},
+		},
+		{
+			name:    "missing_limit",
+			req:     req(nil),
+			setup:   func(fields fields) {},
+			wantErr: true,
+		},
+		{
+			name:    "missing_reason",
+			req:     dto.OverdraftDTO{AccountID: 111, OverdraftLimit: &limit, ChangedBy: "ops"},
+			setup:   func(fields fields) {},
+			wantErr: true,
+		},
+		{
+			name: "too_precise_for_currency",
+			req:  req(&tooPrecise),
+			setup: func(fields fields) {
+				fields.accountRepo.EXPECT().FindForUpdate(gomock.Any(), []uint64{111}).Return(account(entity.AccountActive), nil)
+			},
+			wantErr: true,
+		},
+		{
+			name: "closed_account",
+			req:  req(&limit),
+			setup: func(fields fields) {
+				fields.accountRepo.EXPECT().FindForUpdate(gomock.Any(), []uint64{111}).Return(account(entity.AccountClosed), nil)
+			},
+			wantErr: true,
+		},
+		{
+			name: "audit_error",
+			req:  req(&limit),
+			setup: func(fields fields) {
+				fields.accountRepo.EXPECT().FindForUpdate(gomock.Any(), []uint64{111}).Return(account(entity.AccountActive), nil)
+				fields.accountRepo.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil)
+				fields.auditRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil, errors.New("database error"))
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			uc, testFields := newTestAccountUsecase(ctrl)
+			tt.setup(testFields)
+			// Accounts have no open holds unless a case says otherwise
+			testFields.holdRepo.EXPECT().SumActive(gomock.Any(), gomock.Any(), gomock.Any()).Return(entity.Money{}, nil).AnyTimes()
+
+			got, err := uc.SetOverdraftLimit(context.Background(), tt.req)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("SetOverdraftLimit() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && got.OverdraftLimit != limit {
+				t.Errorf("SetOverdraftLimit() overdraft_limit = %v, want %v", got.OverdraftLimit, limit)
+			}
+		})
+	}
+}
+
+func Test_accountUsecase_ReviewDecisions(t *testing.T) {
+	pending := func() *entity.Transaction {
+		return &entity.Transaction{ID: 42, SourceAccountID: 111, DestinationAccountID: 222,
+			Amount: entity.MustParseMoney("5000.00"), Currency: entity.CurrencyUSD,
+			DestinationAmount: entity.MustParseMoney("5000.00"), DestinationCurrency: entity.CurrencyUSD,
+			Status: entity.TransactionPending}
+	}
+	openReview := func() *entity.RiskReview {
+		return &entity.RiskReview{ID: 7, TransactionID: 42, Rule: "new_account", Status: entity.RiskReviewOpen}
+	}
+	accounts := func(balance string) []*entity.Account {
+		return []*entity.Account{
+			{ID: 111, Balance: entity.MustParseMoney(balance), Currency: entity.CurrencyUSD, Status: entity.AccountActive},
+			{ID: 222, Balance: entity.MustParseMoney("0"), Currency: entity.CurrencyUSD, Status: entity.AccountActive},
+		}
+	}
+	req := dto.ReviewDecisionDTO{TransactionID: 42, DecidedBy: "ops", Note: "customer confirmed"}
+
+	tests := []struct {
+		name       string
+		reject     bool
+		req        dto.ReviewDecisionDTO
+		setup      func(fields fields)
+		wantStatus entity.TransactionStatus
+		wantErr    bool
+	}{
+		{
+			name: "release",
+			req:  req,
+			setup: func(fields fields) {
+				fields.transactionRepo.EXPECT().FindForUpdate(gomock.Any(), uint64(42)).Return(pending(), nil)
+				fields.reviewRepo.EXPECT().FindByTransaction(gomock.Any(), uint64(42)).Return(openReview(), nil)
+				fields.accountRepo.EXPECT().FindForUpdate(gomock.Any(), []uint64{111, 222}).Return(accounts("6000.00"), nil)
+				fields.holdRepo.EXPECT().SumActive(gomock.Any(), uint64(111), gomock.Any()).Return(entity.Money{}, nil)
+				// The held record is posted in place rather than created again
+				fields.transactionRepo.EXPECT().Update(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, tx *entity.Transaction) error {
+						if tx.ID != 42 || tx.Status != entity.TransactionPosted {
+							t.Errorf("unexpected released transaction: %+v", tx)
+						}
+						return nil
+					})
+				fields.ledgerRepo.EXPECT().CreateEntry(gomock.Any(), gomock.Any()).Return(&entity.JournalEntry{}, nil)
+				fields.outboxRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+				fields.accountRepo.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil).Times(2)
+				fields.reviewRepo.EXPECT().Update(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, review *entity.RiskReview) error {
+						if review.Status != entity.RiskReviewReleased || review.DecidedBy != "ops" || review.DecidedAt == nil {
+							t.Errorf("unexpected review decision: %+v", review)
+						}
+						return nil
+					})
+			},
+			wantStatus: entity.TransactionPosted,
+		},
+		{
+			name: "release_insufficient_funds",
+			req:  req,
+			setup: func(fields fields) {
+				fields.transactionRepo.EXPECT().FindForUpdate(gomock.Any(), uint64(42)).Return(pending(), nil)
+				fields.reviewRepo.EXPECT().FindByTransaction(gomock.Any(), uint64(42)).Return(openReview(), nil)
+				fields.accountRepo.EXPECT().FindForUpdate(gomock.Any(), []uint64{111, 222}).Return(accounts("100.00"), nil)
+				fields.holdRepo.EXPECT().SumActive(gomock.Any(), uint64(111), gomock.Any()).Return(entity.Money{}, nil)
+			},
+			wantErr: true,
+		},
+		{
+			name:   "reject",
+			reject: true,
+			req:    req,
+			setup: func(fields fields) {
+				fields.transactionRepo.EXPECT().FindForUpdate(gomock.Any(), uint64(42)).Return(pending(), nil)
+				fields.reviewRepo.EXPECT().FindByTransaction(gomock.Any(), uint64(42)).Return(openReview(), nil)
+				// No account is touched when a transfer is rejected
+				fields.transactionRepo.EXPECT().Update(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, tx *entity.Transaction) error {
+						if tx.Status != entity.TransactionFailed {
+							t.Errorf("rejected transaction stored as %s", tx.Status)
+						}
+						return nil
+					})
+				fields.outboxRepo.EXPECT().Create(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, event *entity.OutboxEvent) error {
+						if event.Type != entity.EventTransferFailed || event.AggregateID != "42" {
+							t.Errorf("unexpected event: %+v", event)
+						}
+						return nil
+					})
+				fields.reviewRepo.EXPECT().Update(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, review *entity.RiskReview) error {
+						if review.Status != entity.RiskReviewRejected || review.DecisionNote != "customer confirmed" {
+							t.Errorf("unexpected review decision: %+v", review)
+						}
+						return nil
+					})
+			},
+			wantStatus: entity.TransactionFailed,
+		},
+		{
+			name: "already_decided",
+			req:  req,
+			setup: func(fields fields) {
+				fields.transactionRepo.EXPECT().FindForUpdate(gomock.Any(), uint64(42)).Return(pending(), nil)
+				review := openReview()
+				review.Status = entity.RiskReviewRejected
+				fields.reviewRepo.EXPECT().FindByTransaction(gomock.Any(), uint64(42)).Return(review, nil)
+			},
+			wantErr: true,
+		},
+		{
+			name: "not_held_for_review",
+			req:  req,
+			setup: func(fields fields) {
+				tx := pending()
+				tx.Status = entity.TransactionPosted
+				fields.transactionRepo.EXPECT().FindForUpdate(gomock.Any(), uint64(42)).Return(tx, nil)
+				fields.reviewRepo.EXPECT().FindByTransaction(gomock.Any(), uint64(42)).Return(nil, nil)
+			},
+			wantErr: true,
+		},
+		{
+			name: "transaction_not_found",
+			req:  req,
+			setup: func(fields fields) {
+				fields.transactionRepo.EXPECT().FindForUpdate(gomock.Any(), uint64(42)).Return(nil, nil)
+			},
+			wantErr: true,
+		},
+		{
+			name:    "missing_decided_by",
+			reject:  true,
+			req:     dto.ReviewDecisionDTO{TransactionID: 42},
+			setup:   func(fields fields) {},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			uc, testFields := newTestAccountUsecase(ctrl)
+			tt.setup(testFields)
+			// Accounts have no open holds unless a case says otherwise
+			testFields.holdRepo.EXPECT().SumActive(gomock.Any(), gomock.Any(), gomock.Any()).Return(entity.Money{}, nil).AnyTimes()
+
+			decide := uc.ReleaseTransaction
+			if tt.reject {
+				decide = uc.RejectTransaction
+			}
+			got, err := decide(context.Background(), tt.req)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("decision error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && got.Status != tt.wantStatus {
+				t.Errorf("decision status = %s, want %s", got.Status, tt.wantStatus)
+			}
+		})
+	}
+}
+
+func Test_accountUsecase_MakeBatchTransaction(t *testing.T) {
+	item := func(src, dst uint64, amount string) dto.TransactionDTO {
+		return dto.TransactionDTO{SourceAccountID: src, DestinationAccountID: dst, Amount: entity.MustParseMoney(amount)}
+	}
+	accounts := func() []*entity.Account {
+		return []*entity.Account{
+			{ID: 111, Balance: entity.MustParseMoney("1000.00"), Currency: entity.CurrencyUSD, Status: entity.AccountActive},
+			{ID: 222, Balance: entity.MustParseMoney("0"), Currency: entity.CurrencyUSD, Status: entity.AccountActive},
+			{ID: 333, Balance: entity.MustParseMoney("50.00"), Currency: entity.CurrencyUSD, Status: entity.AccountActive},
+		}
+	}
+
+	tests := []struct {
+		name          string
+		req           dto.BatchTransactionDTO
+		setup         func(t *testing.T, fields fields)
+		wantErrCode   string
+		wantSucceeded int
+		// wantErrCodes are the expected error codes of the item results, empty for successful items
+		wantErrCodes []string
+	}{
+		{
+			name: "atomic_success",
+			req: dto.BatchTransactionDTO{Mode: dto.BatchAtomic, Transactions: []dto.TransactionDTO{
+				item(333, 111, "50.00"),
+				item(111, 222, "100.00"),
+			}},
+			setup: func(t *testing.T, fields fields) {
+				// All accounts are locked at once, in ascending ID order
+				fields.accountRepo.EXPECT().FindForUpdate(gomock.Any(), []uint64{111, 222, 333}).Return(accounts(), nil)
+				fields.transactionRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(&entity.Transaction{}, nil).Times(2)
+				fields.ledgerRepo.EXPECT().CreateEntry(gomock.Any(), gomock.Any()).Return(&entity.JournalEntry{}, nil).Times(2)
+				fields.outboxRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil).Times(2)
+				fields.accountRepo.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil).Times(4)
+			},
+			wantSucceeded: 2,
+			wantErrCodes:  []string{"", ""},
+		},
+		{
+			name: "atomic_rolls_back_on_failure",
+			req: dto.BatchTransactionDTO{Mode: dto.BatchAtomic, Transactions: []dto.TransactionDTO{
+				item(111, 222, "100.00"),
+				item(333, 222, "100.00"),
+			}},
+			setup: func(t *testing.T, fields fields) {
+				fields.accountRepo.EXPECT().FindForUpdate(gomock.Any(), []uint64{111, 222, 333}).Return(accounts(), nil)
+				fields.transactionRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(&entity.Transaction{}, nil)
+				fields.ledgerRepo.EXPECT().CreateEntry(gomock.Any(), gomock.Any()).Return(&entity.JournalEntry{}, nil)
+				fields.outboxRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+				fields.accountRepo.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil).Times(2)
+			},
+			wantErrCode: apperr.ErrInsufficientFunds.Code,
+		},
+		{
+			name: "atomic_invalid_item",
+			req: dto.BatchTransactionDTO{Mode: dto.BatchAtomic, Transactions: []dto.TransactionDTO{
+				item(111, 222, "100.00"),
+				item(222, 222, "100.00"),
+			}},
+			setup:       func(t *testing.T, fields fields) {},
+			wantErrCode: apperr.ErrInvalidInput.Code,
+		},
+		{
+			name: "best_effort_partial",
+			req: dto.BatchTransactionDTO{Mode: dto.BatchBestEffort, Transactions: []dto.TransactionDTO{
+				item(111, 222, "100.00"),
+				item(111, 222, "200.00"),
+				item(111, 222, "300.00"),
+				item(111, 111, "10.00"),
+				item(111, 999, "10.00"),
+				item(111, 222, "5000.00"),
+			}},
+			setup: func(t *testing.T, fields fields) {
+				// The self-transfer is rejected before locking; the unknown account is simply not returned
+				fields.accountRepo.EXPECT().FindForUpdate(gomock.Any(), []uint64{111, 222, 999}).Return(accounts()[:2], nil)
+				fields.transactionRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(&entity.Transaction{}, nil).Times(3)
+				gomock.InOrder(
+					fields.ledgerRepo.EXPECT().CreateEntry(gomock.Any(), gomock.Any()).Return(&entity.JournalEntry{}, nil),
+					fields.ledgerRepo.EXPECT().CreateEntry(gomock.Any(), gomock.Any()).Return(nil, errors.New("database error")),
+					fields.ledgerRepo.EXPECT().CreateEntry(gomock.Any(), gomock.Any()).Return(&entity.JournalEntry{}, nil),
+				)
+				// Only the two posted transfers are published
+				fields.outboxRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil).Times(2)
+				// The failed second transfer must not leak into the balance used by the third
+				var sourceBalances []string
+				fields.accountRepo.EXPECT().Update(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, acc *entity.Account) error {
+						if acc.ID == 111 {
+							sourceBalances = append(sourceBalances, acc.Balance.String())
+						}
+						return nil
+					}).Times(4)
+				t.Cleanup(func() {
+					if !reflect.DeepEqual(sourceBalances, []string{"900.00", "600.00"}) {
+						t.Errorf("source balances = %v, want [900.00 600.00]", sourceBalances)
+					}
+				})
+			},
+			wantSucceeded: 2,
+			wantErrCodes: []string{"", apperr.ErrInternalServer.Code, "", apperr.ErrInvalidInput.Code,
+				apperr.ErrNotFound.Code, apperr.ErrInsufficientFunds.Code},
+		},
+		{
+			name:        "empty_batch",
+			req:         dto.BatchTransactionDTO{Mode: dto.BatchBestEffort},
+			setup:       func(t *testing.T, fields fields) {},
+			wantErrCode: apperr.ErrInvalidInput.Code,
+		},
+		{
+			name: "unknown_mode",
+			req: dto.BatchTransactionDTO{Mode: "sometimes", Transactions: []dto.TransactionDTO{
+				item(111, 222, "100.00"),
+			}},
+			setup:       func(t *testing.T, fields fields) {},
+			wantErrCode: apperr.ErrInvalidInput.Code,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			uc, testFields := newTestAccountUsecase(ctrl)
+			tt.setup(t, testFields)
+			testFields.holdRepo.EXPECT().SumActive(gomock.Any(), gomock.Any(), gomock.Any()).Return(entity.Money{}, nil).AnyTimes()
+
+			got, err := uc.MakeBatchTransaction(context.Background(), tt.req)
+			if tt.wantErrCode != "" {
+				var appErr apperr.AppError
+				if !errors.As(err, &appErr) || appErr.Code != tt.wantErrCode {
+					t.Errorf("MakeBatchTransaction() error = %+v, want %s", err, tt.wantErrCode)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("MakeBatchTransaction() error = %v", err)
+			}
+
+			if got.Succeeded != tt.wantSucceeded || got.Failed != len(tt.wantErrCodes)-tt.wantSucceeded {
+				t.Errorf("MakeBatchTransaction() succeeded = %d, failed = %d", got.Succeeded, got.Failed)
+			}
+			for i, result := range got.Results {
+				code := ""
+				if result.Error != nil {
+					code = result.Error.Code
+				}
+				if result.Index != i || code != tt.wantErrCodes[i] || (code == "") != (result.Transaction != nil) {
+					t.Errorf("result %d = %+v, want error code %q", i, result, tt.wantErrCodes[i])
+				}
+			}
+		})
+	}
+}
+
+func Test_accountUsecase_MakeSplitPayment(t *testing.T) {
+	leg := func(dst uint64, amount string) dto.SplitLegDTO {
+		return dto.SplitLegDTO{DestinationAccountID: dst, Amount: entity.MustParseMoney(amount)}
+	}
+	req := func(amount string, legs ...dto.SplitLegDTO) dto.SplitPaymentDTO {
+		return dto.SplitPaymentDTO{SourceAccountID: 111, Amount: entity.MustParseMoney(amount), Legs: legs}
+	}
+	accounts := func() []*entity.Account {
+		return []*entity.Account{
+			{ID: 111, Balance: entity.MustParseMoney("100.00"), Currency: entity.CurrencyUSD, Status: entity.AccountActive},
+			{ID: 222, Balance: entity.MustParseMoney("0"), Currency: entity.CurrencyUSD, Status: entity.AccountActive},
+			{ID: 333, Balance: entity.MustParseMoney("0"), Currency: entity.CurrencyUSD, Status: entity.AccountActive},
+			{ID: 444, Balance: entity.MustParseMoney("0"), Currency: entity.CurrencyUSD, Status: entity.AccountActive},
+		}
+	}
+	createSplit := func(fields fields) {
+		fields.splitRepo.EXPECT().Create(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, split *entity.SplitPayment) (*entity.SplitPayment, error) {
+				split.ID = 9
+				return split, nil
+			})
+	}
+
+	tests := []struct {
+		name  string
+		req   dto.SplitPaymentDTO
+		setup func(fields fields)
+		// risk, when set, replaces the empty risk chain
+		risk        RiskEvaluator
+		wantErrCode string
+		wantStatus  entity.TransactionStatus
+	}{
+		{
+			name: "success",
+			req:  req("100.00", leg(444, "85.00"), leg(222, "10.00"), leg(333, "5.00")),
+			setup: func(fields fields) {
+				// Every account is locked in one call, in ascending ID order
+				fields.accountRepo.EXPECT().FindForUpdate(gomock.Any(), []uint64{111, 222, 333, 444}).Return(accounts(), nil)
+				createSplit(fields)
+				fields.transactionRepo.EXPECT().Create(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, tx *entity.Transaction) (*entity.Transaction, error) {
+						if tx.SourceAccountID != 111 || tx.SplitPaymentID == nil || *tx.SplitPaymentID != 9 {
+							t.Errorf("leg not linked to its split payment: %+v", tx)
+						}
+						return tx, nil
+					}).Times(3)
+				fields.ledgerRepo.EXPECT().CreateEntry(gomock.Any(), gomock.Any()).Return(&entity.JournalEntry{}, nil).Times(3)
+				fields.outboxRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil).Times(3)
+				// The source is debited the total and updated once, after the three credits
+				gomock.InOrder(
+					fields.accountRepo.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil).Times(3),
+					fields.accountRepo.EXPECT().Update(gomock.Any(), &entity.Account{
+						ID: 111, Balance: entity.MustParseMoney("0"), Currency: entity.CurrencyUSD, Status: entity.AccountActive,
+					}).Return(nil),
+				)
+			},
+			wantStatus: entity.TransactionPosted,
+		},
+		{
+			name: "review_denies_whole_split",
+			req:  req("100.00", leg(222, "90.00"), leg(333, "10.00")),
+			risk: &riskChain{rules: []RiskRule{newAccountRule{
+				maxAge:     24 * time.Hour,
+				thresholds: map[entity.Currency]entity.Money{entity.CurrencyUSD: entity.MustParseMoney("50.00")},
+				action:     entity.RiskDecisionReview,
+			}}},
+			setup: func(fields fields) {
+				found := accounts()[:3]
+				found[0].CreatedAt = time.Now()
+				fields.accountRepo.EXPECT().FindForUpdate(gomock.Any(), []uint64{111, 222, 333}).Return(found, nil)
+				// Only one leg is flagged; no leg is recorded and no balance moves
+			},
+			wantErrCode: apperr.ErrRiskDenied.Code,
+		},
+		{
+			name:        "legs_do_not_add_up",
+			req:         req("100.00", leg(222, "90.00"), leg(333, "5.00")),
+			setup:       func(fields fields) {},
+			wantErrCode: apperr.ErrInvalidInput.Code,
+		},
+		{
+			name:        "duplicate_destination",
+			req:         req("100.00", leg(222, "50.00"), leg(222, "50.00")),
+			setup:       func(fields fields) {},
+			wantErrCode: apperr.ErrInvalidInput.Code,
+		},
+		{
+			name:        "source_as_destination",
+			req:         req("100.00", leg(111, "50.00"), leg(222, "50.00")),
+			setup:       func(fields fields) {},
+			wantErrCode: apperr.ErrInvalidInput.Code,
+		},
+		{
+			name:        "single_leg",
+			req:         req("100.00", leg(222, "100.00")),
+			setup:       func(fields fields) {},
+			wantErrCode: apperr.ErrInvalidInput.Code,
+		},
+		{
+			name: "insufficient_funds",
+			req:  req("150.00", leg(222, "100.00"), leg(333, "50.00")),
+			setup: func(fields fields) {
+				fields.accountRepo.EXPECT().FindForUpdate(gomock.Any(), []uint64{111, 222, 333}).Return(accounts()[:3], nil)
+			},
+			wantErrCode: apperr.ErrInsufficientFunds.Code,
+		},
+		{
+			name: "cross_currency_leg",
+			req:  req("100.00", leg(222, "50.00"), leg(333, "50.00")),
+			setup: func(fields fields) {
+				found := accounts()[:3]
+				found[2].Currency = entity.CurrencyEUR
+				fields.accountRepo.EXPECT().FindForUpdate(gomock.Any(), []uint64{111, 222, 333}).Return(found, nil)
+			},
+			wantErrCode: apperr.ErrCurrencyMismatch.Code,
+		},
+		{
+			name: "closed_destination",
+			req:  req("100.00", leg(222, "50.00"), leg(333, "50.00")),
+			setup: func(fields fields) {
+				found := accounts()[:3]
+				found[2].Status = entity.AccountClosed
+				fields.accountRepo.EXPECT().FindForUpdate(gomock.Any(), []uint64{111, 222, 333}).Return(found, nil)
+			},
+			wantErrCode: apperr.ErrAccountClosed.Code,
+		},
+		{
+			name: "account_not_found",
+			req:  req("100.00", leg(222, "50.00"), leg(999, "50.00")),
+			setup: func(fields fields) {
+				fields.accountRepo.EXPECT().FindForUpdate(gomock.Any(), []uint64{111, 222, 999}).Return(accounts()[:2], nil)
+			},
+			wantErrCode: apperr.ErrNotFound.Code,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			uc, testFields := newTestAccountUsecase(ctrl)
+			if tt.risk != nil {
+				uc.risk = tt.risk
+			}
+			tt.setup(testFields)
+			testFields.holdRepo.EXPECT().SumActive(gomock.Any(), gomock.Any(), gomock.Any()).Return(entity.Money{}, nil).AnyTimes()
+
+			got, err := uc.MakeSplitPayment(context.Background(), tt.req)
+			if tt.wantErrCode != "" {
+				var appErr apperr.AppError
+				if !errors.As(err, &appErr) || appErr.Code != tt.wantErrCode {
+					t.Errorf("MakeSplitPayment() error = %+v, want %s", err, tt.wantErrCode)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("MakeSplitPayment() error = %v", err)
+			}
+			if got.SplitPaymentID != 9 || got.Status != tt.wantStatus || len(got.Legs) != len(tt.req.Legs) {
+				t.Errorf("MakeSplitPayment() got = %+v", got)
+			}
+		})
+	}
+}
+
+func Test_accountUsecase_SubscribeEvents(t *testing.T) {
+	account := &entity.Account{ID: 111, Balance: entity.MustParseMoney("100.00"), Currency: entity.CurrencyUSD,
+		Status: entity.AccountActive}
+	tests := []struct {
+		name string
+		// resume returns the Last-Event-ID sent by the client, given the ID of the first event published
+		resume      func(first uint64) uint64
+		found       bool
+		findErr     error
+		wantTypes   []string
+		wantErrCode string
+	}{
+		{
+			name:      "first_connection_gets_balance",
+			resume:    func(first uint64) uint64 { return 0 },
+			found:     true,
+			wantTypes: []string{dto.AccountEventBalance},
+		},
+		{
+			name:      "resume_gets_missed_events",
+			resume:    func(first uint64) uint64 { return first },
+			found:     true,
+			wantTypes: []string{dto.AccountEventTransaction, dto.AccountEventBalance},
+		},
+		{
+			name:      "stale_resume_gets_balance",
+			resume:    func(first uint64) uint64 { return first - 1000 },
+			found:     true,
+			wantTypes: []string{dto.AccountEventBalance},
+		},
+		{
+			name:        "account_not_found",
+			resume:      func(first uint64) uint64 { return 0 },
+			wantErrCode: apperr.ErrNotFound.Code,
+		},
+		{
+			name:        "repository_error",
+			resume:      func(first uint64) uint64 { return 0 },
+			findErr:     errors.New("connection refused"),
+			wantErrCode: apperr.ErrInternalServer.Code,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			uc, testFields := newTestAccountUsecase(ctrl)
+			if tt.found {
+				testFields.accountRepo.EXPECT().FindOne(gomock.Any(), uint64(111)).Return(account, nil)
+			} else {
+				testFields.accountRepo.EXPECT().FindOne(gomock.Any(), uint64(111)).Return(nil, tt.findErr)
+			}
+			// The balance sent on a new connection is only read when no events are replayed
+			testFields.holdRepo.EXPECT().SumActive(gomock.Any(), uint64(111), gomock.Any()).
+				Return(entity.MustParseMoney("40.00"), nil).AnyTimes()
+			uc.broadcaster.Publish(111, dto.AccountEventBalance, nil)
+			first := uc.broadcaster.lastID
+			uc.broadcaster.Publish(222, dto.AccountEventBalance, nil)
+			uc.broadcaster.Publish(111, dto.AccountEventTransaction, nil)
+			uc.broadcaster.Publish(111, dto.AccountEventBalance, nil)
+
+			events, sub, err := uc.SubscribeEvents(context.Background(),
+				dto.AccountEventsDTO{AccountID: 111, LastEventID: tt.resume(first)})
+			if tt.wantErrCode != "" {
+				var appErr apperr.AppError
+				if !errors.As(err, &appErr) || appErr.Code != tt.wantErrCode {
+					t.Fatalf("SubscribeEvents() error = %v, want %s", err, tt.wantErrCode)
+				}
+				if len(uc.broadcaster.subscribers) != 0 {
+					t.Error("subscription left open after an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("SubscribeEvents() error = %v", err)
+			}
+			defer sub.Close()
+
+			var types []string
+			for _, e := range events {
+				types = append(types, e.Type)
+			}
+			if !reflect.DeepEqual(types, tt.wantTypes) {
+				t.Errorf("SubscribeEvents() types = %v, want %v", types, tt.wantTypes)
+			}
+			// The balance sent on a new connection resumes after the last event published
+			if tt.wantTypes[0] != dto.AccountEventBalance {
+				return
+			}
+			balance := events[0].Data.(dto.AccountBalanceEventDTO)
+			if events[0].ID != uc.broadcaster.lastID || balance.Balance != account.Balance ||
+				balance.AvailableBalance != entity.MustParseMoney("60.00") {
+				t.Errorf("SubscribeEvents() balance = %+v", events[0])
+			}
+		})
+	}
+}
+
+func Test_accountUsecase_notifiesAfterCommit(t *testing.T) {
+	tests := []struct {
+		name string
+		// outerErr fails the transaction the freeze runs in, after the freeze succeeded
+		outerErr  error
+		wantEvent bool
+	}{
+		{name: "committed", wantEvent: true},
+		{name: "outer_transaction_rolled_back", outerErr: errors.New("idempotency record failed")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			uc, testFields := newTestAccountUsecase(ctrl)
+			uc.txManager = txhook.Wrap(&mock2.MockTxManager{})
+			testFields.accountRepo.EXPECT().FindForUpdate(gomock.Any(), []uint64{111}).Return([]*entity.Account{
+				{ID: 111, Balance: entity.MustParseMoney("100.00"), Status: entity.AccountActive}}, nil)
+			testFields.accountRepo.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil)
+			testFields.accountRepo.EXPECT().FindOne(gomock.Any(), uint64(111)).Return(&entity.Account{
+				ID: 111, Balance: entity.MustParseMoney("100.00"), Status: entity.AccountFrozen}, nil).AnyTimes()
+			testFields.holdRepo.EXPECT().SumActive(gomock.Any(), uint64(111), gomock.Any()).Return(entity.Money{}, nil).AnyTimes()
+
+			_, sub, err := uc.SubscribeEvents(context.Background(), dto.AccountEventsDTO{AccountID: 111})
+			if err != nil {
+				t.Fatalf("SubscribeEvents() error = %v", err)
+			}
+			defer sub.Close()
+
+			_ = uc.txManager.Do(context.Background(), func(ctx context.Context) error {
+				if _, err := uc.FreezeAccount(ctx, 111); err != nil {
+					t.Fatalf("FreezeAccount() error = %v", err)
+				}
+				if len(sub.Events) != 0 {
+					t.Error("change broadcast before the outer transaction committed")
+				}
+				return tt.outerErr
+			})
+
+			select {
+			case e := <-sub.Events:
+				if !tt.wantEvent {
+					t.Errorf("rolled back change broadcast: %+v", e)
+				} else if e.Data.(dto.AccountBalanceEventDTO).Status != entity.AccountFrozen {
+					t.Errorf("broadcast %+v, want the frozen account", e)
+				}
+			default:
+				if tt.wantEvent {
+					t.Error("committed change not broadcast")
+				}
 			}
 		})
 	}
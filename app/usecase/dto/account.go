@@ -1,11 +1,63 @@
 package dto
 
+import (
+	"time"
+
+	"transaction_demo/app/domain/entity"
+)
+
 type AccountDTO struct {
-	AccountID uint64  `json:"account_id" validate:"required,number,gt=0"`
-	Balance   float64 `json:"balance" validate:"required,number,gt=0"`
+	AccountID uint64          `json:"account_id" validate:"required,number,gt=0"`
+	Balance   entity.Money    `json:"balance" validate:"required,gt=0,currency_precision=Currency" swaggertype:"string" example:"1000.00"`
+	Currency  entity.Currency `json:"currency" validate:"omitempty,currency" swaggertype:"string" example:"USD"`
+	// Type is the account product; accounts are opened as checking unless savings is given
+	Type entity.AccountType `json:"type,omitempty" validate:"omitempty,oneof=checking savings" swaggertype:"string" enums:"checking,savings"`
+	// AvailableBalance is the balance minus funds reserved by open holds; output only
+	AvailableBalance entity.Money `json:"available_balance" swaggertype:"string" example:"900.00"`
+	// Status is the lifecycle state of the account; output only
+	Status entity.AccountStatus `json:"status,omitempty" swaggertype:"string" enums:"active,frozen,closed"`
+	// OverdraftLimit is how far below zero the balance may go; output only, set through the admin API
+	OverdraftLimit entity.Money `json:"overdraft_limit" swaggertype:"string" example:"0.00"`
 }
 
 // Validate validates the AccountDTO struct.
 func (a AccountDTO) Validate() error {
 	return GetValidator().Struct(a)
 }
+
+type CloseAccountDTO struct {
+	// AccountID is taken from the path; it is part of the JSON form so idempotency keys are per account
+	AccountID uint64 `json:"account_id,omitempty" validate:"required,gt=0" swaggerignore:"true"`
+	// SweepAccountID receives the remaining balance; required unless the balance is zero
+	SweepAccountID uint64 `json:"sweep_account_id,omitempty" validate:"omitempty,gt=0,nefield=AccountID"`
+}
+
+// Validate validates the CloseAccountDTO struct.
+func (c CloseAccountDTO) Validate() error {
+	return GetValidator().Struct(c)
+}
+
+type OverdraftDTO struct {
+	// AccountID is taken from the path
+	AccountID uint64 `json:"-" validate:"required,gt=0" swaggerignore:"true"`
+	// OverdraftLimit is the new limit in the account currency; zero removes the overdraft
+	OverdraftLimit *entity.Money `json:"overdraft_limit" validate:"required,gte=0" swaggertype:"string" example:"500.00"`
+	ChangedBy      string        `json:"changed_by" validate:"required,max=64"`
+	Reason         string        `json:"reason" validate:"required,max=512"`
+}
+
+// Validate validates the OverdraftDTO struct.
+func (o OverdraftDTO) Validate() error {
+	return GetValidator().Struct(o)
+}
+
+type AccountAuditDTO struct {
+	AuditID   uint64    `json:"audit_id"`
+	AccountID uint64    `json:"account_id"`
+	Field     string    `json:"field" example:"overdraft_limit"`
+	OldValue  string    `json:"old_value" example:"0.00"`
+	NewValue  string    `json:"new_value" example:"500.00"`
+	ChangedBy string    `json:"changed_by"`
+	Reason    string    `json:"reason"`
+	CreatedAt time.Time `json:"created_at"`
+}
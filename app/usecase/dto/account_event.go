@@ -0,0 +1,39 @@
+package dto
+
+import "transaction_demo/app/domain/entity"
+
+// Names of the events of an account activity stream.
+const (
+	// AccountEventBalance carries an AccountBalanceEventDTO.
+	AccountEventBalance = "balance"
+	// AccountEventTransaction carries a TransactionRecordDTO.
+	AccountEventTransaction = "transaction"
+)
+
+// AccountEventDTO is an event of an account activity stream. IDs increase across all accounts,
+// so a client resumes a stream by sending the ID of the last event it received.
+type AccountEventDTO struct {
+	ID   uint64
+	Type string
+	Data interface{}
+}
+
+// AccountBalanceEventDTO is the state of an account after a change. Version increases with every
+// change of the account, so a state with a lower version than one already received is outdated.
+type AccountBalanceEventDTO struct {
+	AccountID uint64       `json:"account_id"`
+	Version   uint64       `json:"version" example:"42"`
+	Balance   entity.Money `json:"balance" swaggertype:"string" example:"1000.00"`
+	// AvailableBalance is the balance minus the funds reserved by open holds
+	AvailableBalance entity.Money         `json:"available_balance" swaggertype:"string" example:"900.00"`
+	Currency         entity.Currency      `json:"currency" swaggertype:"string" example:"USD"`
+	Status           entity.AccountStatus `json:"status" swaggertype:"string" enums:"active,frozen,closed"`
+	OverdraftLimit   entity.Money         `json:"overdraft_limit" swaggertype:"string" example:"0.00"`
+}
+
+// AccountEventsDTO is a subscription request to the activity of an account.
+type AccountEventsDTO struct {
+	AccountID uint64
+	// LastEventID is the ID of the last event received before reconnecting, zero on a first connection
+	LastEventID uint64
+}
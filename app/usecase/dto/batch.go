@@ -0,0 +1,39 @@
+package dto
+
+import "transaction_demo/app/apperr"
+
+// BatchMode selects how a batch of transfers handles a failing item.
+type BatchMode string
+
+const (
+	// BatchAtomic makes every transfer or none: the first failing item rolls back the whole batch
+	BatchAtomic BatchMode = "atomic"
+	// BatchBestEffort makes each transfer independently: failing items are reported and skipped
+	BatchBestEffort BatchMode = "best_effort"
+)
+
+type BatchTransactionDTO struct {
+	Mode BatchMode `json:"mode" validate:"required,oneof=atomic best_effort" swaggertype:"string" enums:"atomic,best_effort"`
+	// Transactions are validated one by one, so that a best-effort batch reports invalid items instead of failing
+	Transactions []TransactionDTO `json:"transactions" validate:"required,min=1,max=1000"`
+}
+
+// Validate validates the BatchTransactionDTO struct.
+func (b BatchTransactionDTO) Validate() error {
+	return GetValidator().Struct(b)
+}
+
+type BatchResultDTO struct {
+	Mode      BatchMode `json:"mode" swaggertype:"string" enums:"atomic,best_effort"`
+	Succeeded int       `json:"succeeded"`
+	Failed    int       `json:"failed"`
+	// Results are in the order of the request transactions
+	Results []BatchItemResultDTO `json:"results"`
+}
+
+// BatchItemResultDTO is the outcome of one transfer of a batch: either the transaction or the error.
+type BatchItemResultDTO struct {
+	Index       int                   `json:"index"`
+	Transaction *TransactionRecordDTO `json:"transaction,omitempty"`
+	Error       *apperr.AppError      `json:"error,omitempty"`
+}
@@ -0,0 +1,37 @@
+package dto
+
+import (
+	"time"
+
+	"transaction_demo/app/domain/entity"
+)
+
+type QuoteRequestDTO struct {
+	SourceCurrency      entity.Currency `json:"source_currency" validate:"required,currency" swaggertype:"string" example:"USD"`
+	DestinationCurrency entity.Currency `json:"destination_currency" validate:"required,currency,nefield=SourceCurrency" swaggertype:"string" example:"EUR"`
+}
+
+// Validate validates the QuoteRequestDTO struct.
+func (q QuoteRequestDTO) Validate() error {
+	return GetValidator().Struct(q)
+}
+
+type QuoteDTO struct {
+	QuoteID             string          `json:"quote_id"`
+	SourceCurrency      entity.Currency `json:"source_currency" swaggertype:"string" example:"USD"`
+	DestinationCurrency entity.Currency `json:"destination_currency" swaggertype:"string" example:"EUR"`
+	Rate                entity.Rate     `json:"rate" swaggertype:"string" example:"0.9234"`
+	ExpiresAt           time.Time       `json:"expires_at"`
+}
+
+type RateDTO struct {
+	BaseCurrency  entity.Currency `json:"base_currency" validate:"required,currency" swaggertype:"string" example:"USD"`
+	QuoteCurrency entity.Currency `json:"quote_currency" validate:"required,currency,nefield=BaseCurrency" swaggertype:"string" example:"EUR"`
+	Rate          entity.Rate     `json:"rate" validate:"required,gt=0" swaggertype:"string" example:"0.9234"`
+	UpdatedAt     time.Time       `json:"updated_at"`
+}
+
+// Validate validates the RateDTO struct.
+func (r RateDTO) Validate() error {
+	return GetValidator().Struct(r)
+}
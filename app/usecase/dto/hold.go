@@ -0,0 +1,48 @@
+package dto
+
+import (
+	"time"
+
+	"transaction_demo/app/domain/entity"
+)
+
+type AuthorizeDTO struct {
+	SourceAccountID      uint64       `json:"source_account_id" validate:"required,number,gt=0"`
+	DestinationAccountID uint64       `json:"destination_account_id" validate:"required,number,gt=0"`
+	Amount               entity.Money `json:"amount" validate:"required,gt=0,currency_precision=Currency" swaggertype:"string" example:"100.50"`
+	// Currency of Amount; defaults to the source account currency and must match it when set
+	Currency entity.Currency `json:"currency,omitempty" validate:"omitempty,currency" swaggertype:"string" example:"USD"`
+}
+
+// Validate validates the AuthorizeDTO struct.
+func (a AuthorizeDTO) Validate() error {
+	return GetValidator().Struct(a)
+}
+
+type CaptureDTO struct {
+	// HoldID is taken from the path; it is part of the JSON form so idempotency keys are per hold
+	HoldID uint64 `json:"hold_id,omitempty" validate:"required,gt=0" swaggerignore:"true"`
+	// Amount to settle; the full hold amount when omitted
+	Amount *entity.Money `json:"amount,omitempty" validate:"omitempty,gt=0" swaggertype:"string" example:"80.00"`
+}
+
+// Validate validates the CaptureDTO struct.
+func (c CaptureDTO) Validate() error {
+	return GetValidator().Struct(c)
+}
+
+type HoldDTO struct {
+	HoldID               uint64       `json:"hold_id"`
+	SourceAccountID      uint64       `json:"source_account_id"`
+	DestinationAccountID uint64       `json:"destination_account_id"`
+	Amount               entity.Money `json:"amount" swaggertype:"string" example:"100.50"`
+	// Fee reserved with the amount; the capture charges it, or less for a partial capture
+	Fee            entity.Money      `json:"fee" swaggertype:"string" example:"1.00"`
+	CapturedAmount entity.Money      `json:"captured_amount" swaggertype:"string" example:"0.00"`
+	Currency       entity.Currency   `json:"currency" swaggertype:"string" example:"USD"`
+	Status         entity.HoldStatus `json:"status" swaggertype:"string" example:"authorized"`
+	TransactionID  *uint64           `json:"transaction_id,omitempty"`
+	// ReviewRequired is set when risk rules flagged the hold: its capture waits for a risk review
+	ReviewRequired bool      `json:"review_required,omitempty"`
+	ExpiresAt      time.Time `json:"expires_at"`
+}
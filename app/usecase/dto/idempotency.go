@@ -0,0 +1,20 @@
+package dto
+
+// IdempotencyDTO identifies a request sent with an Idempotency-Key header.
+type IdempotencyDTO struct {
+	Key     string      `validate:"required,max=255,printascii"`
+	Scope   string      `validate:"required,max=255"`
+	Payload interface{} `validate:"-"` // the bound request body, fingerprinted to detect key reuse
+}
+
+// Validate validates the IdempotencyDTO struct.
+func (i IdempotencyDTO) Validate() error {
+	return GetValidator().Struct(i)
+}
+
+// IdempotentResponseDTO is a response produced or replayed under an idempotency key.
+type IdempotentResponseDTO struct {
+	Status   int
+	Body     []byte // JSON encoded response body
+	Replayed bool   // true when the response was recorded by an earlier request
+}
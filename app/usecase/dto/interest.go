@@ -0,0 +1,58 @@
+package dto
+
+import (
+	"time"
+
+	"transaction_demo/app/domain/entity"
+)
+
+// Date layouts of the interest API; days and months are in UTC.
+const (
+	DateLayout  = "2006-01-02"
+	MonthLayout = "2006-01"
+)
+
+type InterestAccrualRequestDTO struct {
+	// Date is the day to accrue; it must have ended
+	Date string `json:"date" validate:"required,datetime=2006-01-02" example:"2025-09-14"`
+}
+
+// Validate validates the InterestAccrualRequestDTO struct.
+func (i InterestAccrualRequestDTO) Validate() error {
+	return GetValidator().Struct(i)
+}
+
+type InterestPostingRequestDTO struct {
+	// Month is the month to post; it must have ended and its last day must be accrued
+	Month string `json:"month" validate:"required,datetime=2006-01" example:"2025-08"`
+}
+
+// Validate validates the InterestPostingRequestDTO struct.
+func (i InterestPostingRequestDTO) Validate() error {
+	return GetValidator().Struct(i)
+}
+
+type InterestRunDTO struct {
+	Kind entity.InterestRunKind `json:"kind" swaggertype:"string" enums:"accrual,posting"`
+	// Date is the accrued day or the first day of the posted month
+	Date string `json:"date" example:"2025-09-14"`
+	// Accounts is the number of accounts accrued or credited
+	Accounts int64 `json:"accounts"`
+	// AlreadyRun is true when the day or month had been processed before; nothing was done again
+	AlreadyRun  bool      `json:"already_run"`
+	CompletedAt time.Time `json:"completed_at"`
+}
+
+type InterestAccrualDTO struct {
+	AccrualDate string          `json:"accrual_date" example:"2025-09-14"`
+	Balance     entity.Money    `json:"balance" swaggertype:"string" example:"10000.00"`
+	AnnualRate  entity.Rate     `json:"annual_rate" swaggertype:"string" example:"0.0425"`
+	DayCount    entity.DayCount `json:"day_count" swaggertype:"string" enums:"act/365,act/360,act/act"`
+	// Amount keeps sub-cent digits; the monthly posting credits the month's total in whole cents
+	Amount   entity.Money    `json:"amount" swaggertype:"string" example:"1.1644"`
+	Currency entity.Currency `json:"currency" swaggertype:"string" example:"USD"`
+	// Carried marks the sub-cent remainder of the previous monthly posting, credited by the next one
+	Carried bool `json:"carried,omitempty"`
+	// PostedAt is set once the accrual was credited by a monthly posting
+	PostedAt *time.Time `json:"posted_at,omitempty"`
+}
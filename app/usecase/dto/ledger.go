@@ -0,0 +1,61 @@
+package dto
+
+import (
+	"time"
+
+	"transaction_demo/app/domain/entity"
+)
+
+type PostingDTO struct {
+	AccountID     *uint64                 `json:"account_id,omitempty"`
+	SystemAccount string                  `json:"system_account,omitempty" example:"fx:position"`
+	Direction     entity.PostingDirection `json:"direction" swaggertype:"string" example:"debit"`
+	Amount        entity.Money            `json:"amount" swaggertype:"string" example:"100.50"`
+	Currency      entity.Currency         `json:"currency" swaggertype:"string" example:"USD"`
+}
+
+type ReconciliationDTO struct {
+	AccountID     uint64          `json:"account_id"`
+	Currency      entity.Currency `json:"currency" swaggertype:"string" example:"USD"`
+	Balance       entity.Money    `json:"balance" swaggertype:"string" example:"1000.00"`
+	LedgerBalance entity.Money    `json:"ledger_balance" swaggertype:"string" example:"1000.00"`
+	Difference    entity.Money    `json:"difference" swaggertype:"string" example:"0.00"`
+	InBalance     bool            `json:"in_balance"`
+}
+
+type BalanceAsOfRequestDTO struct {
+	AccountID uint64 `form:"-" validate:"required,gt=0"`
+	// AsOf is an RFC 3339 timestamp; postings made at or after it are excluded. Defaults to now.
+	AsOf *time.Time `form:"as_of"`
+}
+
+// Validate validates the BalanceAsOfRequestDTO struct.
+func (b BalanceAsOfRequestDTO) Validate() error {
+	return GetValidator().Struct(b)
+}
+
+type BalanceAsOfDTO struct {
+	AccountID uint64          `json:"account_id"`
+	Balance   entity.Money    `json:"balance" swaggertype:"string" example:"1250.00"`
+	Currency  entity.Currency `json:"currency" swaggertype:"string" example:"USD"`
+	AsOf      time.Time       `json:"as_of"`
+	// SnapshotDate is the day whose closing balance the postings after it were added to;
+	// omitted when the balance was summed from the whole ledger
+	SnapshotDate string `json:"snapshot_date,omitempty" example:"2025-03-30"`
+}
+
+type BalanceSnapshotRequestDTO struct {
+	// Date is the day to snapshot; it must have ended
+	Date string `json:"date" validate:"required,datetime=2006-01-02" example:"2025-03-31"`
+}
+
+// Validate validates the BalanceSnapshotRequestDTO struct.
+func (b BalanceSnapshotRequestDTO) Validate() error {
+	return GetValidator().Struct(b)
+}
+
+type BalanceSnapshotRunDTO struct {
+	Date string `json:"date" example:"2025-03-31"`
+	// Accounts is the number of accounts snapshotted; accounts snapshotted before are not counted
+	Accounts int64 `json:"accounts"`
+}
@@ -0,0 +1,25 @@
+package dto
+
+import "transaction_demo/app/domain/entity"
+
+type ReversalDTO struct {
+	// TransactionID is taken from the path; it is part of the JSON form so idempotency keys are per transaction
+	TransactionID uint64 `json:"transaction_id,omitempty" validate:"required,gt=0" swaggerignore:"true"`
+	// Amount to return to the original source account, in the original transaction currency;
+	// everything not yet reversed when omitted
+	Amount *entity.Money `json:"amount,omitempty" validate:"omitempty,gt=0" swaggertype:"string" example:"25.00"`
+}
+
+// Validate validates the ReversalDTO struct.
+func (r ReversalDTO) Validate() error {
+	return GetValidator().Struct(r)
+}
+
+type ReversalResultDTO struct {
+	TransactionID         uint64          `json:"transaction_id"`
+	OriginalTransactionID uint64          `json:"original_transaction_id"`
+	Amount                entity.Money    `json:"amount" swaggertype:"string" example:"25.00"`
+	Currency              entity.Currency `json:"currency" swaggertype:"string" example:"USD"`
+	// RemainingAmount is what can still be reversed on the original transaction
+	RemainingAmount entity.Money `json:"remaining_amount" swaggertype:"string" example:"75.50"`
+}
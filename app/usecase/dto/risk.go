@@ -0,0 +1,42 @@
+package dto
+
+import (
+	"time"
+
+	"transaction_demo/app/domain/entity"
+)
+
+type ReviewDecisionDTO struct {
+	// TransactionID is taken from the path; it is part of the JSON form so idempotency keys are per transaction
+	TransactionID uint64 `json:"transaction_id,omitempty" validate:"required,gt=0" swaggerignore:"true"`
+	DecidedBy     string `json:"decided_by" validate:"required,max=64"`
+	Note          string `json:"note,omitempty" validate:"max=512"`
+}
+
+// Validate validates the ReviewDecisionDTO struct.
+func (r ReviewDecisionDTO) Validate() error {
+	return GetValidator().Struct(r)
+}
+
+type RiskReviewListDTO struct {
+	// Status defaults to open
+	Status entity.RiskReviewStatus `form:"status" validate:"omitempty,oneof=open released rejected" swaggertype:"string" enums:"open,released,rejected"`
+	Limit  int                     `form:"limit" validate:"omitempty,min=1,max=100"`
+}
+
+// Validate validates the RiskReviewListDTO struct.
+func (r RiskReviewListDTO) Validate() error {
+	return GetValidator().Struct(r)
+}
+
+type RiskReviewDTO struct {
+	ReviewID      uint64                  `json:"review_id"`
+	TransactionID uint64                  `json:"transaction_id"`
+	Rule          string                  `json:"rule" example:"new_account"`
+	Reason        string                  `json:"reason"`
+	Status        entity.RiskReviewStatus `json:"status" swaggertype:"string" enums:"open,released,rejected"`
+	DecidedBy     string                  `json:"decided_by,omitempty"`
+	Note          string                  `json:"note,omitempty"`
+	DecidedAt     *time.Time              `json:"decided_at,omitempty"`
+	CreatedAt     time.Time               `json:"created_at"`
+}
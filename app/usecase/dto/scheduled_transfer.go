@@ -0,0 +1,52 @@
+package dto
+
+import (
+	"time"
+
+	"transaction_demo/app/domain/entity"
+)
+
+type ScheduleTransferDTO struct {
+	SourceAccountID      uint64       `json:"source_account_id" validate:"required,gt=0"`
+	DestinationAccountID uint64       `json:"destination_account_id" validate:"required,gt=0,nefield=SourceAccountID"`
+	Amount               entity.Money `json:"amount" validate:"required,gt=0,currency_precision=Currency" swaggertype:"string" example:"100.50"`
+	// Currency of Amount; defaults to the source account currency and must match it when set
+	Currency entity.Currency `json:"currency,omitempty" validate:"omitempty,currency" swaggertype:"string" example:"USD"`
+	// RunAt is the first (or only) time the transfer is made; recurrences repeat it in UTC
+	RunAt      time.Time         `json:"run_at" validate:"required"`
+	Recurrence entity.Recurrence `json:"recurrence,omitempty" validate:"omitempty,oneof=once daily weekly monthly" swaggertype:"string" enums:"once,daily,weekly,monthly" default:"once"`
+	// EndAt is the last time a recurring transfer may run, inclusive; it runs until cancelled when omitted
+	EndAt *time.Time `json:"end_at,omitempty"`
+}
+
+// Validate validates the ScheduleTransferDTO struct.
+func (s ScheduleTransferDTO) Validate() error {
+	return GetValidator().Struct(s)
+}
+
+type ScheduledTransferDTO struct {
+	ScheduleID           uint64            `json:"schedule_id"`
+	SourceAccountID      uint64            `json:"source_account_id"`
+	DestinationAccountID uint64            `json:"destination_account_id"`
+	Amount               entity.Money      `json:"amount" swaggertype:"string" example:"100.50"`
+	Currency             entity.Currency   `json:"currency" swaggertype:"string" example:"USD"`
+	Recurrence           entity.Recurrence `json:"recurrence" swaggertype:"string" enums:"once,daily,weekly,monthly"`
+	StartAt              time.Time         `json:"start_at"`
+	EndAt                *time.Time        `json:"end_at,omitempty"`
+	// NextRunAt is only set while the schedule is active
+	NextRunAt *time.Time            `json:"next_run_at,omitempty"`
+	Status    entity.ScheduleStatus `json:"status" swaggertype:"string" enums:"active,completed,cancelled"`
+	CreatedAt time.Time             `json:"created_at"`
+	UpdatedAt time.Time             `json:"updated_at"`
+}
+
+type ScheduledRunDTO struct {
+	RunID        uint64           `json:"run_id"`
+	ScheduledFor time.Time        `json:"scheduled_for"`
+	Status       entity.RunStatus `json:"status" swaggertype:"string" enums:"succeeded,failed"`
+	// TransactionID is the transfer made by a succeeded run
+	TransactionID *uint64   `json:"transaction_id,omitempty"`
+	ErrorCode     string    `json:"error_code,omitempty"`
+	ErrorMessage  string    `json:"error_message,omitempty"`
+	CreatedAt     time.Time `json:"created_at"`
+}
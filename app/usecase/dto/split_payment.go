@@ -0,0 +1,38 @@
+package dto
+
+import (
+	"time"
+
+	"transaction_demo/app/domain/entity"
+)
+
+type SplitPaymentDTO struct {
+	SourceAccountID uint64 `json:"source_account_id" validate:"required,gt=0"`
+	// Amount is the total debited from the source account; the leg amounts must add up to it
+	Amount entity.Money `json:"amount" validate:"required,gt=0,currency_precision=Currency" swaggertype:"string" example:"100.00"`
+	// Currency of Amount; defaults to the source account currency and must match it when set
+	Currency entity.Currency `json:"currency,omitempty" validate:"omitempty,currency" swaggertype:"string" example:"USD"`
+	Legs     []SplitLegDTO   `json:"legs" validate:"required,min=2,max=50,dive"`
+}
+
+// SplitLegDTO is one credited account of a split payment, e.g. the seller, the platform fee or the tax.
+type SplitLegDTO struct {
+	DestinationAccountID uint64       `json:"destination_account_id" validate:"required,gt=0"`
+	Amount               entity.Money `json:"amount" validate:"required,gt=0" swaggertype:"string" example:"85.00"`
+}
+
+// Validate validates the SplitPaymentDTO struct.
+func (s SplitPaymentDTO) Validate() error {
+	return GetValidator().Struct(s)
+}
+
+type SplitPaymentResultDTO struct {
+	SplitPaymentID  uint64          `json:"split_payment_id"`
+	SourceAccountID uint64          `json:"source_account_id"`
+	Amount          entity.Money    `json:"amount" swaggertype:"string" example:"100.00"`
+	Currency        entity.Currency `json:"currency" swaggertype:"string" example:"USD"`
+	// Status is posted, or pending when the payment is held for risk review
+	Status    entity.TransactionStatus `json:"status" swaggertype:"string" enums:"pending,posted"`
+	Legs      []TransactionRecordDTO   `json:"legs"`
+	CreatedAt time.Time                `json:"created_at"`
+}
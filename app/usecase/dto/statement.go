@@ -0,0 +1,54 @@
+package dto
+
+import (
+	"time"
+
+	"transaction_demo/app/domain/entity"
+)
+
+type StatementRequestDTO struct {
+	AccountID uint64 `form:"-" validate:"required,gt=0"`
+	// From and To bound the statement period as RFC 3339 timestamps; To is exclusive
+	From   *time.Time             `form:"from" validate:"required"`
+	To     *time.Time             `form:"to" validate:"required"`
+	Format entity.StatementFormat `form:"format" validate:"omitempty,oneof=csv jsonl camt053" swaggertype:"string" enums:"csv,jsonl,camt053"`
+}
+
+// Validate validates the StatementRequestDTO struct.
+func (s StatementRequestDTO) Validate() error {
+	return GetValidator().Struct(s)
+}
+
+// StatementHeaderDTO opens a statement. ClosingBalance is OpeningBalance plus every line's amount.
+type StatementHeaderDTO struct {
+	AccountID      uint64          `json:"account_id"`
+	Currency       entity.Currency `json:"currency" swaggertype:"string" example:"USD"`
+	From           time.Time       `json:"from"`
+	To             time.Time       `json:"to"`
+	OpeningBalance entity.Money    `json:"opening_balance" swaggertype:"string" example:"1000.00"`
+	ClosingBalance entity.Money    `json:"closing_balance" swaggertype:"string" example:"1250.00"`
+	GeneratedAt    time.Time       `json:"generated_at"`
+}
+
+// StatementLineDTO is one movement of a statement.
+type StatementLineDTO struct {
+	JournalEntryID uint64    `json:"journal_entry_id"`
+	TransactionID  *uint64   `json:"transaction_id,omitempty"`
+	Description    string    `json:"description" example:"transfer"`
+	BookedAt       time.Time `json:"booked_at"`
+	// Amount is positive for credits and negative for debits, fee included
+	Amount                entity.Money    `json:"amount" swaggertype:"string" example:"-101.50"`
+	Fee                   entity.Money    `json:"fee" swaggertype:"string" example:"1.50"`
+	Currency              entity.Currency `json:"currency" swaggertype:"string" example:"USD"`
+	CounterpartyAccountID *uint64         `json:"counterparty_account_id,omitempty"`
+	// Balance is the running balance after the movement
+	Balance entity.Money `json:"balance" swaggertype:"string" example:"898.50"`
+}
+
+// StatementSummaryDTO closes a statement.
+type StatementSummaryDTO struct {
+	Entries        int64        `json:"entries"`
+	TotalCredits   entity.Money `json:"total_credits" swaggertype:"string" example:"350.00"`
+	TotalDebits    entity.Money `json:"total_debits" swaggertype:"string" example:"100.00"`
+	ClosingBalance entity.Money `json:"closing_balance" swaggertype:"string" example:"1250.00"`
+}
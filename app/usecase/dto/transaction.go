@@ -1,9 +1,15 @@
 package dto
 
+import "transaction_demo/app/domain/entity"
+
 type TransactionDTO struct {
-	SourceAccountID      uint64  `json:"source_account_id" validate:"required,number,gt=0"`
-	DestinationAccountID uint64  `json:"destination_account_id" validate:"required,number,gt=0"`
-	Amount               float64 `json:"amount" validate:"required,number,gt=0"`
+	SourceAccountID      uint64       `json:"source_account_id" validate:"required,number,gt=0"`
+	DestinationAccountID uint64       `json:"destination_account_id" validate:"required,number,gt=0"`
+	Amount               entity.Money `json:"amount" validate:"required,gt=0,currency_precision=Currency" swaggertype:"string" example:"100.50"`
+	// Currency of Amount; defaults to the source account currency and must match it when set
+	Currency entity.Currency `json:"currency,omitempty" validate:"omitempty,currency" swaggertype:"string" example:"USD"`
+	// QuoteID explicitly requests a cross-currency conversion at the rate locked by an FX quote
+	QuoteID string `json:"quote_id,omitempty" validate:"omitempty,len=32,hexadecimal"`
 }
 
 // Validate validates the TransactionDTO struct.
@@ -0,0 +1,65 @@
+package dto
+
+import (
+	"time"
+
+	"transaction_demo/app/domain/entity"
+)
+
+type TransactionListDTO struct {
+	AccountID uint64                      `form:"-" validate:"required,gt=0"`
+	Direction entity.TransactionDirection `form:"direction" validate:"omitempty,oneof=incoming outgoing" swaggertype:"string" enums:"incoming,outgoing"`
+	// From and To bound the transaction time as RFC 3339 timestamps; To is exclusive
+	From *time.Time `form:"from"`
+	To   *time.Time `form:"to"`
+	// MinAmount and MaxAmount bound the amount in the account currency, inclusive
+	MinAmount *entity.Money `form:"min_amount" validate:"omitempty,gte=0" swaggertype:"string" example:"10.00"`
+	MaxAmount *entity.Money `form:"max_amount" validate:"omitempty,gte=0" swaggertype:"string" example:"500.00"`
+	// Cursor is the next_cursor of the previous page
+	Cursor string `form:"cursor" validate:"omitempty,max=512"`
+	Limit  int    `form:"limit" validate:"omitempty,min=1,max=100"`
+}
+
+// Validate validates the TransactionListDTO struct.
+func (t TransactionListDTO) Validate() error {
+	return GetValidator().Struct(t)
+}
+
+type TransactionRecordDTO struct {
+	TransactionID        uint64 `json:"transaction_id"`
+	SourceAccountID      uint64 `json:"source_account_id"`
+	DestinationAccountID uint64 `json:"destination_account_id"`
+	// Direction is relative to the account whose history is listed
+	Direction             entity.TransactionDirection `json:"direction,omitempty" swaggertype:"string" example:"outgoing"`
+	Amount                entity.Money                `json:"amount" swaggertype:"string" example:"100.50"`
+	Currency              entity.Currency             `json:"currency" swaggertype:"string" example:"USD"`
+	DestinationAmount     entity.Money                `json:"destination_amount" swaggertype:"string" example:"92.81"`
+	DestinationCurrency   entity.Currency             `json:"destination_currency" swaggertype:"string" example:"EUR"`
+	ExchangeRate          entity.Rate                 `json:"exchange_rate" swaggertype:"string" example:"0.9234"`
+	OriginalTransactionID *uint64                     `json:"original_transaction_id,omitempty"`
+	SplitPaymentID        *uint64                     `json:"split_payment_id,omitempty"`
+	// Fee is charged to the source account on top of Amount; omitted when the transfer was free
+	Fee             *FeeDTO                  `json:"fee,omitempty"`
+	Status          entity.TransactionStatus `json:"status" swaggertype:"string" enums:"pending,posted,failed,reversed"`
+	TransactionTime time.Time                `json:"transaction_time"`
+	UpdatedAt       time.Time                `json:"updated_at"`
+}
+
+// FeeDTO is the breakdown of a transfer fee. Amount is Fixed plus Percentage,
+// unless the schedule's minimum or maximum fee applied.
+type FeeDTO struct {
+	Schedule   string          `json:"schedule" example:"internal_usd"`
+	Fixed      entity.Money    `json:"fixed" swaggertype:"string" example:"0.50"`
+	Percentage entity.Money    `json:"percentage" swaggertype:"string" example:"0.50"`
+	Amount     entity.Money    `json:"amount" swaggertype:"string" example:"1.00"`
+	Currency   entity.Currency `json:"currency" swaggertype:"string" example:"USD"`
+	// TotalDebited is the transfer amount plus the fee
+	TotalDebited entity.Money `json:"total_debited" swaggertype:"string" example:"101.50"`
+}
+
+type PageMetaDTO struct {
+	// NextCursor fetches the following page; empty on the last page
+	NextCursor string `json:"next_cursor,omitempty"`
+	HasMore    bool   `json:"has_more"`
+	Limit      int    `json:"limit"`
+}
@@ -1,7 +1,11 @@
 package dto
 
 import (
+	"reflect"
+
 	"github.com/go-playground/validator/v10"
+
+	"transaction_demo/app/domain/entity"
 )
 
 var (
@@ -17,6 +21,38 @@ var (
 func GetValidator() *validator.Validate {
 	if globalValidator == nil {
 		globalValidator = validator.New(validator.WithRequiredStructEnabled())
+		// Validate Money and Rate by their raw units so numeric tags such as gt=0 work on them
+		globalValidator.RegisterCustomTypeFunc(fixedPointValue, entity.Money{}, entity.Rate{})
+		_ = globalValidator.RegisterValidation("currency", validateCurrency)
+		_ = globalValidator.RegisterValidation("currency_precision", validateCurrencyPrecision)
 	}
 	return globalValidator
 }
+
+// fixedPointValue exposes the raw units of entity.Money and entity.Rate fields to the validator.
+func fixedPointValue(field reflect.Value) interface{} {
+	switch v := field.Interface().(type) {
+	case entity.Money:
+		return v.Units()
+	case entity.Rate:
+		return v.Units()
+	}
+	return nil
+}
+
+// validateCurrency checks that the field holds a supported ISO 4217 currency code.
+func validateCurrency(fl validator.FieldLevel) bool {
+	return entity.Currency(fl.Field().String()).IsValid()
+}
+
+// validateCurrencyPrecision checks that a Money field carries no more fractional digits
+// than the minor unit of the currency named by the tag parameter, e.g.
+// `validate:"currency_precision=Currency"`. An empty currency is not checked here.
+func validateCurrencyPrecision(fl validator.FieldLevel) bool {
+	currencyField := fl.Parent().FieldByName(fl.Param())
+	if !currencyField.IsValid() || currencyField.String() == "" {
+		return true
+	}
+	currency := entity.Currency(currencyField.String())
+	return currency.Fits(entity.MoneyFromUnits(fl.Field().Int()))
+}
@@ -0,0 +1,52 @@
+package dto
+
+import (
+	"time"
+
+	"transaction_demo/app/domain/entity"
+)
+
+type RegisterWebhookDTO struct {
+	// URL must use https and resolve to public addresses only
+	URL string `json:"url" validate:"required,url,startswith=https://" example:"https://example.com/hooks/ledger"`
+	// Secret signs the requests; a random one is generated and returned when omitted
+	Secret     string             `json:"secret,omitempty" validate:"omitempty,min=16,max=128"`
+	EventTypes []entity.EventType `json:"event_types" validate:"required,min=1,dive,oneof=account.created transfer.posted transfer.failed" swaggertype:"array,string" enums:"account.created,transfer.posted,transfer.failed"`
+}
+
+// Validate validates the RegisterWebhookDTO struct.
+func (r RegisterWebhookDTO) Validate() error {
+	return GetValidator().Struct(r)
+}
+
+type WebhookEndpointDTO struct {
+	WebhookID  uint64             `json:"webhook_id"`
+	URL        string             `json:"url"`
+	EventTypes []entity.EventType `json:"event_types" swaggertype:"array,string"`
+	// Secret is only returned when the endpoint is registered
+	Secret    string               `json:"secret,omitempty"`
+	Status    entity.WebhookStatus `json:"status" swaggertype:"string" enums:"active,disabled"`
+	CreatedAt time.Time            `json:"created_at"`
+	UpdatedAt time.Time            `json:"updated_at"`
+}
+
+type WebhookDeadLetterDTO struct {
+	DeadLetterID   uint64           `json:"dead_letter_id"`
+	WebhookID      uint64           `json:"webhook_id"`
+	EventID        uint64           `json:"event_id"`
+	EventType      entity.EventType `json:"event_type" swaggertype:"string"`
+	Attempts       int              `json:"attempts"`
+	LastStatusCode int              `json:"last_status_code,omitempty"`
+	LastError      string           `json:"last_error,omitempty"`
+	CreatedAt      time.Time        `json:"created_at"`
+}
+
+type WebhookDeliveryDTO struct {
+	DeliveryID    uint64           `json:"delivery_id"`
+	WebhookID     uint64           `json:"webhook_id"`
+	EventID       uint64           `json:"event_id"`
+	EventType     entity.EventType `json:"event_type" swaggertype:"string"`
+	Attempts      int              `json:"attempts"`
+	NextAttemptAt *time.Time       `json:"next_attempt_at,omitempty"`
+	CreatedAt     time.Time        `json:"created_at"`
+}
@@ -0,0 +1,113 @@
+package usecase
+
+import (
+	"fmt"
+
+	"transaction_demo/app/apperr"
+	"transaction_demo/app/config"
+	"transaction_demo/app/domain/entity"
+)
+
+// FeeCalculator prices transfers with the configured fee schedules.
+type FeeCalculator interface {
+	// Calculate returns the fee of a transfer about to be made.
+	// Transfers no schedule applies to are free and get a zero breakdown.
+	Calculate(transaction *entity.Transaction) (entity.FeeBreakdown, error)
+}
+
+type feeCalculator struct {
+	schedules []entity.FeeSchedule
+}
+
+// NewFeeCalculator builds the calculator from the fees.schedules configuration.
+// An invalid schedule, or two schedules for the same transfer type and currency,
+// fail startup rather than leaving the charged fee ambiguous.
+func NewFeeCalculator(cf *config.Config) (FeeCalculator, error) {
+	schedules := make([]entity.FeeSchedule, 0, len(cf.Fees.Schedules))
+	for _, c := range cf.Fees.Schedules {
+		schedule, err := parseFeeSchedule(c)
+		if err != nil {
+			return nil, fmt.Errorf("fee schedule %q: %w", c.Name, err)
+		}
+		for _, s := range schedules {
+			if s.AppliesTo(schedule.TransferType, schedule.Currency) {
+				return nil, fmt.Errorf("fee schedule %q: %s %s transfers are already priced by %q",
+					c.Name, schedule.TransferType, schedule.Currency, s.Name)
+			}
+		}
+		schedules = append(schedules, schedule)
+	}
+
+	return &feeCalculator{schedules: schedules}, nil
+}
+
+// parseFeeSchedule converts a configured schedule; empty amounts and rates are zero.
+func parseFeeSchedule(c config.FeeSchedule) (entity.FeeSchedule, error) {
+	schedule := entity.FeeSchedule{
+		Name:         c.Name,
+		TransferType: entity.TransferType(c.TransferType),
+		Currency:     entity.Currency(c.Currency),
+		Type:         entity.FeeType(c.Type),
+	}
+	var err error
+	if schedule.Flat, err = parseOptionalMoney(c.Flat); err != nil {
+		return entity.FeeSchedule{}, err
+	}
+	if schedule.Rate, err = parseOptionalRate(c.Rate); err != nil {
+		return entity.FeeSchedule{}, err
+	}
+	if schedule.Min, err = parseOptionalMoney(c.Min); err != nil {
+		return entity.FeeSchedule{}, err
+	}
+	if schedule.Max, err = parseOptionalMoney(c.Max); err != nil {
+		return entity.FeeSchedule{}, err
+	}
+	for _, t := range c.Tiers {
+		var tier entity.FeeTier
+		if tier.UpTo, err = parseOptionalMoney(t.UpTo); err != nil {
+			return entity.FeeSchedule{}, err
+		}
+		if tier.Flat, err = parseOptionalMoney(t.Flat); err != nil {
+			return entity.FeeSchedule{}, err
+		}
+		if tier.Rate, err = parseOptionalRate(t.Rate); err != nil {
+			return entity.FeeSchedule{}, err
+		}
+		schedule.Tiers = append(schedule.Tiers, tier)
+	}
+
+	if err = schedule.Validate(); err != nil {
+		return entity.FeeSchedule{}, err
+	}
+	return schedule, nil
+}
+
+func parseOptionalMoney(s string) (entity.Money, error) {
+	if s == "" {
+		return entity.Money{}, nil
+	}
+	return entity.ParseMoney(s)
+}
+
+func parseOptionalRate(s string) (entity.Rate, error) {
+	if s == "" {
+		return entity.Rate{}, nil
+	}
+	return entity.ParseRate(s)
+}
+
+// Calculate prices the transfer with the schedule of its type and source currency.
+func (c feeCalculator) Calculate(transaction *entity.Transaction) (entity.FeeBreakdown, error) {
+	for _, schedule := range c.schedules {
+		if !schedule.AppliesTo(transaction.Type(), transaction.Currency) {
+			continue
+		}
+		fee, err := schedule.Compute(transaction.Amount)
+		if err != nil {
+			fmt.Println("failed to compute fee", "schedule", schedule.Name, "amount", transaction.Amount, "error", err)
+			return entity.FeeBreakdown{}, apperr.ErrInvalidInput.WithMessage("transfer fee is out of range")
+		}
+		return fee, nil
+	}
+	return entity.FeeBreakdown{}, nil
+}
@@ -0,0 +1,120 @@
+package usecase
+
+import (
+	"testing"
+
+	"transaction_demo/app/config"
+	"transaction_demo/app/domain/entity"
+)
+
+func Test_NewFeeCalculator(t *testing.T) {
+	tests := []struct {
+		name      string
+		schedules []config.FeeSchedule
+		wantErr   bool
+	}{
+		{
+			name: "valid_schedules",
+			schedules: []config.FeeSchedule{
+				{Name: "internal_usd", TransferType: "internal", Currency: "USD", Type: "flat", Flat: "0.50"},
+				{Name: "fx_usd", TransferType: "fx", Currency: "USD", Type: "percentage", Rate: "0.005", Min: "1", Max: "50"},
+				{Name: "internal_eur", TransferType: "internal", Currency: "EUR", Type: "tiered", Tiers: []config.FeeTier{
+					{UpTo: "1000", Flat: "0"},
+					{Rate: "0.001"},
+				}},
+			},
+		},
+		{
+			name:      "no_schedules",
+			schedules: nil,
+		},
+		{
+			name: "duplicate_schedule",
+			schedules: []config.FeeSchedule{
+				{Name: "a", TransferType: "internal", Currency: "USD", Type: "flat", Flat: "0.50"},
+				{Name: "b", TransferType: "internal", Currency: "USD", Type: "flat", Flat: "1.00"},
+			},
+			wantErr: true,
+		},
+		{
+			name:      "unknown_transfer_type",
+			schedules: []config.FeeSchedule{{Name: "x", TransferType: "wire", Currency: "USD", Type: "flat", Flat: "1"}},
+			wantErr:   true,
+		},
+		{
+			name:      "invalid_rate",
+			schedules: []config.FeeSchedule{{Name: "x", TransferType: "fx", Currency: "USD", Type: "percentage", Rate: "1%"}},
+			wantErr:   true,
+		},
+		{
+			name: "min_above_max",
+			schedules: []config.FeeSchedule{{Name: "x", TransferType: "fx", Currency: "USD", Type: "percentage",
+				Rate: "0.01", Min: "10", Max: "5"}},
+			wantErr: true,
+		},
+		{
+			name: "tiers_without_unbounded_last",
+			schedules: []config.FeeSchedule{{Name: "x", TransferType: "internal", Currency: "USD", Type: "tiered",
+				Tiers: []config.FeeTier{{UpTo: "1000", Flat: "1"}}}},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cf := &config.Config{Fees: config.Fees{Schedules: tt.schedules}}
+			_, err := NewFeeCalculator(cf)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NewFeeCalculator() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_feeCalculator_Calculate(t *testing.T) {
+	calculator := &feeCalculator{schedules: []entity.FeeSchedule{
+		{Name: "internal_usd", TransferType: entity.TransferInternal, Currency: entity.CurrencyUSD,
+			Type: entity.FeeFlat, Flat: entity.MustParseMoney("0.50")},
+		{Name: "fx_usd", TransferType: entity.TransferFX, Currency: entity.CurrencyUSD,
+			Type: entity.FeePercentage, Rate: entity.MustParseRate("0.01")},
+	}}
+	tests := []struct {
+		name        string
+		transaction *entity.Transaction
+		want        entity.FeeBreakdown
+	}{
+		{
+			name: "internal",
+			transaction: &entity.Transaction{Amount: entity.MustParseMoney("100"),
+				Currency: entity.CurrencyUSD, DestinationCurrency: entity.CurrencyUSD},
+			want: entity.FeeBreakdown{Schedule: "internal_usd", Fixed: entity.MustParseMoney("0.50"),
+				Amount: entity.MustParseMoney("0.50")},
+		},
+		{
+			name: "fx",
+			transaction: &entity.Transaction{Amount: entity.MustParseMoney("100"),
+				Currency: entity.CurrencyUSD, DestinationCurrency: entity.CurrencyEUR},
+			want: entity.FeeBreakdown{Schedule: "fx_usd", Percentage: entity.MustParseMoney("1.00"),
+				Amount: entity.MustParseMoney("1.00")},
+		},
+		{
+			// No schedule prices EUR transfers, so they are free
+			name: "no_schedule",
+			transaction: &entity.Transaction{Amount: entity.MustParseMoney("100"),
+				Currency: entity.CurrencyEUR, DestinationCurrency: entity.CurrencyEUR},
+			want: entity.FeeBreakdown{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := calculator.Calculate(tt.transaction)
+			if err != nil {
+				t.Fatalf("Calculate() unexpected error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Calculate() got = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
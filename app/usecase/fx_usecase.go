@@ -0,0 +1,133 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"transaction_demo/app/apperr"
+	"transaction_demo/app/config"
+	"transaction_demo/app/domain/entity"
+	"transaction_demo/app/domain/repository"
+	"transaction_demo/app/usecase/dto"
+)
+
+// defaultQuoteTTL is used when the configuration does not set fx.quote_ttl_seconds.
+const defaultQuoteTTL = 30 * time.Second
+
+// FXUC defines the interface for foreign exchange operations.
+// Provides rate management and short-lived quotes that lock a rate for a transfer.
+type FXUC interface {
+	// CreateQuote locks the current rate of a currency pair for a limited time.
+	CreateQuote(ctx context.Context, req dto.QuoteRequestDTO) (dto.QuoteDTO, error)
+
+	// SetRate creates or replaces the current rate of a currency pair.
+	SetRate(ctx context.Context, req dto.RateDTO) (dto.RateDTO, error)
+}
+
+type fxUsecase struct {
+	rateRepo  repository.RateRepository
+	quoteRepo repository.QuoteRepository
+	quoteTTL  time.Duration
+}
+
+func NewFXUsecase(
+	rateRepo repository.RateRepository,
+	quoteRepo repository.QuoteRepository,
+	cf *config.Config) FXUC {
+	quoteTTL := time.Duration(cf.FX.QuoteTTLSeconds) * time.Second
+	if quoteTTL <= 0 {
+		quoteTTL = defaultQuoteTTL
+	}
+	return &fxUsecase{
+		rateRepo:  rateRepo,
+		quoteRepo: quoteRepo,
+		quoteTTL:  quoteTTL,
+	}
+}
+
+// CreateQuote snapshots the current rate of the pair into a single-use quote.
+// The quote ID is later passed to MakeTransaction to convert at exactly this rate.
+func (uc fxUsecase) CreateQuote(ctx context.Context, req dto.QuoteRequestDTO) (dto.QuoteDTO, error) {
+	err := req.Validate()
+	if err != nil {
+		fmt.Println("quote validation failed", "error", err)
+		return dto.QuoteDTO{}, apperr.ErrInvalidInput.WithError(err).WithMessage(err.Error())
+	}
+
+	rate, err := uc.rateRepo.FindRate(ctx, req.SourceCurrency, req.DestinationCurrency)
+	if err != nil {
+		fmt.Println("failed to find rate", "error", err)
+		return dto.QuoteDTO{}, apperr.ErrInternalServer.WithError(err).WithMessage("failed to find exchange rate")
+	}
+	if rate == nil {
+		fmt.Println("rate not found", "base", req.SourceCurrency, "quote", req.DestinationCurrency)
+		return dto.QuoteDTO{}, apperr.ErrNotFound.WithMessage("exchange rate not available for currency pair")
+	}
+
+	quoteID, err := newQuoteID()
+	if err != nil {
+		fmt.Println("failed to generate quote id", "error", err)
+		return dto.QuoteDTO{}, apperr.ErrInternalServer.WithError(err).WithMessage("failed to create quote")
+	}
+
+	now := time.Now()
+	quote, err := uc.quoteRepo.Create(ctx, &entity.FXQuote{
+		ID:                  quoteID,
+		SourceCurrency:      req.SourceCurrency,
+		DestinationCurrency: req.DestinationCurrency,
+		Rate:                rate.Rate,
+		ExpiresAt:           now.Add(uc.quoteTTL),
+		CreatedAt:           now,
+	})
+	if err != nil {
+		fmt.Println("failed to create quote", "error", err)
+		return dto.QuoteDTO{}, apperr.ErrInternalServer.WithError(err).WithMessage("failed to create quote")
+	}
+
+	return dto.QuoteDTO{
+		QuoteID:             quote.ID,
+		SourceCurrency:      quote.SourceCurrency,
+		DestinationCurrency: quote.DestinationCurrency,
+		Rate:                quote.Rate,
+		ExpiresAt:           quote.ExpiresAt,
+	}, nil
+}
+
+// SetRate stores the rate of a currency pair. Outstanding quotes keep the rate they locked.
+func (uc fxUsecase) SetRate(ctx context.Context, req dto.RateDTO) (dto.RateDTO, error) {
+	err := req.Validate()
+	if err != nil {
+		fmt.Println("rate validation failed", "error", err)
+		return dto.RateDTO{}, apperr.ErrInvalidInput.WithError(err).WithMessage(err.Error())
+	}
+
+	rate, err := uc.rateRepo.Upsert(ctx, &entity.ExchangeRate{
+		BaseCurrency:  req.BaseCurrency,
+		QuoteCurrency: req.QuoteCurrency,
+		Rate:          req.Rate,
+		UpdatedAt:     time.Now(),
+	})
+	if err != nil {
+		fmt.Println("failed to save rate", "error", err)
+		return dto.RateDTO{}, apperr.ErrInternalServer.WithError(err).WithMessage("failed to save exchange rate")
+	}
+
+	return dto.RateDTO{
+		BaseCurrency:  rate.BaseCurrency,
+		QuoteCurrency: rate.QuoteCurrency,
+		Rate:          rate.Rate,
+		UpdatedAt:     rate.UpdatedAt,
+	}, nil
+}
+
+// newQuoteID returns a random, unguessable 32 character hex identifier.
+func newQuoteID() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
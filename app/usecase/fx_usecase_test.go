@@ -0,0 +1,106 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+
+	"transaction_demo/app/domain/entity"
+	"transaction_demo/app/domain/repository/mock"
+	"transaction_demo/app/usecase/dto"
+)
+
+func Test_fxUsecase_CreateQuote(t *testing.T) {
+	type fxFields struct {
+		rateRepo  *mock.MockRateRepository
+		quoteRepo *mock.MockQuoteRepository
+	}
+	tests := []struct {
+		name    string
+		req     dto.QuoteRequestDTO
+		setup   func(fields fxFields)
+		want    entity.Rate
+		wantErr bool
+	}{
+		{
+			name: "success",
+			req:  dto.QuoteRequestDTO{SourceCurrency: "USD", DestinationCurrency: "EUR"},
+			setup: func(fields fxFields) {
+				fields.rateRepo.EXPECT().FindRate(gomock.Any(), entity.CurrencyUSD, entity.CurrencyEUR).
+					Return(&entity.ExchangeRate{BaseCurrency: "USD", QuoteCurrency: "EUR", Rate: entity.MustParseRate("0.9234")}, nil)
+				fields.quoteRepo.EXPECT().Create(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, q *entity.FXQuote) (*entity.FXQuote, error) {
+						return q, nil
+					})
+			},
+			want: entity.MustParseRate("0.9234"),
+		},
+		{
+			name:    "validation_error_same_currency",
+			req:     dto.QuoteRequestDTO{SourceCurrency: "USD", DestinationCurrency: "USD"},
+			wantErr: true,
+		},
+		{
+			name:    "validation_error_unknown_currency",
+			req:     dto.QuoteRequestDTO{SourceCurrency: "USD", DestinationCurrency: "ABC"},
+			wantErr: true,
+		},
+		{
+			name: "rate_not_found",
+			req:  dto.QuoteRequestDTO{SourceCurrency: "USD", DestinationCurrency: "JPY"},
+			setup: func(fields fxFields) {
+				fields.rateRepo.EXPECT().FindRate(gomock.Any(), entity.CurrencyUSD, entity.Currency("JPY")).Return(nil, nil)
+			},
+			wantErr: true,
+		},
+		{
+			name: "create_error",
+			req:  dto.QuoteRequestDTO{SourceCurrency: "USD", DestinationCurrency: "EUR"},
+			setup: func(fields fxFields) {
+				fields.rateRepo.EXPECT().FindRate(gomock.Any(), entity.CurrencyUSD, entity.CurrencyEUR).
+					Return(&entity.ExchangeRate{Rate: entity.MustParseRate("0.9234")}, nil)
+				fields.quoteRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil, errors.New("database error"))
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			testFields := fxFields{
+				rateRepo:  mock.NewMockRateRepository(ctrl),
+				quoteRepo: mock.NewMockQuoteRepository(ctrl),
+			}
+			uc := fxUsecase{
+				rateRepo:  testFields.rateRepo,
+				quoteRepo: testFields.quoteRepo,
+				quoteTTL:  30 * time.Second,
+			}
+
+			if tt.setup != nil {
+				tt.setup(testFields)
+			}
+
+			got, err := uc.CreateQuote(context.Background(), tt.req)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("CreateQuote() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			if got.Rate != tt.want || len(got.QuoteID) != 32 {
+				t.Errorf("CreateQuote() got = %+v, want rate %v", got, tt.want)
+			}
+			if ttl := time.Until(got.ExpiresAt); ttl <= 0 || ttl > 30*time.Second {
+				t.Errorf("CreateQuote() expires_at = %v, want within quote TTL", got.ExpiresAt)
+			}
+		})
+	}
+}
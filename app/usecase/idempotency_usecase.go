@@ -0,0 +1,177 @@
+package usecase
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/avito-tech/go-transaction-manager/trm/v2"
+
+	"transaction_demo/app/apperr"
+	"transaction_demo/app/config"
+	"transaction_demo/app/domain/entity"
+	"transaction_demo/app/domain/repository"
+	"transaction_demo/app/usecase/dto"
+)
+
+// defaultIdempotencyTTL is used when the configuration does not set idempotency.ttl_seconds.
+const defaultIdempotencyTTL = 24 * time.Hour
+
+// idempotencyPurgeBatchSize is the number of expired keys deleted per statement.
+const idempotencyPurgeBatchSize = 1000
+
+// IdempotentFunc performs the guarded operation and returns the response status and body.
+// It receives the context of the DB transaction holding the idempotency key.
+type IdempotentFunc func(ctx context.Context) (int, interface{}, error)
+
+// IdempotencyUC defines the interface for executing requests at most once per idempotency key.
+type IdempotencyUC interface {
+	// Execute runs fn once per key and replays the recorded response for retries.
+	Execute(ctx context.Context, req dto.IdempotencyDTO, fn IdempotentFunc) (dto.IdempotentResponseDTO, error)
+	// PurgeExpired deletes the keys expired at now and returns how many were deleted.
+	PurgeExpired(ctx context.Context, now time.Time) (int64, error)
+}
+
+type idempotencyUsecase struct {
+	idempotencyRepo repository.IdempotencyRepository
+	txManager       trm.Manager
+	ttl             time.Duration
+}
+
+func NewIdempotencyUsecase(
+	idempotencyRepo repository.IdempotencyRepository,
+	txManager trm.Manager,
+	cf *config.Config) IdempotencyUC {
+	ttl := time.Duration(cf.Idempotency.TTLSeconds) * time.Second
+	if ttl <= 0 {
+		ttl = defaultIdempotencyTTL
+	}
+	return &idempotencyUsecase{
+		idempotencyRepo: idempotencyRepo,
+		txManager:       txManager,
+		ttl:             ttl,
+	}
+}
+
+// Execute guards fn with an idempotency key.
+//
+// The key row and everything fn writes share one DB transaction:
+// - A new key is reserved, fn runs and its response is stored before commit
+// - A concurrent request with the same key blocks on the reservation until the first one finishes
+// - A retry with the same payload replays the stored status and body without running fn
+// - A retry with a different payload is rejected
+// - If fn fails the transaction rolls back, so the key stays free for a retry
+// - Keys older than the configured TTL are treated as unused
+func (uc idempotencyUsecase) Execute(ctx context.Context, req dto.IdempotencyDTO, fn IdempotentFunc,
+) (dto.IdempotentResponseDTO, error) {
+	err := req.Validate()
+	if err != nil {
+		fmt.Println("idempotency key validation failed", "error", err)
+		return dto.IdempotentResponseDTO{}, apperr.ErrInvalidInput.WithError(err).WithMessage("invalid Idempotency-Key header")
+	}
+
+	requestHash, err := hashPayload(req.Payload)
+	if err != nil {
+		fmt.Println("failed to hash request payload", "error", err)
+		return dto.IdempotentResponseDTO{}, apperr.ErrInternalServer.WithError(err).WithMessage("failed to process request")
+	}
+
+	var res dto.IdempotentResponseDTO
+	err = uc.txManager.Do(ctx, func(ctx context.Context) error {
+		now := time.Now()
+		key := &entity.IdempotencyKey{
+			Scope:       req.Scope,
+			Key:         req.Key,
+			RequestHash: requestHash,
+			CreatedAt:   now,
+			ExpiresAt:   now.Add(uc.ttl),
+		}
+
+		reserved, err := uc.idempotencyRepo.Reserve(ctx, key)
+		if err != nil {
+			fmt.Println("failed to reserve idempotency key", "error", err)
+			return apperr.ErrInternalServer.WithError(err).WithMessage("failed to reserve idempotency key")
+		}
+
+		if !reserved {
+			existing, err := uc.idempotencyRepo.FindForUpdate(ctx, req.Scope, req.Key)
+			if err != nil {
+				fmt.Println("failed to find idempotency key", "error", err)
+				return apperr.ErrInternalServer.WithError(err).WithMessage("failed to find idempotency key")
+			}
+			if existing != nil && !existing.IsExpired(now) {
+				if existing.RequestHash != requestHash {
+					fmt.Println("idempotency key reused with different payload", "key", req.Key, "scope", req.Scope)
+					return apperr.ErrIdempotencyReused.WithMessage("Idempotency-Key was already used with a different request")
+				}
+				res = dto.IdempotentResponseDTO{
+					Status:   existing.ResponseStatus,
+					Body:     existing.ResponseBody,
+					Replayed: true,
+				}
+				return nil
+			}
+			// The previous use has expired: take the key over for this request
+		}
+
+		status, body, err := fn(ctx)
+		if err != nil {
+			return err
+		}
+
+		key.ResponseStatus = status
+		key.ResponseBody, err = json.Marshal(body)
+		if err != nil {
+			fmt.Println("failed to encode response", "error", err)
+			return apperr.ErrInternalServer.WithError(err).WithMessage("failed to record response")
+		}
+		if err = uc.idempotencyRepo.Update(ctx, key); err != nil {
+			fmt.Println("failed to record idempotent response", "error", err)
+			return apperr.ErrInternalServer.WithError(err).WithMessage("failed to record response")
+		}
+
+		res = dto.IdempotentResponseDTO{
+			Status: key.ResponseStatus,
+			Body:   key.ResponseBody,
+		}
+		return nil
+	})
+	if err != nil {
+		fmt.Println("idempotent request failed", "error", err)
+		return dto.IdempotentResponseDTO{}, err
+	}
+
+	return res, nil
+}
+
+// PurgeExpired deletes the expired keys in batches, each in its own statement, so that the
+// purge does not hold the locks of a large backlog at once. Execute treats expired keys as
+// unused, so purging only reclaims their storage.
+func (uc idempotencyUsecase) PurgeExpired(ctx context.Context, now time.Time) (int64, error) {
+	var total int64
+	for {
+		deleted, err := uc.idempotencyRepo.DeleteExpired(ctx, now, idempotencyPurgeBatchSize)
+		if err != nil {
+			fmt.Println("failed to delete expired idempotency keys", "error", err)
+			return total, apperr.ErrInternalServer.WithError(err).WithMessage("failed to purge idempotency keys")
+		}
+		total += deleted
+		if deleted < idempotencyPurgeBatchSize {
+			return total, nil
+		}
+	}
+}
+
+// hashPayload fingerprints a request payload by its canonical JSON encoding,
+// so formatting differences in the raw body do not count as a different request.
+func hashPayload(payload interface{}) (string, error) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
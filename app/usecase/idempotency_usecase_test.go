@@ -0,0 +1,205 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+
+	"transaction_demo/app/domain/entity"
+	"transaction_demo/app/domain/repository/mock"
+	"transaction_demo/app/usecase/dto"
+	mock2 "transaction_demo/cmd/shared/db/mock"
+)
+
+func Test_idempotencyUsecase_Execute(t *testing.T) {
+	payload := dto.TransactionDTO{SourceAccountID: 111, DestinationAccountID: 222, Amount: entity.MustParseMoney("10")}
+	payloadHash, _ := hashPayload(payload)
+
+	tests := []struct {
+		name      string
+		req       dto.IdempotencyDTO
+		setup     func(repo *mock.MockIdempotencyRepository)
+		fnErr     error
+		wantCalls int
+		want      dto.IdempotentResponseDTO
+		wantErr   bool
+	}{
+		{
+			name: "first_request_executes_and_records",
+			req:  dto.IdempotencyDTO{Key: "key-1", Scope: "POST /api/v1/transactions/", Payload: payload},
+			setup: func(repo *mock.MockIdempotencyRepository) {
+				repo.EXPECT().Reserve(gomock.Any(), gomock.Any()).Return(true, nil)
+				repo.EXPECT().Update(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, key *entity.IdempotencyKey) error {
+						if key.ResponseStatus != http.StatusCreated || string(key.ResponseBody) != `{"ok":true}` {
+							t.Errorf("unexpected recorded response %d %s", key.ResponseStatus, key.ResponseBody)
+						}
+						return nil
+					})
+			},
+			wantCalls: 1,
+			want:      dto.IdempotentResponseDTO{Status: http.StatusCreated, Body: []byte(`{"ok":true}`)},
+		},
+		{
+			name: "retry_replays_recorded_response",
+			req:  dto.IdempotencyDTO{Key: "key-1", Scope: "POST /api/v1/transactions/", Payload: payload},
+			setup: func(repo *mock.MockIdempotencyRepository) {
+				repo.EXPECT().Reserve(gomock.Any(), gomock.Any()).Return(false, nil)
+				repo.EXPECT().FindForUpdate(gomock.Any(), "POST /api/v1/transactions/", "key-1").Return(&entity.IdempotencyKey{
+					Scope:          "POST /api/v1/transactions/",
+					Key:            "key-1",
+					RequestHash:    payloadHash,
+					ResponseStatus: http.StatusCreated,
+					ResponseBody:   []byte(`{"original":true}`),
+					ExpiresAt:      time.Now().Add(time.Hour),
+				}, nil)
+			},
+			wantCalls: 0,
+			want:      dto.IdempotentResponseDTO{Status: http.StatusCreated, Body: []byte(`{"original":true}`), Replayed: true},
+		},
+		{
+			name: "key_reused_with_different_payload",
+			req:  dto.IdempotencyDTO{Key: "key-1", Scope: "POST /api/v1/transactions/", Payload: payload},
+			setup: func(repo *mock.MockIdempotencyRepository) {
+				repo.EXPECT().Reserve(gomock.Any(), gomock.Any()).Return(false, nil)
+				repo.EXPECT().FindForUpdate(gomock.Any(), gomock.Any(), gomock.Any()).Return(&entity.IdempotencyKey{
+					RequestHash: "other",
+					ExpiresAt:   time.Now().Add(time.Hour),
+				}, nil)
+			},
+			wantCalls: 0,
+			wantErr:   true,
+		},
+		{
+			name: "expired_key_is_taken_over",
+			req:  dto.IdempotencyDTO{Key: "key-1", Scope: "POST /api/v1/transactions/", Payload: payload},
+			setup: func(repo *mock.MockIdempotencyRepository) {
+				repo.EXPECT().Reserve(gomock.Any(), gomock.Any()).Return(false, nil)
+				repo.EXPECT().FindForUpdate(gomock.Any(), gomock.Any(), gomock.Any()).Return(&entity.IdempotencyKey{
+					RequestHash: "other",
+					ExpiresAt:   time.Now().Add(-time.Second),
+				}, nil)
+				repo.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil)
+			},
+			wantCalls: 1,
+			want:      dto.IdempotentResponseDTO{Status: http.StatusCreated, Body: []byte(`{"ok":true}`)},
+		},
+		{
+			name: "operation_error_is_not_recorded",
+			req:  dto.IdempotencyDTO{Key: "key-1", Scope: "POST /api/v1/transactions/", Payload: payload},
+			setup: func(repo *mock.MockIdempotencyRepository) {
+				repo.EXPECT().Reserve(gomock.Any(), gomock.Any()).Return(true, nil)
+			},
+			fnErr:     errors.New("insufficient balance"),
+			wantCalls: 1,
+			wantErr:   true,
+		},
+		{
+			name:      "validation_error_key_too_long",
+			req:       dto.IdempotencyDTO{Key: string(make([]byte, 256)), Scope: "POST /api/v1/transactions/", Payload: payload},
+			wantCalls: 0,
+			wantErr:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := mock.NewMockIdempotencyRepository(ctrl)
+			uc := idempotencyUsecase{
+				idempotencyRepo: mockRepo,
+				txManager:       mock2.NewMockTxManager(),
+				ttl:             time.Hour,
+			}
+
+			if tt.setup != nil {
+				tt.setup(mockRepo)
+			}
+
+			calls := 0
+			got, err := uc.Execute(context.Background(), tt.req, func(ctx context.Context) (int, interface{}, error) {
+				calls++
+				return http.StatusCreated, map[string]bool{"ok": true}, tt.fnErr
+			})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Execute() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if calls != tt.wantCalls {
+				t.Errorf("Execute() ran operation %d times, want %d", calls, tt.wantCalls)
+			}
+			if !tt.wantErr && (got.Status != tt.want.Status || string(got.Body) != string(tt.want.Body) ||
+				got.Replayed != tt.want.Replayed) {
+				t.Errorf("Execute() got = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_idempotencyUsecase_PurgeExpired(t *testing.T) {
+	now := time.Date(2025, 9, 27, 9, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		setup   func(repo *mock.MockIdempotencyRepository)
+		want    int64
+		wantErr bool
+	}{
+		{
+			name: "nothing_expired",
+			setup: func(repo *mock.MockIdempotencyRepository) {
+				repo.EXPECT().DeleteExpired(gomock.Any(), now, idempotencyPurgeBatchSize).Return(int64(0), nil)
+			},
+		},
+		{
+			// A full batch may leave expired keys behind, so deleting goes on until a batch is short
+			name: "deletes_in_batches",
+			setup: func(repo *mock.MockIdempotencyRepository) {
+				gomock.InOrder(
+					repo.EXPECT().DeleteExpired(gomock.Any(), now, idempotencyPurgeBatchSize).
+						Return(int64(idempotencyPurgeBatchSize), nil),
+					repo.EXPECT().DeleteExpired(gomock.Any(), now, idempotencyPurgeBatchSize).Return(int64(3), nil),
+				)
+			},
+			want: idempotencyPurgeBatchSize + 3,
+		},
+		{
+			name: "repository_error",
+			setup: func(repo *mock.MockIdempotencyRepository) {
+				repo.EXPECT().DeleteExpired(gomock.Any(), now, idempotencyPurgeBatchSize).
+					Return(int64(0), errors.New("db down"))
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := mock.NewMockIdempotencyRepository(ctrl)
+			uc := idempotencyUsecase{
+				idempotencyRepo: mockRepo,
+				txManager:       mock2.NewMockTxManager(),
+				ttl:             time.Hour,
+			}
+			tt.setup(mockRepo)
+
+			got, err := uc.PurgeExpired(context.Background(), now)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("PurgeExpired() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("PurgeExpired() got = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
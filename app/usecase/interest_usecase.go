@@ -0,0 +1,498 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/avito-tech/go-transaction-manager/trm/v2"
+
+	"transaction_demo/app/apperr"
+	"transaction_demo/app/config"
+	"transaction_demo/app/domain/entity"
+	"transaction_demo/app/domain/repository"
+	"transaction_demo/app/usecase/dto"
+)
+
+// interestBatchSize is the number of accounts read per page while accruing or posting interest.
+const interestBatchSize = 500
+
+// defaultAccrualLimit is the number of accruals listed for an account.
+const defaultAccrualLimit = 90
+
+// InterestUC defines the interface for accruing and posting interest on accounts.
+type InterestUC interface {
+	// AccrueInterest accrues the interest of one ended day on every interest-bearing account.
+	AccrueInterest(ctx context.Context, req dto.InterestAccrualRequestDTO) (dto.InterestRunDTO, error)
+
+	// PostInterest credits the interest accrued in one ended month.
+	PostInterest(ctx context.Context, req dto.InterestPostingRequestDTO) (dto.InterestRunDTO, error)
+
+	// ListAccruals lists the latest daily accruals of an account, newest first.
+	ListAccruals(ctx context.Context, accountID uint64) ([]dto.InterestAccrualDTO, error)
+
+	// CatchUp accrues every ended day and posts every ended month not processed yet.
+	CatchUp(ctx context.Context, now time.Time) error
+}
+
+// interestRateKey selects the rate of an account.
+type interestRateKey struct {
+	accountType entity.AccountType
+	currency    entity.Currency
+}
+
+type interestUsecase struct {
+	accountRepo  repository.AccountRepository
+	ledgerRepo   repository.LedgerRepository
+	interestRepo repository.InterestRepository
+	holdRepo     repository.HoldRepository
+	broadcaster  *AccountBroadcaster
+	txManager    trm.Manager
+	rates        map[interestRateKey]entity.InterestRate
+	accountTypes []entity.AccountType
+}
+
+// NewInterestUsecase builds the usecase with the interest.rates configuration.
+// An invalid rate, or two rates for the same account type and currency, fail startup.
+func NewInterestUsecase(
+	accountRepo repository.AccountRepository,
+	ledgerRepo repository.LedgerRepository,
+	interestRepo repository.InterestRepository,
+	holdRepo repository.HoldRepository,
+	broadcaster *AccountBroadcaster,
+	txManager trm.Manager,
+	cf *config.Config) (InterestUC, error) {
+	uc := &interestUsecase{
+		accountRepo:  accountRepo,
+		ledgerRepo:   ledgerRepo,
+		interestRepo: interestRepo,
+		holdRepo:     holdRepo,
+		broadcaster:  broadcaster,
+		txManager:    txManager,
+		rates:        make(map[interestRateKey]entity.InterestRate, len(cf.Interest.Rates)),
+	}
+	for _, c := range cf.Interest.Rates {
+		rate := entity.InterestRate{
+			AccountType: entity.AccountType(c.AccountType),
+			Currency:    entity.Currency(c.Currency),
+			DayCount:    entity.DayCount(c.DayCount),
+		}
+		annualRate, err := entity.ParseRate(c.AnnualRate)
+		if err != nil {
+			return nil, fmt.Errorf("interest rate %s %s: %w", c.AccountType, c.Currency, err)
+		}
+		rate.AnnualRate = annualRate
+		if err = rate.Validate(); err != nil {
+			return nil, fmt.Errorf("interest rate %s %s: %w", c.AccountType, c.Currency, err)
+		}
+
+		key := interestRateKey{accountType: rate.AccountType, currency: rate.Currency}
+		if _, ok := uc.rates[key]; ok {
+			return nil, fmt.Errorf("interest rate %s %s: configured twice", c.AccountType, c.Currency)
+		}
+		uc.rates[key] = rate
+		if !containsAccountType(uc.accountTypes, rate.AccountType) {
+			uc.accountTypes = append(uc.accountTypes, rate.AccountType)
+		}
+	}
+	return uc, nil
+}
+
+func containsAccountType(types []entity.AccountType, t entity.AccountType) bool {
+	for _, v := range types {
+		if v == t {
+			return true
+		}
+	}
+	return false
+}
+
+// AccrueInterest accrues the interest of an ended day.
+//
+// Accrual rules:
+// - Interest is computed on the end-of-day balance, derived from the postings made before midnight UTC
+// - Only positive balances earn interest; closed accounts and accounts without a configured rate are skipped
+// - Each account accrues at most once per day, so running a day again does not accrue twice
+func (uc interestUsecase) AccrueInterest(ctx context.Context, req dto.InterestAccrualRequestDTO,
+) (dto.InterestRunDTO, error) {
+	if err := req.Validate(); err != nil {
+		fmt.Println("interest accrual validation failed", "error", err)
+		return dto.InterestRunDTO{}, apperr.ErrInvalidInput.WithError(err).WithMessage(err.Error())
+	}
+	date, _ := time.Parse(dto.DateLayout, req.Date)
+	if !date.Before(entity.StartOfDay(time.Now())) {
+		fmt.Println("accrual date not ended", "date", req.Date)
+		return dto.InterestRunDTO{}, apperr.ErrInvalidInput.WithMessage("interest can only be accrued for a day that has ended")
+	}
+
+	return uc.accrue(ctx, date)
+}
+
+// PostInterest posts the interest accrued in an ended month.
+//
+// Posting rules:
+// - The month's last day must be accrued first, so that no accrual is left behind
+// - Each account is credited the month's total, truncated to its currency minor unit, from the
+// interest expense account; an account's accruals are posted once, so running a month again credits nothing
+// - The truncated remainder is carried to the next month as an unposted accrual, and a total below
+// the minor unit stays unposted, so that no interest is lost to rounding
+// - Closed accounts cannot be credited and keep their accruals unposted
+func (uc interestUsecase) PostInterest(ctx context.Context, req dto.InterestPostingRequestDTO,
+) (dto.InterestRunDTO, error) {
+	if err := req.Validate(); err != nil {
+		fmt.Println("interest posting validation failed", "error", err)
+		return dto.InterestRunDTO{}, apperr.ErrInvalidInput.WithError(err).WithMessage(err.Error())
+	}
+	month, _ := time.Parse(dto.MonthLayout, req.Month)
+	if !month.Before(entity.StartOfMonth(time.Now())) {
+		fmt.Println("posting month not ended", "month", req.Month)
+		return dto.InterestRunDTO{}, apperr.ErrInvalidInput.WithMessage("interest can only be posted for a month that has ended")
+	}
+
+	return uc.post(ctx, month)
+}
+
+// ListAccruals lists the latest daily accruals of an account.
+func (uc interestUsecase) ListAccruals(ctx context.Context, accountID uint64) ([]dto.InterestAccrualDTO, error) {
+	account, err := uc.accountRepo.FindOne(ctx, accountID)
+	if err != nil {
+		fmt.Println("failed to find account", "error", err)
+		return nil, apperr.ErrInternalServer.WithError(err).WithMessage("failed to find account")
+	}
+	if account == nil {
+		fmt.Println("account not found", "account_id", accountID)
+		return nil, apperr.ErrNotFound.WithMessage("account not found")
+	}
+
+	accruals, err := uc.interestRepo.FindAccruals(ctx, accountID, defaultAccrualLimit)
+	if err != nil {
+		fmt.Println("failed to find accruals", "error", err)
+		return nil, apperr.ErrInternalServer.WithError(err).WithMessage("failed to find interest accruals")
+	}
+
+	res := make([]dto.InterestAccrualDTO, 0, len(accruals))
+	for _, a := range accruals {
+		res = append(res, dto.InterestAccrualDTO{
+			AccrualDate: a.AccrualDate.Format(dto.DateLayout),
+			Balance:     a.Balance,
+			AnnualRate:  a.AnnualRate,
+			DayCount:    a.DayCount,
+			Amount:      a.Amount,
+			Currency:    a.Currency,
+			Carried:     a.Carried,
+			PostedAt:    a.PostedAt,
+		})
+	}
+	return res, nil
+}
+
+// CatchUp accrues the days and posts the months not processed since the last completed runs.
+//
+// Without any accrual run yet, accrual starts with yesterday rather than backfilling history.
+// A month is posted once its last day is accrued, so the first month after deployment is
+// posted partially accrued and earlier months are never posted.
+func (uc interestUsecase) CatchUp(ctx context.Context, now time.Time) error {
+	yesterday := entity.StartOfDay(now).AddDate(0, 0, -1)
+	lastAccrual, err := uc.findLastRun(ctx, entity.InterestRunAccrual)
+	if err != nil {
+		return err
+	}
+	day := yesterday
+	if lastAccrual != nil {
+		day = lastAccrual.RunDate.UTC().AddDate(0, 0, 1)
+	}
+	for ; !day.After(yesterday); day = day.AddDate(0, 0, 1) {
+		if _, err = uc.accrue(ctx, day); err != nil {
+			return err
+		}
+	}
+
+	lastMonth := entity.StartOfMonth(now).AddDate(0, -1, 0)
+	lastPosting, err := uc.findLastRun(ctx, entity.InterestRunPosting)
+	if err != nil {
+		return err
+	}
+	month := lastMonth
+	if lastPosting != nil {
+		month = lastPosting.RunDate.UTC().AddDate(0, 1, 0)
+	}
+	for ; !month.After(lastMonth); month = month.AddDate(0, 1, 0) {
+		accrued, err := uc.findRun(ctx, entity.InterestRunAccrual, month.AddDate(0, 1, -1))
+		if err != nil {
+			return err
+		}
+		if accrued == nil {
+			break
+		}
+		if _, err = uc.post(ctx, month); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// accrue accrues one day on every account of a type that has a configured rate.
+// Accounts are read a page at a time; each page of accruals is a single insert.
+func (uc interestUsecase) accrue(ctx context.Context, date time.Time) (dto.InterestRunDTO, error) {
+	run, err := uc.findRun(ctx, entity.InterestRunAccrual, date)
+	if err != nil || run != nil {
+		return toInterestRunDTO(run, true), err
+	}
+
+	end := date.AddDate(0, 0, 1)
+	var accrued int64
+	for _, accountType := range uc.accountTypes {
+		var afterID uint64
+		for {
+			accounts, err := uc.accountRepo.FindByType(ctx, accountType, afterID, interestBatchSize)
+			if err != nil {
+				fmt.Println("failed to find accounts", "error", err)
+				return dto.InterestRunDTO{}, apperr.ErrInternalServer.WithError(err).WithMessage("failed to find accounts")
+			}
+
+			accruals := make([]*entity.InterestAccrual, 0, len(accounts))
+			for _, account := range accounts {
+				afterID = account.ID
+				accrual, err := uc.accrueAccount(ctx, account, date, end)
+				if err != nil {
+					return dto.InterestRunDTO{}, err
+				}
+				if accrual != nil {
+					accruals = append(accruals, accrual)
+				}
+			}
+
+			inserted, err := uc.interestRepo.CreateAccruals(ctx, accruals)
+			if err != nil {
+				fmt.Println("failed to create accruals", "error", err)
+				return dto.InterestRunDTO{}, apperr.ErrInternalServer.WithError(err).WithMessage("failed to record interest accruals")
+			}
+			accrued += inserted
+
+			if len(accounts) < interestBatchSize {
+				break
+			}
+		}
+	}
+
+	run = &entity.InterestRun{Kind: entity.InterestRunAccrual, RunDate: date, Accounts: accrued, CreatedAt: time.Now()}
+	if err = uc.createRun(ctx, run); err != nil {
+		return dto.InterestRunDTO{}, err
+	}
+	fmt.Println("interest accrued", "date", date.Format(dto.DateLayout), "accounts", accrued)
+	return toInterestRunDTO(run, false), nil
+}
+
+// accrueAccount computes the accrual of an account for the day ending at end, nil when it earns nothing.
+func (uc interestUsecase) accrueAccount(ctx context.Context, account *entity.Account, date time.Time,
+	end time.Time) (*entity.InterestAccrual, error) {
+	rate, ok := uc.rates[interestRateKey{accountType: account.Type, currency: account.Currency}]
+	if !ok || account.Status == entity.AccountClosed || !account.CreatedAt.Before(end) {
+		return nil, nil
+	}
+
+	balance, err := uc.ledgerRepo.SumByAccountBefore(ctx, account.ID, end)
+	if err != nil {
+		fmt.Println("failed to sum postings", "error", err)
+		return nil, apperr.ErrInternalServer.WithError(err).WithMessage("failed to read end-of-day balance")
+	}
+	amount, err := rate.DailyInterest(balance, date)
+	if err != nil {
+		fmt.Println("failed to compute interest", "account_id", account.ID, "balance", balance, "error", err)
+		return nil, apperr.ErrInternalServer.WithError(err).WithMessage("failed to compute interest")
+	}
+	if !amount.IsPositive() {
+		return nil, nil
+	}
+
+	return &entity.InterestAccrual{
+		AccountID:   account.ID,
+		AccrualDate: date,
+		Balance:     balance,
+		AnnualRate:  rate.AnnualRate,
+		DayCount:    rate.DayCount,
+		Amount:      amount,
+		Currency:    account.Currency,
+		CreatedAt:   time.Now(),
+	}, nil
+}
+
+// post credits the accruals of a month, one DB transaction per account.
+func (uc interestUsecase) post(ctx context.Context, month time.Time) (dto.InterestRunDTO, error) {
+	run, err := uc.findRun(ctx, entity.InterestRunPosting, month)
+	if err != nil || run != nil {
+		return toInterestRunDTO(run, true), err
+	}
+
+	end := month.AddDate(0, 1, 0)
+	lastDay := end.AddDate(0, 0, -1)
+	accrued, err := uc.findRun(ctx, entity.InterestRunAccrual, lastDay)
+	if err != nil {
+		return dto.InterestRunDTO{}, err
+	}
+	if accrued == nil {
+		fmt.Println("month not accrued", "month", month.Format(dto.MonthLayout))
+		return dto.InterestRunDTO{}, apperr.ErrInvalidInput.WithMessage(
+			fmt.Sprintf("interest is not accrued through %s yet", lastDay.Format(dto.DateLayout)))
+	}
+
+	var credited int64
+	var afterID uint64
+	for {
+		ids, err := uc.interestRepo.FindAccountsWithUnposted(ctx, end, afterID, interestBatchSize)
+		if err != nil {
+			fmt.Println("failed to find accounts with unposted accruals", "error", err)
+			return dto.InterestRunDTO{}, apperr.ErrInternalServer.WithError(err).WithMessage("failed to find interest accruals")
+		}
+		for _, id := range ids {
+			afterID = id
+			posted, err := uc.postAccount(ctx, id, end)
+			if err != nil {
+				return dto.InterestRunDTO{}, err
+			}
+			if posted {
+				credited++
+			}
+		}
+		if len(ids) < interestBatchSize {
+			break
+		}
+	}
+
+	run = &entity.InterestRun{Kind: entity.InterestRunPosting, RunDate: month, Accounts: credited, CreatedAt: time.Now()}
+	if err = uc.createRun(ctx, run); err != nil {
+		return dto.InterestRunDTO{}, err
+	}
+	fmt.Println("interest posted", "month", month.Format(dto.MonthLayout), "accounts", credited)
+	return toInterestRunDTO(run, false), nil
+}
+
+// postAccount credits an account with its unposted accruals dated before end and reports
+// whether money was credited. The part of their total below the currency minor unit is carried
+// as an accrual dated end, which the next posting credits.
+//
+// The account row is locked before its accruals, like transfers lock accounts first, and the
+// accruals are marked posted in the same DB transaction as the credit.
+func (uc interestUsecase) postAccount(ctx context.Context, accountID uint64, end time.Time) (bool, error) {
+	credited := false
+	err := uc.txManager.Do(ctx, func(ctx context.Context) error {
+		accounts, err := uc.accountRepo.FindForUpdate(ctx, []uint64{accountID})
+		if err != nil {
+			fmt.Println("failed to query account for update", "error", err)
+			return apperr.ErrInternalServer.WithError(err).WithMessage("failed to find account for update")
+		}
+		if len(accounts) == 0 {
+			fmt.Println("account not found for update", "account_id", accountID)
+			return apperr.ErrInternalServer.WithMessage("account not found for update")
+		}
+		account := accounts[0]
+		if !account.CanCredit() {
+			fmt.Println("interest not posted to account", "account_id", accountID, "status", account.Status)
+			return nil
+		}
+
+		accruals, err := uc.interestRepo.FindUnpostedForUpdate(ctx, accountID, end)
+		if err != nil {
+			fmt.Println("failed to find unposted accruals", "error", err)
+			return apperr.ErrInternalServer.WithError(err).WithMessage("failed to find interest accruals")
+		}
+		if len(accruals) == 0 {
+			return nil
+		}
+
+		var total entity.Money
+		ids := make([]uint64, 0, len(accruals))
+		for _, a := range accruals {
+			total = total.Add(a.Amount)
+			ids = append(ids, a.ID)
+		}
+		// Interest below the minor unit is left unposted, to add up with the next month's
+		amount := total.Truncate(account.Currency.Exponent())
+		if !amount.IsPositive() {
+			return nil
+		}
+
+		entry := entity.NewInterestEntry(account.ID, amount, account.Currency)
+		if err = postJournalEntry(ctx, uc.ledgerRepo, entry); err != nil {
+			return err
+		}
+		account.Balance = account.Balance.Add(amount)
+		if err = uc.accountRepo.Update(ctx, account); err != nil {
+			fmt.Println("failed to update account", "error", err)
+			return apperr.ErrInternalServer.WithError(err).WithMessage("failed to update account")
+		}
+		if err = notifyAccountState(ctx, uc.holdRepo, uc.broadcaster, account); err != nil {
+			return err
+		}
+
+		now := time.Now()
+		if err = uc.interestRepo.MarkPosted(ctx, ids, &entry.ID, now); err != nil {
+			fmt.Println("failed to mark accruals posted", "error", err)
+			return apperr.ErrInternalServer.WithError(err).WithMessage("failed to record interest posting")
+		}
+		if remainder := total.Sub(amount); remainder.IsPositive() {
+			last := accruals[len(accruals)-1]
+			carried := &entity.InterestAccrual{
+				AccountID:   account.ID,
+				AccrualDate: end,
+				AnnualRate:  last.AnnualRate,
+				DayCount:    last.DayCount,
+				Amount:      remainder,
+				Currency:    account.Currency,
+				Carried:     true,
+				CreatedAt:   now,
+			}
+			if _, err = uc.interestRepo.CreateAccruals(ctx, []*entity.InterestAccrual{carried}); err != nil {
+				fmt.Println("failed to carry interest remainder", "account_id", accountID, "error", err)
+				return apperr.ErrInternalServer.WithError(err).WithMessage("failed to record interest posting")
+			}
+		}
+		credited = true
+		return nil
+	})
+	if err != nil {
+		fmt.Println("interest posting failed", "account_id", accountID, "error", err)
+		return false, err
+	}
+	return credited, nil
+}
+
+func (uc interestUsecase) findRun(ctx context.Context, kind entity.InterestRunKind, date time.Time,
+) (*entity.InterestRun, error) {
+	run, err := uc.interestRepo.FindRun(ctx, kind, date)
+	if err != nil {
+		fmt.Println("failed to find interest run", "kind", kind, "error", err)
+		return nil, apperr.ErrInternalServer.WithError(err).WithMessage("failed to find interest run")
+	}
+	return run, nil
+}
+
+func (uc interestUsecase) findLastRun(ctx context.Context, kind entity.InterestRunKind) (*entity.InterestRun, error) {
+	run, err := uc.interestRepo.FindLastRun(ctx, kind)
+	if err != nil {
+		fmt.Println("failed to find last interest run", "kind", kind, "error", err)
+		return nil, apperr.ErrInternalServer.WithError(err).WithMessage("failed to find interest run")
+	}
+	return run, nil
+}
+
+func (uc interestUsecase) createRun(ctx context.Context, run *entity.InterestRun) error {
+	if err := uc.interestRepo.CreateRun(ctx, run); err != nil {
+		fmt.Println("failed to record interest run", "kind", run.Kind, "error", err)
+		return apperr.ErrInternalServer.WithError(err).WithMessage("failed to record interest run")
+	}
+	return nil
+}
+
+func toInterestRunDTO(run *entity.InterestRun, alreadyRun bool) dto.InterestRunDTO {
+	if run == nil {
+		return dto.InterestRunDTO{}
+	}
+	return dto.InterestRunDTO{
+		Kind:        run.Kind,
+		Date:        run.RunDate.UTC().Format(dto.DateLayout),
+		Accounts:    run.Accounts,
+		AlreadyRun:  alreadyRun,
+		CompletedAt: run.CreatedAt,
+	}
+}
@@ -0,0 +1,361 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+
+	"transaction_demo/app/apperr"
+	"transaction_demo/app/config"
+	"transaction_demo/app/domain/entity"
+	"transaction_demo/app/domain/repository/mock"
+	"transaction_demo/app/usecase/dto"
+	mock2 "transaction_demo/cmd/shared/db/mock"
+)
+
+type interestFields struct {
+	accountRepo  *mock.MockAccountRepository
+	ledgerRepo   *mock.MockLedgerRepository
+	interestRepo *mock.MockInterestRepository
+	holdRepo     *mock.MockHoldRepository
+}
+
+func newTestInterestUsecase(t *testing.T, ctrl *gomock.Controller) (InterestUC, interestFields) {
+	testFields := interestFields{
+		accountRepo:  mock.NewMockAccountRepository(ctrl),
+		ledgerRepo:   mock.NewMockLedgerRepository(ctrl),
+		interestRepo: mock.NewMockInterestRepository(ctrl),
+		holdRepo:     mock.NewMockHoldRepository(ctrl),
+	}
+	cf := &config.Config{Interest: config.Interest{Rates: []config.InterestRate{
+		{AccountType: "savings", Currency: "USD", AnnualRate: "0.0365", DayCount: "act/365"},
+	}}}
+	uc, err := NewInterestUsecase(testFields.accountRepo, testFields.ledgerRepo, testFields.interestRepo,
+		testFields.holdRepo, NewAccountBroadcaster(cf), &mock2.MockTxManager{}, cf)
+	if err != nil {
+		t.Fatalf("NewInterestUsecase() unexpected error = %v", err)
+	}
+	return uc, testFields
+}
+
+func Test_NewInterestUsecase(t *testing.T) {
+	tests := []struct {
+		name    string
+		rates   []config.InterestRate
+		wantErr bool
+	}{
+		{
+			name: "valid_rates",
+			rates: []config.InterestRate{
+				{AccountType: "savings", Currency: "USD", AnnualRate: "0.0425", DayCount: "act/365"},
+				{AccountType: "savings", Currency: "EUR", AnnualRate: "0.03", DayCount: "act/360"},
+			},
+		},
+		{
+			name: "duplicate_rate",
+			rates: []config.InterestRate{
+				{AccountType: "savings", Currency: "USD", AnnualRate: "0.0425", DayCount: "act/365"},
+				{AccountType: "savings", Currency: "USD", AnnualRate: "0.05", DayCount: "act/365"},
+			},
+			wantErr: true,
+		},
+		{
+			name:    "unknown_day_count",
+			rates:   []config.InterestRate{{AccountType: "savings", Currency: "USD", AnnualRate: "0.04", DayCount: "30/360"}},
+			wantErr: true,
+		},
+		{
+			name:    "invalid_rate",
+			rates:   []config.InterestRate{{AccountType: "savings", Currency: "USD", AnnualRate: "4%", DayCount: "act/365"}},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cf := &config.Config{Interest: config.Interest{Rates: tt.rates}}
+			_, err := NewInterestUsecase(nil, nil, nil, nil, nil, nil, cf)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NewInterestUsecase() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_interestUsecase_AccrueInterest(t *testing.T) {
+	date := time.Date(2025, 9, 14, 0, 0, 0, 0, time.UTC)
+	end := date.AddDate(0, 0, 1)
+	tests := []struct {
+		name     string
+		req      dto.InterestAccrualRequestDTO
+		setup    func(fields interestFields)
+		want     dto.InterestRunDTO
+		wantCode string
+	}{
+		{
+			name: "success",
+			req:  dto.InterestAccrualRequestDTO{Date: "2025-09-14"},
+			setup: func(fields interestFields) {
+				fields.interestRepo.EXPECT().FindRun(gomock.Any(), entity.InterestRunAccrual, date).Return(nil, nil)
+				fields.accountRepo.EXPECT().FindByType(gomock.Any(), entity.AccountSavings, uint64(0), interestBatchSize).
+					Return([]*entity.Account{
+						{ID: 1, Currency: entity.CurrencyUSD, Type: entity.AccountSavings, Status: entity.AccountActive},
+						// No rate is configured for EUR savings
+						{ID: 2, Currency: entity.CurrencyEUR, Type: entity.AccountSavings, Status: entity.AccountActive},
+						{ID: 3, Currency: entity.CurrencyUSD, Type: entity.AccountSavings, Status: entity.AccountClosed},
+						// Overdrawn at the end of the day
+						{ID: 4, Currency: entity.CurrencyUSD, Type: entity.AccountSavings, Status: entity.AccountActive},
+					}, nil)
+				fields.ledgerRepo.EXPECT().SumByAccountBefore(gomock.Any(), uint64(1), end).Return(entity.MustParseMoney("10000"), nil)
+				fields.ledgerRepo.EXPECT().SumByAccountBefore(gomock.Any(), uint64(4), end).Return(entity.MustParseMoney("-10"), nil)
+				fields.interestRepo.EXPECT().CreateAccruals(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, accruals []*entity.InterestAccrual) (int64, error) {
+						if len(accruals) != 1 || accruals[0].AccountID != 1 || accruals[0].Amount != entity.MustParseMoney("1") ||
+							!accruals[0].AccrualDate.Equal(date) {
+							t.Errorf("unexpected accruals: %+v", accruals)
+						}
+						return 1, nil
+					})
+				fields.interestRepo.EXPECT().CreateRun(gomock.Any(), gomock.Any()).Return(nil)
+			},
+			want: dto.InterestRunDTO{Kind: entity.InterestRunAccrual, Date: "2025-09-14", Accounts: 1},
+		},
+		{
+			name: "already_run",
+			req:  dto.InterestAccrualRequestDTO{Date: "2025-09-14"},
+			setup: func(fields interestFields) {
+				fields.interestRepo.EXPECT().FindRun(gomock.Any(), entity.InterestRunAccrual, date).
+					Return(&entity.InterestRun{Kind: entity.InterestRunAccrual, RunDate: date, Accounts: 7}, nil)
+			},
+			want: dto.InterestRunDTO{Kind: entity.InterestRunAccrual, Date: "2025-09-14", Accounts: 7, AlreadyRun: true},
+		},
+		{
+			name:     "day_not_ended",
+			req:      dto.InterestAccrualRequestDTO{Date: time.Now().UTC().Format(dto.DateLayout)},
+			setup:    func(fields interestFields) {},
+			wantCode: apperr.ErrInvalidInput.Code,
+		},
+		{
+			name:     "invalid_date",
+			req:      dto.InterestAccrualRequestDTO{Date: "14/09/2025"},
+			setup:    func(fields interestFields) {},
+			wantCode: apperr.ErrInvalidInput.Code,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			uc, fields := newTestInterestUsecase(t, ctrl)
+			tt.setup(fields)
+
+			got, err := uc.AccrueInterest(context.Background(), tt.req)
+			if tt.wantCode != "" {
+				var appErr apperr.AppError
+				if !errors.As(err, &appErr) || appErr.Code != tt.wantCode {
+					t.Errorf("AccrueInterest() error = %v, want code %s", err, tt.wantCode)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("AccrueInterest() unexpected error = %v", err)
+			}
+			got.CompletedAt = time.Time{}
+			if got != tt.want {
+				t.Errorf("AccrueInterest() got = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_interestUsecase_PostInterest(t *testing.T) {
+	month := time.Date(2025, 8, 1, 0, 0, 0, 0, time.UTC)
+	end := month.AddDate(0, 1, 0)
+	lastDay := end.AddDate(0, 0, -1)
+	tests := []struct {
+		name     string
+		setup    func(fields interestFields)
+		want     dto.InterestRunDTO
+		wantCode string
+	}{
+		{
+			name: "success",
+			setup: func(fields interestFields) {
+				fields.interestRepo.EXPECT().FindRun(gomock.Any(), entity.InterestRunPosting, month).Return(nil, nil)
+				fields.interestRepo.EXPECT().FindRun(gomock.Any(), entity.InterestRunAccrual, lastDay).
+					Return(&entity.InterestRun{Kind: entity.InterestRunAccrual, RunDate: lastDay}, nil)
+				fields.interestRepo.EXPECT().FindAccountsWithUnposted(gomock.Any(), end, uint64(0), interestBatchSize).
+					Return([]uint64{1}, nil)
+				fields.accountRepo.EXPECT().FindForUpdate(gomock.Any(), []uint64{1}).Return([]*entity.Account{
+					{ID: 1, Balance: entity.MustParseMoney("10000"), Currency: entity.CurrencyUSD, Status: entity.AccountActive},
+				}, nil)
+				fields.interestRepo.EXPECT().FindUnpostedForUpdate(gomock.Any(), uint64(1), end).Return([]*entity.InterestAccrual{
+					{ID: 10, Amount: entity.MustParseMoney("1.1644")},
+					{ID: 11, Amount: entity.MustParseMoney("1.1644")},
+				}, nil)
+				// 2.3288 is credited in whole cents, from the interest expense account
+				fields.ledgerRepo.EXPECT().CreateEntry(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, entry *entity.JournalEntry) (*entity.JournalEntry, error) {
+						if entry.Postings[0].SystemAccount != entity.SystemAccountInterestExpense ||
+							entry.Postings[1].Amount != entity.MustParseMoney("2.32") {
+							t.Errorf("unexpected interest entry: %+v", entry.Postings)
+						}
+						entry.ID = 99
+						return entry, nil
+					})
+				fields.accountRepo.EXPECT().Update(gomock.Any(), &entity.Account{
+					ID: 1, Balance: entity.MustParseMoney("10002.32"), Currency: entity.CurrencyUSD, Status: entity.AccountActive,
+				}).Return(nil)
+				fields.interestRepo.EXPECT().MarkPosted(gomock.Any(), []uint64{10, 11}, gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, _ []uint64, entryID *uint64, _ time.Time) error {
+						if entryID == nil || *entryID != 99 {
+							t.Errorf("accruals not linked to the interest entry: %v", entryID)
+						}
+						return nil
+					})
+				// The remaining 0.0088 is carried to the next month's posting
+				fields.interestRepo.EXPECT().CreateAccruals(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, accruals []*entity.InterestAccrual) (int64, error) {
+						if len(accruals) != 1 || !accruals[0].Carried || accruals[0].PostedAt != nil ||
+							!accruals[0].AccrualDate.Equal(end) || accruals[0].Amount != entity.MustParseMoney("0.0088") {
+							t.Errorf("unexpected carried accrual: %+v", accruals)
+						}
+						return 1, nil
+					})
+				fields.interestRepo.EXPECT().CreateRun(gomock.Any(), gomock.Any()).Return(nil)
+			},
+			want: dto.InterestRunDTO{Kind: entity.InterestRunPosting, Date: "2025-08-01", Accounts: 1},
+		},
+		{
+			name: "below_minor_unit_left_unposted",
+			setup: func(fields interestFields) {
+				fields.interestRepo.EXPECT().FindRun(gomock.Any(), entity.InterestRunPosting, month).Return(nil, nil)
+				fields.interestRepo.EXPECT().FindRun(gomock.Any(), entity.InterestRunAccrual, lastDay).
+					Return(&entity.InterestRun{Kind: entity.InterestRunAccrual, RunDate: lastDay}, nil)
+				fields.interestRepo.EXPECT().FindAccountsWithUnposted(gomock.Any(), end, uint64(0), interestBatchSize).
+					Return([]uint64{1}, nil)
+				fields.accountRepo.EXPECT().FindForUpdate(gomock.Any(), []uint64{1}).Return([]*entity.Account{
+					{ID: 1, Balance: entity.MustParseMoney("10"), Currency: entity.CurrencyUSD, Status: entity.AccountActive},
+				}, nil)
+				// The accruals add up with the next month's instead of being closed without a credit
+				fields.interestRepo.EXPECT().FindUnpostedForUpdate(gomock.Any(), uint64(1), end).Return([]*entity.InterestAccrual{
+					{ID: 10, Amount: entity.MustParseMoney("0.0011")},
+					{ID: 11, Amount: entity.MustParseMoney("0.0088"), Carried: true},
+				}, nil)
+				fields.interestRepo.EXPECT().CreateRun(gomock.Any(), gomock.Any()).Return(nil)
+			},
+			want: dto.InterestRunDTO{Kind: entity.InterestRunPosting, Date: "2025-08-01", Accounts: 0},
+		},
+		{
+			name: "closed_account_not_credited",
+			setup: func(fields interestFields) {
+				fields.interestRepo.EXPECT().FindRun(gomock.Any(), entity.InterestRunPosting, month).Return(nil, nil)
+				fields.interestRepo.EXPECT().FindRun(gomock.Any(), entity.InterestRunAccrual, lastDay).
+					Return(&entity.InterestRun{Kind: entity.InterestRunAccrual, RunDate: lastDay}, nil)
+				fields.interestRepo.EXPECT().FindAccountsWithUnposted(gomock.Any(), end, uint64(0), interestBatchSize).
+					Return([]uint64{1}, nil)
+				fields.accountRepo.EXPECT().FindForUpdate(gomock.Any(), []uint64{1}).Return([]*entity.Account{
+					{ID: 1, Currency: entity.CurrencyUSD, Status: entity.AccountClosed},
+				}, nil)
+				fields.interestRepo.EXPECT().CreateRun(gomock.Any(), gomock.Any()).Return(nil)
+			},
+			want: dto.InterestRunDTO{Kind: entity.InterestRunPosting, Date: "2025-08-01", Accounts: 0},
+		},
+		{
+			name: "already_run",
+			setup: func(fields interestFields) {
+				fields.interestRepo.EXPECT().FindRun(gomock.Any(), entity.InterestRunPosting, month).
+					Return(&entity.InterestRun{Kind: entity.InterestRunPosting, RunDate: month, Accounts: 3}, nil)
+			},
+			want: dto.InterestRunDTO{Kind: entity.InterestRunPosting, Date: "2025-08-01", Accounts: 3, AlreadyRun: true},
+		},
+		{
+			name: "last_day_not_accrued",
+			setup: func(fields interestFields) {
+				fields.interestRepo.EXPECT().FindRun(gomock.Any(), entity.InterestRunPosting, month).Return(nil, nil)
+				fields.interestRepo.EXPECT().FindRun(gomock.Any(), entity.InterestRunAccrual, lastDay).Return(nil, nil)
+			},
+			wantCode: apperr.ErrInvalidInput.Code,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			uc, fields := newTestInterestUsecase(t, ctrl)
+			tt.setup(fields)
+			// Accounts have no open holds
+			fields.holdRepo.EXPECT().SumActive(gomock.Any(), gomock.Any(), gomock.Any()).Return(entity.Money{}, nil).AnyTimes()
+
+			got, err := uc.PostInterest(context.Background(), dto.InterestPostingRequestDTO{Month: "2025-08"})
+			if tt.wantCode != "" {
+				var appErr apperr.AppError
+				if !errors.As(err, &appErr) || appErr.Code != tt.wantCode {
+					t.Errorf("PostInterest() error = %v, want code %s", err, tt.wantCode)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("PostInterest() unexpected error = %v", err)
+			}
+			got.CompletedAt = time.Time{}
+			if got != tt.want {
+				t.Errorf("PostInterest() got = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_interestUsecase_CatchUp(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	uc, fields := newTestInterestUsecase(t, ctrl)
+	now := time.Date(2025, 9, 3, 10, 0, 0, 0, time.UTC)
+	sep1 := time.Date(2025, 9, 1, 0, 0, 0, 0, time.UTC)
+	sep2 := time.Date(2025, 9, 2, 0, 0, 0, 0, time.UTC)
+	aug := time.Date(2025, 8, 1, 0, 0, 0, 0, time.UTC)
+	aug31 := time.Date(2025, 8, 31, 0, 0, 0, 0, time.UTC)
+
+	// The last accrual was for Aug 31: Sep 1 and 2 are accrued, no account has a balance
+	fields.interestRepo.EXPECT().FindLastRun(gomock.Any(), entity.InterestRunAccrual).
+		Return(&entity.InterestRun{Kind: entity.InterestRunAccrual, RunDate: aug31}, nil)
+	for _, day := range []time.Time{sep1, sep2} {
+		fields.interestRepo.EXPECT().FindRun(gomock.Any(), entity.InterestRunAccrual, day).Return(nil, nil)
+		fields.interestRepo.EXPECT().CreateRun(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, run *entity.InterestRun) error {
+				if run.Kind != entity.InterestRunAccrual {
+					t.Errorf("unexpected run: %+v", run)
+				}
+				return nil
+			})
+	}
+	fields.accountRepo.EXPECT().FindByType(gomock.Any(), entity.AccountSavings, uint64(0), interestBatchSize).
+		Return(nil, nil).Times(2)
+	fields.interestRepo.EXPECT().CreateAccruals(gomock.Any(), gomock.Any()).Return(int64(0), nil).Times(2)
+
+	// August was never posted and its last day is accrued
+	fields.interestRepo.EXPECT().FindLastRun(gomock.Any(), entity.InterestRunPosting).Return(nil, nil)
+	fields.interestRepo.EXPECT().FindRun(gomock.Any(), entity.InterestRunAccrual, aug31).
+		Return(&entity.InterestRun{Kind: entity.InterestRunAccrual, RunDate: aug31}, nil).Times(2)
+	fields.interestRepo.EXPECT().FindRun(gomock.Any(), entity.InterestRunPosting, aug).Return(nil, nil)
+	fields.interestRepo.EXPECT().FindAccountsWithUnposted(gomock.Any(), sep1, uint64(0), interestBatchSize).Return(nil, nil)
+	fields.interestRepo.EXPECT().CreateRun(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, run *entity.InterestRun) error {
+			if run.Kind != entity.InterestRunPosting || !run.RunDate.Equal(aug) {
+				t.Errorf("unexpected run: %+v", run)
+			}
+			return nil
+		})
+
+	if err := uc.CatchUp(context.Background(), now); err != nil {
+		t.Fatalf("CatchUp() unexpected error = %v", err)
+	}
+}
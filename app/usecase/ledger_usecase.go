@@ -0,0 +1,238 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"transaction_demo/app/apperr"
+	"transaction_demo/app/domain/entity"
+	"transaction_demo/app/domain/repository"
+	"transaction_demo/app/usecase/dto"
+)
+
+// snapshotSettleDelay is how long after midnight a day is snapshotted, so that transfers
+// started before midnight have committed their postings.
+const snapshotSettleDelay = 10 * time.Minute
+
+// LedgerUC defines the interface for reading the double-entry ledger.
+// Provides reconciliation of stored account balances against their postings
+// and account balances at any point in time.
+type LedgerUC interface {
+	// ReconcileAccount compares an account's stored balance with the balance derived from its postings.
+	ReconcileAccount(ctx context.Context, accountID uint64) (dto.ReconciliationDTO, error)
+
+	// GetTransactionPostings returns the postings booked for a transaction.
+	GetTransactionPostings(ctx context.Context, transactionID uint64) ([]dto.PostingDTO, error)
+
+	// GetBalanceAsOf returns the balance an account had at a point in time, derived from its postings.
+	GetBalanceAsOf(ctx context.Context, req dto.BalanceAsOfRequestDTO) (dto.BalanceAsOfDTO, error)
+
+	// SnapshotBalances records the closing balance of an ended day for every account.
+	SnapshotBalances(ctx context.Context, req dto.BalanceSnapshotRequestDTO) (dto.BalanceSnapshotRunDTO, error)
+
+	// CatchUpSnapshots snapshots every ended day since the last snapshotted one.
+	CatchUpSnapshots(ctx context.Context, now time.Time) error
+}
+
+type ledgerUsecase struct {
+	accountRepo  repository.AccountRepository
+	ledgerRepo   repository.LedgerRepository
+	snapshotRepo repository.BalanceSnapshotRepository
+}
+
+func NewLedgerUsecase(
+	accountRepo repository.AccountRepository,
+	ledgerRepo repository.LedgerRepository,
+	snapshotRepo repository.BalanceSnapshotRepository) LedgerUC {
+	return &ledgerUsecase{
+		accountRepo:  accountRepo,
+		ledgerRepo:   ledgerRepo,
+		snapshotRepo: snapshotRepo,
+	}
+}
+
+// ReconcileAccount derives the balance of an account from its postings and reports any
+// difference with accounts.balance. A difference means a balance changed without a
+// matching journal entry.
+func (uc ledgerUsecase) ReconcileAccount(ctx context.Context, accountID uint64) (dto.ReconciliationDTO, error) {
+	account, err := uc.accountRepo.FindOne(ctx, accountID)
+	if err != nil {
+		fmt.Println("failed to find account", "error", err)
+		return dto.ReconciliationDTO{}, apperr.ErrInternalServer.WithError(err).WithMessage("failed to find account")
+	}
+	if account == nil {
+		fmt.Println("account not found", "account_id", accountID)
+		return dto.ReconciliationDTO{}, apperr.ErrNotFound.WithMessage("account not found")
+	}
+
+	ledgerBalance, err := uc.ledgerRepo.SumByAccount(ctx, accountID)
+	if err != nil {
+		fmt.Println("failed to sum postings", "error", err)
+		return dto.ReconciliationDTO{}, apperr.ErrInternalServer.WithError(err).WithMessage("failed to read ledger")
+	}
+
+	difference := account.Balance.Sub(ledgerBalance)
+	if !difference.IsZero() {
+		fmt.Println("account out of balance with ledger", "account_id", accountID,
+			"balance", account.Balance, "ledger_balance", ledgerBalance)
+	}
+
+	return dto.ReconciliationDTO{
+		AccountID:     account.ID,
+		Currency:      account.Currency,
+		Balance:       account.Balance,
+		LedgerBalance: ledgerBalance,
+		Difference:    difference,
+		InBalance:     difference.IsZero(),
+	}, nil
+}
+
+// GetTransactionPostings lists the postings of every journal entry booked for a transaction.
+func (uc ledgerUsecase) GetTransactionPostings(ctx context.Context, transactionID uint64) ([]dto.PostingDTO, error) {
+	postings, err := uc.ledgerRepo.FindPostingsByTransaction(ctx, transactionID)
+	if err != nil {
+		fmt.Println("failed to find postings", "error", err)
+		return nil, apperr.ErrInternalServer.WithError(err).WithMessage("failed to read ledger")
+	}
+	if len(postings) == 0 {
+		fmt.Println("no postings for transaction", "transaction_id", transactionID)
+		return nil, apperr.ErrNotFound.WithMessage("transaction not found")
+	}
+
+	res := make([]dto.PostingDTO, 0, len(postings))
+	for _, p := range postings {
+		res = append(res, dto.PostingDTO{
+			AccountID:     p.AccountID,
+			SystemAccount: p.SystemAccount,
+			Direction:     p.Direction,
+			Amount:        p.Amount,
+			Currency:      p.Currency,
+		})
+	}
+	return res, nil
+}
+
+// GetBalanceAsOf derives the balance of an account at a point in time from the ledger.
+//
+// Balance rules:
+// - The balance sums the postings made before as_of, so as_of 2025-04-01T00:00:00Z is the closing balance of March 31
+// - When a day ended before as_of was snapshotted, only the postings made after that day are summed
+// - as_of defaults to now; it cannot be in the future nor before the account was opened
+func (uc ledgerUsecase) GetBalanceAsOf(ctx context.Context, req dto.BalanceAsOfRequestDTO) (dto.BalanceAsOfDTO, error) {
+	if err := req.Validate(); err != nil {
+		fmt.Println("balance as of validation failed", "error", err)
+		return dto.BalanceAsOfDTO{}, apperr.ErrInvalidInput.WithError(err).WithMessage(err.Error())
+	}
+	now := time.Now()
+	asOf := now
+	if req.AsOf != nil {
+		asOf = *req.AsOf
+	}
+	if asOf.After(now) {
+		fmt.Println("balance as of in the future", "as_of", asOf)
+		return dto.BalanceAsOfDTO{}, apperr.ErrInvalidInput.WithMessage("as_of must not be in the future")
+	}
+
+	account, err := uc.accountRepo.FindOne(ctx, req.AccountID)
+	if err != nil {
+		fmt.Println("failed to find account", "error", err)
+		return dto.BalanceAsOfDTO{}, apperr.ErrInternalServer.WithError(err).WithMessage("failed to find account")
+	}
+	if account == nil {
+		fmt.Println("account not found", "account_id", req.AccountID)
+		return dto.BalanceAsOfDTO{}, apperr.ErrNotFound.WithMessage("account not found")
+	}
+	if asOf.Before(account.CreatedAt) {
+		fmt.Println("balance as of before account opened", "account_id", req.AccountID, "as_of", asOf)
+		return dto.BalanceAsOfDTO{}, apperr.ErrInvalidInput.WithMessage("as_of is before the account was opened")
+	}
+
+	balance, snapshot, err := balanceAt(ctx, uc.ledgerRepo, uc.snapshotRepo, account.ID, asOf)
+	if err != nil {
+		return dto.BalanceAsOfDTO{}, err
+	}
+
+	res := dto.BalanceAsOfDTO{AccountID: account.ID, Currency: account.Currency, AsOf: asOf}
+	if snapshot != nil {
+		res.SnapshotDate = snapshot.SnapshotDate.UTC().Format(dto.DateLayout)
+	}
+	res.Balance = balance
+	return res, nil
+}
+
+// balanceAt derives the balance of an account from the postings made before at, starting
+// from the latest snapshot of a day that ended by then, which is returned when one was used.
+func balanceAt(ctx context.Context, ledgerRepo repository.LedgerRepository,
+	snapshotRepo repository.BalanceSnapshotRepository, accountID uint64, at time.Time,
+) (entity.Money, *entity.BalanceSnapshot, error) {
+	// A snapshot is usable once its day ended at or before at
+	snapshot, err := snapshotRepo.FindLatest(ctx, accountID, entity.StartOfDay(at).AddDate(0, 0, -1))
+	if err != nil {
+		fmt.Println("failed to find balance snapshot", "error", err)
+		return entity.Money{}, nil, apperr.ErrInternalServer.WithError(err).WithMessage("failed to read balance snapshot")
+	}
+
+	var balance entity.Money
+	if snapshot == nil {
+		balance, err = ledgerRepo.SumByAccountBefore(ctx, accountID, at)
+	} else {
+		balance, err = ledgerRepo.SumByAccountBetween(ctx, accountID, snapshot.End(), at)
+		balance = balance.Add(snapshot.Balance)
+	}
+	if err != nil {
+		fmt.Println("failed to sum postings", "error", err)
+		return entity.Money{}, nil, apperr.ErrInternalServer.WithError(err).WithMessage("failed to read ledger")
+	}
+	return balance, snapshot, nil
+}
+
+// SnapshotBalances snapshots the closing balance of an ended day. Snapshotting a day
+// again only adds the accounts missing from it. A day can be snapshotted once
+// snapshotSettleDelay has passed since its end, like CatchUpSnapshots does.
+func (uc ledgerUsecase) SnapshotBalances(ctx context.Context, req dto.BalanceSnapshotRequestDTO,
+) (dto.BalanceSnapshotRunDTO, error) {
+	if err := req.Validate(); err != nil {
+		fmt.Println("balance snapshot validation failed", "error", err)
+		return dto.BalanceSnapshotRunDTO{}, apperr.ErrInvalidInput.WithError(err).WithMessage(err.Error())
+	}
+	date, _ := time.Parse(dto.DateLayout, req.Date)
+	if !date.Before(entity.StartOfDay(time.Now().Add(-snapshotSettleDelay))) {
+		fmt.Println("snapshot date not settled", "date", req.Date)
+		return dto.BalanceSnapshotRunDTO{}, apperr.ErrInvalidInput.WithMessage(
+			"balances can only be snapshotted for a day that has ended and settled")
+	}
+
+	return uc.snapshot(ctx, date)
+}
+
+// CatchUpSnapshots snapshots the days ended since the last snapshotted day, in order, since each
+// day's snapshot builds on the previous one. Without any snapshot yet it starts with yesterday.
+func (uc ledgerUsecase) CatchUpSnapshots(ctx context.Context, now time.Time) error {
+	yesterday := entity.StartOfDay(now.Add(-snapshotSettleDelay)).AddDate(0, 0, -1)
+	last, err := uc.snapshotRepo.FindLastDate(ctx)
+	if err != nil {
+		fmt.Println("failed to find last balance snapshot", "error", err)
+		return apperr.ErrInternalServer.WithError(err).WithMessage("failed to read balance snapshot")
+	}
+	day := yesterday
+	if last != nil {
+		day = entity.StartOfDay(*last).AddDate(0, 0, 1)
+	}
+	for ; !day.After(yesterday); day = day.AddDate(0, 0, 1) {
+		if _, err = uc.snapshot(ctx, day); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (uc ledgerUsecase) snapshot(ctx context.Context, date time.Time) (dto.BalanceSnapshotRunDTO, error) {
+	inserted, err := uc.snapshotRepo.CreateForDay(ctx, date)
+	if err != nil {
+		fmt.Println("failed to snapshot balances", "date", date.Format(dto.DateLayout), "error", err)
+		return dto.BalanceSnapshotRunDTO{}, apperr.ErrInternalServer.WithError(err).WithMessage("failed to snapshot balances")
+	}
+	fmt.Println("balances snapshotted", "date", date.Format(dto.DateLayout), "accounts", inserted)
+	return dto.BalanceSnapshotRunDTO{Date: date.Format(dto.DateLayout), Accounts: inserted}, nil
+}
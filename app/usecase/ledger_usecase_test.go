@@ -0,0 +1,231 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+
+	"transaction_demo/app/apperr"
+	"transaction_demo/app/domain/entity"
+	"transaction_demo/app/domain/repository/mock"
+	"transaction_demo/app/usecase/dto"
+)
+
+func Test_ledgerUsecase_ReconcileAccount(t *testing.T) {
+	type ledgerFields struct {
+		accountRepo *mock.MockAccountRepository
+		ledgerRepo  *mock.MockLedgerRepository
+	}
+	tests := []struct {
+		name    string
+		setup   func(fields ledgerFields)
+		want    dto.ReconciliationDTO
+		wantErr bool
+	}{
+		{
+			name: "in_balance",
+			setup: func(fields ledgerFields) {
+				fields.accountRepo.EXPECT().FindOne(gomock.Any(), uint64(111)).Return(&entity.Account{
+					ID: 111, Balance: entity.MustParseMoney("899.50"), Currency: entity.CurrencyUSD,
+				}, nil)
+				fields.ledgerRepo.EXPECT().SumByAccount(gomock.Any(), uint64(111)).Return(entity.MustParseMoney("899.50"), nil)
+			},
+			want: dto.ReconciliationDTO{
+				AccountID:     111,
+				Currency:      entity.CurrencyUSD,
+				Balance:       entity.MustParseMoney("899.50"),
+				LedgerBalance: entity.MustParseMoney("899.50"),
+				InBalance:     true,
+			},
+		},
+		{
+			name: "out_of_balance",
+			setup: func(fields ledgerFields) {
+				fields.accountRepo.EXPECT().FindOne(gomock.Any(), uint64(111)).Return(&entity.Account{
+					ID: 111, Balance: entity.MustParseMoney("1000.00"), Currency: entity.CurrencyUSD,
+				}, nil)
+				fields.ledgerRepo.EXPECT().SumByAccount(gomock.Any(), uint64(111)).Return(entity.MustParseMoney("899.50"), nil)
+			},
+			want: dto.ReconciliationDTO{
+				AccountID:     111,
+				Currency:      entity.CurrencyUSD,
+				Balance:       entity.MustParseMoney("1000.00"),
+				LedgerBalance: entity.MustParseMoney("899.50"),
+				Difference:    entity.MustParseMoney("100.50"),
+				InBalance:     false,
+			},
+		},
+		{
+			name: "account_not_found",
+			setup: func(fields ledgerFields) {
+				fields.accountRepo.EXPECT().FindOne(gomock.Any(), uint64(111)).Return(nil, nil)
+			},
+			wantErr: true,
+		},
+		{
+			name: "sum_error",
+			setup: func(fields ledgerFields) {
+				fields.accountRepo.EXPECT().FindOne(gomock.Any(), uint64(111)).Return(&entity.Account{ID: 111}, nil)
+				fields.ledgerRepo.EXPECT().SumByAccount(gomock.Any(), uint64(111)).Return(entity.Money{}, errors.New("database error"))
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			testFields := ledgerFields{
+				accountRepo: mock.NewMockAccountRepository(ctrl),
+				ledgerRepo:  mock.NewMockLedgerRepository(ctrl),
+			}
+			uc := ledgerUsecase{
+				accountRepo: testFields.accountRepo,
+				ledgerRepo:  testFields.ledgerRepo,
+			}
+			if tt.setup != nil {
+				tt.setup(testFields)
+			}
+
+			got, err := uc.ReconcileAccount(context.Background(), 111)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ReconcileAccount() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ReconcileAccount() got = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_ledgerUsecase_GetBalanceAsOf(t *testing.T) {
+	type ledgerFields struct {
+		accountRepo  *mock.MockAccountRepository
+		ledgerRepo   *mock.MockLedgerRepository
+		snapshotRepo *mock.MockBalanceSnapshotRepository
+	}
+	opened := time.Date(2025, 1, 10, 9, 0, 0, 0, time.UTC)
+	asOf := time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC)
+	account := &entity.Account{ID: 111, Balance: entity.MustParseMoney("5000"), Currency: entity.CurrencyUSD, CreatedAt: opened}
+	tests := []struct {
+		name     string
+		asOf     *time.Time
+		setup    func(fields ledgerFields)
+		want     dto.BalanceAsOfDTO
+		wantCode string
+	}{
+		{
+			name: "from_snapshot",
+			asOf: &asOf,
+			setup: func(fields ledgerFields) {
+				fields.accountRepo.EXPECT().FindOne(gomock.Any(), uint64(111)).Return(account, nil)
+				// The snapshot of March 31 closed at as_of, so nothing is left to add
+				fields.snapshotRepo.EXPECT().FindLatest(gomock.Any(), uint64(111), time.Date(2025, 3, 31, 0, 0, 0, 0, time.UTC)).
+					Return(&entity.BalanceSnapshot{AccountID: 111, SnapshotDate: time.Date(2025, 3, 30, 0, 0, 0, 0, time.UTC),
+						Balance: entity.MustParseMoney("1200.00")}, nil)
+				fields.ledgerRepo.EXPECT().SumByAccountBetween(gomock.Any(), uint64(111),
+					time.Date(2025, 3, 31, 0, 0, 0, 0, time.UTC), asOf).Return(entity.MustParseMoney("50.00"), nil)
+			},
+			want: dto.BalanceAsOfDTO{AccountID: 111, Balance: entity.MustParseMoney("1250.00"), Currency: entity.CurrencyUSD,
+				AsOf: asOf, SnapshotDate: "2025-03-30"},
+		},
+		{
+			name: "without_snapshot",
+			asOf: &asOf,
+			setup: func(fields ledgerFields) {
+				fields.accountRepo.EXPECT().FindOne(gomock.Any(), uint64(111)).Return(account, nil)
+				fields.snapshotRepo.EXPECT().FindLatest(gomock.Any(), uint64(111), gomock.Any()).Return(nil, nil)
+				fields.ledgerRepo.EXPECT().SumByAccountBefore(gomock.Any(), uint64(111), asOf).
+					Return(entity.MustParseMoney("1250.00"), nil)
+			},
+			want: dto.BalanceAsOfDTO{AccountID: 111, Balance: entity.MustParseMoney("1250.00"), Currency: entity.CurrencyUSD,
+				AsOf: asOf},
+		},
+		{
+			name: "before_account_opened",
+			asOf: &opened,
+			setup: func(fields ledgerFields) {
+				fields.accountRepo.EXPECT().FindOne(gomock.Any(), uint64(111)).Return(&entity.Account{
+					ID: 111, Currency: entity.CurrencyUSD, CreatedAt: opened.Add(time.Second),
+				}, nil)
+			},
+			wantCode: apperr.ErrInvalidInput.Code,
+		},
+		{
+			name:     "in_the_future",
+			asOf:     func() *time.Time { t := time.Now().Add(time.Hour); return &t }(),
+			setup:    func(fields ledgerFields) {},
+			wantCode: apperr.ErrInvalidInput.Code,
+		},
+		{
+			name: "account_not_found",
+			asOf: &asOf,
+			setup: func(fields ledgerFields) {
+				fields.accountRepo.EXPECT().FindOne(gomock.Any(), uint64(111)).Return(nil, nil)
+			},
+			wantCode: apperr.ErrNotFound.Code,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			testFields := ledgerFields{
+				accountRepo:  mock.NewMockAccountRepository(ctrl),
+				ledgerRepo:   mock.NewMockLedgerRepository(ctrl),
+				snapshotRepo: mock.NewMockBalanceSnapshotRepository(ctrl),
+			}
+			uc := ledgerUsecase{
+				accountRepo:  testFields.accountRepo,
+				ledgerRepo:   testFields.ledgerRepo,
+				snapshotRepo: testFields.snapshotRepo,
+			}
+			tt.setup(testFields)
+
+			got, err := uc.GetBalanceAsOf(context.Background(), dto.BalanceAsOfRequestDTO{AccountID: 111, AsOf: tt.asOf})
+			if tt.wantCode != "" {
+				var appErr apperr.AppError
+				if !errors.As(err, &appErr) || appErr.Code != tt.wantCode {
+					t.Errorf("GetBalanceAsOf() error = %v, want code %s", err, tt.wantCode)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("GetBalanceAsOf() unexpected error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GetBalanceAsOf() got = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_ledgerUsecase_CatchUpSnapshots(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	snapshotRepo := mock.NewMockBalanceSnapshotRepository(ctrl)
+	uc := ledgerUsecase{snapshotRepo: snapshotRepo}
+
+	// Shortly after midnight the day that just ended is left for the next poll
+	now := time.Date(2025, 4, 3, 0, 5, 0, 0, time.UTC)
+	last := time.Date(2025, 3, 30, 0, 0, 0, 0, time.UTC)
+	snapshotRepo.EXPECT().FindLastDate(gomock.Any()).Return(&last, nil)
+	gomock.InOrder(
+		snapshotRepo.EXPECT().CreateForDay(gomock.Any(), time.Date(2025, 3, 31, 0, 0, 0, 0, time.UTC)).Return(int64(2), nil),
+		snapshotRepo.EXPECT().CreateForDay(gomock.Any(), time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC)).Return(int64(2), nil),
+	)
+
+	if err := uc.CatchUpSnapshots(context.Background(), now); err != nil {
+		t.Fatalf("CatchUpSnapshots() unexpected error = %v", err)
+	}
+}
@@ -0,0 +1,104 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"transaction_demo/app/apperr"
+	"transaction_demo/app/config"
+	"transaction_demo/app/domain/entity"
+	"transaction_demo/app/domain/repository"
+)
+
+// LimitEvaluator checks transfers against the configured limits and velocity rules.
+type LimitEvaluator interface {
+	// Evaluate checks a transfer about to be made from its source account.
+	// It must run inside the transfer's DB transaction, after the source account is locked.
+	Evaluate(ctx context.Context, transaction *entity.Transaction, now time.Time) error
+}
+
+type limitEvaluator struct {
+	transactionRepo repository.TransactionRepository
+	rules           []entity.LimitRule
+}
+
+// NewLimitEvaluator builds the evaluator from the limits.rules configuration.
+// An invalid rule fails startup rather than being silently ignored.
+func NewLimitEvaluator(transactionRepo repository.TransactionRepository, cf *config.Config) (LimitEvaluator, error) {
+	rules := make([]entity.LimitRule, 0, len(cf.Limits.Rules))
+	for _, r := range cf.Limits.Rules {
+		rule := entity.LimitRule{
+			Name:     r.Name,
+			Type:     entity.LimitRuleType(r.Type),
+			Window:   entity.LimitWindow(r.Window),
+			Currency: entity.Currency(r.Currency),
+			Count:    r.Count,
+		}
+		if r.Amount != "" {
+			amount, err := entity.ParseMoney(r.Amount)
+			if err != nil {
+				return nil, fmt.Errorf("limit rule %q: %w", r.Name, err)
+			}
+			rule.Amount = amount
+		}
+		if err := rule.Validate(); err != nil {
+			return nil, fmt.Errorf("limit rule %q: %w", r.Name, err)
+		}
+		rules = append(rules, rule)
+	}
+
+	return &limitEvaluator{transactionRepo: transactionRepo, rules: rules}, nil
+}
+
+// Evaluate checks the transfer against every rule of the source account currency.
+//
+// Windowed rules read the account's past transfers from the transactions table, with the
+// holds still open, which become transfers when captured.
+// The source account row is locked by the caller, so concurrent transfers from the
+// same account are evaluated one after another and each sees the ones committed before it.
+// Usage is read once per window, however many rules share it.
+func (e limitEvaluator) Evaluate(ctx context.Context, transaction *entity.Transaction, now time.Time) error {
+	usages := make(map[entity.LimitWindow]entity.TransferUsage)
+	for _, rule := range e.rules {
+		if !rule.AppliesTo(transaction.Currency) {
+			continue
+		}
+
+		if rule.Type == entity.LimitMaxAmount {
+			if transaction.Amount.GreaterThan(rule.Amount) {
+				fmt.Println("transfer limit exceeded", "rule", rule.Name, "amount", transaction.Amount, "limit", rule.Amount)
+				return apperr.ErrLimitExceeded.WithMessage(fmt.Sprintf("%s: transfer amount exceeds the limit of %s %s",
+					rule.Name, rule.Amount, rule.Currency))
+			}
+			continue
+		}
+
+		usage, ok := usages[rule.Window]
+		if !ok {
+			var err error
+			usage, err = e.transactionRepo.SumOutgoingSince(ctx, transaction.SourceAccountID, rule.Window.Start(now), now)
+			if err != nil {
+				fmt.Println("failed to sum outgoing transfers", "error", err)
+				return apperr.ErrInternalServer.WithError(err).WithMessage("failed to evaluate transfer limits")
+			}
+			usages[rule.Window] = usage
+		}
+
+		switch rule.Type {
+		case entity.LimitMaxTotal:
+			if usage.Total.Add(transaction.Amount).GreaterThan(rule.Amount) {
+				fmt.Println("transfer limit exceeded", "rule", rule.Name, "used", usage.Total, "limit", rule.Amount)
+				return apperr.ErrLimitExceeded.WithMessage(fmt.Sprintf("%s: total per %s would exceed %s %s, %s already sent",
+					rule.Name, rule.Window, rule.Amount, rule.Currency, usage.Total))
+			}
+		case entity.LimitMaxCount:
+			if usage.Count >= rule.Count {
+				fmt.Println("transfer limit exceeded", "rule", rule.Name, "count", usage.Count, "limit", rule.Count)
+				return apperr.ErrLimitExceeded.WithMessage(fmt.Sprintf("%s: at most %d transfers per %s",
+					rule.Name, rule.Count, rule.Window))
+			}
+		}
+	}
+	return nil
+}
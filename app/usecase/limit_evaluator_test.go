@@ -0,0 +1,182 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+
+	"transaction_demo/app/apperr"
+	"transaction_demo/app/config"
+	"transaction_demo/app/domain/entity"
+	"transaction_demo/app/domain/repository/mock"
+)
+
+func Test_NewLimitEvaluator(t *testing.T) {
+	tests := []struct {
+		name    string
+		rules   []config.LimitRule
+		wantErr bool
+	}{
+		{
+			name: "valid_rules",
+			rules: []config.LimitRule{
+				{Name: "per_transfer", Type: "max_amount", Currency: "USD", Amount: "1000.00"},
+				{Name: "daily", Type: "max_total", Window: "day", Currency: "USD", Amount: "5000"},
+				{Name: "hourly", Type: "max_count", Window: "hour", Count: 10},
+			},
+		},
+		{
+			name:  "no_rules",
+			rules: nil,
+		},
+		{
+			name:    "unknown_type",
+			rules:   []config.LimitRule{{Name: "x", Type: "max_speed", Currency: "USD", Amount: "1"}},
+			wantErr: true,
+		},
+		{
+			name:    "amount_rule_without_currency",
+			rules:   []config.LimitRule{{Name: "x", Type: "max_amount", Amount: "1000.00"}},
+			wantErr: true,
+		},
+		{
+			name:    "invalid_amount",
+			rules:   []config.LimitRule{{Name: "x", Type: "max_amount", Currency: "USD", Amount: "lots"}},
+			wantErr: true,
+		},
+		{
+			name:    "unknown_window",
+			rules:   []config.LimitRule{{Name: "x", Type: "max_count", Window: "week", Count: 5}},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cf := &config.Config{Limits: config.Limits{Rules: tt.rules}}
+			_, err := NewLimitEvaluator(nil, cf)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NewLimitEvaluator() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_limitEvaluator_Evaluate(t *testing.T) {
+	now := time.Date(2025, 9, 15, 14, 30, 0, 0, time.UTC)
+	dayStart := time.Date(2025, 9, 15, 0, 0, 0, 0, time.UTC)
+	monthStart := time.Date(2025, 9, 1, 0, 0, 0, 0, time.UTC)
+	hourStart := time.Date(2025, 9, 15, 14, 0, 0, 0, time.UTC)
+
+	rules := []entity.LimitRule{
+		{Name: "per_transfer", Type: entity.LimitMaxAmount, Currency: entity.CurrencyUSD, Amount: entity.MustParseMoney("1000.00")},
+		{Name: "daily", Type: entity.LimitMaxTotal, Window: entity.LimitWindowDay, Currency: entity.CurrencyUSD,
+			Amount: entity.MustParseMoney("3000.00")},
+		{Name: "monthly", Type: entity.LimitMaxTotal, Window: entity.LimitWindowMonth, Currency: entity.CurrencyUSD,
+			Amount: entity.MustParseMoney("10000.00")},
+		{Name: "hourly", Type: entity.LimitMaxCount, Window: entity.LimitWindowHour, Count: 5},
+	}
+	transfer := func(amount string, currency entity.Currency) *entity.Transaction {
+		return &entity.Transaction{SourceAccountID: 111, Amount: entity.MustParseMoney(amount), Currency: currency}
+	}
+	usage := func(total string, count int64) entity.TransferUsage {
+		return entity.TransferUsage{Total: entity.MustParseMoney(total), Count: count}
+	}
+
+	tests := []struct {
+		name        string
+		transaction *entity.Transaction
+		setup       func(repo *mock.MockTransactionRepository)
+		wantErr     bool
+		wantCode    string
+	}{
+		{
+			name:        "within_all_limits",
+			transaction: transfer("500.00", entity.CurrencyUSD),
+			setup: func(repo *mock.MockTransactionRepository) {
+				// One query per window
+				repo.EXPECT().SumOutgoingSince(gomock.Any(), uint64(111), dayStart, now).Return(usage("2500.00", 3), nil)
+				repo.EXPECT().SumOutgoingSince(gomock.Any(), uint64(111), monthStart, now).Return(usage("9500.00", 20), nil)
+				repo.EXPECT().SumOutgoingSince(gomock.Any(), uint64(111), hourStart, now).Return(usage("500.00", 4), nil)
+			},
+		},
+		{
+			name:        "exceeds_per_transfer",
+			transaction: transfer("1000.01", entity.CurrencyUSD),
+			setup:       func(repo *mock.MockTransactionRepository) {},
+			wantErr:     true,
+			wantCode:    apperr.ErrLimitExceeded.Code,
+		},
+		{
+			name:        "exceeds_daily_total",
+			transaction: transfer("500.01", entity.CurrencyUSD),
+			setup: func(repo *mock.MockTransactionRepository) {
+				repo.EXPECT().SumOutgoingSince(gomock.Any(), uint64(111), dayStart, now).Return(usage("2500.00", 3), nil)
+			},
+			wantErr:  true,
+			wantCode: apperr.ErrLimitExceeded.Code,
+		},
+		{
+			name:        "exceeds_monthly_total",
+			transaction: transfer("600.00", entity.CurrencyUSD),
+			setup: func(repo *mock.MockTransactionRepository) {
+				repo.EXPECT().SumOutgoingSince(gomock.Any(), uint64(111), dayStart, now).Return(usage("0.00", 0), nil)
+				repo.EXPECT().SumOutgoingSince(gomock.Any(), uint64(111), monthStart, now).Return(usage("9500.00", 20), nil)
+			},
+			wantErr:  true,
+			wantCode: apperr.ErrLimitExceeded.Code,
+		},
+		{
+			name:        "exceeds_hourly_count",
+			transaction: transfer("10.00", entity.CurrencyUSD),
+			setup: func(repo *mock.MockTransactionRepository) {
+				repo.EXPECT().SumOutgoingSince(gomock.Any(), uint64(111), dayStart, now).Return(usage("50.00", 5), nil)
+				repo.EXPECT().SumOutgoingSince(gomock.Any(), uint64(111), monthStart, now).Return(usage("50.00", 5), nil)
+				repo.EXPECT().SumOutgoingSince(gomock.Any(), uint64(111), hourStart, now).Return(usage("50.00", 5), nil)
+			},
+			wantErr:  true,
+			wantCode: apperr.ErrLimitExceeded.Code,
+		},
+		{
+			name:        "other_currency_only_counted",
+			transaction: transfer("50000", entity.Currency("JPY")),
+			setup: func(repo *mock.MockTransactionRepository) {
+				repo.EXPECT().SumOutgoingSince(gomock.Any(), uint64(111), hourStart, now).Return(usage("0", 0), nil)
+			},
+		},
+		{
+			name:        "usage_error",
+			transaction: transfer("10.00", entity.CurrencyUSD),
+			setup: func(repo *mock.MockTransactionRepository) {
+				repo.EXPECT().SumOutgoingSince(gomock.Any(), uint64(111), dayStart, now).
+					Return(entity.TransferUsage{}, errors.New("database error"))
+			},
+			wantErr:  true,
+			wantCode: apperr.ErrInternalServer.Code,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := mock.NewMockTransactionRepository(ctrl)
+			tt.setup(repo)
+			e := limitEvaluator{transactionRepo: repo, rules: rules}
+
+			err := e.Evaluate(context.Background(), tt.transaction, now)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Evaluate() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			var appErr apperr.AppError
+			if tt.wantCode != "" && (!errors.As(err, &appErr) || appErr.Code != tt.wantCode) {
+				t.Errorf("Evaluate() error = %+v, want code %s", err, tt.wantCode)
+			}
+		})
+	}
+}
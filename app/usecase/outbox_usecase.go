@@ -0,0 +1,175 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/avito-tech/go-transaction-manager/trm/v2"
+
+	"transaction_demo/app/apperr"
+	"transaction_demo/app/domain/entity"
+	"transaction_demo/app/domain/repository"
+)
+
+// publishLease is how long a relay may take to publish the batch it claimed before other relays
+// claim its events again. It is far longer than a batch takes, even with a broker timing out.
+const publishLease = time.Minute
+
+// EventPublisher delivers outbox events to a log, a file or a message broker.
+// Publish returns once the event was accepted; after an error the same event is published
+// again, so an event may be delivered more than once.
+type EventPublisher interface {
+	Publish(ctx context.Context, event *entity.OutboxEvent) error
+	Close() error
+}
+
+// OutboxUC defines the interface of the outbox relay, which publishes the recorded domain events.
+type OutboxUC interface {
+	// PublishPending publishes up to limit unpublished events in ID order and returns how many were published.
+	PublishPending(ctx context.Context, limit int) (int, error)
+
+	// QueueWebhooks queues up to limit events for the webhook endpoints subscribed to them and returns
+	// how many were queued.
+	QueueWebhooks(ctx context.Context, limit int) (int, error)
+}
+
+type outboxUsecase struct {
+	outboxRepo  repository.OutboxRepository
+	webhookRepo repository.WebhookRepository
+	publisher   EventPublisher
+	txManager   trm.Manager
+}
+
+func NewOutboxUsecase(
+	outboxRepo repository.OutboxRepository,
+	webhookRepo repository.WebhookRepository,
+	publisher EventPublisher,
+	txManager trm.Manager) OutboxUC {
+	return &outboxUsecase{
+		outboxRepo:  outboxRepo,
+		webhookRepo: webhookRepo,
+		publisher:   publisher,
+		txManager:   txManager,
+	}
+}
+
+// PublishPending publishes the oldest unpublished events.
+//
+// The batch is claimed in a short DB transaction, which leases its events for publishLease, and
+// published after that transaction committed, so that no lock is held while talking to the broker:
+// - Other relays skip leased events, so they never publish the same event concurrently
+// - A batch is published in ID order, and the first failure stops it so that later events do not
+// overtake the failed one; the failed and the remaining events are released for the next poll
+// - A published event is marked with its publish time and never published again
+// - A relay stopped while publishing leaves its batch leased until the lease expires; meanwhile
+// other relays publish later events, so the order across batches is not guaranteed
+func (uc outboxUsecase) PublishPending(ctx context.Context, limit int) (int, error) {
+	events, err := uc.claimPending(ctx, limit)
+	if err != nil {
+		return 0, err
+	}
+
+	for i, event := range events {
+		if publishErr := uc.publisher.Publish(ctx, event); publishErr != nil {
+			fmt.Println("failed to publish event", "event_id", event.ID, "type", event.Type, "error", publishErr)
+			uc.releaseFailed(ctx, events[i:], publishErr)
+			return i, apperr.ErrInternalServer.WithError(publishErr).WithMessage("failed to publish event")
+		}
+		// An event published but not marked is published again once its lease expires
+		if err = uc.outboxRepo.MarkPublished(ctx, event.ID, time.Now()); err != nil {
+			fmt.Println("failed to mark outbox event published", "event_id", event.ID, "error", err)
+			return i, apperr.ErrInternalServer.WithError(err).WithMessage("failed to update outbox event")
+		}
+	}
+	return len(events), nil
+}
+
+// claimPending locks the oldest unpublished events that are not leased, and leases them.
+func (uc outboxUsecase) claimPending(ctx context.Context, limit int) ([]*entity.OutboxEvent, error) {
+	var events []*entity.OutboxEvent
+	err := uc.txManager.Do(ctx, func(ctx context.Context) error {
+		now := time.Now()
+		var err error
+		events, err = uc.outboxRepo.FindUnpublishedForUpdate(ctx, limit, now)
+		if err != nil {
+			fmt.Println("failed to find outbox events", "error", err)
+			return apperr.ErrInternalServer.WithError(err).WithMessage("failed to find outbox events")
+		}
+		if len(events) == 0 {
+			return nil
+		}
+
+		if err = uc.outboxRepo.LeaseForPublish(ctx, outboxEventIDs(events), now.Add(publishLease)); err != nil {
+			fmt.Println("failed to lease outbox events", "error", err)
+			return apperr.ErrInternalServer.WithError(err).WithMessage("failed to update outbox events")
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return events, nil
+}
+
+// releaseFailed records the failed publish of the first event and releases it with the events after it.
+// Errors are only logged: the leases expire anyway, which delays the events by publishLease at most.
+func (uc outboxUsecase) releaseFailed(ctx context.Context, events []*entity.OutboxEvent, publishErr error) {
+	if err := uc.outboxRepo.RecordPublishFailure(ctx, events[0].ID, publishErr.Error()); err != nil {
+		fmt.Println("failed to record publish failure", "event_id", events[0].ID, "error", err)
+	}
+	if err := uc.outboxRepo.ReleaseLeases(ctx, outboxEventIDs(events)); err != nil {
+		fmt.Println("failed to release outbox events", "error", err)
+	}
+}
+
+// QueueWebhooks queues the oldest events not yet queued for the webhook endpoints subscribed to them.
+//
+// Queuing is separate from publishing, so that webhooks keep receiving events while the broker is down.
+// The batch is queued and marked in one DB transaction, so each event is queued exactly once: a delivery
+// moved to the dead letters is never queued again.
+func (uc outboxUsecase) QueueWebhooks(ctx context.Context, limit int) (int, error) {
+	var queued int
+	err := uc.txManager.Do(ctx, func(ctx context.Context) error {
+		events, err := uc.outboxRepo.FindUnqueuedForUpdate(ctx, limit)
+		if err != nil {
+			fmt.Println("failed to find outbox events", "error", err)
+			return apperr.ErrInternalServer.WithError(err).WithMessage("failed to find outbox events")
+		}
+		if len(events) == 0 {
+			return nil
+		}
+
+		now := time.Now()
+		for _, event := range events {
+			payload, err := event.Envelope()
+			if err != nil {
+				fmt.Println("failed to encode event", "event_id", event.ID, "error", err)
+				return apperr.ErrInternalServer.WithError(err).WithMessage("failed to encode event")
+			}
+			if _, err = uc.webhookRepo.CreateDeliveries(ctx, event, payload, now); err != nil {
+				fmt.Println("failed to queue webhook deliveries", "event_id", event.ID, "error", err)
+				return apperr.ErrInternalServer.WithError(err).WithMessage("failed to queue webhook deliveries")
+			}
+		}
+
+		if err = uc.outboxRepo.MarkWebhooksQueued(ctx, outboxEventIDs(events), now); err != nil {
+			fmt.Println("failed to mark outbox events queued", "error", err)
+			return apperr.ErrInternalServer.WithError(err).WithMessage("failed to update outbox events")
+		}
+		queued = len(events)
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return queued, nil
+}
+
+func outboxEventIDs(events []*entity.OutboxEvent) []uint64 {
+	ids := make([]uint64, 0, len(events))
+	for _, event := range events {
+		ids = append(ids, event.ID)
+	}
+	return ids
+}
@@ -0,0 +1,233 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+
+	"transaction_demo/app/domain/entity"
+	"transaction_demo/app/domain/repository/mock"
+	mock2 "transaction_demo/cmd/shared/db/mock"
+)
+
+// stubEventPublisher publishes events with a function and records the IDs of the published ones.
+type stubEventPublisher struct {
+	publish   func(event *entity.OutboxEvent) error
+	published []uint64
+}
+
+func (s *stubEventPublisher) Publish(_ context.Context, event *entity.OutboxEvent) error {
+	if err := s.publish(event); err != nil {
+		return err
+	}
+	s.published = append(s.published, event.ID)
+	return nil
+}
+
+func (s *stubEventPublisher) Close() error {
+	return nil
+}
+
+func Test_outboxUsecase_PublishPending(t *testing.T) {
+	pending := func() []*entity.OutboxEvent {
+		return []*entity.OutboxEvent{
+			{ID: 1, Type: entity.EventAccountCreated},
+			{ID: 2, Type: entity.EventTransferPosted},
+			{ID: 3, Type: entity.EventTransferPosted, Attempts: 1},
+		}
+	}
+	tests := []struct {
+		name          string
+		publish       func(event *entity.OutboxEvent) error
+		setup         func(outboxRepo *mock.MockOutboxRepository)
+		wantPublished []uint64
+		wantCount     int
+		wantErr       bool
+	}{
+		{
+			name:    "success",
+			publish: func(event *entity.OutboxEvent) error { return nil },
+			setup: func(outboxRepo *mock.MockOutboxRepository) {
+				gomock.InOrder(
+					outboxRepo.EXPECT().FindUnpublishedForUpdate(gomock.Any(), 100, gomock.Any()).Return(pending(), nil),
+					outboxRepo.EXPECT().LeaseForPublish(gomock.Any(), []uint64{1, 2, 3}, gomock.Any()).Return(nil),
+					outboxRepo.EXPECT().MarkPublished(gomock.Any(), uint64(1), gomock.Any()).Return(nil),
+					outboxRepo.EXPECT().MarkPublished(gomock.Any(), uint64(2), gomock.Any()).Return(nil),
+					outboxRepo.EXPECT().MarkPublished(gomock.Any(), uint64(3), gomock.Any()).Return(nil),
+				)
+			},
+			wantPublished: []uint64{1, 2, 3},
+			wantCount:     3,
+		},
+		{
+			name:    "nothing_pending",
+			publish: func(event *entity.OutboxEvent) error { return nil },
+			setup: func(outboxRepo *mock.MockOutboxRepository) {
+				outboxRepo.EXPECT().FindUnpublishedForUpdate(gomock.Any(), 100, gomock.Any()).Return(nil, nil)
+			},
+		},
+		{
+			// The failed event stops the batch so that the third one does not overtake it;
+			// both are released for the next poll
+			name: "publish_error_stops_batch",
+			publish: func(event *entity.OutboxEvent) error {
+				if event.ID == 2 {
+					return errors.New("broker unavailable")
+				}
+				return nil
+			},
+			setup: func(outboxRepo *mock.MockOutboxRepository) {
+				gomock.InOrder(
+					outboxRepo.EXPECT().FindUnpublishedForUpdate(gomock.Any(), 100, gomock.Any()).Return(pending(), nil),
+					outboxRepo.EXPECT().LeaseForPublish(gomock.Any(), []uint64{1, 2, 3}, gomock.Any()).Return(nil),
+					outboxRepo.EXPECT().MarkPublished(gomock.Any(), uint64(1), gomock.Any()).Return(nil),
+					outboxRepo.EXPECT().RecordPublishFailure(gomock.Any(), uint64(2), "broker unavailable").Return(nil),
+					outboxRepo.EXPECT().ReleaseLeases(gomock.Any(), []uint64{2, 3}).Return(nil),
+				)
+			},
+			wantPublished: []uint64{1},
+			wantCount:     1,
+			wantErr:       true,
+		},
+		{
+			name:    "find_error",
+			publish: func(event *entity.OutboxEvent) error { return nil },
+			setup: func(outboxRepo *mock.MockOutboxRepository) {
+				outboxRepo.EXPECT().FindUnpublishedForUpdate(gomock.Any(), 100, gomock.Any()).
+					Return(nil, errors.New("database error"))
+			},
+			wantErr: true,
+		},
+		{
+			// Nothing is published without a lease
+			name:    "lease_error",
+			publish: func(event *entity.OutboxEvent) error { return nil },
+			setup: func(outboxRepo *mock.MockOutboxRepository) {
+				outboxRepo.EXPECT().FindUnpublishedForUpdate(gomock.Any(), 100, gomock.Any()).Return(pending(), nil)
+				outboxRepo.EXPECT().LeaseForPublish(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(errors.New("database error"))
+			},
+			wantErr: true,
+		},
+		{
+			name:    "mark_error",
+			publish: func(event *entity.OutboxEvent) error { return nil },
+			setup: func(outboxRepo *mock.MockOutboxRepository) {
+				outboxRepo.EXPECT().FindUnpublishedForUpdate(gomock.Any(), 100, gomock.Any()).Return(pending(), nil)
+				outboxRepo.EXPECT().LeaseForPublish(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+				outboxRepo.EXPECT().MarkPublished(gomock.Any(), uint64(1), gomock.Any()).
+					Return(errors.New("database error"))
+			},
+			wantPublished: []uint64{1},
+			wantErr:       true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			outboxRepo := mock.NewMockOutboxRepository(ctrl)
+			publisher := &stubEventPublisher{publish: tt.publish}
+			uc := NewOutboxUsecase(outboxRepo, mock.NewMockWebhookRepository(ctrl), publisher, &mock2.MockTxManager{})
+			tt.setup(outboxRepo)
+
+			got, err := uc.PublishPending(context.Background(), 100)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("PublishPending() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.wantCount {
+				t.Errorf("PublishPending() got = %d, want %d", got, tt.wantCount)
+			}
+			if len(publisher.published) != len(tt.wantPublished) {
+				t.Fatalf("published %v, want %v", publisher.published, tt.wantPublished)
+			}
+			for i, id := range tt.wantPublished {
+				if publisher.published[i] != id {
+					t.Errorf("published %v, want %v", publisher.published, tt.wantPublished)
+				}
+			}
+		})
+	}
+}
+
+func Test_outboxUsecase_QueueWebhooks(t *testing.T) {
+	unqueued := func() []*entity.OutboxEvent {
+		return []*entity.OutboxEvent{
+			{ID: 1, Type: entity.EventAccountCreated},
+			{ID: 2, Type: entity.EventTransferPosted},
+		}
+	}
+	tests := []struct {
+		name      string
+		setup     func(outboxRepo *mock.MockOutboxRepository, webhookRepo *mock.MockWebhookRepository)
+		wantCount int
+		wantErr   bool
+	}{
+		{
+			name: "success",
+			setup: func(outboxRepo *mock.MockOutboxRepository, webhookRepo *mock.MockWebhookRepository) {
+				outboxRepo.EXPECT().FindUnqueuedForUpdate(gomock.Any(), 100).Return(unqueued(), nil)
+				webhookRepo.EXPECT().CreateDeliveries(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(int64(1), nil).Times(2)
+				outboxRepo.EXPECT().MarkWebhooksQueued(gomock.Any(), []uint64{1, 2}, gomock.Any()).Return(nil)
+			},
+			wantCount: 2,
+		},
+		{
+			name: "nothing_to_queue",
+			setup: func(outboxRepo *mock.MockOutboxRepository, webhookRepo *mock.MockWebhookRepository) {
+				outboxRepo.EXPECT().FindUnqueuedForUpdate(gomock.Any(), 100).Return(nil, nil)
+			},
+		},
+		{
+			// Nothing is marked queued, so the whole batch is queued again by the next poll
+			name: "create_deliveries_error",
+			setup: func(outboxRepo *mock.MockOutboxRepository, webhookRepo *mock.MockWebhookRepository) {
+				outboxRepo.EXPECT().FindUnqueuedForUpdate(gomock.Any(), 100).Return(unqueued(), nil)
+				webhookRepo.EXPECT().CreateDeliveries(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(int64(0), errors.New("database error"))
+			},
+			wantErr: true,
+		},
+		{
+			name: "mark_error",
+			setup: func(outboxRepo *mock.MockOutboxRepository, webhookRepo *mock.MockWebhookRepository) {
+				outboxRepo.EXPECT().FindUnqueuedForUpdate(gomock.Any(), 100).Return(unqueued(), nil)
+				webhookRepo.EXPECT().CreateDeliveries(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(int64(1), nil).Times(2)
+				outboxRepo.EXPECT().MarkWebhooksQueued(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(errors.New("database error"))
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			outboxRepo := mock.NewMockOutboxRepository(ctrl)
+			webhookRepo := mock.NewMockWebhookRepository(ctrl)
+			// Queuing for webhooks never publishes
+			publisher := &stubEventPublisher{publish: func(event *entity.OutboxEvent) error {
+				t.Errorf("event %d published", event.ID)
+				return nil
+			}}
+			uc := NewOutboxUsecase(outboxRepo, webhookRepo, publisher, &mock2.MockTxManager{})
+			tt.setup(outboxRepo, webhookRepo)
+
+			got, err := uc.QueueWebhooks(context.Background(), 100)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("QueueWebhooks() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.wantCount {
+				t.Errorf("QueueWebhooks() got = %d, want %d", got, tt.wantCount)
+			}
+		})
+	}
+}
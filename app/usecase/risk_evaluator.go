@@ -0,0 +1,218 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"transaction_demo/app/config"
+	"transaction_demo/app/domain/entity"
+	"transaction_demo/app/domain/repository"
+)
+
+// RiskInput is what risk rules see of a transfer about to be made.
+// The source and destination accounts are locked by the caller.
+type RiskInput struct {
+	Transaction *entity.Transaction
+	Source      *entity.Account
+	Destination *entity.Account
+	Now         time.Time
+}
+
+// RiskEvaluator decides whether a transfer is posted, held for review or refused.
+type RiskEvaluator interface {
+	// Evaluate assesses a transfer before any money moves.
+	Evaluate(ctx context.Context, in RiskInput) (entity.RiskAssessment, error)
+}
+
+// RiskRule is one check of the risk chain. A rule that does not match returns an allow decision.
+type RiskRule interface {
+	Name() string
+	Evaluate(ctx context.Context, in RiskInput) (entity.RiskAssessment, error)
+}
+
+// riskChain runs its rules in order and keeps the most severe decision:
+// the first deny ends the evaluation, otherwise the first review wins over allow.
+type riskChain struct {
+	rules []RiskRule
+}
+
+// NewRiskEvaluator builds the chain of built-in rules enabled in the risk configuration.
+func NewRiskEvaluator(transactionRepo repository.TransactionRepository, cf *config.Config) (RiskEvaluator, error) {
+	var rules []RiskRule
+
+	if c := cf.Risk.NewAccount; c.MaxAgeHours > 0 {
+		action, err := riskAction(c.Action)
+		if err != nil {
+			return nil, fmt.Errorf("risk rule new_account: %w", err)
+		}
+		thresholds := make(map[entity.Currency]entity.Money, len(c.Amounts))
+		for code, amount := range c.Amounts {
+			// The configuration loader lower-cases map keys
+			currency := entity.Currency(strings.ToUpper(code))
+			threshold, err := entity.ParseMoney(amount)
+			if err != nil || !currency.IsValid() {
+				return nil, fmt.Errorf("risk rule new_account: invalid amount %s %q", code, amount)
+			}
+			thresholds[currency] = threshold
+		}
+		rules = append(rules, newAccountRule{
+			maxAge:     time.Duration(c.MaxAgeHours) * time.Hour,
+			thresholds: thresholds,
+			action:     action,
+		})
+	}
+
+	if c := cf.Risk.RoundTrip; c.WindowMinutes > 0 {
+		action, err := riskAction(c.Action)
+		if err != nil {
+			return nil, fmt.Errorf("risk rule round_trip: %w", err)
+		}
+		rules = append(rules, roundTripRule{
+			transactionRepo: transactionRepo,
+			window:          time.Duration(c.WindowMinutes) * time.Minute,
+			action:          action,
+		})
+	}
+
+	if c := cf.Risk.UnusualAmount; c.HistoryDays > 0 && c.Multiplier > 0 {
+		action, err := riskAction(c.Action)
+		if err != nil {
+			return nil, fmt.Errorf("risk rule unusual_amount: %w", err)
+		}
+		rules = append(rules, unusualAmountRule{
+			transactionRepo: transactionRepo,
+			history:         time.Duration(c.HistoryDays) * 24 * time.Hour,
+			minTransfers:    c.MinTransfers,
+			multiplier:      c.Multiplier,
+			action:          action,
+		})
+	}
+
+	return &riskChain{rules: rules}, nil
+}
+
+// riskAction parses the configured decision of a matching rule, review by default.
+func riskAction(action string) (entity.RiskDecision, error) {
+	switch decision := entity.RiskDecision(action); decision {
+	case "":
+		return entity.RiskDecisionReview, nil
+	case entity.RiskDecisionReview, entity.RiskDecisionDeny:
+		return decision, nil
+	}
+	return "", fmt.Errorf("unknown action %q", action)
+}
+
+func (c riskChain) Evaluate(ctx context.Context, in RiskInput) (entity.RiskAssessment, error) {
+	result := entity.RiskAssessment{Decision: entity.RiskDecisionAllow}
+	for _, rule := range c.rules {
+		assessment, err := rule.Evaluate(ctx, in)
+		if err != nil {
+			return entity.RiskAssessment{}, fmt.Errorf("risk rule %s: %w", rule.Name(), err)
+		}
+		switch assessment.Decision {
+		case entity.RiskDecisionDeny:
+			return assessment, nil
+		case entity.RiskDecisionReview:
+			if result.Decision == entity.RiskDecisionAllow {
+				result = assessment
+			}
+		}
+	}
+	return result, nil
+}
+
+// newAccountRule flags transfers above a per-currency threshold from accounts opened recently.
+type newAccountRule struct {
+	maxAge     time.Duration
+	thresholds map[entity.Currency]entity.Money
+	action     entity.RiskDecision
+}
+
+func (r newAccountRule) Name() string {
+	return "new_account"
+}
+
+func (r newAccountRule) Evaluate(_ context.Context, in RiskInput) (entity.RiskAssessment, error) {
+	threshold, ok := r.thresholds[in.Transaction.Currency]
+	if !ok || !in.Transaction.Amount.GreaterThan(threshold) {
+		return entity.RiskAssessment{Decision: entity.RiskDecisionAllow}, nil
+	}
+	if in.Now.Sub(in.Source.CreatedAt) >= r.maxAge {
+		return entity.RiskAssessment{Decision: entity.RiskDecisionAllow}, nil
+	}
+	return entity.RiskAssessment{
+		Decision: r.action,
+		Rule:     r.Name(),
+		Reason: fmt.Sprintf("transfer of %s %s from an account opened %s ago",
+			in.Transaction.Amount, in.Transaction.Currency, in.Now.Sub(in.Source.CreatedAt).Truncate(time.Minute)),
+	}, nil
+}
+
+// roundTripRule flags a transfer to an account that sent money to the source shortly before.
+type roundTripRule struct {
+	transactionRepo repository.TransactionRepository
+	window          time.Duration
+	action          entity.RiskDecision
+}
+
+func (r roundTripRule) Name() string {
+	return "round_trip"
+}
+
+func (r roundTripRule) Evaluate(ctx context.Context, in RiskInput) (entity.RiskAssessment, error) {
+	returned, err := r.transactionRepo.HasTransferSince(ctx,
+		in.Transaction.DestinationAccountID, in.Transaction.SourceAccountID, in.Now.Add(-r.window))
+	if err != nil {
+		return entity.RiskAssessment{}, err
+	}
+	if !returned {
+		return entity.RiskAssessment{Decision: entity.RiskDecisionAllow}, nil
+	}
+	return entity.RiskAssessment{
+		Decision: r.action,
+		Rule:     r.Name(),
+		Reason: fmt.Sprintf("account %d sent money to account %d within the last %s",
+			in.Transaction.DestinationAccountID, in.Transaction.SourceAccountID, r.window),
+	}, nil
+}
+
+// unusualAmountRule flags a transfer larger than multiplier times the source account's
+// average outgoing transfer over the history period. Accounts with too short a history
+// are not assessed.
+type unusualAmountRule struct {
+	transactionRepo repository.TransactionRepository
+	history         time.Duration
+	minTransfers    int64
+	multiplier      int64
+	action          entity.RiskDecision
+}
+
+func (r unusualAmountRule) Name() string {
+	return "unusual_amount"
+}
+
+func (r unusualAmountRule) Evaluate(ctx context.Context, in RiskInput) (entity.RiskAssessment, error) {
+	usage, err := r.transactionRepo.SumOutgoingSince(ctx, in.Transaction.SourceAccountID, in.Now.Add(-r.history), in.Now)
+	if err != nil {
+		return entity.RiskAssessment{}, err
+	}
+	if usage.Count == 0 || usage.Count < r.minTransfers {
+		return entity.RiskAssessment{Decision: entity.RiskDecisionAllow}, nil
+	}
+
+	// amount > multiplier * total / count, compared without division or overflow
+	amount := new(big.Int).Mul(big.NewInt(in.Transaction.Amount.Units()), big.NewInt(usage.Count))
+	limit := new(big.Int).Mul(big.NewInt(usage.Total.Units()), big.NewInt(r.multiplier))
+	if amount.Cmp(limit) <= 0 {
+		return entity.RiskAssessment{Decision: entity.RiskDecisionAllow}, nil
+	}
+	return entity.RiskAssessment{
+		Decision: r.action,
+		Rule:     r.Name(),
+		Reason: fmt.Sprintf("transfer of %s %s is over %d times the average of the last %d transfers",
+			in.Transaction.Amount, in.Transaction.Currency, r.multiplier, usage.Count),
+	}, nil
+}
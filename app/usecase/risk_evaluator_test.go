@@ -0,0 +1,194 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+
+	"transaction_demo/app/config"
+	"transaction_demo/app/domain/entity"
+	"transaction_demo/app/domain/repository/mock"
+)
+
+func Test_NewRiskEvaluator(t *testing.T) {
+	tests := []struct {
+		name      string
+		risk      config.Risk
+		wantRules int
+		wantErr   bool
+	}{
+		{
+			name: "all_rules",
+			risk: config.Risk{
+				NewAccount:    config.NewAccountRisk{MaxAgeHours: 72, Amounts: map[string]string{"usd": "1000.00"}},
+				RoundTrip:     config.RoundTripRisk{WindowMinutes: 60, Action: "deny"},
+				UnusualAmount: config.UnusualAmountRisk{HistoryDays: 90, MinTransfers: 5, Multiplier: 10},
+			},
+			wantRules: 3,
+		},
+		{
+			name:      "no_rules",
+			wantRules: 0,
+		},
+		{
+			name:    "unknown_action",
+			risk:    config.Risk{RoundTrip: config.RoundTripRisk{WindowMinutes: 60, Action: "allow"}},
+			wantErr: true,
+		},
+		{
+			name:    "invalid_amount",
+			risk:    config.Risk{NewAccount: config.NewAccountRisk{MaxAgeHours: 72, Amounts: map[string]string{"usd": "lots"}}},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewRiskEvaluator(nil, &config.Config{Risk: tt.risk})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NewRiskEvaluator() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && len(got.(*riskChain).rules) != tt.wantRules {
+				t.Errorf("NewRiskEvaluator() rules = %d, want %d", len(got.(*riskChain).rules), tt.wantRules)
+			}
+		})
+	}
+}
+
+func Test_riskChain_Evaluate(t *testing.T) {
+	now := time.Date(2025, 9, 15, 14, 30, 0, 0, time.UTC)
+	transfer := func(amount string) *entity.Transaction {
+		return &entity.Transaction{SourceAccountID: 111, DestinationAccountID: 222,
+			Amount: entity.MustParseMoney(amount), Currency: entity.CurrencyUSD}
+	}
+	source := &entity.Account{ID: 111, Currency: entity.CurrencyUSD, CreatedAt: now.Add(-30 * 24 * time.Hour)}
+	newSource := &entity.Account{ID: 111, Currency: entity.CurrencyUSD, CreatedAt: now.Add(-2 * time.Hour)}
+	history := now.Add(-90 * 24 * time.Hour)
+
+	tests := []struct {
+		name         string
+		amount       string
+		source       *entity.Account
+		setup        func(repo *mock.MockTransactionRepository)
+		wantDecision entity.RiskDecision
+		wantRule     string
+		wantErr      bool
+	}{
+		{
+			name:   "allow",
+			amount: "100.00",
+			source: source,
+			setup: func(repo *mock.MockTransactionRepository) {
+				repo.EXPECT().HasTransferSince(gomock.Any(), uint64(222), uint64(111), now.Add(-time.Hour)).Return(false, nil)
+				repo.EXPECT().SumOutgoingSince(gomock.Any(), uint64(111), history, now).
+					Return(entity.TransferUsage{Total: entity.MustParseMoney("1000.00"), Count: 10}, nil)
+			},
+			wantDecision: entity.RiskDecisionAllow,
+		},
+		{
+			name:   "new_account_large_transfer",
+			amount: "1500.00",
+			source: newSource,
+			setup: func(repo *mock.MockTransactionRepository) {
+				repo.EXPECT().HasTransferSince(gomock.Any(), uint64(222), uint64(111), gomock.Any()).Return(false, nil)
+				repo.EXPECT().SumOutgoingSince(gomock.Any(), uint64(111), gomock.Any(), now).Return(entity.TransferUsage{}, nil)
+			},
+			wantDecision: entity.RiskDecisionReview,
+			wantRule:     "new_account",
+		},
+		{
+			name:   "new_account_at_threshold",
+			amount: "1000.00",
+			source: newSource,
+			setup: func(repo *mock.MockTransactionRepository) {
+				repo.EXPECT().HasTransferSince(gomock.Any(), uint64(222), uint64(111), gomock.Any()).Return(false, nil)
+				repo.EXPECT().SumOutgoingSince(gomock.Any(), uint64(111), gomock.Any(), now).Return(entity.TransferUsage{}, nil)
+			},
+			wantDecision: entity.RiskDecisionAllow,
+		},
+		{
+			// The round trip rule denies, which wins over the earlier review
+			name:   "deny_wins_over_review",
+			amount: "1500.00",
+			source: newSource,
+			setup: func(repo *mock.MockTransactionRepository) {
+				repo.EXPECT().HasTransferSince(gomock.Any(), uint64(222), uint64(111), gomock.Any()).Return(true, nil)
+			},
+			wantDecision: entity.RiskDecisionDeny,
+			wantRule:     "round_trip",
+		},
+		{
+			// 10 transfers totalling 1000.00 average 100.00; 1000.01 is over 10 times that
+			name:   "unusual_amount",
+			amount: "1000.01",
+			source: source,
+			setup: func(repo *mock.MockTransactionRepository) {
+				repo.EXPECT().HasTransferSince(gomock.Any(), uint64(222), uint64(111), gomock.Any()).Return(false, nil)
+				repo.EXPECT().SumOutgoingSince(gomock.Any(), uint64(111), history, now).
+					Return(entity.TransferUsage{Total: entity.MustParseMoney("1000.00"), Count: 10}, nil)
+			},
+			wantDecision: entity.RiskDecisionReview,
+			wantRule:     "unusual_amount",
+		},
+		{
+			name:   "unusual_amount_short_history",
+			amount: "5000.00",
+			source: source,
+			setup: func(repo *mock.MockTransactionRepository) {
+				repo.EXPECT().HasTransferSince(gomock.Any(), uint64(222), uint64(111), gomock.Any()).Return(false, nil)
+				repo.EXPECT().SumOutgoingSince(gomock.Any(), uint64(111), history, now).
+					Return(entity.TransferUsage{Total: entity.MustParseMoney("40.00"), Count: 4}, nil)
+			},
+			wantDecision: entity.RiskDecisionAllow,
+		},
+		{
+			name:   "repository_error",
+			amount: "100.00",
+			source: source,
+			setup: func(repo *mock.MockTransactionRepository) {
+				repo.EXPECT().HasTransferSince(gomock.Any(), uint64(222), uint64(111), gomock.Any()).
+					Return(false, errors.New("database error"))
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := mock.NewMockTransactionRepository(ctrl)
+			tt.setup(repo)
+
+			chain := riskChain{rules: []RiskRule{
+				newAccountRule{
+					maxAge:     72 * time.Hour,
+					thresholds: map[entity.Currency]entity.Money{entity.CurrencyUSD: entity.MustParseMoney("1000.00")},
+					action:     entity.RiskDecisionReview,
+				},
+				roundTripRule{transactionRepo: repo, window: time.Hour, action: entity.RiskDecisionDeny},
+				unusualAmountRule{transactionRepo: repo, history: 90 * 24 * time.Hour, minTransfers: 5, multiplier: 10,
+					action: entity.RiskDecisionReview},
+			}}
+
+			got, err := chain.Evaluate(context.Background(), RiskInput{
+				Transaction: transfer(tt.amount),
+				Source:      tt.source,
+				Destination: &entity.Account{ID: 222, Currency: entity.CurrencyUSD},
+				Now:         now,
+			})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Evaluate() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got.Decision != tt.wantDecision || got.Rule != tt.wantRule {
+				t.Errorf("Evaluate() = %+v, want %s by %q", got, tt.wantDecision, tt.wantRule)
+			}
+		})
+	}
+}
@@ -0,0 +1,321 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/avito-tech/go-transaction-manager/trm/v2"
+
+	"transaction_demo/app/apperr"
+	"transaction_demo/app/domain/entity"
+	"transaction_demo/app/domain/repository"
+	"transaction_demo/app/usecase/dto"
+)
+
+// defaultRunHistoryLimit is the number of runs listed for a schedule.
+const defaultRunHistoryLimit = 50
+
+// ScheduledTransferUC defines the interface for transfers made at a future time, once or on a recurrence.
+type ScheduledTransferUC interface {
+	// Schedule registers a transfer to be made at run_at and, for recurring transfers, repeatedly after it.
+	Schedule(ctx context.Context, req dto.ScheduleTransferDTO) (dto.ScheduledTransferDTO, error)
+
+	// GetSchedule retrieves a scheduled transfer.
+	GetSchedule(ctx context.Context, id uint64) (dto.ScheduledTransferDTO, error)
+
+	// CancelSchedule stops an active scheduled transfer before its next run.
+	CancelSchedule(ctx context.Context, id uint64) (dto.ScheduledTransferDTO, error)
+
+	// ListRuns lists the latest runs of a scheduled transfer, newest first.
+	ListRuns(ctx context.Context, id uint64) ([]dto.ScheduledRunDTO, error)
+
+	// RunDue makes the transfers due at now, at most limit of them, and returns how many ran.
+	RunDue(ctx context.Context, now time.Time, limit int) (int, error)
+}
+
+type scheduledTransferUsecase struct {
+	scheduleRepo repository.ScheduledTransferRepository
+	accountRepo  repository.AccountRepository
+	accountUC    AccountUC
+	txManager    trm.Manager
+}
+
+func NewScheduledTransferUsecase(
+	scheduleRepo repository.ScheduledTransferRepository,
+	accountRepo repository.AccountRepository,
+	accountUC AccountUC,
+	txManager trm.Manager) ScheduledTransferUC {
+	return &scheduledTransferUsecase{
+		scheduleRepo: scheduleRepo,
+		accountRepo:  accountRepo,
+		accountUC:    accountUC,
+		txManager:    txManager,
+	}
+}
+
+// Schedule validates and stores a scheduled transfer.
+//
+// The accounts are checked now so that obvious mistakes fail early, but balances, statuses,
+// limits and risk rules are only evaluated when each occurrence runs:
+// - Both accounts must exist and not be closed
+// - Both accounts must use the same currency; a scheduled transfer cannot lock an FX quote
+// - The first run must be in the future, and an end date is only allowed on a recurrence
+func (uc scheduledTransferUsecase) Schedule(ctx context.Context, req dto.ScheduleTransferDTO,
+) (dto.ScheduledTransferDTO, error) {
+	err := req.Validate()
+	if err != nil {
+		fmt.Println("schedule validation failed", "error", err)
+		return dto.ScheduledTransferDTO{}, apperr.ErrInvalidInput.WithError(err).WithMessage(err.Error())
+	}
+
+	if req.Recurrence == "" {
+		req.Recurrence = entity.RecurrenceOnce
+	}
+	now := time.Now()
+	if !req.RunAt.After(now) {
+		fmt.Println("schedule in the past", "run_at", req.RunAt)
+		return dto.ScheduledTransferDTO{}, apperr.ErrInvalidInput.WithMessage("run_at must be in the future")
+	}
+	if req.EndAt != nil && (req.Recurrence == entity.RecurrenceOnce || req.EndAt.Before(req.RunAt)) {
+		fmt.Println("invalid schedule end", "recurrence", req.Recurrence, "end_at", req.EndAt)
+		return dto.ScheduledTransferDTO{}, apperr.ErrInvalidInput.WithMessage(
+			"end_at is only allowed on a recurring transfer and must not be before run_at")
+	}
+
+	sourceAcc, err := uc.findAccount(ctx, req.SourceAccountID)
+	if err != nil {
+		return dto.ScheduledTransferDTO{}, err
+	}
+	destAcc, err := uc.findAccount(ctx, req.DestinationAccountID)
+	if err != nil {
+		return dto.ScheduledTransferDTO{}, err
+	}
+	if req.Currency != "" && req.Currency != sourceAcc.Currency {
+		fmt.Println("amount currency does not match source account", "currency", req.Currency, "account_currency", sourceAcc.Currency)
+		return dto.ScheduledTransferDTO{}, apperr.ErrCurrencyMismatch.WithMessage("amount currency does not match source account currency")
+	}
+	if destAcc.Currency != sourceAcc.Currency {
+		fmt.Println("scheduled transfer across currencies", "source_currency", sourceAcc.Currency,
+			"destination_currency", destAcc.Currency)
+		return dto.ScheduledTransferDTO{}, apperr.ErrCurrencyMismatch.WithMessage(
+			"scheduled transfers cannot convert currencies; both accounts must use the same currency")
+	}
+
+	startAt := req.RunAt.UTC()
+	var endAt *time.Time
+	if req.EndAt != nil {
+		end := req.EndAt.UTC()
+		endAt = &end
+	}
+	schedule, err := uc.scheduleRepo.Create(ctx, &entity.ScheduledTransfer{
+		SourceAccountID:      sourceAcc.ID,
+		DestinationAccountID: destAcc.ID,
+		Amount:               req.Amount,
+		Currency:             sourceAcc.Currency,
+		Recurrence:           req.Recurrence,
+		StartAt:              startAt,
+		EndAt:                endAt,
+		NextRunAt:            startAt,
+		Status:               entity.ScheduleActive,
+		CreatedAt:            now,
+		UpdatedAt:            now,
+	})
+	if err != nil {
+		fmt.Println("failed to create scheduled transfer", "error", err)
+		return dto.ScheduledTransferDTO{}, apperr.ErrInternalServer.WithError(err).WithMessage("failed to create scheduled transfer")
+	}
+
+	return toScheduledTransferDTO(schedule), nil
+}
+
+// GetSchedule retrieves a scheduled transfer by ID.
+func (uc scheduledTransferUsecase) GetSchedule(ctx context.Context, id uint64) (dto.ScheduledTransferDTO, error) {
+	schedule, err := uc.scheduleRepo.FindOne(ctx, id)
+	if err != nil {
+		fmt.Println("failed to find scheduled transfer", "error", err)
+		return dto.ScheduledTransferDTO{}, apperr.ErrInternalServer.WithError(err).WithMessage("failed to find scheduled transfer")
+	}
+	if schedule == nil {
+		fmt.Println("scheduled transfer not found", "schedule_id", id)
+		return dto.ScheduledTransferDTO{}, apperr.ErrNotFound.WithMessage("scheduled transfer not found")
+	}
+
+	return toScheduledTransferDTO(schedule), nil
+}
+
+// CancelSchedule cancels an active scheduled transfer.
+// The schedule row is locked, so a run already claimed by a worker completes first.
+func (uc scheduledTransferUsecase) CancelSchedule(ctx context.Context, id uint64) (dto.ScheduledTransferDTO, error) {
+	var schedule *entity.ScheduledTransfer
+	err := uc.txManager.Do(ctx, func(ctx context.Context) error {
+		var err error
+		schedule, err = uc.scheduleRepo.FindForUpdate(ctx, id)
+		if err != nil {
+			fmt.Println("failed to find scheduled transfer", "error", err)
+			return apperr.ErrInternalServer.WithError(err).WithMessage("failed to find scheduled transfer")
+		}
+		if schedule == nil {
+			fmt.Println("scheduled transfer not found", "schedule_id", id)
+			return apperr.ErrNotFound.WithMessage("scheduled transfer not found")
+		}
+		if schedule.Status != entity.ScheduleActive {
+			fmt.Println("scheduled transfer not active", "schedule_id", id, "status", schedule.Status)
+			return apperr.ErrInvalidInput.WithMessage("scheduled transfer is " + string(schedule.Status))
+		}
+
+		schedule.Status = entity.ScheduleCancelled
+		schedule.UpdatedAt = time.Now()
+		if err = uc.scheduleRepo.Update(ctx, schedule); err != nil {
+			fmt.Println("failed to update scheduled transfer", "error", err)
+			return apperr.ErrInternalServer.WithError(err).WithMessage("failed to update scheduled transfer")
+		}
+		return nil
+	})
+	if err != nil {
+		fmt.Println("cancel schedule failed", "error", err)
+		return dto.ScheduledTransferDTO{}, err
+	}
+
+	return toScheduledTransferDTO(schedule), nil
+}
+
+// ListRuns lists the latest runs of a scheduled transfer.
+func (uc scheduledTransferUsecase) ListRuns(ctx context.Context, id uint64) ([]dto.ScheduledRunDTO, error) {
+	if _, err := uc.GetSchedule(ctx, id); err != nil {
+		return nil, err
+	}
+
+	runs, err := uc.scheduleRepo.FindRuns(ctx, id, defaultRunHistoryLimit)
+	if err != nil {
+		fmt.Println("failed to find runs", "error", err)
+		return nil, apperr.ErrInternalServer.WithError(err).WithMessage("failed to find scheduled transfer runs")
+	}
+
+	res := make([]dto.ScheduledRunDTO, 0, len(runs))
+	for _, run := range runs {
+		res = append(res, dto.ScheduledRunDTO{
+			RunID:         run.ID,
+			ScheduledFor:  run.ScheduledFor,
+			Status:        run.Status,
+			TransactionID: run.TransactionID,
+			ErrorCode:     run.ErrorCode,
+			ErrorMessage:  run.ErrorMessage,
+			CreatedAt:     run.CreatedAt,
+		})
+	}
+	return res, nil
+}
+
+// RunDue makes the transfers that are due, one DB transaction per schedule.
+//
+// Each transaction claims the earliest due schedule with SELECT ... FOR UPDATE SKIP LOCKED,
+// so several workers can run side by side without making an occurrence twice. The transfer
+// itself goes through AccountUC.MakeTransaction in a nested transaction: when it fails, only
+// the transfer is rolled back, and the failed run is still recorded and the schedule advanced.
+// A failed occurrence is not retried.
+func (uc scheduledTransferUsecase) RunDue(ctx context.Context, now time.Time, limit int) (int, error) {
+	ran := 0
+	for ran < limit {
+		claimed := false
+		err := uc.txManager.Do(ctx, func(ctx context.Context) error {
+			schedule, err := uc.scheduleRepo.ClaimDue(ctx, now)
+			if err != nil {
+				fmt.Println("failed to claim scheduled transfer", "error", err)
+				return apperr.ErrInternalServer.WithError(err).WithMessage("failed to claim scheduled transfer")
+			}
+			if schedule == nil {
+				return nil
+			}
+			claimed = true
+			return uc.run(ctx, schedule, now)
+		})
+		if err != nil {
+			return ran, err
+		}
+		if !claimed {
+			break
+		}
+		ran++
+	}
+	return ran, nil
+}
+
+// run makes the occurrence of a claimed schedule due at its NextRunAt and records the outcome.
+func (uc scheduledTransferUsecase) run(ctx context.Context, schedule *entity.ScheduledTransfer, now time.Time) error {
+	run := &entity.ScheduledTransferRun{
+		ScheduledTransferID: schedule.ID,
+		ScheduledFor:        schedule.NextRunAt,
+		CreatedAt:           now,
+	}
+
+	transaction, err := uc.accountUC.MakeTransaction(ctx, dto.TransactionDTO{
+		SourceAccountID:      schedule.SourceAccountID,
+		DestinationAccountID: schedule.DestinationAccountID,
+		Amount:               schedule.Amount,
+		Currency:             schedule.Currency,
+	})
+	if err != nil {
+		appErr := toAppError(err)
+		run.Status = entity.RunFailed
+		run.ErrorCode = appErr.Code
+		run.ErrorMessage = appErr.Message
+		fmt.Println("scheduled transfer failed", "schedule_id", schedule.ID, "code", appErr.Code, "message", appErr.Message)
+	} else {
+		run.Status = entity.RunSucceeded
+		run.TransactionID = &transaction.TransactionID
+		fmt.Println("scheduled transfer made", "schedule_id", schedule.ID, "transaction_id", transaction.TransactionID)
+	}
+
+	if _, err = uc.scheduleRepo.CreateRun(ctx, run); err != nil {
+		fmt.Println("failed to record run", "error", err)
+		return apperr.ErrInternalServer.WithError(err).WithMessage("failed to record scheduled transfer run")
+	}
+
+	schedule.Advance(now)
+	schedule.UpdatedAt = now
+	if err = uc.scheduleRepo.Update(ctx, schedule); err != nil {
+		fmt.Println("failed to update scheduled transfer", "error", err)
+		return apperr.ErrInternalServer.WithError(err).WithMessage("failed to update scheduled transfer")
+	}
+	return nil
+}
+
+// findAccount finds an account that can take part in a scheduled transfer.
+func (uc scheduledTransferUsecase) findAccount(ctx context.Context, id uint64) (*entity.Account, error) {
+	account, err := uc.accountRepo.FindOne(ctx, id)
+	if err != nil {
+		fmt.Println("failed to find account", "error", err)
+		return nil, apperr.ErrInternalServer.WithError(err).WithMessage("failed to find account")
+	}
+	if account == nil {
+		fmt.Println("account not found", "account_id", id)
+		return nil, apperr.ErrNotFound.WithMessage(fmt.Sprintf("account %d not found", id))
+	}
+	if account.Status == entity.AccountClosed {
+		fmt.Println("account closed", "account_id", id)
+		return nil, apperr.ErrAccountClosed.WithMessage(fmt.Sprintf("account %d is closed", id))
+	}
+	return account, nil
+}
+
+func toScheduledTransferDTO(schedule *entity.ScheduledTransfer) dto.ScheduledTransferDTO {
+	res := dto.ScheduledTransferDTO{
+		ScheduleID:           schedule.ID,
+		SourceAccountID:      schedule.SourceAccountID,
+		DestinationAccountID: schedule.DestinationAccountID,
+		Amount:               schedule.Amount,
+		Currency:             schedule.Currency,
+		Recurrence:           schedule.Recurrence,
+		StartAt:              schedule.StartAt,
+		EndAt:                schedule.EndAt,
+		Status:               schedule.Status,
+		CreatedAt:            schedule.CreatedAt,
+		UpdatedAt:            schedule.UpdatedAt,
+	}
+	if schedule.Status == entity.ScheduleActive {
+		nextRunAt := schedule.NextRunAt
+		res.NextRunAt = &nextRunAt
+	}
+	return res
+}
@@ -0,0 +1,306 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+
+	"transaction_demo/app/apperr"
+	"transaction_demo/app/domain/entity"
+	"transaction_demo/app/domain/repository/mock"
+	"transaction_demo/app/usecase/dto"
+	mock2 "transaction_demo/cmd/shared/db/mock"
+)
+
+// stubAccountUC makes transfers with a function; the other AccountUC methods are not used by the scheduler.
+type stubAccountUC struct {
+	AccountUC
+	makeTransaction func(req dto.TransactionDTO) (dto.TransactionRecordDTO, error)
+}
+
+func (s stubAccountUC) MakeTransaction(_ context.Context, req dto.TransactionDTO) (dto.TransactionRecordDTO, error) {
+	return s.makeTransaction(req)
+}
+
+type scheduleFields struct {
+	scheduleRepo *mock.MockScheduledTransferRepository
+	accountRepo  *mock.MockAccountRepository
+}
+
+func newTestScheduledTransferUsecase(ctrl *gomock.Controller, accountUC AccountUC,
+) (scheduledTransferUsecase, scheduleFields) {
+	testFields := scheduleFields{
+		scheduleRepo: mock.NewMockScheduledTransferRepository(ctrl),
+		accountRepo:  mock.NewMockAccountRepository(ctrl),
+	}
+	uc := scheduledTransferUsecase{
+		scheduleRepo: testFields.scheduleRepo,
+		accountRepo:  testFields.accountRepo,
+		accountUC:    accountUC,
+		txManager:    &mock2.MockTxManager{},
+	}
+	return uc, testFields
+}
+
+func Test_scheduledTransferUsecase_Schedule(t *testing.T) {
+	runAt := time.Now().Add(time.Hour)
+	endAt := runAt.Add(30 * 24 * time.Hour)
+	usd := func(id uint64) *entity.Account {
+		return &entity.Account{ID: id, Currency: entity.CurrencyUSD, Status: entity.AccountActive}
+	}
+	tests := []struct {
+		name     string
+		req      dto.ScheduleTransferDTO
+		setup    func(fields scheduleFields)
+		wantCode string
+	}{
+		{
+			name: "success_monthly",
+			req: dto.ScheduleTransferDTO{SourceAccountID: 111, DestinationAccountID: 222,
+				Amount: entity.MustParseMoney("100"), RunAt: runAt, Recurrence: entity.RecurrenceMonthly, EndAt: &endAt},
+			setup: func(fields scheduleFields) {
+				fields.accountRepo.EXPECT().FindOne(gomock.Any(), uint64(111)).Return(usd(111), nil)
+				fields.accountRepo.EXPECT().FindOne(gomock.Any(), uint64(222)).Return(usd(222), nil)
+				fields.scheduleRepo.EXPECT().Create(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, s *entity.ScheduledTransfer) (*entity.ScheduledTransfer, error) {
+						if s.Status != entity.ScheduleActive || !s.NextRunAt.Equal(runAt) || s.Currency != entity.CurrencyUSD {
+							t.Errorf("unexpected schedule: %+v", s)
+						}
+						s.ID = 1
+						return s, nil
+					})
+			},
+		},
+		{
+			name: "defaults_to_once",
+			req: dto.ScheduleTransferDTO{SourceAccountID: 111, DestinationAccountID: 222,
+				Amount: entity.MustParseMoney("100"), RunAt: runAt},
+			setup: func(fields scheduleFields) {
+				fields.accountRepo.EXPECT().FindOne(gomock.Any(), uint64(111)).Return(usd(111), nil)
+				fields.accountRepo.EXPECT().FindOne(gomock.Any(), uint64(222)).Return(usd(222), nil)
+				fields.scheduleRepo.EXPECT().Create(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, s *entity.ScheduledTransfer) (*entity.ScheduledTransfer, error) {
+						if s.Recurrence != entity.RecurrenceOnce {
+							t.Errorf("unexpected recurrence: %s", s.Recurrence)
+						}
+						return s, nil
+					})
+			},
+		},
+		{
+			name: "run_at_in_the_past",
+			req: dto.ScheduleTransferDTO{SourceAccountID: 111, DestinationAccountID: 222,
+				Amount: entity.MustParseMoney("100"), RunAt: time.Now().Add(-time.Minute)},
+			setup:    func(fields scheduleFields) {},
+			wantCode: apperr.ErrInvalidInput.Code,
+		},
+		{
+			name: "end_at_on_once",
+			req: dto.ScheduleTransferDTO{SourceAccountID: 111, DestinationAccountID: 222,
+				Amount: entity.MustParseMoney("100"), RunAt: runAt, EndAt: &endAt},
+			setup:    func(fields scheduleFields) {},
+			wantCode: apperr.ErrInvalidInput.Code,
+		},
+		{
+			name: "same_account",
+			req: dto.ScheduleTransferDTO{SourceAccountID: 111, DestinationAccountID: 111,
+				Amount: entity.MustParseMoney("100"), RunAt: runAt},
+			setup:    func(fields scheduleFields) {},
+			wantCode: apperr.ErrInvalidInput.Code,
+		},
+		{
+			name: "destination_not_found",
+			req: dto.ScheduleTransferDTO{SourceAccountID: 111, DestinationAccountID: 222,
+				Amount: entity.MustParseMoney("100"), RunAt: runAt},
+			setup: func(fields scheduleFields) {
+				fields.accountRepo.EXPECT().FindOne(gomock.Any(), uint64(111)).Return(usd(111), nil)
+				fields.accountRepo.EXPECT().FindOne(gomock.Any(), uint64(222)).Return(nil, nil)
+			},
+			wantCode: apperr.ErrNotFound.Code,
+		},
+		{
+			name: "currency_mismatch",
+			req: dto.ScheduleTransferDTO{SourceAccountID: 111, DestinationAccountID: 222,
+				Amount: entity.MustParseMoney("100"), RunAt: runAt},
+			setup: func(fields scheduleFields) {
+				fields.accountRepo.EXPECT().FindOne(gomock.Any(), uint64(111)).Return(usd(111), nil)
+				fields.accountRepo.EXPECT().FindOne(gomock.Any(), uint64(222)).Return(&entity.Account{
+					ID: 222, Currency: entity.CurrencyEUR, Status: entity.AccountActive}, nil)
+			},
+			wantCode: apperr.ErrCurrencyMismatch.Code,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			uc, fields := newTestScheduledTransferUsecase(ctrl, nil)
+			tt.setup(fields)
+
+			got, err := uc.Schedule(context.Background(), tt.req)
+			if tt.wantCode != "" {
+				var appErr apperr.AppError
+				if !errors.As(err, &appErr) || appErr.Code != tt.wantCode {
+					t.Errorf("Schedule() error = %v, want code %s", err, tt.wantCode)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Schedule() unexpected error = %v", err)
+			}
+			if got.Status != entity.ScheduleActive || got.NextRunAt == nil {
+				t.Errorf("Schedule() got = %+v", got)
+			}
+		})
+	}
+}
+
+func Test_scheduledTransferUsecase_CancelSchedule(t *testing.T) {
+	tests := []struct {
+		name     string
+		status   entity.ScheduleStatus
+		wantCode string
+	}{
+		{name: "success", status: entity.ScheduleActive},
+		{name: "already_completed", status: entity.ScheduleCompleted, wantCode: apperr.ErrInvalidInput.Code},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			uc, fields := newTestScheduledTransferUsecase(ctrl, nil)
+			fields.scheduleRepo.EXPECT().FindForUpdate(gomock.Any(), uint64(1)).Return(&entity.ScheduledTransfer{
+				ID: 1, Status: tt.status, NextRunAt: time.Now()}, nil)
+			if tt.wantCode == "" {
+				fields.scheduleRepo.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil)
+			}
+
+			got, err := uc.CancelSchedule(context.Background(), 1)
+			if tt.wantCode != "" {
+				var appErr apperr.AppError
+				if !errors.As(err, &appErr) || appErr.Code != tt.wantCode {
+					t.Errorf("CancelSchedule() error = %v, want code %s", err, tt.wantCode)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("CancelSchedule() unexpected error = %v", err)
+			}
+			if got.Status != entity.ScheduleCancelled || got.NextRunAt != nil {
+				t.Errorf("CancelSchedule() got = %+v", got)
+			}
+		})
+	}
+}
+
+func Test_scheduledTransferUsecase_RunDue(t *testing.T) {
+	now := time.Date(2025, 9, 15, 9, 0, 0, 0, time.UTC)
+	daily := func() *entity.ScheduledTransfer {
+		return &entity.ScheduledTransfer{
+			ID: 1, SourceAccountID: 111, DestinationAccountID: 222, Amount: entity.MustParseMoney("25"),
+			Currency: entity.CurrencyUSD, Recurrence: entity.RecurrenceDaily, StartAt: now.Add(-time.Minute),
+			NextRunAt: now.Add(-time.Minute), Status: entity.ScheduleActive,
+		}
+	}
+	tests := []struct {
+		name            string
+		limit           int
+		makeTransaction func(req dto.TransactionDTO) (dto.TransactionRecordDTO, error)
+		setup           func(fields scheduleFields)
+		want            int
+	}{
+		{
+			name:  "success_advances_schedule",
+			limit: 10,
+			makeTransaction: func(req dto.TransactionDTO) (dto.TransactionRecordDTO, error) {
+				return dto.TransactionRecordDTO{TransactionID: 77}, nil
+			},
+			setup: func(fields scheduleFields) {
+				gomock.InOrder(
+					fields.scheduleRepo.EXPECT().ClaimDue(gomock.Any(), now).Return(daily(), nil),
+					fields.scheduleRepo.EXPECT().ClaimDue(gomock.Any(), now).Return(nil, nil),
+				)
+				fields.scheduleRepo.EXPECT().CreateRun(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, run *entity.ScheduledTransferRun) (*entity.ScheduledTransferRun, error) {
+						if run.Status != entity.RunSucceeded || run.TransactionID == nil || *run.TransactionID != 77 {
+							t.Errorf("unexpected run: %+v", run)
+						}
+						return run, nil
+					})
+				fields.scheduleRepo.EXPECT().Update(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, s *entity.ScheduledTransfer) error {
+						if !s.NextRunAt.Equal(now.Add(24*time.Hour-time.Minute)) || s.Occurrence != 1 {
+							t.Errorf("unexpected schedule: %+v", s)
+						}
+						return nil
+					})
+			},
+			want: 1,
+		},
+		{
+			name:  "failed_run_recorded",
+			limit: 10,
+			makeTransaction: func(req dto.TransactionDTO) (dto.TransactionRecordDTO, error) {
+				return dto.TransactionRecordDTO{}, apperr.ErrInsufficientFunds.WithMessage("insufficient funds")
+			},
+			setup: func(fields scheduleFields) {
+				gomock.InOrder(
+					fields.scheduleRepo.EXPECT().ClaimDue(gomock.Any(), now).Return(daily(), nil),
+					fields.scheduleRepo.EXPECT().ClaimDue(gomock.Any(), now).Return(nil, nil),
+				)
+				fields.scheduleRepo.EXPECT().CreateRun(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, run *entity.ScheduledTransferRun) (*entity.ScheduledTransferRun, error) {
+						if run.Status != entity.RunFailed || run.ErrorCode != apperr.ErrInsufficientFunds.Code {
+							t.Errorf("unexpected run: %+v", run)
+						}
+						return run, nil
+					})
+				fields.scheduleRepo.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil)
+			},
+			want: 1,
+		},
+		{
+			name:  "stops_at_max",
+			limit: 1,
+			makeTransaction: func(req dto.TransactionDTO) (dto.TransactionRecordDTO, error) {
+				return dto.TransactionRecordDTO{TransactionID: 77}, nil
+			},
+			setup: func(fields scheduleFields) {
+				fields.scheduleRepo.EXPECT().ClaimDue(gomock.Any(), now).Return(daily(), nil)
+				fields.scheduleRepo.EXPECT().CreateRun(gomock.Any(), gomock.Any()).Return(&entity.ScheduledTransferRun{}, nil)
+				fields.scheduleRepo.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil)
+			},
+			want: 1,
+		},
+		{
+			name:  "nothing_due",
+			limit: 10,
+			setup: func(fields scheduleFields) {
+				fields.scheduleRepo.EXPECT().ClaimDue(gomock.Any(), now).Return(nil, nil)
+			},
+			want: 0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			uc, fields := newTestScheduledTransferUsecase(ctrl, stubAccountUC{makeTransaction: tt.makeTransaction})
+			tt.setup(fields)
+
+			got, err := uc.RunDue(context.Background(), now, tt.limit)
+			if err != nil {
+				t.Fatalf("RunDue() unexpected error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("RunDue() got = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,163 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/avito-tech/go-transaction-manager/trm/v2"
+
+	"transaction_demo/app/apperr"
+	"transaction_demo/app/domain/entity"
+	"transaction_demo/app/domain/repository"
+	"transaction_demo/app/usecase/dto"
+	"transaction_demo/cmd/shared/db"
+)
+
+// StatementWriter renders a statement while it is streamed: Begin once, WriteLine for
+// each movement, oldest first, then End.
+type StatementWriter interface {
+	// Begin writes the statement header, before any line.
+	Begin(header dto.StatementHeaderDTO) error
+	// WriteLine writes one movement.
+	WriteLine(line dto.StatementLineDTO) error
+	// End completes the statement after the last line.
+	End(summary dto.StatementSummaryDTO) error
+}
+
+// StatementUC defines the interface for producing account statements.
+type StatementUC interface {
+	// WriteStatement streams the statement of an account for a period into w. An error returned
+	// before w.Begin was called left w untouched; after that the output is incomplete.
+	WriteStatement(ctx context.Context, req dto.StatementRequestDTO, w StatementWriter) error
+}
+
+type statementUsecase struct {
+	accountRepo     repository.AccountRepository
+	transactionRepo repository.TransactionRepository
+	ledgerRepo      repository.LedgerRepository
+	snapshotRepo    repository.BalanceSnapshotRepository
+	txManager       trm.Manager
+}
+
+func NewStatementUsecase(
+	accountRepo repository.AccountRepository,
+	transactionRepo repository.TransactionRepository,
+	ledgerRepo repository.LedgerRepository,
+	snapshotRepo repository.BalanceSnapshotRepository,
+	txManager trm.Manager) StatementUC {
+	return &statementUsecase{
+		accountRepo:     accountRepo,
+		transactionRepo: transactionRepo,
+		ledgerRepo:      ledgerRepo,
+		snapshotRepo:    snapshotRepo,
+		txManager:       txManager,
+	}
+}
+
+// WriteStatement streams an account statement.
+//
+// Statement rules:
+// - Movements are the ledger postings of the account booked from `from` until `to`, netted per journal entry,
+// so a transfer and its fee are one debit
+// - A period ending in the future is cut at the current time
+// - The opening balance is the balance at `from`; the closing balance is the opening balance plus every movement
+// - Movements are streamed from the database one at a time, so a period of any length uses constant memory
+// - The balances and the movements are read in one read-only snapshot, so postings committed while the
+// statement is written are left out of both
+func (uc statementUsecase) WriteStatement(ctx context.Context, req dto.StatementRequestDTO, w StatementWriter) error {
+	if err := req.Validate(); err != nil {
+		fmt.Println("statement validation failed", "error", err)
+		return apperr.ErrInvalidInput.WithError(err).WithMessage(err.Error())
+	}
+	now := time.Now()
+	from, to := *req.From, *req.To
+	if to.After(now) {
+		to = now
+	}
+	if !from.Before(to) {
+		fmt.Println("empty statement period", "from", from, "to", to)
+		return apperr.ErrInvalidInput.WithMessage("from must be before to and in the past")
+	}
+
+	return uc.txManager.DoWithSettings(ctx, db.SnapshotSettings(), func(ctx context.Context) error {
+		return uc.writeStatement(ctx, req.AccountID, from, to, now, w)
+	})
+}
+
+// writeStatement reads and writes the statement of a validated period, within the snapshot of ctx.
+func (uc statementUsecase) writeStatement(ctx context.Context, accountID uint64, from, to, now time.Time,
+	w StatementWriter) error {
+	account, err := uc.accountRepo.FindOne(ctx, accountID)
+	if err != nil {
+		fmt.Println("failed to find account", "error", err)
+		return apperr.ErrInternalServer.WithError(err).WithMessage("failed to find account")
+	}
+	if account == nil {
+		fmt.Println("account not found", "account_id", accountID)
+		return apperr.ErrNotFound.WithMessage("account not found")
+	}
+
+	opening, _, err := balanceAt(ctx, uc.ledgerRepo, uc.snapshotRepo, account.ID, from)
+	if err != nil {
+		return err
+	}
+	// The closing balance is written first by formats such as camt.053, so it is read before the movements
+	movements, err := uc.ledgerRepo.SumByAccountBetween(ctx, account.ID, from, to)
+	if err != nil {
+		fmt.Println("failed to sum postings", "error", err)
+		return apperr.ErrInternalServer.WithError(err).WithMessage("failed to read ledger")
+	}
+	closing := opening.Add(movements)
+
+	err = w.Begin(dto.StatementHeaderDTO{
+		AccountID:      account.ID,
+		Currency:       account.Currency,
+		From:           from,
+		To:             to,
+		OpeningBalance: opening,
+		ClosingBalance: closing,
+		GeneratedAt:    now,
+	})
+	if err != nil {
+		fmt.Println("failed to write statement header", "error", err)
+		return err
+	}
+
+	summary := dto.StatementSummaryDTO{}
+	balance := opening
+	filter := entity.StatementFilter{AccountID: account.ID, From: from, To: to}
+	err = uc.transactionRepo.IterateStatement(ctx, filter, func(line *entity.StatementLine) error {
+		balance = balance.Add(line.Amount)
+		summary.Entries++
+		if line.Amount.IsNegative() {
+			summary.TotalDebits = summary.TotalDebits.Sub(line.Amount)
+		} else {
+			summary.TotalCredits = summary.TotalCredits.Add(line.Amount)
+		}
+		return w.WriteLine(dto.StatementLineDTO{
+			JournalEntryID:        line.JournalEntryID,
+			TransactionID:         line.TransactionID,
+			Description:           line.Description,
+			BookedAt:              line.BookedAt,
+			Amount:                line.Amount,
+			Fee:                   line.Fee,
+			Currency:              line.Currency,
+			CounterpartyAccountID: line.CounterpartyAccountID,
+			Balance:               balance,
+		})
+	})
+	if err != nil {
+		fmt.Println("failed to stream statement", "account_id", account.ID, "error", err)
+		return err
+	}
+	// Postings are append-only and read in one snapshot, so this only fails on an inconsistent ledger
+	if balance != closing {
+		fmt.Println("statement movements do not add up", "account_id", account.ID,
+			"closing_balance", closing, "streamed_balance", balance)
+		return apperr.ErrInternalServer.WithMessage("statement movements do not add up to the closing balance")
+	}
+
+	summary.ClosingBalance = closing
+	return w.End(summary)
+}
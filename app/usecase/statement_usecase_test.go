@@ -0,0 +1,183 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+
+	"transaction_demo/app/apperr"
+	"transaction_demo/app/domain/entity"
+	"transaction_demo/app/domain/repository/mock"
+	"transaction_demo/app/usecase/dto"
+	mock2 "transaction_demo/cmd/shared/db/mock"
+)
+
+// recordingStatementWriter keeps what a statement writer was given.
+type recordingStatementWriter struct {
+	header  *dto.StatementHeaderDTO
+	lines   []dto.StatementLineDTO
+	summary *dto.StatementSummaryDTO
+}
+
+func (w *recordingStatementWriter) Begin(header dto.StatementHeaderDTO) error {
+	w.header = &header
+	return nil
+}
+
+func (w *recordingStatementWriter) WriteLine(line dto.StatementLineDTO) error {
+	w.lines = append(w.lines, line)
+	return nil
+}
+
+func (w *recordingStatementWriter) End(summary dto.StatementSummaryDTO) error {
+	w.summary = &summary
+	return nil
+}
+
+func Test_statementUsecase_WriteStatement(t *testing.T) {
+	type statementFields struct {
+		accountRepo     *mock.MockAccountRepository
+		transactionRepo *mock.MockTransactionRepository
+		ledgerRepo      *mock.MockLedgerRepository
+		snapshotRepo    *mock.MockBalanceSnapshotRepository
+	}
+	from := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC)
+	txID, counterparty := uint64(7), uint64(222)
+	lines := []*entity.StatementLine{
+		{JournalEntryID: 3, TransactionID: &txID, Description: "transfer", BookedAt: from.AddDate(0, 0, 2),
+			Amount: entity.MustParseMoney("-101.50"), Fee: entity.MustParseMoney("1.50"), Currency: entity.CurrencyUSD,
+			CounterpartyAccountID: &counterparty},
+		{JournalEntryID: 9, Description: "interest", BookedAt: from.AddDate(0, 0, 20),
+			Amount: entity.MustParseMoney("3.25"), Currency: entity.CurrencyUSD},
+	}
+	expectAccount := func(fields statementFields) {
+		fields.accountRepo.EXPECT().FindOne(gomock.Any(), uint64(111)).Return(&entity.Account{
+			ID: 111, Currency: entity.CurrencyUSD,
+		}, nil)
+		fields.snapshotRepo.EXPECT().FindLatest(gomock.Any(), uint64(111), gomock.Any()).Return(nil, nil)
+		fields.ledgerRepo.EXPECT().SumByAccountBefore(gomock.Any(), uint64(111), from).Return(entity.MustParseMoney("1000.00"), nil)
+	}
+	streamLines := func(_ context.Context, _ entity.StatementFilter, fn func(*entity.StatementLine) error) error {
+		for _, line := range lines {
+			if err := fn(line); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	tests := []struct {
+		name        string
+		from        *time.Time
+		to          *time.Time
+		setup       func(fields statementFields)
+		wantLines   []dto.StatementLineDTO
+		wantSummary *dto.StatementSummaryDTO
+		wantCode    string
+	}{
+		{
+			name: "success",
+			from: &from,
+			to:   &to,
+			setup: func(fields statementFields) {
+				expectAccount(fields)
+				fields.ledgerRepo.EXPECT().SumByAccountBetween(gomock.Any(), uint64(111), from, to).
+					Return(entity.MustParseMoney("-98.25"), nil)
+				fields.transactionRepo.EXPECT().IterateStatement(gomock.Any(),
+					entity.StatementFilter{AccountID: 111, From: from, To: to}, gomock.Any()).DoAndReturn(streamLines)
+			},
+			wantLines: []dto.StatementLineDTO{
+				{JournalEntryID: 3, TransactionID: &txID, Description: "transfer", BookedAt: from.AddDate(0, 0, 2),
+					Amount: entity.MustParseMoney("-101.50"), Fee: entity.MustParseMoney("1.50"), Currency: entity.CurrencyUSD,
+					CounterpartyAccountID: &counterparty, Balance: entity.MustParseMoney("898.50")},
+				{JournalEntryID: 9, Description: "interest", BookedAt: from.AddDate(0, 0, 20),
+					Amount: entity.MustParseMoney("3.25"), Currency: entity.CurrencyUSD, Balance: entity.MustParseMoney("901.75")},
+			},
+			wantSummary: &dto.StatementSummaryDTO{Entries: 2, TotalCredits: entity.MustParseMoney("3.25"),
+				TotalDebits: entity.MustParseMoney("101.50"), ClosingBalance: entity.MustParseMoney("901.75")},
+		},
+		{
+			name: "movements_do_not_add_up",
+			from: &from,
+			to:   &to,
+			setup: func(fields statementFields) {
+				expectAccount(fields)
+				fields.ledgerRepo.EXPECT().SumByAccountBetween(gomock.Any(), uint64(111), from, to).
+					Return(entity.MustParseMoney("-101.50"), nil)
+				fields.transactionRepo.EXPECT().IterateStatement(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(streamLines)
+			},
+			wantCode: apperr.ErrInternalServer.Code,
+		},
+		{
+			name: "account_not_found",
+			from: &from,
+			to:   &to,
+			setup: func(fields statementFields) {
+				fields.accountRepo.EXPECT().FindOne(gomock.Any(), uint64(111)).Return(nil, nil)
+			},
+			wantCode: apperr.ErrNotFound.Code,
+		},
+		{
+			name:     "empty_period",
+			from:     &to,
+			to:       &from,
+			setup:    func(fields statementFields) {},
+			wantCode: apperr.ErrInvalidInput.Code,
+		},
+		{
+			name:     "missing_from",
+			to:       &to,
+			setup:    func(fields statementFields) {},
+			wantCode: apperr.ErrInvalidInput.Code,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			testFields := statementFields{
+				accountRepo:     mock.NewMockAccountRepository(ctrl),
+				transactionRepo: mock.NewMockTransactionRepository(ctrl),
+				ledgerRepo:      mock.NewMockLedgerRepository(ctrl),
+				snapshotRepo:    mock.NewMockBalanceSnapshotRepository(ctrl),
+			}
+			uc := NewStatementUsecase(testFields.accountRepo, testFields.transactionRepo, testFields.ledgerRepo,
+				testFields.snapshotRepo, &mock2.MockTxManager{})
+			tt.setup(testFields)
+
+			w := &recordingStatementWriter{}
+			req := dto.StatementRequestDTO{AccountID: 111, From: tt.from, To: tt.to, Format: entity.StatementCSV}
+			err := uc.WriteStatement(context.Background(), req, w)
+			if tt.wantCode != "" {
+				var appErr apperr.AppError
+				if !errors.As(err, &appErr) || appErr.Code != tt.wantCode {
+					t.Errorf("WriteStatement() error = %v, want code %s", err, tt.wantCode)
+				}
+				if w.summary != nil {
+					t.Errorf("WriteStatement() completed a failed statement")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("WriteStatement() unexpected error = %v", err)
+			}
+			if w.header == nil || w.header.OpeningBalance != entity.MustParseMoney("1000.00") ||
+				w.header.ClosingBalance != tt.wantSummary.ClosingBalance {
+				t.Errorf("WriteStatement() header = %+v", w.header)
+			}
+			if !reflect.DeepEqual(w.lines, tt.wantLines) {
+				t.Errorf("WriteStatement() lines = %+v, want %+v", w.lines, tt.wantLines)
+			}
+			if !reflect.DeepEqual(w.summary, tt.wantSummary) {
+				t.Errorf("WriteStatement() summary = %+v, want %+v", w.summary, tt.wantSummary)
+			}
+		})
+	}
+}
@@ -0,0 +1,496 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"net"
+	"time"
+
+	"github.com/avito-tech/go-transaction-manager/trm/v2"
+
+	"transaction_demo/app/apperr"
+	"transaction_demo/app/config"
+	"transaction_demo/app/domain/entity"
+	"transaction_demo/app/domain/repository"
+	"transaction_demo/app/usecase/dto"
+)
+
+// Retry policy used when the configuration does not set webhooks.*.
+const (
+	defaultWebhookMaxAttempts    = 8
+	defaultWebhookInitialBackoff = 30 * time.Second
+	defaultWebhookMaxBackoff     = 6 * time.Hour
+)
+
+// minWebhookDeliveryLease is how long a worker may take to send a delivery it claimed before other
+// workers claim it again. The lease is at least twice the sender timeout, so it outlasts any attempt.
+const minWebhookDeliveryLease = time.Minute
+
+// defaultDeadLetterLimit is the number of dead letters listed.
+const defaultDeadLetterLimit = 100
+
+// WebhookSender sends signed webhook requests.
+type WebhookSender interface {
+	// Send posts the payload of a delivery to the endpoint, signed at sentAt, and returns the HTTP
+	// status of the response. An error means that no response was received.
+	Send(ctx context.Context, endpoint *entity.WebhookEndpoint, delivery *entity.WebhookDelivery,
+		sentAt time.Time) (int, error)
+}
+
+// WebhookUC defines the interface for webhook endpoints and the delivery of events to them.
+type WebhookUC interface {
+	// RegisterEndpoint registers a URL to receive the events of the given types.
+	RegisterEndpoint(ctx context.Context, req dto.RegisterWebhookDTO) (dto.WebhookEndpointDTO, error)
+
+	// ListEndpoints lists the registered endpoints, oldest first.
+	ListEndpoints(ctx context.Context) ([]dto.WebhookEndpointDTO, error)
+
+	// GetEndpoint retrieves a registered endpoint.
+	GetEndpoint(ctx context.Context, id uint64) (dto.WebhookEndpointDTO, error)
+
+	// DisableEndpoint stops the delivery of events to an endpoint.
+	DisableEndpoint(ctx context.Context, id uint64) (dto.WebhookEndpointDTO, error)
+
+	// ListDeadLetters lists the deliveries that failed permanently and were not replayed, newest first.
+	ListDeadLetters(ctx context.Context) ([]dto.WebhookDeadLetterDTO, error)
+
+	// ReplayDeadLetter queues a dead letter for delivery again.
+	ReplayDeadLetter(ctx context.Context, id uint64) (dto.WebhookDeliveryDTO, error)
+
+	// DeliverDue sends the deliveries due at now, at most limit of them, and returns how many were attempted.
+	DeliverDue(ctx context.Context, now time.Time, limit int) (int, error)
+}
+
+type webhookUsecase struct {
+	webhookRepo    repository.WebhookRepository
+	sender         WebhookSender
+	txManager      trm.Manager
+	maxAttempts    int
+	initialBackoff time.Duration
+	maxBackoff     time.Duration
+	lease          time.Duration
+	// allowPrivateNetworks accepts endpoints on loopback and private addresses, for local development
+	allowPrivateNetworks bool
+	lookupIP             func(ctx context.Context, host string) ([]net.IPAddr, error)
+}
+
+func NewWebhookUsecase(
+	webhookRepo repository.WebhookRepository,
+	sender WebhookSender,
+	txManager trm.Manager,
+	cf *config.Config) WebhookUC {
+	maxAttempts := cf.Webhooks.MaxAttempts
+	if maxAttempts <= 0 {
+		maxAttempts = defaultWebhookMaxAttempts
+	}
+	initialBackoff := time.Duration(cf.Webhooks.InitialBackoffSeconds) * time.Second
+	if initialBackoff <= 0 {
+		initialBackoff = defaultWebhookInitialBackoff
+	}
+	maxBackoff := time.Duration(cf.Webhooks.MaxBackoffSeconds) * time.Second
+	if maxBackoff <= 0 {
+		maxBackoff = defaultWebhookMaxBackoff
+	}
+	lease := 2 * time.Duration(cf.Webhooks.TimeoutSeconds) * time.Second
+	if lease < minWebhookDeliveryLease {
+		lease = minWebhookDeliveryLease
+	}
+	return &webhookUsecase{
+		webhookRepo:    webhookRepo,
+		sender:         sender,
+		txManager:      txManager,
+		maxAttempts:    maxAttempts,
+		initialBackoff: initialBackoff,
+		maxBackoff:     maxBackoff,
+		lease:          lease,
+
+		allowPrivateNetworks: cf.Webhooks.AllowPrivateNetworks,
+		lookupIP:             net.DefaultResolver.LookupIPAddr,
+	}
+}
+
+// RegisterEndpoint validates and stores a webhook endpoint.
+// Events recorded from now on are delivered to it; earlier events are not.
+// A secret is generated when none is given; it is only returned by this call.
+func (uc webhookUsecase) RegisterEndpoint(ctx context.Context, req dto.RegisterWebhookDTO,
+) (dto.WebhookEndpointDTO, error) {
+	err := req.Validate()
+	if err != nil {
+		fmt.Println("webhook validation failed", "error", err)
+		return dto.WebhookEndpointDTO{}, apperr.ErrInvalidInput.WithError(err).WithMessage(err.Error())
+	}
+	if err = uc.checkDestination(ctx, req.URL); err != nil {
+		return dto.WebhookEndpointDTO{}, err
+	}
+
+	secret := req.Secret
+	if secret == "" {
+		if secret, err = newWebhookSecret(); err != nil {
+			fmt.Println("failed to generate webhook secret", "error", err)
+			return dto.WebhookEndpointDTO{}, apperr.ErrInternalServer.WithError(err).WithMessage("failed to generate secret")
+		}
+	}
+	eventTypes := entity.EventTypes{}
+	for _, eventType := range req.EventTypes {
+		if !eventTypes.Contains(eventType) {
+			eventTypes = append(eventTypes, eventType)
+		}
+	}
+
+	now := time.Now()
+	endpoint, err := uc.webhookRepo.CreateEndpoint(ctx, &entity.WebhookEndpoint{
+		URL:        req.URL,
+		Secret:     secret,
+		EventTypes: eventTypes,
+		Status:     entity.WebhookActive,
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	})
+	if err != nil {
+		fmt.Println("failed to create webhook", "error", err)
+		return dto.WebhookEndpointDTO{}, apperr.ErrInternalServer.WithError(err).WithMessage("failed to create webhook")
+	}
+
+	res := toWebhookEndpointDTO(endpoint)
+	res.Secret = endpoint.Secret
+	return res, nil
+}
+
+// checkDestination refuses webhook URLs that are not HTTPS or whose host resolves to a loopback,
+// link-local or private address. The sender checks the address again when it connects, since the
+// host can resolve to another address by then.
+func (uc webhookUsecase) checkDestination(ctx context.Context, rawURL string) error {
+	host, err := entity.ParseWebhookURL(rawURL)
+	if err != nil {
+		fmt.Println("invalid webhook URL", "error", err)
+		return apperr.ErrInvalidInput.WithError(err).WithMessage(err.Error())
+	}
+	if uc.allowPrivateNetworks {
+		return nil
+	}
+
+	addrs, err := uc.lookupIP(ctx, host)
+	if err != nil {
+		fmt.Println("failed to resolve webhook host", "host", host, "error", err)
+		return apperr.ErrInvalidInput.WithError(err).WithMessage("webhook URL host does not resolve")
+	}
+	for _, addr := range addrs {
+		if !entity.IsPublicWebhookAddress(addr.IP) {
+			fmt.Println("webhook host is not public", "host", host, "address", addr.IP)
+			return apperr.ErrInvalidInput.WithError(entity.ErrWebhookDestination).
+				WithMessage("webhook URL must not point to a loopback, link-local or private address")
+		}
+	}
+	return nil
+}
+
+// ListEndpoints returns the registered endpoints, without their secrets.
+func (uc webhookUsecase) ListEndpoints(ctx context.Context) ([]dto.WebhookEndpointDTO, error) {
+	endpoints, err := uc.webhookRepo.FindEndpoints(ctx)
+	if err != nil {
+		fmt.Println("failed to find webhooks", "error", err)
+		return nil, apperr.ErrInternalServer.WithError(err).WithMessage("failed to find webhooks")
+	}
+
+	res := make([]dto.WebhookEndpointDTO, 0, len(endpoints))
+	for _, endpoint := range endpoints {
+		res = append(res, toWebhookEndpointDTO(endpoint))
+	}
+	return res, nil
+}
+
+// GetEndpoint returns a registered endpoint, without its secret.
+func (uc webhookUsecase) GetEndpoint(ctx context.Context, id uint64) (dto.WebhookEndpointDTO, error) {
+	endpoint, err := uc.findEndpoint(ctx, id)
+	if err != nil {
+		return dto.WebhookEndpointDTO{}, err
+	}
+	return toWebhookEndpointDTO(endpoint), nil
+}
+
+// DisableEndpoint disables an endpoint. It receives no new events, and its pending deliveries
+// are moved to the dead letters when they fall due.
+func (uc webhookUsecase) DisableEndpoint(ctx context.Context, id uint64) (dto.WebhookEndpointDTO, error) {
+	endpoint, err := uc.findEndpoint(ctx, id)
+	if err != nil {
+		return dto.WebhookEndpointDTO{}, err
+	}
+	if endpoint.Status == entity.WebhookDisabled {
+		return toWebhookEndpointDTO(endpoint), nil
+	}
+
+	endpoint.Status = entity.WebhookDisabled
+	endpoint.UpdatedAt = time.Now()
+	if err = uc.webhookRepo.UpdateEndpoint(ctx, endpoint); err != nil {
+		fmt.Println("failed to update webhook", "error", err)
+		return dto.WebhookEndpointDTO{}, apperr.ErrInternalServer.WithError(err).WithMessage("failed to update webhook")
+	}
+	return toWebhookEndpointDTO(endpoint), nil
+}
+
+// ListDeadLetters returns the latest dead letters not replayed yet.
+func (uc webhookUsecase) ListDeadLetters(ctx context.Context) ([]dto.WebhookDeadLetterDTO, error) {
+	deadLetters, err := uc.webhookRepo.FindDeadLetters(ctx, defaultDeadLetterLimit)
+	if err != nil {
+		fmt.Println("failed to find dead letters", "error", err)
+		return nil, apperr.ErrInternalServer.WithError(err).WithMessage("failed to find dead letters")
+	}
+
+	res := make([]dto.WebhookDeadLetterDTO, 0, len(deadLetters))
+	for _, deadLetter := range deadLetters {
+		res = append(res, dto.WebhookDeadLetterDTO{
+			DeadLetterID:   deadLetter.ID,
+			WebhookID:      deadLetter.EndpointID,
+			EventID:        deadLetter.EventID,
+			EventType:      deadLetter.EventType,
+			Attempts:       deadLetter.Attempts,
+			LastStatusCode: deadLetter.LastStatusCode,
+			LastError:      deadLetter.LastError,
+			CreatedAt:      deadLetter.CreatedAt,
+		})
+	}
+	return res, nil
+}
+
+// ReplayDeadLetter queues a dead letter as a new delivery, due now, with a fresh retry budget.
+// A dead letter is replayed at most once, and only to an active endpoint.
+func (uc webhookUsecase) ReplayDeadLetter(ctx context.Context, id uint64) (dto.WebhookDeliveryDTO, error) {
+	var delivery *entity.WebhookDelivery
+	err := uc.txManager.Do(ctx, func(ctx context.Context) error {
+		deadLetter, err := uc.webhookRepo.FindDeadLetterForUpdate(ctx, id)
+		if err != nil {
+			fmt.Println("failed to find dead letter", "error", err)
+			return apperr.ErrInternalServer.WithError(err).WithMessage("failed to find dead letter")
+		}
+		if deadLetter == nil {
+			fmt.Println("dead letter not found", "dead_letter_id", id)
+			return apperr.ErrNotFound.WithMessage("dead letter not found")
+		}
+		if deadLetter.ReplayedAt != nil {
+			fmt.Println("dead letter already replayed", "dead_letter_id", id)
+			return apperr.ErrAlreadyExists.WithMessage("dead letter already replayed")
+		}
+
+		endpoint, err := uc.findEndpoint(ctx, deadLetter.EndpointID)
+		if err != nil {
+			return err
+		}
+		if endpoint.Status != entity.WebhookActive {
+			fmt.Println("webhook is disabled", "webhook_id", endpoint.ID)
+			return apperr.ErrAlreadyExists.WithMessage("webhook is disabled")
+		}
+
+		now := time.Now()
+		delivery, err = uc.webhookRepo.CreateDelivery(ctx, &entity.WebhookDelivery{
+			EndpointID:    deadLetter.EndpointID,
+			EventID:       deadLetter.EventID,
+			EventType:     deadLetter.EventType,
+			Payload:       deadLetter.Payload,
+			NextAttemptAt: &now,
+			CreatedAt:     now,
+			UpdatedAt:     now,
+		})
+		if err != nil {
+			fmt.Println("failed to create webhook delivery", "error", err)
+			return apperr.ErrInternalServer.WithError(err).WithMessage("failed to create webhook delivery")
+		}
+
+		deadLetter.ReplayedAt = &now
+		if err = uc.webhookRepo.UpdateDeadLetter(ctx, deadLetter); err != nil {
+			fmt.Println("failed to update dead letter", "error", err)
+			return apperr.ErrInternalServer.WithError(err).WithMessage("failed to update dead letter")
+		}
+		return nil
+	})
+	if err != nil {
+		return dto.WebhookDeliveryDTO{}, err
+	}
+
+	return dto.WebhookDeliveryDTO{
+		DeliveryID:    delivery.ID,
+		WebhookID:     delivery.EndpointID,
+		EventID:       delivery.EventID,
+		EventType:     delivery.EventType,
+		Attempts:      delivery.Attempts,
+		NextAttemptAt: delivery.NextAttemptAt,
+		CreatedAt:     delivery.CreatedAt,
+	}, nil
+}
+
+// DeliverDue sends due deliveries one at a time.
+//
+// A delivery is claimed in a short DB transaction, which leases it by setting its next attempt to
+// the end of the lease, and sent after that transaction committed, so that no lock or connection
+// is held while the receiver answers:
+// - Other workers skip leased deliveries, as they are not due, so a delivery is sent by one worker at a time
+// - The outcome is recorded once the receiver answered or the sender timed out, in a second short write
+// - A worker stopped while sending leaves the delivery leased; it is sent again once the lease expires
+func (uc webhookUsecase) DeliverDue(ctx context.Context, now time.Time, limit int) (int, error) {
+	attempted := 0
+	for attempted < limit {
+		delivery, endpoint, err := uc.claimDue(ctx, now)
+		if err != nil {
+			return attempted, err
+		}
+		if delivery == nil {
+			break
+		}
+		attempted++
+		if endpoint == nil {
+			continue
+		}
+		if err = uc.deliver(ctx, endpoint, delivery); err != nil {
+			return attempted, err
+		}
+	}
+	return attempted, nil
+}
+
+// claimDue locks the delivery due the longest, with its endpoint, and leases it. A delivery to a
+// disabled endpoint is moved to the dead letters instead, and returned without an endpoint.
+func (uc webhookUsecase) claimDue(ctx context.Context, now time.Time,
+) (*entity.WebhookDelivery, *entity.WebhookEndpoint, error) {
+	var (
+		delivery *entity.WebhookDelivery
+		endpoint *entity.WebhookEndpoint
+	)
+	err := uc.txManager.Do(ctx, func(ctx context.Context) error {
+		var err error
+		delivery, err = uc.webhookRepo.ClaimDueDelivery(ctx, now)
+		if err != nil {
+			fmt.Println("failed to claim webhook delivery", "error", err)
+			return apperr.ErrInternalServer.WithError(err).WithMessage("failed to claim webhook delivery")
+		}
+		if delivery == nil {
+			return nil
+		}
+
+		endpoint, err = uc.webhookRepo.FindEndpoint(ctx, delivery.EndpointID)
+		if err != nil {
+			fmt.Println("failed to find webhook", "error", err)
+			return apperr.ErrInternalServer.WithError(err).WithMessage("failed to find webhook")
+		}
+		if endpoint == nil || endpoint.Status != entity.WebhookActive {
+			endpoint = nil
+			delivery.LastError = "webhook is disabled"
+			return uc.deadLetter(ctx, delivery)
+		}
+
+		leasedUntil := time.Now().Add(uc.lease)
+		delivery.NextAttemptAt = &leasedUntil
+		return uc.updateDelivery(ctx, delivery)
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return delivery, endpoint, nil
+}
+
+// deliver makes one attempt at a leased delivery and records its outcome:
+// - A 2xx response marks the delivery delivered
+// - Any other response, or no response, schedules a retry with exponential backoff
+// - The last allowed attempt moves it to the dead letters
+//
+// The request is signed when it is sent, not when the batch was claimed: a batch can take longer
+// than the tolerance receivers allow on the signature timestamp.
+func (uc webhookUsecase) deliver(ctx context.Context, endpoint *entity.WebhookEndpoint,
+	delivery *entity.WebhookDelivery) error {
+	status, sendErr := uc.sender.Send(ctx, endpoint, delivery, time.Now())
+	now := time.Now()
+	delivery.Attempts++
+	delivery.LastStatusCode = status
+	delivery.UpdatedAt = now
+	switch {
+	case sendErr == nil && status >= 200 && status < 300:
+		delivery.LastError = ""
+		delivery.DeliveredAt = &now
+		delivery.NextAttemptAt = nil
+		return uc.updateDelivery(ctx, delivery)
+	case sendErr != nil:
+		delivery.LastError = sendErr.Error()
+	default:
+		delivery.LastError = fmt.Sprintf("webhook answered %d", status)
+	}
+	fmt.Println("webhook delivery failed", "delivery_id", delivery.ID, "attempts", delivery.Attempts,
+		"error", delivery.LastError)
+
+	if delivery.Attempts >= uc.maxAttempts {
+		// The dead letter and the removal of the delivery are made together
+		return uc.txManager.Do(ctx, func(ctx context.Context) error {
+			return uc.deadLetter(ctx, delivery)
+		})
+	}
+	next := now.Add(uc.backoff(delivery.Attempts))
+	delivery.NextAttemptAt = &next
+	return uc.updateDelivery(ctx, delivery)
+}
+
+// backoff returns the wait before the retry following the given number of attempts:
+// the initial backoff, doubled after every further attempt, up to the maximum.
+func (uc webhookUsecase) backoff(attempts int) time.Duration {
+	wait := uc.initialBackoff
+	for i := 1; i < attempts && wait < uc.maxBackoff; i++ {
+		wait *= 2
+	}
+	if wait > uc.maxBackoff {
+		wait = uc.maxBackoff
+	}
+	return wait
+}
+
+// deadLetter moves a delivery to the dead letters.
+func (uc webhookUsecase) deadLetter(ctx context.Context, delivery *entity.WebhookDelivery) error {
+	fmt.Println("webhook delivery dead-lettered", "delivery_id", delivery.ID, "error", delivery.LastError)
+	if _, err := uc.webhookRepo.CreateDeadLetter(ctx, entity.NewWebhookDeadLetter(delivery)); err != nil {
+		fmt.Println("failed to create dead letter", "error", err)
+		return apperr.ErrInternalServer.WithError(err).WithMessage("failed to create dead letter")
+	}
+	if err := uc.webhookRepo.DeleteDelivery(ctx, delivery.ID); err != nil {
+		fmt.Println("failed to delete webhook delivery", "error", err)
+		return apperr.ErrInternalServer.WithError(err).WithMessage("failed to delete webhook delivery")
+	}
+	return nil
+}
+
+func (uc webhookUsecase) updateDelivery(ctx context.Context, delivery *entity.WebhookDelivery) error {
+	if err := uc.webhookRepo.UpdateDelivery(ctx, delivery); err != nil {
+		fmt.Println("failed to update webhook delivery", "error", err)
+		return apperr.ErrInternalServer.WithError(err).WithMessage("failed to update webhook delivery")
+	}
+	return nil
+}
+
+func (uc webhookUsecase) findEndpoint(ctx context.Context, id uint64) (*entity.WebhookEndpoint, error) {
+	endpoint, err := uc.webhookRepo.FindEndpoint(ctx, id)
+	if err != nil {
+		fmt.Println("failed to find webhook", "error", err)
+		return nil, apperr.ErrInternalServer.WithError(err).WithMessage("failed to find webhook")
+	}
+	if endpoint == nil {
+		fmt.Println("webhook not found", "webhook_id", id)
+		return nil, apperr.ErrNotFound.WithMessage("webhook not found")
+	}
+	return endpoint, nil
+}
+
+// newWebhookSecret returns a random secret of 32 bytes, hex encoded behind a whsec_ prefix.
+func newWebhookSecret() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return "whsec_" + hex.EncodeToString(b), nil
+}
+
+func toWebhookEndpointDTO(endpoint *entity.WebhookEndpoint) dto.WebhookEndpointDTO {
+	return dto.WebhookEndpointDTO{
+		WebhookID:  endpoint.ID,
+		URL:        endpoint.URL,
+		EventTypes: endpoint.EventTypes,
+		Status:     endpoint.Status,
+		CreatedAt:  endpoint.CreatedAt,
+		UpdatedAt:  endpoint.UpdatedAt,
+	}
+}
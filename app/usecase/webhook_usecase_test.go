@@ -0,0 +1,448 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+
+	"transaction_demo/app/apperr"
+	"transaction_demo/app/config"
+	"transaction_demo/app/domain/entity"
+	"transaction_demo/app/domain/repository/mock"
+	"transaction_demo/app/usecase/dto"
+	mock2 "transaction_demo/cmd/shared/db/mock"
+)
+
+// stubWebhookSender answers every request with a function.
+type stubWebhookSender struct {
+	send func(endpoint *entity.WebhookEndpoint, delivery *entity.WebhookDelivery) (int, error)
+	// sentAt records the signing time of the last request
+	sentAt *time.Time
+}
+
+func (s stubWebhookSender) Send(_ context.Context, endpoint *entity.WebhookEndpoint,
+	delivery *entity.WebhookDelivery, sentAt time.Time) (int, error) {
+	if s.sentAt != nil {
+		*s.sentAt = sentAt
+	}
+	return s.send(endpoint, delivery)
+}
+
+func newTestWebhookUsecase(ctrl *gomock.Controller, sender WebhookSender,
+) (WebhookUC, *mock.MockWebhookRepository) {
+	webhookRepo := mock.NewMockWebhookRepository(ctrl)
+	cf := &config.Config{Webhooks: config.Webhooks{MaxAttempts: 3, InitialBackoffSeconds: 30, MaxBackoffSeconds: 3600}}
+	uc := NewWebhookUsecase(webhookRepo, sender, &mock2.MockTxManager{}, cf).(*webhookUsecase)
+	uc.lookupIP = stubLookupIP
+	return uc, webhookRepo
+}
+
+// stubLookupIP resolves IP literals and a few test hosts without DNS.
+func stubLookupIP(_ context.Context, host string) ([]net.IPAddr, error) {
+	if ip := net.ParseIP(host); ip != nil {
+		return []net.IPAddr{{IP: ip}}, nil
+	}
+	switch host {
+	case "example.com":
+		return []net.IPAddr{{IP: net.ParseIP("93.184.215.14")}}, nil
+	case "internal.example.com":
+		return []net.IPAddr{{IP: net.ParseIP("93.184.215.14")}, {IP: net.ParseIP("10.0.0.5")}}, nil
+	}
+	return nil, &net.DNSError{Err: "no such host", Name: host, IsNotFound: true}
+}
+
+func Test_webhookUsecase_RegisterEndpoint(t *testing.T) {
+	tests := []struct {
+		name       string
+		req        dto.RegisterWebhookDTO
+		setup      func(webhookRepo *mock.MockWebhookRepository)
+		wantSecret string
+		wantCode   string
+	}{
+		{
+			name: "success",
+			req: dto.RegisterWebhookDTO{URL: "https://example.com/hooks", Secret: "0123456789abcdef",
+				EventTypes: []entity.EventType{entity.EventTransferPosted, entity.EventTransferPosted}},
+			setup: func(webhookRepo *mock.MockWebhookRepository) {
+				webhookRepo.EXPECT().CreateEndpoint(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, e *entity.WebhookEndpoint) (*entity.WebhookEndpoint, error) {
+						if e.Status != entity.WebhookActive || len(e.EventTypes) != 1 {
+							t.Errorf("unexpected endpoint: %+v", e)
+						}
+						e.ID = 1
+						return e, nil
+					})
+			},
+			wantSecret: "0123456789abcdef",
+		},
+		{
+			name: "generated_secret",
+			req: dto.RegisterWebhookDTO{URL: "https://example.com/hooks",
+				EventTypes: []entity.EventType{entity.EventAccountCreated}},
+			setup: func(webhookRepo *mock.MockWebhookRepository) {
+				webhookRepo.EXPECT().CreateEndpoint(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, e *entity.WebhookEndpoint) (*entity.WebhookEndpoint, error) {
+						return e, nil
+					})
+			},
+			wantSecret: "whsec_",
+		},
+		{
+			name: "unknown_event_type",
+			req: dto.RegisterWebhookDTO{URL: "https://example.com/hooks",
+				EventTypes: []entity.EventType{"account.deleted"}},
+			setup:    func(webhookRepo *mock.MockWebhookRepository) {},
+			wantCode: apperr.ErrInvalidInput.Code,
+		},
+		{
+			name:     "invalid_url",
+			req:      dto.RegisterWebhookDTO{URL: "ftp://example.com", EventTypes: []entity.EventType{entity.EventTransferPosted}},
+			setup:    func(webhookRepo *mock.MockWebhookRepository) {},
+			wantCode: apperr.ErrInvalidInput.Code,
+		},
+		{
+			name:     "plain_http",
+			req:      dto.RegisterWebhookDTO{URL: "http://example.com/hooks", EventTypes: []entity.EventType{entity.EventTransferPosted}},
+			setup:    func(webhookRepo *mock.MockWebhookRepository) {},
+			wantCode: apperr.ErrInvalidInput.Code,
+		},
+		{
+			name: "loopback_address",
+			req: dto.RegisterWebhookDTO{URL: "https://127.0.0.1:8443/hooks",
+				EventTypes: []entity.EventType{entity.EventTransferPosted}},
+			setup:    func(webhookRepo *mock.MockWebhookRepository) {},
+			wantCode: apperr.ErrInvalidInput.Code,
+		},
+		{
+			name: "cloud_metadata_address",
+			req: dto.RegisterWebhookDTO{URL: "https://169.254.169.254/latest/meta-data",
+				EventTypes: []entity.EventType{entity.EventTransferPosted}},
+			setup:    func(webhookRepo *mock.MockWebhookRepository) {},
+			wantCode: apperr.ErrInvalidInput.Code,
+		},
+		{
+			// One private address among the answers is enough to refuse the host
+			name: "host_resolving_to_private_address",
+			req: dto.RegisterWebhookDTO{URL: "https://internal.example.com/hooks",
+				EventTypes: []entity.EventType{entity.EventTransferPosted}},
+			setup:    func(webhookRepo *mock.MockWebhookRepository) {},
+			wantCode: apperr.ErrInvalidInput.Code,
+		},
+		{
+			name: "unresolvable_host",
+			req: dto.RegisterWebhookDTO{URL: "https://unknown.example.com/hooks",
+				EventTypes: []entity.EventType{entity.EventTransferPosted}},
+			setup:    func(webhookRepo *mock.MockWebhookRepository) {},
+			wantCode: apperr.ErrInvalidInput.Code,
+		},
+		{
+			name: "create_error",
+			req: dto.RegisterWebhookDTO{URL: "https://example.com/hooks",
+				EventTypes: []entity.EventType{entity.EventTransferPosted}},
+			setup: func(webhookRepo *mock.MockWebhookRepository) {
+				webhookRepo.EXPECT().CreateEndpoint(gomock.Any(), gomock.Any()).Return(nil, errors.New("database error"))
+			},
+			wantCode: apperr.ErrInternalServer.Code,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			uc, webhookRepo := newTestWebhookUsecase(ctrl, nil)
+			tt.setup(webhookRepo)
+
+			got, err := uc.RegisterEndpoint(context.Background(), tt.req)
+			if tt.wantCode != "" {
+				var appErr apperr.AppError
+				if !errors.As(err, &appErr) || appErr.Code != tt.wantCode {
+					t.Errorf("RegisterEndpoint() error = %v, want code %s", err, tt.wantCode)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("RegisterEndpoint() unexpected error = %v", err)
+			}
+			if !strings.HasPrefix(got.Secret, tt.wantSecret) || len(got.Secret) < 16 {
+				t.Errorf("RegisterEndpoint() secret = %q, want prefix %q", got.Secret, tt.wantSecret)
+			}
+		})
+	}
+}
+
+func Test_webhookUsecase_GetEndpoint(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	uc, webhookRepo := newTestWebhookUsecase(ctrl, nil)
+	webhookRepo.EXPECT().FindEndpoint(gomock.Any(), uint64(1)).Return(&entity.WebhookEndpoint{
+		ID: 1, URL: "https://example.com/hooks", Secret: "0123456789abcdef", Status: entity.WebhookActive}, nil)
+	webhookRepo.EXPECT().FindEndpoint(gomock.Any(), uint64(2)).Return(nil, nil)
+
+	got, err := uc.GetEndpoint(context.Background(), 1)
+	if err != nil || got.WebhookID != 1 || got.Secret != "" {
+		t.Errorf("GetEndpoint() got = %+v, error = %v; the secret must not be returned", got, err)
+	}
+	var appErr apperr.AppError
+	if _, err = uc.GetEndpoint(context.Background(), 2); !errors.As(err, &appErr) || appErr.Code != apperr.ErrNotFound.Code {
+		t.Errorf("GetEndpoint() error = %v, want not found", err)
+	}
+}
+
+func Test_webhookUsecase_DeliverDue(t *testing.T) {
+	// Deliveries are claimed as of now and signed when sent, later
+	now := time.Date(2025, 9, 20, 12, 0, 0, 0, time.UTC)
+	var sentAt time.Time
+	active := &entity.WebhookEndpoint{ID: 1, URL: "https://example.com/hooks", Status: entity.WebhookActive}
+	due := func(attempts int) *entity.WebhookDelivery {
+		return &entity.WebhookDelivery{ID: 10, EndpointID: 1, EventID: 7, EventType: entity.EventTransferPosted,
+			Attempts: attempts, NextAttemptAt: &now}
+	}
+	// leased expects the claimed delivery to be leased before it is sent
+	leased := func(webhookRepo *mock.MockWebhookRepository) *gomock.Call {
+		return webhookRepo.EXPECT().UpdateDelivery(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, d *entity.WebhookDelivery) error {
+				if !sentAt.IsZero() || d.NextAttemptAt == nil ||
+					d.NextAttemptAt.Before(time.Now().Add(minWebhookDeliveryLease-time.Second)) {
+					t.Errorf("delivery not leased before it was sent: %+v", d)
+				}
+				return nil
+			})
+	}
+	tests := []struct {
+		name          string
+		send          func(endpoint *entity.WebhookEndpoint, delivery *entity.WebhookDelivery) (int, error)
+		setup         func(webhookRepo *mock.MockWebhookRepository)
+		wantAttempted int
+		wantErr       bool
+	}{
+		{
+			name: "delivered",
+			send: func(*entity.WebhookEndpoint, *entity.WebhookDelivery) (int, error) { return 200, nil },
+			setup: func(webhookRepo *mock.MockWebhookRepository) {
+				gomock.InOrder(
+					webhookRepo.EXPECT().ClaimDueDelivery(gomock.Any(), now).Return(due(0), nil),
+					webhookRepo.EXPECT().ClaimDueDelivery(gomock.Any(), now).Return(nil, nil),
+				)
+				webhookRepo.EXPECT().FindEndpoint(gomock.Any(), uint64(1)).Return(active, nil)
+				gomock.InOrder(leased(webhookRepo), webhookRepo.EXPECT().UpdateDelivery(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, d *entity.WebhookDelivery) error {
+						if d.DeliveredAt == nil || d.NextAttemptAt != nil || d.Attempts != 1 || d.LastStatusCode != 200 {
+							t.Errorf("unexpected delivery: %+v", d)
+						}
+						return nil
+					}))
+			},
+			wantAttempted: 1,
+		},
+		{
+			// The third attempt waits twice as long as the second: 30s, 60s, ...
+			name: "retried_with_backoff",
+			send: func(*entity.WebhookEndpoint, *entity.WebhookDelivery) (int, error) { return 503, nil },
+			setup: func(webhookRepo *mock.MockWebhookRepository) {
+				gomock.InOrder(
+					webhookRepo.EXPECT().ClaimDueDelivery(gomock.Any(), now).Return(due(1), nil),
+					webhookRepo.EXPECT().ClaimDueDelivery(gomock.Any(), now).Return(nil, nil),
+				)
+				webhookRepo.EXPECT().FindEndpoint(gomock.Any(), uint64(1)).Return(active, nil)
+				gomock.InOrder(leased(webhookRepo), webhookRepo.EXPECT().UpdateDelivery(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, d *entity.WebhookDelivery) error {
+						if d.DeliveredAt != nil || d.NextAttemptAt == nil || d.NextAttemptAt.Before(sentAt.Add(time.Minute)) ||
+							d.Attempts != 2 || d.LastError != "webhook answered 503" {
+							t.Errorf("unexpected delivery: %+v", d)
+						}
+						return nil
+					}))
+			},
+			wantAttempted: 1,
+		},
+		{
+			name: "dead_lettered_after_max_attempts",
+			send: func(*entity.WebhookEndpoint, *entity.WebhookDelivery) (int, error) {
+				return 0, errors.New("connection refused")
+			},
+			setup: func(webhookRepo *mock.MockWebhookRepository) {
+				gomock.InOrder(
+					webhookRepo.EXPECT().ClaimDueDelivery(gomock.Any(), now).Return(due(2), nil),
+					webhookRepo.EXPECT().ClaimDueDelivery(gomock.Any(), now).Return(nil, nil),
+				)
+				webhookRepo.EXPECT().FindEndpoint(gomock.Any(), uint64(1)).Return(active, nil)
+				leased(webhookRepo)
+				webhookRepo.EXPECT().CreateDeadLetter(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, d *entity.WebhookDeadLetter) (*entity.WebhookDeadLetter, error) {
+						if d.DeliveryID != 10 || d.Attempts != 3 || d.LastError != "connection refused" {
+							t.Errorf("unexpected dead letter: %+v", d)
+						}
+						return d, nil
+					})
+				webhookRepo.EXPECT().DeleteDelivery(gomock.Any(), uint64(10)).Return(nil)
+			},
+			wantAttempted: 1,
+		},
+		{
+			name: "disabled_endpoint_dead_lettered_unsent",
+			send: func(*entity.WebhookEndpoint, *entity.WebhookDelivery) (int, error) {
+				t.Error("a disabled endpoint must not be sent to")
+				return 200, nil
+			},
+			setup: func(webhookRepo *mock.MockWebhookRepository) {
+				gomock.InOrder(
+					webhookRepo.EXPECT().ClaimDueDelivery(gomock.Any(), now).Return(due(0), nil),
+					webhookRepo.EXPECT().ClaimDueDelivery(gomock.Any(), now).Return(nil, nil),
+				)
+				webhookRepo.EXPECT().FindEndpoint(gomock.Any(), uint64(1)).Return(&entity.WebhookEndpoint{
+					ID: 1, Status: entity.WebhookDisabled}, nil)
+				webhookRepo.EXPECT().CreateDeadLetter(gomock.Any(), gomock.Any()).Return(&entity.WebhookDeadLetter{}, nil)
+				webhookRepo.EXPECT().DeleteDelivery(gomock.Any(), uint64(10)).Return(nil)
+			},
+			wantAttempted: 1,
+		},
+		{
+			name: "stops_at_max",
+			send: func(*entity.WebhookEndpoint, *entity.WebhookDelivery) (int, error) { return 204, nil },
+			setup: func(webhookRepo *mock.MockWebhookRepository) {
+				webhookRepo.EXPECT().ClaimDueDelivery(gomock.Any(), now).
+					DoAndReturn(func(context.Context, time.Time) (*entity.WebhookDelivery, error) {
+						return due(0), nil
+					}).Times(2)
+				webhookRepo.EXPECT().FindEndpoint(gomock.Any(), uint64(1)).Return(active, nil).Times(2)
+				// Each delivery is leased, then marked delivered
+				webhookRepo.EXPECT().UpdateDelivery(gomock.Any(), gomock.Any()).Return(nil).Times(4)
+			},
+			wantAttempted: 2,
+		},
+		{
+			// The delivery was sent, so it counts as attempted; its lease expires and it is sent again
+			name: "outcome_not_recorded",
+			send: func(*entity.WebhookEndpoint, *entity.WebhookDelivery) (int, error) { return 200, nil },
+			setup: func(webhookRepo *mock.MockWebhookRepository) {
+				webhookRepo.EXPECT().ClaimDueDelivery(gomock.Any(), now).Return(due(0), nil)
+				webhookRepo.EXPECT().FindEndpoint(gomock.Any(), uint64(1)).Return(active, nil)
+				gomock.InOrder(leased(webhookRepo),
+					webhookRepo.EXPECT().UpdateDelivery(gomock.Any(), gomock.Any()).Return(errors.New("database error")))
+			},
+			wantAttempted: 1,
+			wantErr:       true,
+		},
+		{
+			name: "claim_error",
+			send: func(*entity.WebhookEndpoint, *entity.WebhookDelivery) (int, error) { return 200, nil },
+			setup: func(webhookRepo *mock.MockWebhookRepository) {
+				webhookRepo.EXPECT().ClaimDueDelivery(gomock.Any(), now).Return(nil, errors.New("database error"))
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			sentAt = time.Time{}
+			uc, webhookRepo := newTestWebhookUsecase(ctrl, stubWebhookSender{send: tt.send, sentAt: &sentAt})
+			tt.setup(webhookRepo)
+
+			started := time.Now()
+			got, err := uc.DeliverDue(context.Background(), now, 2)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("DeliverDue() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !sentAt.IsZero() && sentAt.Before(started) {
+				t.Errorf("DeliverDue() signed at %v, before the deliveries were sent", sentAt)
+			}
+			if got != tt.wantAttempted {
+				t.Errorf("DeliverDue() got = %d, want %d", got, tt.wantAttempted)
+			}
+		})
+	}
+}
+
+func Test_webhookUsecase_ReplayDeadLetter(t *testing.T) {
+	replayedAt := time.Now().Add(-time.Hour)
+	deadLetter := func(replayedAt *time.Time) *entity.WebhookDeadLetter {
+		return &entity.WebhookDeadLetter{ID: 5, DeliveryID: 10, EndpointID: 1, EventID: 7,
+			EventType: entity.EventTransferPosted, Payload: []byte(`{}`), Attempts: 8, ReplayedAt: replayedAt}
+	}
+	tests := []struct {
+		name     string
+		setup    func(webhookRepo *mock.MockWebhookRepository)
+		wantCode string
+	}{
+		{
+			name: "success",
+			setup: func(webhookRepo *mock.MockWebhookRepository) {
+				webhookRepo.EXPECT().FindDeadLetterForUpdate(gomock.Any(), uint64(5)).Return(deadLetter(nil), nil)
+				webhookRepo.EXPECT().FindEndpoint(gomock.Any(), uint64(1)).Return(&entity.WebhookEndpoint{
+					ID: 1, Status: entity.WebhookActive}, nil)
+				webhookRepo.EXPECT().CreateDelivery(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, d *entity.WebhookDelivery) (*entity.WebhookDelivery, error) {
+						if d.Attempts != 0 || d.NextAttemptAt == nil || d.EventID != 7 || d.EndpointID != 1 {
+							t.Errorf("unexpected delivery: %+v", d)
+						}
+						d.ID = 11
+						return d, nil
+					})
+				webhookRepo.EXPECT().UpdateDeadLetter(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, d *entity.WebhookDeadLetter) error {
+						if d.ReplayedAt == nil {
+							t.Errorf("dead letter not marked replayed")
+						}
+						return nil
+					})
+			},
+		},
+		{
+			name: "not_found",
+			setup: func(webhookRepo *mock.MockWebhookRepository) {
+				webhookRepo.EXPECT().FindDeadLetterForUpdate(gomock.Any(), uint64(5)).Return(nil, nil)
+			},
+			wantCode: apperr.ErrNotFound.Code,
+		},
+		{
+			name: "already_replayed",
+			setup: func(webhookRepo *mock.MockWebhookRepository) {
+				webhookRepo.EXPECT().FindDeadLetterForUpdate(gomock.Any(), uint64(5)).Return(deadLetter(&replayedAt), nil)
+			},
+			wantCode: apperr.ErrAlreadyExists.Code,
+		},
+		{
+			name: "endpoint_disabled",
+			setup: func(webhookRepo *mock.MockWebhookRepository) {
+				webhookRepo.EXPECT().FindDeadLetterForUpdate(gomock.Any(), uint64(5)).Return(deadLetter(nil), nil)
+				webhookRepo.EXPECT().FindEndpoint(gomock.Any(), uint64(1)).Return(&entity.WebhookEndpoint{
+					ID: 1, Status: entity.WebhookDisabled}, nil)
+			},
+			wantCode: apperr.ErrAlreadyExists.Code,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			uc, webhookRepo := newTestWebhookUsecase(ctrl, nil)
+			tt.setup(webhookRepo)
+
+			got, err := uc.ReplayDeadLetter(context.Background(), 5)
+			if tt.wantCode != "" {
+				var appErr apperr.AppError
+				if !errors.As(err, &appErr) || appErr.Code != tt.wantCode {
+					t.Errorf("ReplayDeadLetter() error = %v, want code %s", err, tt.wantCode)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ReplayDeadLetter() unexpected error = %v", err)
+			}
+			if got.DeliveryID != 11 || got.WebhookID != 1 {
+				t.Errorf("ReplayDeadLetter() got = %+v", got)
+			}
+		})
+	}
+}
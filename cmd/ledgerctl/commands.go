@@ -0,0 +1,222 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"transaction_demo/app/domain/entity"
+	"transaction_demo/app/usecase"
+	"transaction_demo/app/usecase/dto"
+)
+
+const usage = `Usage: ledgerctl <command> [flags]
+
+Commands:
+  create     open an account
+  balance    show the balance of an account
+  transfer   move money between two accounts
+  history    list the transactions of an account, newest first
+  freeze     block debits from an account
+  unfreeze   make a frozen account active again
+
+Run ledgerctl <command> -h for the flags of a command.
+`
+
+// errHelp is returned when help was asked for and written.
+var errHelp = flag.ErrHelp
+
+// transferIdempotencyScope is the scope the HTTP API records the idempotency keys of
+// POST /api/v1/transactions under, so that a key sent to either makes the transfer once.
+const transferIdempotencyScope = http.MethodPost + " /api/v1/transactions/"
+
+// usecases are the usecases the commands run.
+type usecases struct {
+	account     usecase.AccountUC
+	idempotency usecase.IdempotencyUC
+}
+
+// command is a parsed command line, run once the usecases are initialized.
+type command struct {
+	name   string
+	output outputFormat
+	run    func(ctx context.Context, uc usecases) (result, error)
+}
+
+// parseCommand parses the command line without the program name. Usage errors are written to stderr
+// and returned; help is written to stderr and errHelp returned.
+func parseCommand(args []string, stderr io.Writer) (command, error) {
+	if len(args) == 0 {
+		fmt.Fprint(stderr, usage)
+		return command{}, errors.New("no command given")
+	}
+	name := args[0]
+	if name == "help" || name == "-h" || name == "-help" || name == "--help" {
+		fmt.Fprint(stderr, usage)
+		return command{}, errHelp
+	}
+
+	fs := flag.NewFlagSet("ledgerctl "+name, flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	output := fs.String("o", string(outputTable), "output format: table or json")
+	var (
+		run      func(ctx context.Context, uc usecases) (result, error)
+		required []string
+	)
+	switch name {
+	case "create":
+		var req dto.AccountDTO
+		fs.Uint64Var(&req.AccountID, "account", 0, "account ID")
+		moneyVar(fs, &req.Balance, "balance", "opening balance, e.g. 1000.00")
+		fs.Func("currency", "ISO 4217 currency, USD by default", func(s string) error {
+			req.Currency = entity.Currency(s)
+			return nil
+		})
+		fs.Func("type", "checking, the default, or savings", func(s string) error {
+			req.Type = entity.AccountType(s)
+			return nil
+		})
+		required = []string{"account", "balance"}
+		run = func(ctx context.Context, uc usecases) (result, error) {
+			account, err := uc.account.Create(ctx, req)
+			return accountResult(account), err
+		}
+	case "balance", "freeze", "unfreeze":
+		accountID := fs.Uint64("account", 0, "account ID")
+		required = []string{"account"}
+		run = func(ctx context.Context, uc usecases) (result, error) {
+			var (
+				account dto.AccountDTO
+				err     error
+			)
+			switch name {
+			case "freeze":
+				account, err = uc.account.FreezeAccount(ctx, *accountID)
+			case "unfreeze":
+				account, err = uc.account.UnfreezeAccount(ctx, *accountID)
+			default:
+				account, err = uc.account.GetBalance(ctx, *accountID)
+			}
+			return accountResult(account), err
+		}
+	case "transfer":
+		var req dto.TransactionDTO
+		fs.Uint64Var(&req.SourceAccountID, "from", 0, "source account ID")
+		fs.Uint64Var(&req.DestinationAccountID, "to", 0, "destination account ID")
+		moneyVar(fs, &req.Amount, "amount", "amount to transfer, e.g. 100.50")
+		fs.Func("currency", "currency of the amount; must match the source account when set", func(s string) error {
+			req.Currency = entity.Currency(s)
+			return nil
+		})
+		fs.StringVar(&req.QuoteID, "quote", "", "FX quote ID, for a cross-currency transfer")
+		idempotencyKey := fs.String("idempotency-key", "",
+			"makes the transfer safe to retry, like the Idempotency-Key header of the HTTP API")
+		required = []string{"from", "to", "amount"}
+		run = func(ctx context.Context, uc usecases) (result, error) {
+			if *idempotencyKey == "" {
+				transaction, err := uc.account.MakeTransaction(ctx, req)
+				return transactionResult(transaction), err
+			}
+			return transferIdempotent(ctx, uc, req, *idempotencyKey)
+		}
+	case "history":
+		var req dto.TransactionListDTO
+		fs.Uint64Var(&req.AccountID, "account", 0, "account ID")
+		fs.Func("direction", "incoming or outgoing; both by default", func(s string) error {
+			req.Direction = entity.TransactionDirection(s)
+			return nil
+		})
+		timeVar(fs, &req.From, "from", "earliest transaction time, YYYY-MM-DD or RFC 3339, inclusive")
+		timeVar(fs, &req.To, "to", "latest transaction time, YYYY-MM-DD or RFC 3339, exclusive")
+		fs.StringVar(&req.Cursor, "cursor", "", "cursor of the next page, as printed below the previous one")
+		fs.IntVar(&req.Limit, "limit", 0, "page size, 1 to 100; 20 by default")
+		required = []string{"account"}
+		run = func(ctx context.Context, uc usecases) (result, error) {
+			transactions, meta, err := uc.account.ListTransactions(ctx, req)
+			return historyResult(transactions, meta), err
+		}
+	default:
+		fmt.Fprint(stderr, usage)
+		return command{}, fmt.Errorf("unknown command %q", name)
+	}
+
+	if err := fs.Parse(args[1:]); err != nil {
+		return command{}, err
+	}
+	if fs.NArg() > 0 {
+		return command{}, usageError(fs, "unexpected arguments %v", fs.Args())
+	}
+	set := make(map[string]bool)
+	fs.Visit(func(f *flag.Flag) { set[f.Name] = true })
+	for _, flagName := range required {
+		if !set[flagName] {
+			return command{}, usageError(fs, "flag is required: -%s", flagName)
+		}
+	}
+	format := outputFormat(*output)
+	if format != outputTable && format != outputJSON {
+		return command{}, usageError(fs, "invalid value %q for flag -o: must be table or json", *output)
+	}
+	return command{name: name, output: format, run: run}, nil
+}
+
+// transferIdempotent makes a transfer under an idempotency key, with the statuses the HTTP API
+// records, so that a retry through either replays the transfer made first.
+func transferIdempotent(ctx context.Context, uc usecases, req dto.TransactionDTO, key string) (result, error) {
+	res, err := uc.idempotency.Execute(ctx, dto.IdempotencyDTO{Key: key, Scope: transferIdempotencyScope, Payload: req},
+		func(txCtx context.Context) (int, interface{}, error) {
+			transaction, err := uc.account.MakeTransaction(txCtx, req)
+			if transaction.Status == entity.TransactionPending {
+				return http.StatusAccepted, transaction, err
+			}
+			return http.StatusCreated, transaction, err
+		})
+	if err != nil {
+		return result{}, err
+	}
+
+	var transaction dto.TransactionRecordDTO
+	if err = json.Unmarshal(res.Body, &transaction); err != nil {
+		return result{}, fmt.Errorf("decode the recorded transfer: %w", err)
+	}
+	out := transactionResult(transaction)
+	if res.Replayed {
+		out.footer = "replayed: a transfer was already made with this idempotency key"
+	}
+	return out, nil
+}
+
+// usageError writes an error and the usage of a command, the way the flag package reports invalid flags.
+func usageError(fs *flag.FlagSet, format string, a ...interface{}) error {
+	err := fmt.Errorf(format, a...)
+	fmt.Fprintln(fs.Output(), err)
+	fs.Usage()
+	return err
+}
+
+// moneyVar defines a flag holding an amount such as 100.50.
+func moneyVar(fs *flag.FlagSet, m *entity.Money, name, usage string) {
+	fs.Func(name, usage, func(s string) (err error) {
+		*m, err = entity.ParseMoney(s)
+		return err
+	})
+}
+
+// timeVar defines a flag holding a date, read as midnight UTC, or an RFC 3339 timestamp.
+func timeVar(fs *flag.FlagSet, t **time.Time, name, usage string) {
+	fs.Func(name, usage, func(s string) error {
+		parsed, err := time.Parse(dto.DateLayout, s)
+		if err != nil {
+			if parsed, err = time.Parse(time.RFC3339, s); err != nil {
+				return errors.New("must be YYYY-MM-DD or an RFC 3339 timestamp")
+			}
+		}
+		*t = &parsed
+		return nil
+	})
+}
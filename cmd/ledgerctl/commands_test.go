@@ -0,0 +1,246 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"transaction_demo/app/domain/entity"
+	"transaction_demo/app/usecase"
+	"transaction_demo/app/usecase/dto"
+)
+
+// stubAccountUC records the requests of the commands; the AccountUC methods not used by ledgerctl are not called.
+type stubAccountUC struct {
+	usecase.AccountUC
+	calls []string
+}
+
+var testAccount = dto.AccountDTO{
+	AccountID: 111, Balance: entity.MustParseMoney("1000.50"), AvailableBalance: entity.MustParseMoney("900.50"),
+	Currency: entity.CurrencyUSD, Type: entity.AccountChecking, Status: entity.AccountActive,
+}
+
+var testTransaction = dto.TransactionRecordDTO{
+	TransactionID: 7, SourceAccountID: 111, DestinationAccountID: 222,
+	Amount: entity.MustParseMoney("100.50"), Currency: entity.CurrencyUSD,
+	DestinationAmount: entity.MustParseMoney("100.50"), DestinationCurrency: entity.CurrencyUSD,
+	Fee:             &dto.FeeDTO{Amount: entity.NewMoney(1), Currency: entity.CurrencyUSD},
+	Status:          entity.TransactionPosted,
+	TransactionTime: time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC),
+}
+
+func (s *stubAccountUC) Create(_ context.Context, account dto.AccountDTO) (dto.AccountDTO, error) {
+	s.calls = append(s.calls, "create "+account.Balance.String()+" "+string(account.Currency)+" "+string(account.Type))
+	return testAccount, nil
+}
+
+func (s *stubAccountUC) GetBalance(_ context.Context, id uint64) (dto.AccountDTO, error) {
+	s.calls = append(s.calls, "balance")
+	return testAccount, nil
+}
+
+func (s *stubAccountUC) FreezeAccount(_ context.Context, id uint64) (dto.AccountDTO, error) {
+	s.calls = append(s.calls, "freeze")
+	account := testAccount
+	account.Status = entity.AccountFrozen
+	return account, nil
+}
+
+func (s *stubAccountUC) MakeTransaction(_ context.Context, req dto.TransactionDTO) (dto.TransactionRecordDTO, error) {
+	s.calls = append(s.calls, "transfer "+req.Amount.String())
+	return testTransaction, nil
+}
+
+func (s *stubAccountUC) ListTransactions(_ context.Context, req dto.TransactionListDTO,
+) ([]dto.TransactionRecordDTO, dto.PageMetaDTO, error) {
+	from := "-"
+	if req.From != nil {
+		from = req.From.Format(time.RFC3339)
+	}
+	s.calls = append(s.calls, "history "+string(req.Direction)+" "+from)
+	transaction := testTransaction
+	transaction.Direction = entity.TransactionOutgoing
+	return []dto.TransactionRecordDTO{transaction}, dto.PageMetaDTO{NextCursor: "abc", HasMore: true, Limit: 1}, nil
+}
+
+// stubIdempotencyUC records the responses by scope and key, and replays them for a reused key.
+type stubIdempotencyUC struct {
+	responses map[string]dto.IdempotentResponseDTO
+}
+
+func (s *stubIdempotencyUC) Execute(ctx context.Context, req dto.IdempotencyDTO, fn usecase.IdempotentFunc,
+) (dto.IdempotentResponseDTO, error) {
+	if res, ok := s.responses[req.Scope+" "+req.Key]; ok {
+		res.Replayed = true
+		return res, nil
+	}
+	status, body, err := fn(ctx)
+	if err != nil {
+		return dto.IdempotentResponseDTO{}, err
+	}
+	data, err := json.Marshal(body)
+	if err != nil {
+		return dto.IdempotentResponseDTO{}, err
+	}
+	res := dto.IdempotentResponseDTO{Status: status, Body: data}
+	s.responses[req.Scope+" "+req.Key] = res
+	return res, nil
+}
+
+func (s *stubIdempotencyUC) PurgeExpired(context.Context, time.Time) (int64, error) {
+	return 0, nil
+}
+
+func Test_parseCommand(t *testing.T) {
+	tests := []struct {
+		name      string
+		args      []string
+		wantCall  string
+		wantOut   []string
+		wantErr   string
+		wantUsage bool
+	}{
+		{
+			name:     "create",
+			args:     []string{"create", "-account", "111", "-balance", "1000.50", "-type", "savings"},
+			wantCall: "create 1000.50  savings",
+			wantOut:  []string{"ACCOUNT  TYPE", "111      checking  active  USD       1000.50  900.50     0.00"},
+		},
+		{
+			name:     "balance_json",
+			args:     []string{"balance", "-account", "111", "-o", "json"},
+			wantCall: "balance",
+			wantOut:  []string{`"account_id": 111`, `"balance": "1000.50"`, `"available_balance": "900.50"`},
+		},
+		{
+			name:     "freeze",
+			args:     []string{"freeze", "-account", "111"},
+			wantCall: "freeze",
+			wantOut:  []string{"frozen"},
+		},
+		{
+			name:     "transfer",
+			args:     []string{"transfer", "-from", "111", "-to", "222", "-amount", "100.5"},
+			wantCall: "transfer 100.50",
+			wantOut:  []string{"7            2026-03-01T12:00:00Z  -          111   222  100.50 USD  100.50 USD  1.00 USD  posted"},
+		},
+		{
+			name:     "transfer_idempotent",
+			args:     []string{"transfer", "-from", "111", "-to", "222", "-amount", "100.5", "-idempotency-key", "k1"},
+			wantCall: "transfer 100.50",
+			wantOut:  []string{"7            2026-03-01T12:00:00Z  -          111   222  100.50 USD  100.50 USD  1.00 USD  posted"},
+		},
+		{
+			name:     "history",
+			args:     []string{"history", "-account", "111", "-direction", "outgoing", "-from", "2026-03-01"},
+			wantCall: "history outgoing 2026-03-01T00:00:00Z",
+			wantOut:  []string{"outgoing", "more transactions: -cursor abc"},
+		},
+		{
+			name:     "history_json",
+			args:     []string{"history", "-account", "111", "-o", "json"},
+			wantCall: "history  -",
+			wantOut:  []string{`"data": [`, `"next_cursor": "abc"`},
+		},
+		{
+			name:      "missing_required_flag",
+			args:      []string{"transfer", "-from", "111", "-amount", "10"},
+			wantErr:   "flag is required: -to",
+			wantUsage: true,
+		},
+		{
+			name:      "invalid_amount",
+			args:      []string{"transfer", "-from", "111", "-to", "222", "-amount", "1e3"},
+			wantErr:   "invalid value",
+			wantUsage: true,
+		},
+		{
+			name:      "invalid_output",
+			args:      []string{"balance", "-account", "111", "-o", "yaml"},
+			wantErr:   "must be table or json",
+			wantUsage: true,
+		},
+		{
+			name:    "unknown_command",
+			args:    []string{"delete", "-account", "111"},
+			wantErr: `unknown command "delete"`,
+		},
+		{
+			name:    "no_command",
+			wantErr: "no command given",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stderr bytes.Buffer
+			cmd, err := parseCommand(tt.args, &stderr)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("parseCommand() error = %v, want %q", err, tt.wantErr)
+				}
+				if tt.wantUsage && !strings.Contains(stderr.String(), "Usage of ledgerctl") {
+					t.Errorf("parseCommand() did not write the command usage: %s", stderr.String())
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseCommand() error = %v", err)
+			}
+
+			accountUC := &stubAccountUC{}
+			uc := usecases{account: accountUC, idempotency: &stubIdempotencyUC{responses: map[string]dto.IdempotentResponseDTO{}}}
+			res, err := cmd.run(context.Background(), uc)
+			if err != nil {
+				t.Fatalf("run() error = %v", err)
+			}
+			if len(accountUC.calls) != 1 || accountUC.calls[0] != tt.wantCall {
+				t.Errorf("run() calls = %q, want %q", accountUC.calls, tt.wantCall)
+			}
+			var out bytes.Buffer
+			if err = res.write(&out, cmd.output); err != nil {
+				t.Fatalf("write() error = %v", err)
+			}
+			for _, want := range tt.wantOut {
+				if !strings.Contains(out.String(), want) {
+					t.Errorf("output does not contain %q:\n%s", want, out.String())
+				}
+			}
+		})
+	}
+}
+
+func Test_transferIdempotent(t *testing.T) {
+	// A transfer retried with the same key, here or through the HTTP API, is made once
+	accountUC := &stubAccountUC{}
+	idempotencyUC := &stubIdempotencyUC{responses: map[string]dto.IdempotentResponseDTO{}}
+	uc := usecases{account: accountUC, idempotency: idempotencyUC}
+	req := dto.TransactionDTO{SourceAccountID: 111, DestinationAccountID: 222, Amount: entity.MustParseMoney("100.50")}
+
+	first, err := transferIdempotent(context.Background(), uc, req, "k1")
+	if err != nil {
+		t.Fatalf("transferIdempotent() error = %v", err)
+	}
+	retried, err := transferIdempotent(context.Background(), uc, req, "k1")
+	if err != nil {
+		t.Fatalf("transferIdempotent() retry error = %v", err)
+	}
+
+	if len(accountUC.calls) != 1 {
+		t.Errorf("transfers made = %q, want one", accountUC.calls)
+	}
+	if _, ok := idempotencyUC.responses["POST /api/v1/transactions/ k1"]; !ok {
+		t.Errorf("key not recorded in the scope of the HTTP API: %v", idempotencyUC.responses)
+	}
+	if first.footer != "" || !strings.Contains(retried.footer, "replayed") {
+		t.Errorf("footers = %q, %q, want the retry marked as replayed", first.footer, retried.footer)
+	}
+	if got := retried.data.(dto.TransactionRecordDTO); got.TransactionID != testTransaction.TransactionID ||
+		got.Amount != testTransaction.Amount {
+		t.Errorf("replayed transfer = %+v", got)
+	}
+}
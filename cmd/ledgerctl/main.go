@@ -0,0 +1,106 @@
+// Command ledgerctl lets operations staff manage accounts and transfers directly against the database,
+// through the same usecases as the HTTP API.
+//
+// Usage:
+//
+//	ledgerctl create -account 111 -balance 1000.00 [-currency USD] [-type checking]
+//	ledgerctl balance -account 111
+//	ledgerctl transfer -from 111 -to 222 -amount 100.50 [-currency USD] [-quote <quote_id>] [-idempotency-key <key>]
+//	ledgerctl history -account 111 [-direction outgoing] [-from 2025-03-01] [-to 2025-04-01] [-limit 20] [-cursor <cursor>]
+//	ledgerctl freeze -account 111
+//	ledgerctl unfreeze -account 111
+//
+// Every command takes -o table, the default, or -o json. JSON output is the body the HTTP API returns.
+// A transfer with -idempotency-key is made at most once per key, and the key is shared with the
+// Idempotency-Key header of POST /api/v1/transactions.
+// The configuration is read like the server's, from APP_ENV.
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+
+	"go.uber.org/fx"
+
+	"transaction_demo/app/apperr"
+	"transaction_demo/app/constant"
+	"transaction_demo/app/registry"
+)
+
+// Exit codes besides constant.ApplicationLoadFailed.
+const (
+	exitCommandFailed = 1
+	exitUsage         = 2
+)
+
+func main() {
+	cmd, err := parseCommand(os.Args[1:], os.Stderr)
+	if errors.Is(err, errHelp) {
+		return
+	}
+	if err != nil {
+		os.Exit(exitUsage)
+	}
+	os.Exit(execute(cmd))
+}
+
+// execute runs a command between the start and the stop of the application, like the server
+// runs its requests, and returns the exit code.
+func execute(cmd command) int {
+	var uc usecases
+	app := fx.New(
+		registry.ProvideSingletons,
+		registry.ProvideRepositories,
+		registry.ProvideUsecases,
+		fx.Populate(&uc.account, &uc.idempotency),
+		fx.NopLogger,
+	)
+	if err := app.Err(); err != nil {
+		fmt.Fprintln(os.Stderr, "failed to initialize", "error", err)
+		return constant.ApplicationLoadFailed
+	}
+
+	startCtx, cancel := context.WithTimeout(context.Background(), app.StartTimeout())
+	defer cancel()
+	if err := app.Start(startCtx); err != nil {
+		fmt.Fprintln(os.Stderr, "failed to start", "error", err)
+		return constant.ApplicationLoadFailed
+	}
+	defer func() {
+		stopCtx, cancel := context.WithTimeout(context.Background(), app.StopTimeout())
+		defer cancel()
+		if err := app.Stop(stopCtx); err != nil {
+			fmt.Fprintln(os.Stderr, "failed to stop", "error", err)
+		}
+	}()
+
+	res, err := cmd.run(context.Background(), uc)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, cmd.name, "failed:", errorText(err))
+		return exitCommandFailed
+	}
+	if err = res.write(os.Stdout, cmd.output); err != nil {
+		fmt.Fprintln(os.Stderr, "failed to write output", "error", err)
+		return exitCommandFailed
+	}
+	return 0
+}
+
+// errorText describes an error for the operator, with the code and message of application errors.
+func errorText(err error) string {
+	var appErr apperr.AppError
+	if !errors.As(err, &appErr) {
+		return err.Error()
+	}
+	text := appErr.Code
+	if appErr.Message != "" {
+		text += ": " + appErr.Message
+	}
+	// The wrapped error is optional, and AppError.Error requires it
+	if appErr.Err != nil {
+		text += " (" + appErr.Err.Error() + ")"
+	}
+	return text
+}
@@ -0,0 +1,123 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"transaction_demo/app/usecase/dto"
+)
+
+type outputFormat string
+
+const (
+	outputTable outputFormat = "table"
+	outputJSON  outputFormat = "json"
+)
+
+// result is the outcome of a command, as a table for people and as data for JSON output.
+type result struct {
+	// data is written as JSON, in the form the HTTP API returns it
+	data   interface{}
+	header []string
+	rows   [][]string
+	// footer is written below the table, e.g. how to fetch the next page
+	footer string
+}
+
+func (r result) write(w io.Writer, format outputFormat) error {
+	if format == outputJSON {
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(r.data)
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, strings.Join(r.header, "\t"))
+	for _, row := range r.rows {
+		fmt.Fprintln(tw, strings.Join(row, "\t"))
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+	if r.footer != "" {
+		_, err := fmt.Fprintln(w, r.footer)
+		return err
+	}
+	return nil
+}
+
+var accountHeader = []string{"ACCOUNT", "TYPE", "STATUS", "CURRENCY", "BALANCE", "AVAILABLE", "OVERDRAFT LIMIT"}
+
+func accountResult(account dto.AccountDTO) result {
+	return result{
+		data:   account,
+		header: accountHeader,
+		rows: [][]string{{
+			strconv.FormatUint(account.AccountID, 10),
+			string(account.Type),
+			string(account.Status),
+			string(account.Currency),
+			account.Balance.String(),
+			account.AvailableBalance.String(),
+			account.OverdraftLimit.String(),
+		}},
+	}
+}
+
+var transactionHeader = []string{"TRANSACTION", "TIME", "DIRECTION", "FROM", "TO", "AMOUNT", "CREDITED", "FEE", "STATUS"}
+
+func transactionResult(transaction dto.TransactionRecordDTO) result {
+	return result{
+		data:   transaction,
+		header: transactionHeader,
+		rows:   [][]string{transactionRow(transaction)},
+	}
+}
+
+// historyResult is a page of transaction history; its JSON form has the data and meta envelope of the HTTP API.
+func historyResult(transactions []dto.TransactionRecordDTO, meta dto.PageMetaDTO) result {
+	res := result{
+		data: struct {
+			Data []dto.TransactionRecordDTO `json:"data"`
+			Meta dto.PageMetaDTO            `json:"meta"`
+		}{Data: transactions, Meta: meta},
+		header: transactionHeader,
+		rows:   make([][]string, 0, len(transactions)),
+	}
+	for _, transaction := range transactions {
+		res.rows = append(res.rows, transactionRow(transaction))
+	}
+	if meta.HasMore {
+		res.footer = "more transactions: -cursor " + meta.NextCursor
+	}
+	return res
+}
+
+// transactionRow shows the amounts with their currency, since a cross-currency transfer credits
+// another currency than it debits.
+func transactionRow(transaction dto.TransactionRecordDTO) []string {
+	fee := "-"
+	if transaction.Fee != nil {
+		fee = transaction.Fee.Amount.String() + " " + string(transaction.Fee.Currency)
+	}
+	direction := string(transaction.Direction)
+	if direction == "" {
+		direction = "-"
+	}
+	return []string{
+		strconv.FormatUint(transaction.TransactionID, 10),
+		transaction.TransactionTime.UTC().Format(time.RFC3339),
+		direction,
+		strconv.FormatUint(transaction.SourceAccountID, 10),
+		strconv.FormatUint(transaction.DestinationAccountID, 10),
+		transaction.Amount.String() + " " + string(transaction.Currency),
+		transaction.DestinationAmount.String() + " " + string(transaction.DestinationCurrency),
+		fee,
+		string(transaction.Status),
+	}
+}
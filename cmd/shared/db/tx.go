@@ -1,21 +1,35 @@
 package db
 
 import (
+	"database/sql"
+
 	trmgorm "github.com/avito-tech/go-transaction-manager/drivers/gorm/v2"
 	"github.com/avito-tech/go-transaction-manager/trm/v2"
 	"github.com/avito-tech/go-transaction-manager/trm/v2/manager"
 	"github.com/avito-tech/go-transaction-manager/trm/v2/settings"
 	"gorm.io/gorm"
+
+	"transaction_demo/cmd/shared/db/txhook"
 )
 
 // GetTxManager returns a transaction manager for the given GORM database instance.
 // It uses the default transaction manager factory for GORM and sets the propagation to Nested.
+// Functions registered with txhook.AfterCommit run once the outermost transaction commits.
 func GetTxManager(db *gorm.DB) trm.Manager {
-	return manager.Must(
+	return txhook.Wrap(manager.Must(
 		trmgorm.NewDefaultFactory(db),
 		manager.WithSettings(trmgorm.MustSettings(
 			settings.Must(
 				settings.WithPropagation(trm.PropagationNested))),
 		),
-	)
+	))
+}
+
+// SnapshotSettings are the settings of a read-only REPEATABLE READ transaction: every query in it sees
+// the database as of its first query, so reads spread over several queries agree with each other.
+func SnapshotSettings() trm.Settings {
+	return trmgorm.MustSettings(settings.Must(), trmgorm.WithTxOptions(&sql.TxOptions{
+		Isolation: sql.LevelRepeatableRead,
+		ReadOnly:  true,
+	}))
 }
@@ -0,0 +1,77 @@
+// Package txhook runs functions once the database transaction they were registered in commits.
+// Usecases often run nested in the transaction of another usecase or of an idempotent request,
+// so returning from their own Do does not mean that their changes are committed.
+package txhook
+
+import (
+	"context"
+	"sync"
+
+	"github.com/avito-tech/go-transaction-manager/trm/v2"
+)
+
+type hooksKey struct{}
+
+// hooks are the functions registered within one Do call.
+type hooks struct {
+	mu  sync.Mutex
+	fns []func()
+}
+
+func (h *hooks) add(fns ...func()) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.fns = append(h.fns, fns...)
+}
+
+// manager is a transaction manager that runs the functions registered with AfterCommit.
+type manager struct {
+	trm.Manager
+}
+
+// Wrap returns a transaction manager doing the same as m, which also runs the functions registered
+// with AfterCommit once the outermost transaction commits.
+func Wrap(m trm.Manager) trm.Manager {
+	return &manager{Manager: m}
+}
+
+func (m *manager) Do(ctx context.Context, fn func(ctx context.Context) error) error {
+	return m.run(ctx, func(ctx context.Context) error {
+		return m.Manager.Do(ctx, fn)
+	})
+}
+
+func (m *manager) DoWithSettings(ctx context.Context, s trm.Settings, fn func(ctx context.Context) error) error {
+	return m.run(ctx, func(ctx context.Context) error {
+		return m.Manager.DoWithSettings(ctx, s, fn)
+	})
+}
+
+// run collects the functions registered within do:
+// - A failed transaction, nested or not, drops them since its changes were rolled back
+// - A nested transaction hands them over to the enclosing one, which may still roll back
+// - The outermost transaction runs them, in registration order, once committed
+func (m *manager) run(ctx context.Context, do func(ctx context.Context) error) error {
+	own := &hooks{}
+	if err := do(context.WithValue(ctx, hooksKey{}, own)); err != nil {
+		return err
+	}
+	if parent, ok := ctx.Value(hooksKey{}).(*hooks); ok {
+		parent.add(own.fns...)
+		return nil
+	}
+	for _, fn := range own.fns {
+		fn()
+	}
+	return nil
+}
+
+// AfterCommit registers fn to run once the transaction of ctx commits; fn is dropped if it rolls back.
+// Outside a transaction of a wrapped manager, fn runs immediately.
+func AfterCommit(ctx context.Context, fn func()) {
+	if h, ok := ctx.Value(hooksKey{}).(*hooks); ok {
+		h.add(fn)
+		return
+	}
+	fn()
+}
@@ -0,0 +1,107 @@
+package txhook
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"transaction_demo/cmd/shared/db/mock"
+)
+
+func TestAfterCommit(t *testing.T) {
+	errFailed := errors.New("failed")
+	tests := []struct {
+		name string
+		// run registers hooks, recording their names in ran, through the wrapped manager
+		run  func(m *manager, ran *[]string) error
+		want []string
+	}{
+		{
+			name: "committed",
+			run: func(m *manager, ran *[]string) error {
+				return m.Do(context.Background(), func(ctx context.Context) error {
+					AfterCommit(ctx, func() { *ran = append(*ran, "a") })
+					AfterCommit(ctx, func() { *ran = append(*ran, "b") })
+					if len(*ran) != 0 {
+						t.Error("hook ran before commit")
+					}
+					return nil
+				})
+			},
+			want: []string{"a", "b"},
+		},
+		{
+			name: "rolled_back",
+			run: func(m *manager, ran *[]string) error {
+				return m.Do(context.Background(), func(ctx context.Context) error {
+					AfterCommit(ctx, func() { *ran = append(*ran, "a") })
+					return errFailed
+				})
+			},
+		},
+		{
+			// The hooks of a nested transaction wait for the outer commit
+			name: "nested_committed",
+			run: func(m *manager, ran *[]string) error {
+				return m.Do(context.Background(), func(ctx context.Context) error {
+					err := m.Do(ctx, func(ctx context.Context) error {
+						AfterCommit(ctx, func() { *ran = append(*ran, "inner") })
+						return nil
+					})
+					if len(*ran) != 0 {
+						t.Error("nested hook ran before the outer commit")
+					}
+					AfterCommit(ctx, func() { *ran = append(*ran, "outer") })
+					return err
+				})
+			},
+			want: []string{"inner", "outer"},
+		},
+		{
+			// A failed savepoint drops its hooks and keeps those of the enclosing transaction
+			name: "nested_rolled_back",
+			run: func(m *manager, ran *[]string) error {
+				return m.Do(context.Background(), func(ctx context.Context) error {
+					AfterCommit(ctx, func() { *ran = append(*ran, "outer") })
+					_ = m.Do(ctx, func(ctx context.Context) error {
+						AfterCommit(ctx, func() { *ran = append(*ran, "inner") })
+						return errFailed
+					})
+					return nil
+				})
+			},
+			want: []string{"outer"},
+		},
+		{
+			name: "outer_rolled_back",
+			run: func(m *manager, ran *[]string) error {
+				return m.Do(context.Background(), func(ctx context.Context) error {
+					_ = m.Do(ctx, func(ctx context.Context) error {
+						AfterCommit(ctx, func() { *ran = append(*ran, "inner") })
+						return nil
+					})
+					return errFailed
+				})
+			},
+		},
+		{
+			name: "outside_transaction",
+			run: func(m *manager, ran *[]string) error {
+				AfterCommit(context.Background(), func() { *ran = append(*ran, "a") })
+				return nil
+			},
+			want: []string{"a"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := Wrap(mock.NewMockTxManager()).(*manager)
+			var ran []string
+			_ = tt.run(m, &ran)
+			if !reflect.DeepEqual(ran, tt.want) {
+				t.Errorf("ran %v, want %v", ran, tt.want)
+			}
+		})
+	}
+}
@@ -3,17 +3,22 @@ package main
 import (
 	"context"
 	"fmt"
+	"net"
 	"os"
 	"strconv"
 
 	"github.com/gin-gonic/gin"
 	"go.uber.org/fx"
 	"go.uber.org/fx/fxevent"
+	"google.golang.org/grpc"
 
 	"transaction_demo/app/config"
 	"transaction_demo/app/interface/api/handler"
 	"transaction_demo/app/interface/api/route"
+	"transaction_demo/app/interface/rpc"
+	"transaction_demo/app/interface/worker"
 	"transaction_demo/app/registry"
+	"transaction_demo/app/usecase"
 )
 
 // main initializes the application using Uber Fx framework.
@@ -24,9 +29,17 @@ func main() {
 		registry.ProvideSingletons,
 		registry.ProvideRepositories,
 		registry.ProvideUsecases,
-		fx.Provide(handler.NewAccountHandler),
-		fx.Invoke(route.RegisterAccountRoutes),
-		fx.Invoke(startServer),
+		fx.Provide(handler.NewAccountHandler, handler.NewFXHandler, handler.NewLedgerHandler,
+			handler.NewScheduledTransferHandler, handler.NewInterestHandler, handler.NewStatementHandler,
+			handler.NewWebhookHandler),
+		fx.Provide(rpc.NewAccountServer, rpc.NewServer),
+		fx.Provide(worker.NewScheduledTransferWorker, worker.NewInterestWorker, worker.NewBalanceSnapshotWorker,
+			worker.NewOutboxRelay, worker.NewWebhookDeliveryWorker, worker.NewIdempotencyPurgeWorker),
+		fx.Invoke(route.RegisterAccountRoutes, route.RegisterFXRoutes, route.RegisterLedgerRoutes,
+			route.RegisterScheduledTransferRoutes, route.RegisterInterestRoutes, route.RegisterStatementRoutes,
+			route.RegisterWebhookRoutes),
+		fx.Invoke(startServer, startGRPCServer, startScheduledTransferWorker, startInterestWorker, startBalanceSnapshotWorker,
+			startOutboxRelay, startWebhookDeliveryWorker, startIdempotencyPurgeWorker),
 		fx.WithLogger(func() fxevent.Logger {
 			return &fxevent.ConsoleLogger{W: os.Stdout}
 		}),
@@ -56,3 +69,190 @@ func startServer(
 		},
 	})
 }
+
+// startGRPCServer starts the gRPC server alongside the HTTP server, unless disabled.
+// On stop, in-flight calls are given until the stop deadline to finish.
+func startGRPCServer(
+	lc fx.Lifecycle,
+	server *grpc.Server,
+	cf *config.Config,
+) {
+	if !cf.GRPC.Enabled {
+		fmt.Println("grpc server disabled")
+		return
+	}
+	lc.Append(fx.Hook{
+		OnStart: func(ctx context.Context) error {
+			lis, err := net.Listen("tcp", ":"+strconv.Itoa(int(cf.GRPC.Port)))
+			if err != nil {
+				fmt.Println("start grpc server fail", "error", err)
+				return err
+			}
+			go func() {
+				if err := server.Serve(lis); err != nil {
+					fmt.Println("grpc server fail", "error", err)
+				}
+			}()
+			fmt.Println("start grpc server", "port", cf.GRPC.Port)
+			return nil
+		},
+		OnStop: func(ctx context.Context) error {
+			fmt.Println("stop grpc server", "port", cf.GRPC.Port)
+			stopped := make(chan struct{})
+			go func() {
+				server.GracefulStop()
+				close(stopped)
+			}()
+			select {
+			case <-stopped:
+			case <-ctx.Done():
+				server.Stop()
+			}
+			return nil
+		},
+	})
+}
+
+// startScheduledTransferWorker runs the scheduled transfer worker alongside the server, unless disabled.
+func startScheduledTransferWorker(
+	lc fx.Lifecycle,
+	w *worker.ScheduledTransferWorker,
+	cf *config.Config,
+) {
+	if !cf.Scheduler.Enabled {
+		fmt.Println("scheduled transfer worker disabled")
+		return
+	}
+	lc.Append(fx.Hook{
+		OnStart: func(ctx context.Context) error {
+			w.Start()
+			fmt.Println("start scheduled transfer worker")
+			return nil
+		},
+		OnStop: func(ctx context.Context) error {
+			fmt.Println("stop scheduled transfer worker")
+			return w.Stop(ctx)
+		},
+	})
+}
+
+// startInterestWorker runs the interest accrual and posting worker alongside the server, unless disabled.
+func startInterestWorker(
+	lc fx.Lifecycle,
+	w *worker.InterestWorker,
+	cf *config.Config,
+) {
+	if !cf.Interest.Enabled {
+		fmt.Println("interest worker disabled")
+		return
+	}
+	lc.Append(fx.Hook{
+		OnStart: func(ctx context.Context) error {
+			w.Start()
+			fmt.Println("start interest worker")
+			return nil
+		},
+		OnStop: func(ctx context.Context) error {
+			fmt.Println("stop interest worker")
+			return w.Stop(ctx)
+		},
+	})
+}
+
+// startBalanceSnapshotWorker runs the daily balance snapshot worker alongside the server, unless disabled.
+func startBalanceSnapshotWorker(
+	lc fx.Lifecycle,
+	w *worker.BalanceSnapshotWorker,
+	cf *config.Config,
+) {
+	if !cf.Snapshots.Enabled {
+		fmt.Println("balance snapshot worker disabled")
+		return
+	}
+	lc.Append(fx.Hook{
+		OnStart: func(ctx context.Context) error {
+			w.Start()
+			fmt.Println("start balance snapshot worker")
+			return nil
+		},
+		OnStop: func(ctx context.Context) error {
+			fmt.Println("stop balance snapshot worker")
+			return w.Stop(ctx)
+		},
+	})
+}
+
+// startOutboxRelay runs the outbox relay alongside the server, unless disabled.
+// Events keep being recorded while the relay is disabled, and are published once it runs.
+func startOutboxRelay(
+	lc fx.Lifecycle,
+	w *worker.OutboxRelay,
+	publisher usecase.EventPublisher,
+	cf *config.Config,
+) {
+	if !cf.Events.Enabled {
+		fmt.Println("outbox relay disabled")
+		return
+	}
+	lc.Append(fx.Hook{
+		OnStart: func(ctx context.Context) error {
+			w.Start()
+			fmt.Println("start outbox relay", "publisher", cf.Events.Publisher)
+			return nil
+		},
+		OnStop: func(ctx context.Context) error {
+			fmt.Println("stop outbox relay")
+			if err := w.Stop(ctx); err != nil {
+				return err
+			}
+			return publisher.Close()
+		},
+	})
+}
+
+// startWebhookDeliveryWorker runs the webhook delivery worker alongside the server, unless disabled.
+// Deliveries keep being queued while the worker is disabled, and are sent once it runs.
+func startWebhookDeliveryWorker(
+	lc fx.Lifecycle,
+	w *worker.WebhookDeliveryWorker,
+	cf *config.Config,
+) {
+	if !cf.Webhooks.Enabled {
+		fmt.Println("webhook delivery worker disabled")
+		return
+	}
+	lc.Append(fx.Hook{
+		OnStart: func(ctx context.Context) error {
+			w.Start()
+			fmt.Println("start webhook delivery worker")
+			return nil
+		},
+		OnStop: func(ctx context.Context) error {
+			fmt.Println("stop webhook delivery worker")
+			return w.Stop(ctx)
+		},
+	})
+}
+
+// startIdempotencyPurgeWorker runs the worker deleting expired idempotency keys alongside the server, unless disabled.
+func startIdempotencyPurgeWorker(
+	lc fx.Lifecycle,
+	w *worker.IdempotencyPurgeWorker,
+	cf *config.Config,
+) {
+	if !cf.Idempotency.PurgeEnabled {
+		fmt.Println("idempotency purge worker disabled")
+		return
+	}
+	lc.Append(fx.Hook{
+		OnStart: func(ctx context.Context) error {
+			w.Start()
+			fmt.Println("start idempotency purge worker")
+			return nil
+		},
+		OnStop: func(ctx context.Context) error {
+			fmt.Println("stop idempotency purge worker")
+			return w.Stop(ctx)
+		},
+	})
+}
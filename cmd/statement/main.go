@@ -0,0 +1,97 @@
+// Command statement exports the statement of an account for a period to a file.
+//
+// Usage:
+//
+//	statement -account 111 -from 2025-03-01 -to 2025-04-01 -format camt053 [-out statement.xml]
+//
+// from and to are dates (midnight UTC) or RFC 3339 timestamps; to is exclusive.
+// The file defaults to statement_<account>_<from>_<to>.<ext> in the working directory.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"go.uber.org/fx"
+
+	"transaction_demo/app/constant"
+	"transaction_demo/app/domain/entity"
+	"transaction_demo/app/interface/statement"
+	"transaction_demo/app/registry"
+	"transaction_demo/app/usecase"
+	"transaction_demo/app/usecase/dto"
+)
+
+func main() {
+	accountID := flag.Uint64("account", 0, "account ID")
+	fromFlag := flag.String("from", "", "start of the period, YYYY-MM-DD or RFC 3339, inclusive")
+	toFlag := flag.String("to", "", "end of the period, YYYY-MM-DD or RFC 3339, exclusive")
+	format := flag.String("format", string(entity.StatementCSV), "statement format: csv, jsonl or camt053")
+	outPath := flag.String("out", "", "output file, statement_<account>_<from>_<to>.<ext> by default")
+	flag.Parse()
+
+	from, err := parseTime(*fromFlag)
+	if err != nil {
+		exit("invalid -from", err)
+	}
+	to, err := parseTime(*toFlag)
+	if err != nil {
+		exit("invalid -to", err)
+	}
+	req := dto.StatementRequestDTO{AccountID: *accountID, From: &from, To: &to, Format: entity.StatementFormat(*format)}
+	if *outPath == "" {
+		*outPath = statement.FileName(req.AccountID, from, to, req.Format)
+	}
+
+	var statementUC usecase.StatementUC
+	app := fx.New(
+		registry.ProvideSingletons,
+		registry.ProvideRepositories,
+		registry.ProvideUsecases,
+		fx.Populate(&statementUC),
+		fx.NopLogger,
+	)
+	if err = app.Err(); err != nil {
+		exit("failed to initialize", err)
+	}
+
+	if err = export(statementUC, req, *outPath); err != nil {
+		exit("statement export failed", err)
+	}
+	fmt.Println("statement exported", "file", *outPath)
+}
+
+// export writes the statement to path; the file is removed when the export fails.
+func export(statementUC usecase.StatementUC, req dto.StatementRequestDTO, path string) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	w, err := statement.NewWriter(req.Format, file)
+	if err == nil {
+		err = statementUC.WriteStatement(context.Background(), req, w)
+	}
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		_ = os.Remove(path)
+	}
+	return err
+}
+
+// parseTime accepts a date, read as midnight UTC, or an RFC 3339 timestamp.
+func parseTime(s string) (time.Time, error) {
+	if t, err := time.Parse(dto.DateLayout, s); err == nil {
+		return t, nil
+	}
+	return time.Parse(time.RFC3339, s)
+}
+
+func exit(msg string, err error) {
+	fmt.Fprintln(os.Stderr, msg, "error", err)
+	os.Exit(constant.ApplicationLoadFailed)
+}
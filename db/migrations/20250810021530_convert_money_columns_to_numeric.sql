@@ -0,0 +1,9 @@
+-- +goose Up
+-- Money is stored as exact NUMERIC instead of binary floating point.
+-- Existing values are rounded to the 4 fractional digits kept by the application.
+ALTER TABLE accounts ALTER COLUMN balance TYPE NUMERIC(20, 4) USING ROUND(balance::NUMERIC, 4);
+ALTER TABLE transactions ALTER COLUMN amount TYPE NUMERIC(20, 4) USING ROUND(amount::NUMERIC, 4);
+
+-- +goose Down
+ALTER TABLE transactions ALTER COLUMN amount TYPE DOUBLE PRECISION USING amount::DOUBLE PRECISION;
+ALTER TABLE accounts ALTER COLUMN balance TYPE DOUBLE PRECISION USING balance::DOUBLE PRECISION;
@@ -0,0 +1,27 @@
+-- +goose Up
+-- Every account that existed before currencies were introduced is a USD account.
+ALTER TABLE accounts ADD COLUMN currency CHAR(3) NOT NULL DEFAULT 'USD';
+ALTER TABLE accounts ALTER COLUMN currency DROP DEFAULT;
+
+ALTER TABLE transactions
+    ADD COLUMN currency CHAR(3) NOT NULL DEFAULT 'USD',
+    ADD COLUMN destination_amount NUMERIC(20, 4),
+    ADD COLUMN destination_currency CHAR(3) NOT NULL DEFAULT 'USD',
+    ADD COLUMN exchange_rate NUMERIC(20, 8) NOT NULL DEFAULT 1;
+
+-- Historical transfers were all same-currency, so both legs carry the same amount
+UPDATE transactions SET destination_amount = amount;
+
+ALTER TABLE transactions
+    ALTER COLUMN destination_amount SET NOT NULL,
+    ALTER COLUMN currency DROP DEFAULT,
+    ALTER COLUMN destination_currency DROP DEFAULT,
+    ALTER COLUMN exchange_rate DROP DEFAULT;
+
+-- +goose Down
+ALTER TABLE transactions
+    DROP COLUMN exchange_rate,
+    DROP COLUMN destination_currency,
+    DROP COLUMN destination_amount,
+    DROP COLUMN currency;
+ALTER TABLE accounts DROP COLUMN currency;
@@ -0,0 +1,26 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS rates (
+    id BIGSERIAL PRIMARY KEY,
+    base_currency CHAR(3) NOT NULL,
+    quote_currency CHAR(3) NOT NULL,
+    rate NUMERIC(20, 8) NOT NULL CHECK (rate > 0),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    UNIQUE (base_currency, quote_currency)
+);
+
+CREATE TABLE IF NOT EXISTS fx_quotes (
+    id VARCHAR(32) PRIMARY KEY,
+    source_currency CHAR(3) NOT NULL,
+    destination_currency CHAR(3) NOT NULL,
+    rate NUMERIC(20, 8) NOT NULL,
+    expires_at TIMESTAMP NOT NULL,
+    used_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+ALTER TABLE transactions ADD COLUMN quote_id VARCHAR(32) REFERENCES fx_quotes(id);
+
+-- +goose Down
+ALTER TABLE transactions DROP COLUMN quote_id;
+DROP TABLE IF EXISTS fx_quotes;
+DROP TABLE IF EXISTS rates;
@@ -0,0 +1,87 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS journal_entries (
+    id BIGSERIAL PRIMARY KEY,
+    transaction_id BIGINT REFERENCES transactions(id),
+    description VARCHAR(255) NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_journal_entries_transaction_id ON journal_entries (transaction_id);
+
+-- A posting hits either a customer account or a named system account, never both
+CREATE TABLE IF NOT EXISTS postings (
+    id BIGSERIAL PRIMARY KEY,
+    journal_entry_id BIGINT NOT NULL REFERENCES journal_entries(id),
+    account_id BIGINT REFERENCES accounts(id),
+    system_account VARCHAR(64) NOT NULL DEFAULT '',
+    direction VARCHAR(6) NOT NULL CHECK (direction IN ('debit', 'credit')),
+    amount NUMERIC(20, 4) NOT NULL CHECK (amount > 0),
+    currency CHAR(3) NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    CHECK ((account_id IS NOT NULL AND system_account = '') OR (account_id IS NULL AND system_account <> ''))
+);
+
+CREATE INDEX IF NOT EXISTS idx_postings_journal_entry_id ON postings (journal_entry_id);
+CREATE INDEX IF NOT EXISTS idx_postings_account_id ON postings (account_id);
+
+-- Debits must equal credits per currency within a journal entry. The check is deferred
+-- to commit time so all postings of an entry can be inserted first.
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION check_journal_entry_balanced() RETURNS TRIGGER AS $$
+BEGIN
+    IF EXISTS (
+        SELECT 1
+        FROM postings
+        WHERE journal_entry_id = NEW.journal_entry_id
+        GROUP BY currency
+        HAVING SUM(CASE WHEN direction = 'debit' THEN amount ELSE -amount END) <> 0
+    ) THEN
+        RAISE EXCEPTION 'journal entry % is not balanced', NEW.journal_entry_id;
+    END IF;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+-- +goose StatementEnd
+
+CREATE CONSTRAINT TRIGGER trg_postings_balanced
+    AFTER INSERT ON postings
+    DEFERRABLE INITIALLY DEFERRED
+    FOR EACH ROW EXECUTE FUNCTION check_journal_entry_balanced();
+
+-- Postings are immutable; corrections are booked as new entries
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION reject_posting_change() RETURNS TRIGGER AS $$
+BEGIN
+    RAISE EXCEPTION 'postings are append-only';
+END;
+$$ LANGUAGE plpgsql;
+-- +goose StatementEnd
+
+CREATE TRIGGER trg_postings_append_only
+    BEFORE UPDATE OR DELETE ON postings
+    FOR EACH ROW EXECUTE FUNCTION reject_posting_change();
+
+-- Open the ledger with the balances accounts hold today, funded from opening-balance equity
+-- +goose StatementBegin
+DO $$
+DECLARE
+    acc RECORD;
+    entry_id BIGINT;
+BEGIN
+    FOR acc IN SELECT id, balance, currency FROM accounts WHERE balance <> 0 ORDER BY id LOOP
+        INSERT INTO journal_entries (description) VALUES ('opening balance') RETURNING id INTO entry_id;
+        INSERT INTO postings (journal_entry_id, account_id, system_account, direction, amount, currency) VALUES
+            (entry_id, acc.id, '', CASE WHEN acc.balance > 0 THEN 'credit' ELSE 'debit' END, ABS(acc.balance), acc.currency),
+            (entry_id, NULL, 'equity:opening_balance', CASE WHEN acc.balance > 0 THEN 'debit' ELSE 'credit' END, ABS(acc.balance), acc.currency);
+    END LOOP;
+END;
+$$;
+-- +goose StatementEnd
+
+-- +goose Down
+DROP TRIGGER IF EXISTS trg_postings_append_only ON postings;
+DROP TRIGGER IF EXISTS trg_postings_balanced ON postings;
+DROP FUNCTION IF EXISTS reject_posting_change();
+DROP FUNCTION IF EXISTS check_journal_entry_balanced();
+DROP TABLE IF EXISTS postings;
+DROP TABLE IF EXISTS journal_entries;
@@ -0,0 +1,21 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS holds (
+    id BIGSERIAL PRIMARY KEY,
+    source_account_id BIGINT NOT NULL REFERENCES accounts(id),
+    destination_account_id BIGINT NOT NULL REFERENCES accounts(id),
+    amount NUMERIC(20, 4) NOT NULL CHECK (amount > 0),
+    currency CHAR(3) NOT NULL,
+    captured_amount NUMERIC(20, 4) NOT NULL DEFAULT 0 CHECK (captured_amount >= 0 AND captured_amount <= amount),
+    status VARCHAR(16) NOT NULL CHECK (status IN ('authorized', 'captured', 'voided', 'expired')),
+    transaction_id BIGINT REFERENCES transactions(id),
+    expires_at TIMESTAMP NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+-- Available balance sums the open holds of an account
+CREATE INDEX IF NOT EXISTS idx_holds_source_account_authorized ON holds (source_account_id, expires_at)
+    WHERE status = 'authorized';
+
+-- +goose Down
+DROP TABLE IF EXISTS holds;
@@ -0,0 +1,10 @@
+-- +goose Up
+-- A reversal is a regular transfer in the opposite direction linked to the transfer it undoes
+ALTER TABLE transactions ADD COLUMN IF NOT EXISTS original_transaction_id BIGINT REFERENCES transactions(id);
+
+CREATE INDEX IF NOT EXISTS idx_transactions_original_transaction_id ON transactions (original_transaction_id)
+    WHERE original_transaction_id IS NOT NULL;
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_transactions_original_transaction_id;
+ALTER TABLE transactions DROP COLUMN IF EXISTS original_transaction_id;
@@ -0,0 +1,10 @@
+-- +goose Up
+-- Account history is listed newest first with a (transaction_time, id) keyset cursor
+CREATE INDEX IF NOT EXISTS idx_transactions_source_history
+    ON transactions (source_account_id, transaction_time DESC, id DESC);
+CREATE INDEX IF NOT EXISTS idx_transactions_destination_history
+    ON transactions (destination_account_id, transaction_time DESC, id DESC);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_transactions_destination_history;
+DROP INDEX IF EXISTS idx_transactions_source_history;
@@ -0,0 +1,19 @@
+-- +goose Up
+-- Every transaction recorded so far was applied in the same DB transaction it was created in
+ALTER TABLE transactions ADD COLUMN IF NOT EXISTS status VARCHAR(16) NOT NULL DEFAULT 'posted'
+    CHECK (status IN ('pending', 'posted', 'failed', 'reversed'));
+ALTER TABLE transactions ADD COLUMN IF NOT EXISTS updated_at TIMESTAMP NOT NULL DEFAULT NOW();
+
+UPDATE transactions SET updated_at = transaction_time;
+
+-- Transfers whose full amount was already reversed
+UPDATE transactions t SET status = 'reversed'
+WHERE t.original_transaction_id IS NULL
+  AND t.amount <= (SELECT COALESCE(SUM(r.destination_amount), 0)
+                   FROM transactions r WHERE r.original_transaction_id = t.id);
+
+ALTER TABLE transactions ALTER COLUMN status DROP DEFAULT;
+
+-- +goose Down
+ALTER TABLE transactions DROP COLUMN IF EXISTS updated_at;
+ALTER TABLE transactions DROP COLUMN IF EXISTS status;
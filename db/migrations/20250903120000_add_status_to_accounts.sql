@@ -0,0 +1,8 @@
+-- +goose Up
+ALTER TABLE accounts ADD COLUMN IF NOT EXISTS status VARCHAR(16) NOT NULL DEFAULT 'active'
+    CHECK (status IN ('active', 'frozen', 'closed'));
+ALTER TABLE accounts ADD COLUMN IF NOT EXISTS closed_at TIMESTAMP;
+
+-- +goose Down
+ALTER TABLE accounts DROP COLUMN IF EXISTS closed_at;
+ALTER TABLE accounts DROP COLUMN IF EXISTS status;
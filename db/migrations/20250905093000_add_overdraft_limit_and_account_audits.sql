@@ -0,0 +1,21 @@
+-- +goose Up
+ALTER TABLE accounts ADD COLUMN IF NOT EXISTS overdraft_limit NUMERIC(20, 4) NOT NULL DEFAULT 0
+    CHECK (overdraft_limit >= 0);
+
+-- Administrative changes to account settings, append only
+CREATE TABLE IF NOT EXISTS account_audits (
+    id BIGSERIAL PRIMARY KEY,
+    account_id BIGINT NOT NULL REFERENCES accounts(id),
+    field VARCHAR(64) NOT NULL,
+    old_value TEXT NOT NULL,
+    new_value TEXT NOT NULL,
+    changed_by VARCHAR(64) NOT NULL,
+    reason TEXT NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_account_audits_account_id ON account_audits (account_id, id);
+
+-- +goose Down
+DROP TABLE IF EXISTS account_audits;
+ALTER TABLE accounts DROP COLUMN IF EXISTS overdraft_limit;
@@ -0,0 +1,23 @@
+-- +goose Up
+-- Transfers held as pending by a risk rule, waiting for an operator decision
+CREATE TABLE IF NOT EXISTS risk_reviews (
+    id BIGSERIAL PRIMARY KEY,
+    transaction_id BIGINT NOT NULL UNIQUE REFERENCES transactions(id),
+    rule VARCHAR(64) NOT NULL,
+    reason TEXT NOT NULL,
+    status VARCHAR(16) NOT NULL CHECK (status IN ('open', 'released', 'rejected')),
+    decided_by VARCHAR(64) NOT NULL DEFAULT '',
+    decision_note TEXT NOT NULL DEFAULT '',
+    decided_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_risk_reviews_open ON risk_reviews (id) WHERE status = 'open';
+
+-- Round-trip detection looks up recent transfers between two accounts
+CREATE INDEX IF NOT EXISTS idx_transactions_account_pair
+    ON transactions (source_account_id, destination_account_id, transaction_time);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_transactions_account_pair;
+DROP TABLE IF EXISTS risk_reviews;
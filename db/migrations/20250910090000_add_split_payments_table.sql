@@ -0,0 +1,19 @@
+-- +goose Up
+-- A split payment debits one account once and credits several; each credit is a leg in transactions
+CREATE TABLE IF NOT EXISTS split_payments (
+    id BIGSERIAL PRIMARY KEY,
+    source_account_id BIGINT NOT NULL REFERENCES accounts(id),
+    amount NUMERIC(20, 4) NOT NULL CHECK (amount > 0),
+    currency CHAR(3) NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+ALTER TABLE transactions ADD COLUMN IF NOT EXISTS split_payment_id BIGINT REFERENCES split_payments(id);
+
+CREATE INDEX IF NOT EXISTS idx_transactions_split_payment_id ON transactions (split_payment_id)
+    WHERE split_payment_id IS NOT NULL;
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_transactions_split_payment_id;
+ALTER TABLE transactions DROP COLUMN IF EXISTS split_payment_id;
+DROP TABLE IF EXISTS split_payments;
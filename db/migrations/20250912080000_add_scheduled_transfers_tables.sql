@@ -0,0 +1,37 @@
+-- +goose Up
+-- Transfers made at a future time, once or on a recurrence
+CREATE TABLE IF NOT EXISTS scheduled_transfers (
+    id BIGSERIAL PRIMARY KEY,
+    source_account_id BIGINT NOT NULL REFERENCES accounts(id),
+    destination_account_id BIGINT NOT NULL REFERENCES accounts(id),
+    amount NUMERIC(20, 4) NOT NULL CHECK (amount > 0),
+    currency CHAR(3) NOT NULL,
+    recurrence VARCHAR(16) NOT NULL CHECK (recurrence IN ('once', 'daily', 'weekly', 'monthly')),
+    start_at TIMESTAMP NOT NULL,
+    end_at TIMESTAMP,
+    next_run_at TIMESTAMP NOT NULL,
+    occurrence BIGINT NOT NULL DEFAULT 0,
+    status VARCHAR(16) NOT NULL CHECK (status IN ('active', 'completed', 'cancelled')),
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+-- Workers claim due schedules in next_run_at order
+CREATE INDEX IF NOT EXISTS idx_scheduled_transfers_due ON scheduled_transfers (next_run_at) WHERE status = 'active';
+
+-- One row per executed occurrence; the unique key keeps an occurrence from running twice
+CREATE TABLE IF NOT EXISTS scheduled_transfer_runs (
+    id BIGSERIAL PRIMARY KEY,
+    scheduled_transfer_id BIGINT NOT NULL REFERENCES scheduled_transfers(id),
+    scheduled_for TIMESTAMP NOT NULL,
+    status VARCHAR(16) NOT NULL CHECK (status IN ('succeeded', 'failed')),
+    transaction_id BIGINT REFERENCES transactions(id),
+    error_code VARCHAR(64) NOT NULL DEFAULT '',
+    error_message TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    UNIQUE (scheduled_transfer_id, scheduled_for)
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS scheduled_transfer_runs;
+DROP TABLE IF EXISTS scheduled_transfers;
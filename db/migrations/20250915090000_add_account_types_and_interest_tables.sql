@@ -0,0 +1,44 @@
+-- +goose Up
+ALTER TABLE accounts ADD COLUMN IF NOT EXISTS type VARCHAR(16) NOT NULL DEFAULT 'checking'
+    CHECK (type IN ('checking', 'savings'));
+
+CREATE INDEX IF NOT EXISTS idx_accounts_type ON accounts (type, id);
+
+-- End-of-day balances are derived from the postings made before midnight
+CREATE INDEX IF NOT EXISTS idx_postings_account_id_created_at ON postings (account_id, created_at);
+
+-- Interest earned by an account on one day; the unique key makes accruing a day again a no-op
+CREATE TABLE IF NOT EXISTS interest_accruals (
+    id BIGSERIAL PRIMARY KEY,
+    account_id BIGINT NOT NULL REFERENCES accounts(id),
+    accrual_date DATE NOT NULL,
+    balance NUMERIC(20, 4) NOT NULL,
+    annual_rate NUMERIC(20, 8) NOT NULL,
+    day_count VARCHAR(16) NOT NULL,
+    amount NUMERIC(20, 4) NOT NULL CHECK (amount > 0),
+    currency CHAR(3) NOT NULL,
+    journal_entry_id BIGINT REFERENCES journal_entries(id),
+    posted_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    UNIQUE (account_id, accrual_date)
+);
+
+CREATE INDEX IF NOT EXISTS idx_interest_accruals_unposted ON interest_accruals (account_id, accrual_date)
+    WHERE posted_at IS NULL;
+
+-- Completed accrual days and posting months
+CREATE TABLE IF NOT EXISTS interest_runs (
+    id BIGSERIAL PRIMARY KEY,
+    kind VARCHAR(16) NOT NULL CHECK (kind IN ('accrual', 'posting')),
+    run_date DATE NOT NULL,
+    accounts BIGINT NOT NULL DEFAULT 0,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    UNIQUE (kind, run_date)
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS interest_runs;
+DROP TABLE IF EXISTS interest_accruals;
+DROP INDEX IF EXISTS idx_postings_account_id_created_at;
+DROP INDEX IF EXISTS idx_accounts_type;
+ALTER TABLE accounts DROP COLUMN IF EXISTS type;
@@ -0,0 +1,16 @@
+-- +goose Up
+-- Closing balance of each account per day (UTC), so historical balances add only the postings
+-- made after the latest snapshot instead of summing the whole ledger
+CREATE TABLE IF NOT EXISTS balance_snapshots (
+    account_id BIGINT NOT NULL REFERENCES accounts(id),
+    snapshot_date DATE NOT NULL,
+    balance NUMERIC(20, 4) NOT NULL,
+    currency CHAR(3) NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (account_id, snapshot_date)
+);
+
+CREATE INDEX IF NOT EXISTS idx_balance_snapshots_snapshot_date ON balance_snapshots (snapshot_date);
+
+-- +goose Down
+DROP TABLE IF EXISTS balance_snapshots;
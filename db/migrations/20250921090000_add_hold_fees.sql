@@ -0,0 +1,14 @@
+-- +goose Up
+-- The fee of a held transfer is reserved with its amount and charged on capture
+ALTER TABLE holds
+    ADD COLUMN IF NOT EXISTS fee_schedule VARCHAR(64) NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS fee_fixed NUMERIC(20, 4) NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS fee_percentage NUMERIC(20, 4) NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS fee_amount NUMERIC(20, 4) NOT NULL DEFAULT 0 CHECK (fee_amount >= 0);
+
+-- +goose Down
+ALTER TABLE holds
+    DROP COLUMN IF EXISTS fee_amount,
+    DROP COLUMN IF EXISTS fee_percentage,
+    DROP COLUMN IF EXISTS fee_fixed,
+    DROP COLUMN IF EXISTS fee_schedule;
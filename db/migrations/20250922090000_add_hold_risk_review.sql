@@ -0,0 +1,10 @@
+-- +goose Up
+-- A hold flagged by a risk rule at authorization has its capture held for review
+ALTER TABLE holds
+    ADD COLUMN IF NOT EXISTS risk_rule VARCHAR(64) NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS risk_reason TEXT NOT NULL DEFAULT '';
+
+-- +goose Down
+ALTER TABLE holds
+    DROP COLUMN IF EXISTS risk_reason,
+    DROP COLUMN IF EXISTS risk_rule;
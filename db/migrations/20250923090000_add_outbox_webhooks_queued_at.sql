@@ -0,0 +1,12 @@
+-- +goose Up
+-- Events are queued for webhooks by the relay apart from their publishing, once each
+ALTER TABLE outbox_events ADD COLUMN IF NOT EXISTS webhooks_queued_at TIMESTAMP;
+
+-- Published events were queued for webhooks when they were published
+UPDATE outbox_events SET webhooks_queued_at = published_at WHERE published_at IS NOT NULL;
+
+CREATE INDEX IF NOT EXISTS idx_outbox_events_webhooks_unqueued ON outbox_events (id) WHERE webhooks_queued_at IS NULL;
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_outbox_events_webhooks_unqueued;
+ALTER TABLE outbox_events DROP COLUMN IF EXISTS webhooks_queued_at;
@@ -0,0 +1,6 @@
+-- +goose Up
+-- A relay leases the events it publishes, so that it holds no lock while talking to the broker
+ALTER TABLE outbox_events ADD COLUMN IF NOT EXISTS publish_leased_until TIMESTAMP;
+
+-- +goose Down
+ALTER TABLE outbox_events DROP COLUMN IF EXISTS publish_leased_until;
@@ -0,0 +1,6 @@
+-- +goose Up
+-- Counts the changes of an account, so that consumers of its states can tell the newest one
+ALTER TABLE accounts ADD COLUMN IF NOT EXISTS version BIGINT NOT NULL DEFAULT 0;
+
+-- +goose Down
+ALTER TABLE accounts DROP COLUMN IF EXISTS version;
@@ -0,0 +1,14 @@
+-- +goose Up
+-- A carried accrual is the part of a posting below the currency minor unit, credited by the next
+-- posting; it is dated on the first day of the next month, which a daily accrual may also be
+ALTER TABLE interest_accruals ADD COLUMN IF NOT EXISTS carried BOOLEAN NOT NULL DEFAULT FALSE;
+ALTER TABLE interest_accruals DROP CONSTRAINT IF EXISTS interest_accruals_account_id_accrual_date_key;
+ALTER TABLE interest_accruals ADD CONSTRAINT interest_accruals_account_id_accrual_date_carried_key
+    UNIQUE (account_id, accrual_date, carried);
+
+-- +goose Down
+DELETE FROM interest_accruals WHERE carried;
+ALTER TABLE interest_accruals DROP CONSTRAINT IF EXISTS interest_accruals_account_id_accrual_date_carried_key;
+ALTER TABLE interest_accruals ADD CONSTRAINT interest_accruals_account_id_accrual_date_key
+    UNIQUE (account_id, accrual_date);
+ALTER TABLE interest_accruals DROP COLUMN IF EXISTS carried;
@@ -0,0 +1,49 @@
+-- +goose Up
+-- Amounts are held in Go as int64 units of 10^-4, which cannot hold the 16 integer digits of
+-- NUMERIC(20, 4). Money columns are narrowed to 14 integer digits, the range Money accepts.
+ALTER TABLE accounts
+    ALTER COLUMN balance TYPE NUMERIC(18, 4),
+    ALTER COLUMN overdraft_limit TYPE NUMERIC(18, 4);
+ALTER TABLE transactions
+    ALTER COLUMN amount TYPE NUMERIC(18, 4),
+    ALTER COLUMN destination_amount TYPE NUMERIC(18, 4),
+    ALTER COLUMN fee_fixed TYPE NUMERIC(18, 4),
+    ALTER COLUMN fee_percentage TYPE NUMERIC(18, 4),
+    ALTER COLUMN fee_amount TYPE NUMERIC(18, 4);
+ALTER TABLE holds
+    ALTER COLUMN amount TYPE NUMERIC(18, 4),
+    ALTER COLUMN captured_amount TYPE NUMERIC(18, 4),
+    ALTER COLUMN fee_fixed TYPE NUMERIC(18, 4),
+    ALTER COLUMN fee_percentage TYPE NUMERIC(18, 4),
+    ALTER COLUMN fee_amount TYPE NUMERIC(18, 4);
+ALTER TABLE postings ALTER COLUMN amount TYPE NUMERIC(18, 4);
+ALTER TABLE split_payments ALTER COLUMN amount TYPE NUMERIC(18, 4);
+ALTER TABLE scheduled_transfers ALTER COLUMN amount TYPE NUMERIC(18, 4);
+ALTER TABLE interest_accruals
+    ALTER COLUMN balance TYPE NUMERIC(18, 4),
+    ALTER COLUMN amount TYPE NUMERIC(18, 4);
+ALTER TABLE balance_snapshots ALTER COLUMN balance TYPE NUMERIC(18, 4);
+
+-- +goose Down
+ALTER TABLE balance_snapshots ALTER COLUMN balance TYPE NUMERIC(20, 4);
+ALTER TABLE interest_accruals
+    ALTER COLUMN amount TYPE NUMERIC(20, 4),
+    ALTER COLUMN balance TYPE NUMERIC(20, 4);
+ALTER TABLE scheduled_transfers ALTER COLUMN amount TYPE NUMERIC(20, 4);
+ALTER TABLE split_payments ALTER COLUMN amount TYPE NUMERIC(20, 4);
+ALTER TABLE postings ALTER COLUMN amount TYPE NUMERIC(20, 4);
+ALTER TABLE holds
+    ALTER COLUMN fee_amount TYPE NUMERIC(20, 4),
+    ALTER COLUMN fee_percentage TYPE NUMERIC(20, 4),
+    ALTER COLUMN fee_fixed TYPE NUMERIC(20, 4),
+    ALTER COLUMN captured_amount TYPE NUMERIC(20, 4),
+    ALTER COLUMN amount TYPE NUMERIC(20, 4);
+ALTER TABLE transactions
+    ALTER COLUMN fee_amount TYPE NUMERIC(20, 4),
+    ALTER COLUMN fee_percentage TYPE NUMERIC(20, 4),
+    ALTER COLUMN fee_fixed TYPE NUMERIC(20, 4),
+    ALTER COLUMN destination_amount TYPE NUMERIC(20, 4),
+    ALTER COLUMN amount TYPE NUMERIC(20, 4);
+ALTER TABLE accounts
+    ALTER COLUMN overdraft_limit TYPE NUMERIC(20, 4),
+    ALTER COLUMN balance TYPE NUMERIC(20, 4);
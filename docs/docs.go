@@ -28,6 +28,14 @@ const docTemplate = `{
                     "Account"
                 ],
                 "summary": "Create a new account",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Makes the request safe to retry",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
+                ],
                 "responses": {
                     "200": {
                         "description": "OK"
@@ -44,6 +52,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/apperr.AppError"
                         }
                     },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/apperr.AppError"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -94,9 +108,65 @@ const docTemplate = `{
                 }
             }
         },
-        "/transaction": {
+        "/accounts/{account_id}/balance": {
+            "get": {
+                "description": "Return the balance an account had at as_of, derived from its ledger postings made before it. Without as_of the current ledger balance is returned.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Ledger"
+                ],
+                "summary": "Get an account balance as of a time",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Account ID",
+                        "name": "account_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "RFC 3339 timestamp, e.g. 2025-04-01T00:00:00Z for the closing balance of March 31",
+                        "name": "as_of",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.BalanceAsOfDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/apperr.AppError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/apperr.AppError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/apperr.AppError"
+                        }
+                    }
+                }
+            }
+        },
+        "/accounts/{account_id}/close": {
             "post": {
-                "description": "Perform a transaction on an account, updating its balance.",
+                "description": "Close an account for good. The balance must be zero, or is swept to sweep_account_id.",
                 "consumes": [
                     "application/json"
                 ],
@@ -104,12 +174,38 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Transaction"
+                    "Account"
+                ],
+                "summary": "Close an account",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Makes the request safe to retry",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Account ID",
+                        "name": "account_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Account receiving the remaining balance",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/dto.CloseAccountDTO"
+                        }
+                    }
                 ],
-                "summary": "Make a transaction",
                 "responses": {
                     "200": {
-                        "description": "OK"
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.AccountDTO"
+                        }
                     },
                     "400": {
                         "description": "Bad Request",
@@ -123,6 +219,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/apperr.AppError"
                         }
                     },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/apperr.AppError"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/apperr.AppError"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -131,35 +239,3152 @@ const docTemplate = `{
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "apperr.AppError": {
-            "type": "object",
-            "properties": {
-                "code": {
-                    "type": "string"
-                },
-                "message": {
-                    "type": "string"
-                },
-                "status": {
-                    "type": "integer"
+        },
+        "/accounts/{account_id}/events": {
+            "get": {
+                "description": "Server-Sent Events stream of the balance changes (event \"balance\", an AccountBalanceEventDTO) and new or updated transactions (event \"transaction\", a TransactionRecordDTO) of an account, as they are committed. A new connection starts with the current balance. A client reconnecting with Last-Event-ID first gets the events it missed, or the current balance when they are no longer retained.",
+                "produces": [
+                    "text/event-stream"
+                ],
+                "tags": [
+                    "Account"
+                ],
+                "summary": "Stream account activity",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Account ID",
+                        "name": "account_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "ID of the last event received, to resume the stream",
+                        "name": "Last-Event-ID",
+                        "in": "header"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Same as Last-Event-ID, for clients that cannot set headers",
+                        "name": "last_event_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.AccountBalanceEventDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/apperr.AppError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/apperr.AppError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/apperr.AppError"
+                        }
+                    }
                 }
             }
         },
-        "dto.AccountDTO": {
-            "type": "object",
-            "required": [
-                "account_id",
-                "balance"
-            ],
-            "properties": {
-                "account_id": {
-                    "type": "integer"
-                },
-                "balance": {
-                    "type": "number"
+        "/accounts/{account_id}/freeze": {
+            "post": {
+                "description": "Block debits from an account. A frozen account keeps receiving money.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Account"
+                ],
+                "summary": "Freeze an account",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Account ID",
+                        "name": "account_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.AccountDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/apperr.AppError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/apperr.AppError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/apperr.AppError"
+                        }
+                    }
+                }
+            }
+        },
+        "/accounts/{account_id}/interest-accruals": {
+            "get": {
+                "description": "List the latest daily interest accruals of an account, newest first, posted or not.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Accounts"
+                ],
+                "summary": "List interest accruals",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Account ID",
+                        "name": "account_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.InterestAccrualDTO"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/apperr.AppError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/apperr.AppError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/apperr.AppError"
+                        }
+                    }
+                }
+            }
+        },
+        "/accounts/{account_id}/reconciliation": {
+            "get": {
+                "description": "Compare the stored balance of an account with the balance derived from its postings.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Ledger"
+                ],
+                "summary": "Reconcile an account",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Account ID",
+                        "name": "account_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ReconciliationDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/apperr.AppError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/apperr.AppError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/apperr.AppError"
+                        }
+                    }
+                }
+            }
+        },
+        "/accounts/{account_id}/statement": {
+            "get": {
+                "description": "Stream the statement of an account for a period: opening balance, every movement and closing balance, as CSV, JSON Lines or ISO 20022 camt.053 XML.",
+                "produces": [
+                    "text/csv",
+                    "application/x-ndjson",
+                    "application/xml"
+                ],
+                "tags": [
+                    "Accounts"
+                ],
+                "summary": "Download an account statement",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Account ID",
+                        "name": "account_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Start of the period, RFC 3339, inclusive",
+                        "name": "from",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "End of the period, RFC 3339, exclusive",
+                        "name": "to",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "csv",
+                            "jsonl",
+                            "camt053"
+                        ],
+                        "type": "string",
+                        "default": "csv",
+                        "description": "Statement format",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/apperr.AppError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/apperr.AppError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/apperr.AppError"
+                        }
+                    }
+                }
+            }
+        },
+        "/accounts/{account_id}/transactions": {
+            "get": {
+                "description": "List the transfers an account sent or received, newest first. Pass meta.next_cursor as cursor to fetch the next page.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Account"
+                ],
+                "summary": "List account transactions",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Account ID",
+                        "name": "account_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "incoming or outgoing",
+                        "name": "direction",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Earliest transaction time (RFC 3339), inclusive",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Latest transaction time (RFC 3339), exclusive",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Minimum amount in the account currency",
+                        "name": "min_amount",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Maximum amount in the account currency",
+                        "name": "max_amount",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor of the next page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Page size, 1 to 100",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.TransactionRecordDTO"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/apperr.AppError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/apperr.AppError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/apperr.AppError"
+                        }
+                    }
+                }
+            }
+        },
+        "/accounts/{account_id}/unfreeze": {
+            "post": {
+                "description": "Make a frozen account active again.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Account"
+                ],
+                "summary": "Unfreeze an account",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Account ID",
+                        "name": "account_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.AccountDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/apperr.AppError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/apperr.AppError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/apperr.AppError"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/accounts/{account_id}/audits": {
+            "get": {
+                "description": "List the administrative changes made to an account, oldest first.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "List account audit trail",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Account ID",
+                        "name": "account_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.AccountAuditDTO"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/apperr.AppError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/apperr.AppError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/apperr.AppError"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/accounts/{account_id}/overdraft": {
+            "put": {
+                "description": "Change how far below zero the balance of an account may go. Every change is recorded in the account audit trail.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Set an account overdraft limit",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Account ID",
+                        "name": "account_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "New overdraft limit",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.OverdraftDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.AccountDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/apperr.AppError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/apperr.AppError"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/apperr.AppError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/apperr.AppError"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/balance-snapshots": {
+            "post": {
+                "description": "Record the closing balance of an ended day for every account, once 10 minutes have passed since its end so that late postings have committed, to speed up balance-as-of queries. Snapshotting a day again only adds the accounts missing from it.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Snapshot balances for a day",
+                "parameters": [
+                    {
+                        "description": "Day to snapshot",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.BalanceSnapshotRequestDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.BalanceSnapshotRunDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/apperr.AppError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/apperr.AppError"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/interest/accruals": {
+            "post": {
+                "description": "Accrue the interest earned on an ended day by every account with a configured rate, on its end-of-day balance. Running a day again accrues nothing.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Accrue interest for a day",
+                "parameters": [
+                    {
+                        "description": "Day to accrue",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.InterestAccrualRequestDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.InterestRunDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/apperr.AppError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/apperr.AppError"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/interest/postings": {
+            "post": {
+                "description": "Credit every account with the interest it accrued in an ended month, from the interest expense account. Running a month again credits nothing.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Post interest for a month",
+                "parameters": [
+                    {
+                        "description": "Month to post",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.InterestPostingRequestDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.InterestRunDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/apperr.AppError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/apperr.AppError"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/reviews": {
+            "get": {
+                "description": "List risk reviews by status, oldest first. Open reviews are listed by default.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "List risk reviews",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "open",
+                        "description": "open, released or rejected",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 50,
+                        "description": "Page size, 1 to 100",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.RiskReviewDTO"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/apperr.AppError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/apperr.AppError"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/transactions/{transaction_id}/reject": {
+            "post": {
+                "description": "Fail a transfer held for risk review. No money moves.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Reject a held transfer",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Makes the request safe to retry",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Transaction ID",
+                        "name": "transaction_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Review decision",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.ReviewDecisionDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.TransactionRecordDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/apperr.AppError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/apperr.AppError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/apperr.AppError"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/transactions/{transaction_id}/release": {
+            "post": {
+                "description": "Post a transfer held for risk review. Account status and funds are checked again at release.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Release a held transfer",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Makes the request safe to retry",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Transaction ID",
+                        "name": "transaction_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Review decision",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.ReviewDecisionDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.TransactionRecordDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/apperr.AppError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/apperr.AppError"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/apperr.AppError"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/apperr.AppError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/apperr.AppError"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/webhooks/dead-letters": {
+            "get": {
+                "description": "List the latest webhook deliveries that exhausted their retries and were not replayed, newest first.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "List webhook dead letters",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.WebhookDeadLetterDTO"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/apperr.AppError"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/webhooks/dead-letters/{dead_letter_id}/replay": {
+            "post": {
+                "description": "Queue a dead-lettered delivery again, due now and with a fresh retry budget. A dead letter is replayed at most once, and only to an active webhook.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Replay a webhook dead letter",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Dead letter ID",
+                        "name": "dead_letter_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.WebhookDeliveryDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/apperr.AppError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/apperr.AppError"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/apperr.AppError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/apperr.AppError"
+                        }
+                    }
+                }
+            }
+        },
+        "/fx/quotes": {
+            "post": {
+                "description": "Lock the current rate of a currency pair for a short time. Pass the returned quote_id to the transaction endpoint.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "FX"
+                ],
+                "summary": "Create an FX quote",
+                "parameters": [
+                    {
+                        "description": "Currency pair",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.QuoteRequestDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/dto.QuoteDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/apperr.AppError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/apperr.AppError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/apperr.AppError"
+                        }
+                    }
+                }
+            }
+        },
+        "/fx/rates": {
+            "put": {
+                "description": "Create or replace the current rate of a currency pair.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "FX"
+                ],
+                "summary": "Set an exchange rate",
+                "parameters": [
+                    {
+                        "description": "Exchange rate",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.RateDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.RateDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/apperr.AppError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/apperr.AppError"
+                        }
+                    }
+                }
+            }
+        },
+        "/holds": {
+            "post": {
+                "description": "Place a hold on the source account for a transfer that is captured or voided later. Held funds are excluded from the available balance until the hold is captured, voided or expires.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Hold"
+                ],
+                "summary": "Authorize a transfer",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Makes the request safe to retry",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Transfer to authorize",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.AuthorizeDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/dto.HoldDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/apperr.AppError"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/apperr.AppError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/apperr.AppError"
+                        }
+                    }
+                }
+            }
+        },
+        "/holds/{hold_id}/capture": {
+            "post": {
+                "description": "Settle an authorized hold as a transfer, for the full held amount or a lower one. Any remainder is released.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Hold"
+                ],
+                "summary": "Capture a hold",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Makes the request safe to retry",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Hold ID",
+                        "name": "hold_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Amount to capture",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/dto.CaptureDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.HoldDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/apperr.AppError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/apperr.AppError"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/apperr.AppError"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/apperr.AppError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/apperr.AppError"
+                        }
+                    }
+                }
+            }
+        },
+        "/holds/{hold_id}/void": {
+            "post": {
+                "description": "Release an authorized hold without moving money.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Hold"
+                ],
+                "summary": "Void a hold",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Hold ID",
+                        "name": "hold_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.HoldDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/apperr.AppError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/apperr.AppError"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/apperr.AppError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/apperr.AppError"
+                        }
+                    }
+                }
+            }
+        },
+        "/scheduled-transfers": {
+            "post": {
+                "description": "Make a transfer at a future time, once or on a daily, weekly or monthly recurrence with an optional end date. Each run goes through the same checks as an immediate transfer and its outcome is recorded.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Scheduled transfer"
+                ],
+                "summary": "Schedule a transfer",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Makes the request safe to retry",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Transfer to schedule",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.ScheduleTransferDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ScheduledTransferDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/apperr.AppError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/apperr.AppError"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/apperr.AppError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/apperr.AppError"
+                        }
+                    }
+                }
+            }
+        },
+        "/scheduled-transfers/{schedule_id}": {
+            "get": {
+                "description": "Retrieve a scheduled transfer, including its status and next run time.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Scheduled transfer"
+                ],
+                "summary": "Get a scheduled transfer",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Schedule ID",
+                        "name": "schedule_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ScheduledTransferDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/apperr.AppError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/apperr.AppError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/apperr.AppError"
+                        }
+                    }
+                }
+            }
+        },
+        "/scheduled-transfers/{schedule_id}/cancel": {
+            "post": {
+                "description": "Stop an active scheduled transfer. Runs already made are not undone.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Scheduled transfer"
+                ],
+                "summary": "Cancel a scheduled transfer",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Schedule ID",
+                        "name": "schedule_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ScheduledTransferDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/apperr.AppError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/apperr.AppError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/apperr.AppError"
+                        }
+                    }
+                }
+            }
+        },
+        "/scheduled-transfers/{schedule_id}/runs": {
+            "get": {
+                "description": "List the latest runs of a scheduled transfer, newest first, with the transaction made or the error.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Scheduled transfer"
+                ],
+                "summary": "List scheduled transfer runs",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Schedule ID",
+                        "name": "schedule_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.ScheduledRunDTO"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/apperr.AppError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/apperr.AppError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/apperr.AppError"
+                        }
+                    }
+                }
+            }
+        },
+        "/transactions": {
+            "post": {
+                "description": "Perform a transaction on an account, updating its balance.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Transaction"
+                ],
+                "summary": "Make a transaction",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Makes the request safe to retry",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/dto.TransactionRecordDTO"
+                        }
+                    },
+                    "202": {
+                        "description": "Transfer held for risk review",
+                        "schema": {
+                            "$ref": "#/definitions/dto.TransactionRecordDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/apperr.AppError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/apperr.AppError"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/apperr.AppError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/apperr.AppError"
+                        }
+                    }
+                }
+            }
+        },
+        "/transactions/batch": {
+            "post": {
+                "description": "Perform up to 1000 transfers in one request. In atomic mode every transfer is made or none is, and the first failing one is returned as the error. In best_effort mode each transfer is made on its own and failures are reported per item with status 207.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Transaction"
+                ],
+                "summary": "Make a batch of transfers",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Makes the request safe to retry",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Transfers to make",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.BatchTransactionDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/dto.BatchResultDTO"
+                        }
+                    },
+                    "207": {
+                        "description": "Some best_effort transfers failed",
+                        "schema": {
+                            "$ref": "#/definitions/dto.BatchResultDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/apperr.AppError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/apperr.AppError"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/apperr.AppError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/apperr.AppError"
+                        }
+                    }
+                }
+            }
+        },
+        "/transactions/split": {
+            "post": {
+                "description": "Debit the source account once and credit several accounts, e.g. the seller, the platform fee and the tax. The leg amounts must add up to the amount, and every account must use the source account currency. Split payments are never held for risk review: a leg that would be held refuses the whole payment.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Transaction"
+                ],
+                "summary": "Make a split payment",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Makes the request safe to retry",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Split payment",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.SplitPaymentDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/dto.SplitPaymentResultDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/apperr.AppError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/apperr.AppError"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/apperr.AppError"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/apperr.AppError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/apperr.AppError"
+                        }
+                    }
+                }
+            }
+        },
+        "/transactions/{transaction_id}": {
+            "get": {
+                "description": "Retrieve a transaction by its ID, including its status.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Transaction"
+                ],
+                "summary": "Get a transaction",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Transaction ID",
+                        "name": "transaction_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.TransactionRecordDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/apperr.AppError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/apperr.AppError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/apperr.AppError"
+                        }
+                    }
+                }
+            }
+        },
+        "/transactions/{transaction_id}/postings": {
+            "get": {
+                "description": "List the debit and credit postings booked for a transaction.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Ledger"
+                ],
+                "summary": "Get transaction postings",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Transaction ID",
+                        "name": "transaction_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.PostingDTO"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/apperr.AppError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/apperr.AppError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/apperr.AppError"
+                        }
+                    }
+                }
+            }
+        },
+        "/transactions/{transaction_id}/reversals": {
+            "post": {
+                "description": "Return all or part of a transfer to its source account. The reversal is a new transaction linked to the original one.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Transaction"
+                ],
+                "summary": "Reverse a transaction",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Makes the request safe to retry",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Transaction ID",
+                        "name": "transaction_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Amount to reverse",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ReversalDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ReversalResultDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/apperr.AppError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/apperr.AppError"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/apperr.AppError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/apperr.AppError"
+                        }
+                    }
+                }
+            }
+        },
+        "/webhooks": {
+            "get": {
+                "description": "List the registered webhook endpoints, oldest first, without their secrets.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhook"
+                ],
+                "summary": "List webhooks",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.WebhookEndpointDTO"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/apperr.AppError"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Register a URL to receive the events of the given types as signed POST requests. The signing secret is generated when omitted and is only returned in this response.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhook"
+                ],
+                "summary": "Register a webhook",
+                "parameters": [
+                    {
+                        "description": "Endpoint to register",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.RegisterWebhookDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/dto.WebhookEndpointDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/apperr.AppError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/apperr.AppError"
+                        }
+                    }
+                }
+            }
+        },
+        "/webhooks/{webhook_id}": {
+            "get": {
+                "description": "Retrieve a registered webhook endpoint, without its secret.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhook"
+                ],
+                "summary": "Get a webhook",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Webhook ID",
+                        "name": "webhook_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.WebhookEndpointDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/apperr.AppError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/apperr.AppError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/apperr.AppError"
+                        }
+                    }
+                }
+            }
+        },
+        "/webhooks/{webhook_id}/disable": {
+            "post": {
+                "description": "Stop delivering events to a webhook endpoint. Its pending deliveries are moved to the dead letters.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhook"
+                ],
+                "summary": "Disable a webhook",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Webhook ID",
+                        "name": "webhook_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.WebhookEndpointDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/apperr.AppError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/apperr.AppError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/apperr.AppError"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "apperr.AppError": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.AccountAuditDTO": {
+            "type": "object",
+            "properties": {
+                "account_id": {
+                    "type": "integer"
+                },
+                "audit_id": {
+                    "type": "integer"
+                },
+                "changed_by": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "field": {
+                    "type": "string",
+                    "example": "overdraft_limit"
+                },
+                "new_value": {
+                    "type": "string",
+                    "example": "500.00"
+                },
+                "old_value": {
+                    "type": "string",
+                    "example": "0.00"
+                },
+                "reason": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.AccountBalanceEventDTO": {
+            "type": "object",
+            "properties": {
+                "account_id": {
+                    "type": "integer"
+                },
+                "available_balance": {
+                    "description": "AvailableBalance is the balance minus the funds reserved by open holds",
+                    "type": "string",
+                    "example": "900.00"
+                },
+                "balance": {
+                    "type": "string",
+                    "example": "1000.00"
+                },
+                "currency": {
+                    "type": "string",
+                    "example": "USD"
+                },
+                "overdraft_limit": {
+                    "type": "string",
+                    "example": "0.00"
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "active",
+                        "frozen",
+                        "closed"
+                    ]
+                },
+                "version": {
+                    "type": "integer",
+                    "example": 42
+                }
+            }
+        },
+        "dto.AccountDTO": {
+            "type": "object",
+            "required": [
+                "account_id",
+                "balance"
+            ],
+            "properties": {
+                "account_id": {
+                    "type": "integer"
+                },
+                "available_balance": {
+                    "description": "AvailableBalance is the balance minus funds reserved by open holds; output only",
+                    "type": "string",
+                    "example": "900.00"
+                },
+                "balance": {
+                    "type": "string",
+                    "example": "1000.00"
+                },
+                "currency": {
+                    "type": "string",
+                    "example": "USD"
+                },
+                "overdraft_limit": {
+                    "description": "OverdraftLimit is how far below zero the balance may go; output only, set through the admin API",
+                    "type": "string",
+                    "example": "0.00"
+                },
+                "status": {
+                    "description": "Status is the lifecycle state of the account; output only",
+                    "type": "string",
+                    "enum": [
+                        "active",
+                        "frozen",
+                        "closed"
+                    ]
+                },
+                "type": {
+                    "description": "Type is the account product; accounts are opened as checking unless savings is given",
+                    "type": "string",
+                    "enum": [
+                        "checking",
+                        "savings"
+                    ]
+                }
+            }
+        },
+        "dto.AuthorizeDTO": {
+            "type": "object",
+            "required": [
+                "amount",
+                "destination_account_id",
+                "source_account_id"
+            ],
+            "properties": {
+                "amount": {
+                    "type": "string",
+                    "example": "100.50"
+                },
+                "currency": {
+                    "description": "Currency of Amount; defaults to the source account currency and must match it when set",
+                    "type": "string",
+                    "example": "USD"
+                },
+                "destination_account_id": {
+                    "type": "integer"
+                },
+                "source_account_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.BalanceAsOfDTO": {
+            "type": "object",
+            "properties": {
+                "account_id": {
+                    "type": "integer"
+                },
+                "as_of": {
+                    "type": "string"
+                },
+                "balance": {
+                    "type": "string",
+                    "example": "1250.00"
+                },
+                "currency": {
+                    "type": "string",
+                    "example": "USD"
+                },
+                "snapshot_date": {
+                    "description": "SnapshotDate is the day whose closing balance the postings after it were added to;\nomitted when the balance was summed from the whole ledger",
+                    "type": "string",
+                    "example": "2025-03-30"
+                }
+            }
+        },
+        "dto.BalanceSnapshotRequestDTO": {
+            "type": "object",
+            "required": [
+                "date"
+            ],
+            "properties": {
+                "date": {
+                    "description": "Date is the day to snapshot; it must have ended",
+                    "type": "string",
+                    "example": "2025-03-31"
+                }
+            }
+        },
+        "dto.BalanceSnapshotRunDTO": {
+            "type": "object",
+            "properties": {
+                "accounts": {
+                    "description": "Accounts is the number of accounts snapshotted; accounts snapshotted before are not counted",
+                    "type": "integer"
+                },
+                "date": {
+                    "type": "string",
+                    "example": "2025-03-31"
+                }
+            }
+        },
+        "dto.BatchItemResultDTO": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "$ref": "#/definitions/apperr.AppError"
+                },
+                "index": {
+                    "type": "integer"
+                },
+                "transaction": {
+                    "$ref": "#/definitions/dto.TransactionRecordDTO"
+                }
+            }
+        },
+        "dto.BatchResultDTO": {
+            "type": "object",
+            "properties": {
+                "failed": {
+                    "type": "integer"
+                },
+                "mode": {
+                    "type": "string",
+                    "enum": [
+                        "atomic",
+                        "best_effort"
+                    ]
+                },
+                "results": {
+                    "description": "Results are in the order of the request transactions",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.BatchItemResultDTO"
+                    }
+                },
+                "succeeded": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.BatchTransactionDTO": {
+            "type": "object",
+            "required": [
+                "mode",
+                "transactions"
+            ],
+            "properties": {
+                "mode": {
+                    "type": "string",
+                    "enum": [
+                        "atomic",
+                        "best_effort"
+                    ]
+                },
+                "transactions": {
+                    "description": "Transactions are validated one by one, so that a best-effort batch reports invalid items instead of failing",
+                    "type": "array",
+                    "maxItems": 1000,
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/dto.TransactionDTO"
+                    }
+                }
+            }
+        },
+        "dto.CaptureDTO": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "description": "Amount to settle; the full hold amount when omitted",
+                    "type": "string",
+                    "example": "80.00"
+                }
+            }
+        },
+        "dto.CloseAccountDTO": {
+            "type": "object",
+            "properties": {
+                "sweep_account_id": {
+                    "description": "SweepAccountID receives the remaining balance; required unless the balance is zero",
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.FeeDTO": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "string",
+                    "example": "1.00"
+                },
+                "currency": {
+                    "type": "string",
+                    "example": "USD"
+                },
+                "fixed": {
+                    "type": "string",
+                    "example": "0.50"
+                },
+                "percentage": {
+                    "type": "string",
+                    "example": "0.50"
+                },
+                "schedule": {
+                    "type": "string",
+                    "example": "internal_usd"
+                },
+                "total_debited": {
+                    "description": "TotalDebited is the transfer amount plus the fee",
+                    "type": "string",
+                    "example": "101.50"
+                }
+            }
+        },
+        "dto.HoldDTO": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "string",
+                    "example": "100.50"
+                },
+                "captured_amount": {
+                    "type": "string",
+                    "example": "0.00"
+                },
+                "currency": {
+                    "type": "string",
+                    "example": "USD"
+                },
+                "destination_account_id": {
+                    "type": "integer"
+                },
+                "expires_at": {
+                    "type": "string"
+                },
+                "fee": {
+                    "description": "Fee reserved with the amount; the capture charges it, or less for a partial capture",
+                    "type": "string",
+                    "example": "1.00"
+                },
+                "hold_id": {
+                    "type": "integer"
+                },
+                "review_required": {
+                    "description": "ReviewRequired is set when risk rules flagged the hold: its capture waits for a risk review",
+                    "type": "boolean"
+                },
+                "source_account_id": {
+                    "type": "integer"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "authorized"
+                },
+                "transaction_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.InterestAccrualDTO": {
+            "type": "object",
+            "properties": {
+                "accrual_date": {
+                    "type": "string",
+                    "example": "2025-09-14"
+                },
+                "amount": {
+                    "description": "Amount keeps sub-cent digits; the monthly posting credits the month's total in whole cents",
+                    "type": "string",
+                    "example": "1.1644"
+                },
+                "annual_rate": {
+                    "type": "string",
+                    "example": "0.0425"
+                },
+                "balance": {
+                    "type": "string",
+                    "example": "10000.00"
+                },
+                "carried": {
+                    "description": "Carried marks the sub-cent remainder of the previous monthly posting, credited by the next one",
+                    "type": "boolean"
+                },
+                "currency": {
+                    "type": "string",
+                    "example": "USD"
+                },
+                "day_count": {
+                    "type": "string",
+                    "enum": [
+                        "act/365",
+                        "act/360",
+                        "act/act"
+                    ]
+                },
+                "posted_at": {
+                    "description": "PostedAt is set once the accrual was credited by a monthly posting",
+                    "type": "string"
+                }
+            }
+        },
+        "dto.InterestAccrualRequestDTO": {
+            "type": "object",
+            "required": [
+                "date"
+            ],
+            "properties": {
+                "date": {
+                    "description": "Date is the day to accrue; it must have ended",
+                    "type": "string",
+                    "example": "2025-09-14"
+                }
+            }
+        },
+        "dto.InterestPostingRequestDTO": {
+            "type": "object",
+            "required": [
+                "month"
+            ],
+            "properties": {
+                "month": {
+                    "description": "Month is the month to post; it must have ended and its last day must be accrued",
+                    "type": "string",
+                    "example": "2025-08"
+                }
+            }
+        },
+        "dto.InterestRunDTO": {
+            "type": "object",
+            "properties": {
+                "accounts": {
+                    "description": "Accounts is the number of accounts accrued or credited",
+                    "type": "integer"
+                },
+                "already_run": {
+                    "description": "AlreadyRun is true when the day or month had been processed before; nothing was done again",
+                    "type": "boolean"
+                },
+                "completed_at": {
+                    "type": "string"
+                },
+                "date": {
+                    "description": "Date is the accrued day or the first day of the posted month",
+                    "type": "string",
+                    "example": "2025-09-14"
+                },
+                "kind": {
+                    "type": "string",
+                    "enum": [
+                        "accrual",
+                        "posting"
+                    ]
+                }
+            }
+        },
+        "dto.OverdraftDTO": {
+            "type": "object",
+            "required": [
+                "changed_by",
+                "overdraft_limit",
+                "reason"
+            ],
+            "properties": {
+                "changed_by": {
+                    "type": "string",
+                    "maxLength": 64
+                },
+                "overdraft_limit": {
+                    "description": "OverdraftLimit is the new limit in the account currency; zero removes the overdraft",
+                    "type": "string",
+                    "minLength": 0,
+                    "example": "500.00"
+                },
+                "reason": {
+                    "type": "string",
+                    "maxLength": 512
+                }
+            }
+        },
+        "dto.PostingDTO": {
+            "type": "object",
+            "properties": {
+                "account_id": {
+                    "type": "integer"
+                },
+                "amount": {
+                    "type": "string",
+                    "example": "100.50"
+                },
+                "currency": {
+                    "type": "string",
+                    "example": "USD"
+                },
+                "direction": {
+                    "type": "string",
+                    "example": "debit"
+                },
+                "system_account": {
+                    "type": "string",
+                    "example": "fx:position"
+                }
+            }
+        },
+        "dto.QuoteDTO": {
+            "type": "object",
+            "properties": {
+                "destination_currency": {
+                    "type": "string",
+                    "example": "EUR"
+                },
+                "expires_at": {
+                    "type": "string"
+                },
+                "quote_id": {
+                    "type": "string"
+                },
+                "rate": {
+                    "type": "string",
+                    "example": "0.9234"
+                },
+                "source_currency": {
+                    "type": "string",
+                    "example": "USD"
+                }
+            }
+        },
+        "dto.QuoteRequestDTO": {
+            "type": "object",
+            "required": [
+                "destination_currency",
+                "source_currency"
+            ],
+            "properties": {
+                "destination_currency": {
+                    "type": "string",
+                    "example": "EUR"
+                },
+                "source_currency": {
+                    "type": "string",
+                    "example": "USD"
+                }
+            }
+        },
+        "dto.RateDTO": {
+            "type": "object",
+            "required": [
+                "base_currency",
+                "quote_currency",
+                "rate"
+            ],
+            "properties": {
+                "base_currency": {
+                    "type": "string",
+                    "example": "USD"
+                },
+                "quote_currency": {
+                    "type": "string",
+                    "example": "EUR"
+                },
+                "rate": {
+                    "type": "string",
+                    "example": "0.9234"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.ReconciliationDTO": {
+            "type": "object",
+            "properties": {
+                "account_id": {
+                    "type": "integer"
+                },
+                "balance": {
+                    "type": "string",
+                    "example": "1000.00"
+                },
+                "currency": {
+                    "type": "string",
+                    "example": "USD"
+                },
+                "difference": {
+                    "type": "string",
+                    "example": "0.00"
+                },
+                "in_balance": {
+                    "type": "boolean"
+                },
+                "ledger_balance": {
+                    "type": "string",
+                    "example": "1000.00"
+                }
+            }
+        },
+        "dto.RegisterWebhookDTO": {
+            "type": "object",
+            "required": [
+                "event_types",
+                "url"
+            ],
+            "properties": {
+                "event_types": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "type": "string",
+                        "enum": [
+                            "account.created",
+                            "transfer.posted",
+                            "transfer.failed"
+                        ]
+                    }
+                },
+                "secret": {
+                    "description": "Secret signs the requests; a random one is generated and returned when omitted",
+                    "type": "string",
+                    "maxLength": 128,
+                    "minLength": 16
+                },
+                "url": {
+                    "description": "URL must use https and resolve to public addresses only",
+                    "type": "string",
+                    "example": "https://example.com/hooks/ledger"
+                }
+            }
+        },
+        "dto.ReversalDTO": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "description": "Amount to return to the original source account, in the original transaction currency;\neverything not yet reversed when omitted",
+                    "type": "string",
+                    "example": "25.00"
+                }
+            }
+        },
+        "dto.ReversalResultDTO": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "string",
+                    "example": "25.00"
+                },
+                "currency": {
+                    "type": "string",
+                    "example": "USD"
+                },
+                "original_transaction_id": {
+                    "type": "integer"
+                },
+                "remaining_amount": {
+                    "description": "RemainingAmount is what can still be reversed on the original transaction",
+                    "type": "string",
+                    "example": "75.50"
+                },
+                "transaction_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.ReviewDecisionDTO": {
+            "type": "object",
+            "required": [
+                "decided_by"
+            ],
+            "properties": {
+                "decided_by": {
+                    "type": "string",
+                    "maxLength": 64
+                },
+                "note": {
+                    "type": "string",
+                    "maxLength": 512
+                }
+            }
+        },
+        "dto.RiskReviewDTO": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "decided_at": {
+                    "type": "string"
+                },
+                "decided_by": {
+                    "type": "string"
+                },
+                "note": {
+                    "type": "string"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "review_id": {
+                    "type": "integer"
+                },
+                "rule": {
+                    "type": "string",
+                    "example": "new_account"
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "open",
+                        "released",
+                        "rejected"
+                    ]
+                },
+                "transaction_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.ScheduleTransferDTO": {
+            "type": "object",
+            "required": [
+                "amount",
+                "destination_account_id",
+                "run_at",
+                "source_account_id"
+            ],
+            "properties": {
+                "amount": {
+                    "type": "string",
+                    "example": "100.50"
+                },
+                "currency": {
+                    "description": "Currency of Amount; defaults to the source account currency and must match it when set",
+                    "type": "string",
+                    "example": "USD"
+                },
+                "destination_account_id": {
+                    "type": "integer"
+                },
+                "end_at": {
+                    "description": "EndAt is the last time a recurring transfer may run, inclusive; it runs until cancelled when omitted",
+                    "type": "string"
+                },
+                "recurrence": {
+                    "type": "string",
+                    "default": "once",
+                    "enum": [
+                        "once",
+                        "daily",
+                        "weekly",
+                        "monthly"
+                    ]
+                },
+                "run_at": {
+                    "description": "RunAt is the first (or only) time the transfer is made; recurrences repeat it in UTC",
+                    "type": "string"
+                },
+                "source_account_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.ScheduledRunDTO": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "error_code": {
+                    "type": "string"
+                },
+                "error_message": {
+                    "type": "string"
+                },
+                "run_id": {
+                    "type": "integer"
+                },
+                "scheduled_for": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "succeeded",
+                        "failed"
+                    ]
+                },
+                "transaction_id": {
+                    "description": "TransactionID is the transfer made by a succeeded run",
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.ScheduledTransferDTO": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "string",
+                    "example": "100.50"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "currency": {
+                    "type": "string",
+                    "example": "USD"
+                },
+                "destination_account_id": {
+                    "type": "integer"
+                },
+                "end_at": {
+                    "type": "string"
+                },
+                "next_run_at": {
+                    "description": "NextRunAt is only set while the schedule is active",
+                    "type": "string"
+                },
+                "recurrence": {
+                    "type": "string",
+                    "enum": [
+                        "once",
+                        "daily",
+                        "weekly",
+                        "monthly"
+                    ]
+                },
+                "schedule_id": {
+                    "type": "integer"
+                },
+                "source_account_id": {
+                    "type": "integer"
+                },
+                "start_at": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "active",
+                        "completed",
+                        "cancelled"
+                    ]
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.SplitLegDTO": {
+            "type": "object",
+            "required": [
+                "amount",
+                "destination_account_id"
+            ],
+            "properties": {
+                "amount": {
+                    "type": "string",
+                    "example": "85.00"
+                },
+                "destination_account_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.SplitPaymentDTO": {
+            "type": "object",
+            "required": [
+                "amount",
+                "legs",
+                "source_account_id"
+            ],
+            "properties": {
+                "amount": {
+                    "description": "Amount is the total debited from the source account; the leg amounts must add up to it",
+                    "type": "string",
+                    "example": "100.00"
+                },
+                "currency": {
+                    "description": "Currency of Amount; defaults to the source account currency and must match it when set",
+                    "type": "string",
+                    "example": "USD"
+                },
+                "legs": {
+                    "type": "array",
+                    "maxItems": 50,
+                    "minItems": 2,
+                    "items": {
+                        "$ref": "#/definitions/dto.SplitLegDTO"
+                    }
+                },
+                "source_account_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.SplitPaymentResultDTO": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "string",
+                    "example": "100.00"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "currency": {
+                    "type": "string",
+                    "example": "USD"
+                },
+                "legs": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.TransactionRecordDTO"
+                    }
+                },
+                "source_account_id": {
+                    "type": "integer"
+                },
+                "split_payment_id": {
+                    "type": "integer"
+                },
+                "status": {
+                    "description": "Status is posted, or pending when the payment is held for risk review",
+                    "type": "string",
+                    "enum": [
+                        "pending",
+                        "posted"
+                    ]
+                }
+            }
+        },
+        "dto.TransactionDTO": {
+            "type": "object",
+            "required": [
+                "amount",
+                "destination_account_id",
+                "source_account_id"
+            ],
+            "properties": {
+                "amount": {
+                    "type": "string",
+                    "example": "100.50"
+                },
+                "currency": {
+                    "description": "Currency of Amount; defaults to the source account currency and must match it when set",
+                    "type": "string",
+                    "example": "USD"
+                },
+                "destination_account_id": {
+                    "type": "integer"
+                },
+                "quote_id": {
+                    "description": "QuoteID explicitly requests a cross-currency conversion at the rate locked by an FX quote",
+                    "type": "string"
+                },
+                "source_account_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.TransactionRecordDTO": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "string",
+                    "example": "100.50"
+                },
+                "currency": {
+                    "type": "string",
+                    "example": "USD"
+                },
+                "destination_account_id": {
+                    "type": "integer"
+                },
+                "destination_amount": {
+                    "type": "string",
+                    "example": "92.81"
+                },
+                "destination_currency": {
+                    "type": "string",
+                    "example": "EUR"
+                },
+                "direction": {
+                    "description": "Direction is relative to the account whose history is listed",
+                    "type": "string",
+                    "example": "outgoing"
+                },
+                "exchange_rate": {
+                    "type": "string",
+                    "example": "0.9234"
+                },
+                "fee": {
+                    "description": "Fee is charged to the source account on top of Amount; omitted when the transfer was free",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/dto.FeeDTO"
+                        }
+                    ]
+                },
+                "original_transaction_id": {
+                    "type": "integer"
+                },
+                "source_account_id": {
+                    "type": "integer"
+                },
+                "split_payment_id": {
+                    "type": "integer"
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "pending",
+                        "posted",
+                        "failed",
+                        "reversed"
+                    ]
+                },
+                "transaction_id": {
+                    "type": "integer"
+                },
+                "transaction_time": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.WebhookDeadLetterDTO": {
+            "type": "object",
+            "properties": {
+                "attempts": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "dead_letter_id": {
+                    "type": "integer"
+                },
+                "event_id": {
+                    "type": "integer"
+                },
+                "event_type": {
+                    "type": "string"
+                },
+                "last_error": {
+                    "type": "string"
+                },
+                "last_status_code": {
+                    "type": "integer"
+                },
+                "webhook_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.WebhookDeliveryDTO": {
+            "type": "object",
+            "properties": {
+                "attempts": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "delivery_id": {
+                    "type": "integer"
+                },
+                "event_id": {
+                    "type": "integer"
+                },
+                "event_type": {
+                    "type": "string"
+                },
+                "next_attempt_at": {
+                    "type": "string"
+                },
+                "webhook_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.WebhookEndpointDTO": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "event_types": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "secret": {
+                    "description": "Secret is only returned when the endpoint is registered",
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "active",
+                        "disabled"
+                    ]
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string"
+                },
+                "webhook_id": {
+                    "type": "integer"
                 }
             }
         }